@@ -0,0 +1,3 @@
+docker build --network host -f gserver/Dockerfile --target gctl . -t gctl-build-image
+docker run --net host --name gctl-build -v $PWD/gtuncli:/go/src/gTunnel/gtuncli gctl-build-image
+docker rm gctl-build
@@ -0,0 +1,81 @@
+package common
+
+import (
+	"fmt"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// WaitConnected waits until the byte stream of the connection is set
+// up by the remote side. It returns false if the connection is closed
+// or timeout passes first.
+func (c *Connection) WaitConnected(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-c.Connected:
+		return true
+	case <-c.Kill:
+	case <-timer.C:
+	}
+	return false
+}
+
+// WaitControlStream waits until the control stream of the tunnel is
+// set, which is when the remote side acknowledged the tunnel. It
+// returns false if the tunnel is stopped or timeout passes first.
+func (t *Tunnel) WaitControlStream(timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-t.ctrlReady:
+		return true
+	case <-t.ctx.Done():
+	case <-timer.C:
+	}
+	return false
+}
+
+// awaitAck fails an accepted connection that the remote side does not
+// acknowledge within ConnectAckTimeout.
+func (t *Tunnel) awaitAck(c *Connection) {
+	timer := time.NewTimer(ConnectAckTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-c.started:
+		return
+	case <-c.Kill:
+		return
+	case <-t.ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	reason := fmt.Sprintf("no acknowledgement within %s", ConnectAckTimeout)
+	Log.WithTunnel(t.id).WithConnection(c.ID).Warnf(
+		"Connection for %s failed: %s", c.GetOriginAddress(), reason)
+	t.connectionFailed(ConnectionFailure{
+		ConnectionID:  c.ID,
+		OriginAddress: c.GetOriginAddress(),
+		Code:          cs.ConnectionError_DIAL_TIMEOUT,
+		Message:       reason,
+	})
+	t.dropConnection(c)
+}
+
+// dropConnection closes a connection that could not be set up and
+// tells the remote side to forget it as well.
+func (t *Tunnel) dropConnection(c *Connection) {
+	c.Close()
+	t.RemoveConnection(c.ID)
+
+	message := new(cs.TunnelControlMessage)
+	message.Operation = TunnelCtrlDisconnect
+	message.TunnelId = t.id
+	message.ConnectionId = c.ID
+	t.SendCtrlMessage(message)
+}
@@ -3,7 +3,6 @@ package common
 import (
 	"context"
 	"crypto/rand"
-	"log"
 	"math/big"
 )
 
@@ -33,7 +32,7 @@ func GenerateToken() (string, error) {
 	tokenSize, err := rand.Int(reader, max)
 
 	if err != nil {
-		log.Print("[!] Failed to generate a password size\n")
+		Log.Errorf("Failed to generate a password size")
 	}
 
 	tokenSize.Add(tokenSize, min)
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Modes in which a Balancer spreads new connections across the tunnels
+// that share them.
+const (
+	BalanceRoundRobin       = "roundrobin"
+	BalanceLeastConnections = "leastconn"
+)
+
+// CheckBalanceMode returns an error if mode is not a balance mode.
+func CheckBalanceMode(mode string) error {
+	switch mode {
+	case BalanceRoundRobin, BalanceLeastConnections:
+		return nil
+	}
+	return fmt.Errorf("unknown balance mode %q, use %s or %s", mode,
+		BalanceRoundRobin, BalanceLeastConnections)
+}
+
+// Balancer spreads the connections that are accepted by a group of
+// forward tunnels, usually on different endpoints that reach the same
+// destination network, across the tunnels of the group.
+type Balancer struct {
+	mode    string
+	tunnels []*Tunnel
+	next    int
+	mutex   sync.Mutex
+}
+
+// NewBalancer is a constructor for the Balancer struct. Mode is
+// BalanceRoundRobin or BalanceLeastConnections.
+func NewBalancer(mode string) *Balancer {
+	b := new(Balancer)
+	b.mode = mode
+	b.tunnels = make([]*Tunnel, 0)
+	return b
+}
+
+// GetMode gets the mode of the balancer.
+func (b *Balancer) GetMode() string {
+	return b.mode
+}
+
+// Add adds a tunnel to the group of the balancer. The connections that
+// the tunnel accepts are spread across the group from now on.
+func (b *Balancer) Add(t *Tunnel) {
+	b.mutex.Lock()
+	b.tunnels = append(b.tunnels, t)
+	b.mutex.Unlock()
+
+	t.mutex.Lock()
+	t.balancer = b
+	t.mutex.Unlock()
+}
+
+// Len returns the number of tunnels in the group that are not stopped.
+func (b *Balancer) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.prune()
+	return len(b.tunnels)
+}
+
+// Pick returns the tunnel that takes the next connection. Tunnels that
+// are paused, at their connection limit or not yet acknowledged by their
+// endpoint are passed over. It returns nil if no tunnel can take the
+// connection.
+func (b *Balancer) Pick() *Tunnel {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.prune()
+	var picked *Tunnel
+	count := 0
+	for i := range b.tunnels {
+		// Ties are broken in round-robin order
+		n := (b.next + i) % len(b.tunnels)
+		t := b.tunnels[n]
+		if t.IsPaused() || t.connectionLimitReached() != "" ||
+			t.GetControlStream() == nil {
+			continue
+		}
+		if b.mode != BalanceLeastConnections {
+			b.next = n + 1
+			return t
+		}
+		if c := t.ConnectionCount(); picked == nil || c < count {
+			picked, count = t, c
+		}
+	}
+	b.next++
+	return picked
+}
+
+// prune forgets the tunnels of the group that are stopped. The caller
+// must hold the mutex.
+func (b *Balancer) prune() {
+	tunnels := b.tunnels[:0]
+	for _, t := range b.tunnels {
+		if t.ctx.Err() == nil {
+			tunnels = append(tunnels, t)
+		}
+	}
+	for i := len(tunnels); i < len(b.tunnels); i++ {
+		b.tunnels[i] = nil
+	}
+	b.tunnels = tunnels
+}
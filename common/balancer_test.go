@@ -0,0 +1,87 @@
+package common
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestBalancer(t *testing.T) {
+	tunnels := make([]*Tunnel, 3)
+	ctrls := make([]*ctrlPipe, 3)
+	for i := range tunnels {
+		tunnels[i] = NewTunnel("tunnel", TunnelDirectionForward,
+			net.ParseIP("127.0.0.1"), 0, net.ParseIP("127.0.0.1"), 1)
+		defer tunnels[i].Stop()
+		ctrls[i] = &ctrlPipe{
+			sent: make(chan *cs.TunnelControlMessage, 4),
+			recv: make(chan *cs.TunnelControlMessage),
+		}
+	}
+	a, b := tunnels[0], tunnels[1]
+	a.SetControlStream(ctrls[0])
+	b.SetControlStream(ctrls[1])
+
+	if err := CheckBalanceMode("random"); err == nil {
+		t.Errorf("CheckBalanceMode accepted an unknown mode")
+	}
+
+	// Tunnels that are not acknowledged yet are passed over
+	rr := NewBalancer(BalanceRoundRobin)
+	for _, tunnel := range tunnels {
+		rr.Add(tunnel)
+	}
+	for i, want := range []*Tunnel{a, b, a, b} {
+		if got := rr.Pick(); got != want {
+			t.Errorf("roundrobin pick %d: got tunnel %p, want %p", i, got, want)
+		}
+	}
+	b.Pause(false)
+	if got := rr.Pick(); got != a {
+		t.Errorf("roundrobin picked a paused tunnel")
+	}
+	b.Unpause()
+
+	lc := NewBalancer(BalanceLeastConnections)
+	lc.Add(a)
+	lc.Add(b)
+	local, remote := net.Pipe()
+	defer remote.Close()
+	a.AddConnection(NewConnection(local))
+	for i := 0; i < 2; i++ {
+		if got := lc.Pick(); got != b {
+			t.Errorf("leastconn pick %d did not pick the idle tunnel", i)
+		}
+	}
+
+	// Connections accepted by one tunnel are connected by the other
+	if !a.AddListener("") {
+		t.Fatalf("AddListener failed")
+	}
+	conn, err := net.Dial("tcp", a.listeners[0].Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	select {
+	case message := <-ctrls[1].sent:
+		if message.Operation != TunnelCtrlConnect {
+			t.Errorf("unexpected message %v", message)
+		}
+	case <-ctrls[0].sent:
+		t.Errorf("connection was not balanced to the idle tunnel")
+	case <-time.After(5 * time.Second):
+		t.Fatalf("connection was not connected")
+	}
+
+	// Stopped tunnels leave the group
+	b.Stop()
+	if n := lc.Len(); n != 1 {
+		t.Errorf("Len after stop: got %d, want 1", n)
+	}
+	if got := lc.Pick(); got != a {
+		t.Errorf("leastconn did not pick the remaining tunnel")
+	}
+}
@@ -0,0 +1,191 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+
+	utls "github.com/refraction-networking/utls"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLS fingerprints that the ClientHello of gClient can mimic. The Go
+// fingerprint is the one of crypto/tls, which is widely signatured.
+const (
+	TLSFingerprintGo      = "go"
+	TLSFingerprintChrome  = "chrome"
+	TLSFingerprintFirefox = "firefox"
+	TLSFingerprintSafari  = "safari"
+	TLSFingerprintEdge    = "edge"
+)
+
+// browserFingerprints are the uTLS ClientHellos of the browsers, which
+// follow the current release that uTLS has a fingerprint of.
+var browserFingerprints = map[string]utls.ClientHelloID{
+	TLSFingerprintChrome:  utls.HelloChrome_Auto,
+	TLSFingerprintFirefox: utls.HelloFirefox_Auto,
+	TLSFingerprintSafari:  utls.HelloSafari_Auto,
+	TLSFingerprintEdge:    utls.HelloEdge_Auto,
+}
+
+// CheckTLSFingerprint returns an error if name is not a TLS fingerprint.
+// An empty name is the Go fingerprint.
+func CheckTLSFingerprint(name string) error {
+	if _, ok := browserFingerprints[name]; ok || name == "" || name == TLSFingerprintGo {
+		return nil
+	}
+	return fmt.Errorf("unknown tls fingerprint %s", name)
+}
+
+// tlsConn is a crypto/tls or uTLS client connection.
+type tlsConn interface {
+	net.Conn
+	HandshakeContext(ctx context.Context) error
+}
+
+// tlsClient returns a TLS client connection on conn with the ClientHello
+// of fingerprint. If nextProtos is not nil, it replaces the protocols
+// that the browser offers with ALPN, as browsers only offer http/1.1
+// for WebSockets.
+func tlsClient(conn net.Conn, config *tls.Config, fingerprint string,
+	nextProtos []string) (tlsConn, error) {
+
+	if err := CheckTLSFingerprint(fingerprint); err != nil {
+		return nil, err
+	}
+	hello, ok := browserFingerprints[fingerprint]
+	if !ok {
+		config = config.Clone()
+		if nextProtos != nil {
+			config.NextProtos = nextProtos
+		}
+		return tls.Client(conn, config), nil
+	}
+	return browserClient(conn, config, hello, nextProtos)
+}
+
+// browserClient returns a uTLS client connection on conn that sends the
+// ClientHello of hello.
+func browserClient(conn net.Conn, config *tls.Config, hello utls.ClientHelloID,
+	nextProtos []string) (*utls.UConn, error) {
+
+	uconfig := &utls.Config{
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+	if nextProtos == nil {
+		return utls.UClient(conn, uconfig, hello), nil
+	}
+
+	spec, err := utls.UTLSIdToSpec(hello)
+	if err != nil {
+		return nil, err
+	}
+	for _, extension := range spec.Extensions {
+		if alpn, ok := extension.(*utls.ALPNExtension); ok {
+			alpn.AlpnProtocols = nextProtos
+		}
+	}
+	uconn := utls.UClient(conn, uconfig, utls.HelloCustom)
+	if err := uconn.ApplyPreset(&spec); err != nil {
+		return nil, err
+	}
+	return uconn, nil
+}
+
+// browserCredentials are gRPC transport credentials that handshake with
+// the ClientHello of a browser.
+type browserCredentials struct {
+	config *tls.Config
+	hello  utls.ClientHelloID
+}
+
+// NewTLSCredentials returns the gRPC transport credentials with which
+// gClient connects. Their ClientHello mimics the browser fingerprint,
+// unless it is TLSFingerprintGo or empty.
+func NewTLSCredentials(config *tls.Config, fingerprint string) (
+	credentials.TransportCredentials, error) {
+
+	if err := CheckTLSFingerprint(fingerprint); err != nil {
+		return nil, err
+	}
+	hello, ok := browserFingerprints[fingerprint]
+	if !ok {
+		return credentials.NewTLS(config), nil
+	}
+
+	c := new(browserCredentials)
+	c.config = config.Clone()
+	c.hello = hello
+	return c, nil
+}
+
+// ClientHandshake does the TLS handshake on rawConn. The server name is
+// taken from the authority unless the config has one.
+func (c *browserCredentials) ClientHandshake(ctx context.Context, authority string,
+	rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+
+	config := c.config.Clone()
+	if config.ServerName == "" {
+		config.ServerName = authority
+		if host, _, err := net.SplitHostPort(authority); err == nil {
+			config.ServerName = host
+		}
+	}
+
+	conn, err := browserClient(rawConn, config, c.hello, nil)
+	if err == nil {
+		err = conn.HandshakeContext(ctx)
+	}
+	if err != nil {
+		rawConn.Close()
+		return nil, nil, err
+	}
+
+	state := conn.ConnectionState()
+	info := credentials.TLSInfo{
+		State: tls.ConnectionState{
+			Version:            state.Version,
+			HandshakeComplete:  state.HandshakeComplete,
+			CipherSuite:        state.CipherSuite,
+			NegotiatedProtocol: state.NegotiatedProtocol,
+			ServerName:         state.ServerName,
+			PeerCertificates:   state.PeerCertificates,
+		},
+		CommonAuthInfo: credentials.CommonAuthInfo{
+			SecurityLevel: credentials.PrivacyAndIntegrity,
+		},
+	}
+	return conn, info, nil
+}
+
+// ServerHandshake fails, the credentials are only used by clients.
+func (c *browserCredentials) ServerHandshake(rawConn net.Conn) (net.Conn,
+	credentials.AuthInfo, error) {
+	return nil, nil, errors.New("browser tls credentials are client only")
+}
+
+// Info returns the protocol of the credentials.
+func (c *browserCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{
+		SecurityProtocol: "tls",
+		SecurityVersion:  "1.2",
+		ServerName:       c.config.ServerName,
+	}
+}
+
+// Clone returns a copy of the credentials.
+func (c *browserCredentials) Clone() credentials.TransportCredentials {
+	clone := new(browserCredentials)
+	clone.config = c.config.Clone()
+	clone.hello = c.hello
+	return clone
+}
+
+// OverrideServerName sets the server name that is sent.
+func (c *browserCredentials) OverrideServerName(name string) error {
+	c.config.ServerName = name
+	return nil
+}
@@ -0,0 +1,126 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// helloServer starts a TLS server that passes the ClientHellos it
+// receives to hellos.
+func helloServer(hellos chan *tls.ClientHelloInfo) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			hellos <- hello
+			return nil, nil
+		},
+	}
+	server.StartTLS()
+	return server
+}
+
+// hasGREASE returns true if values contain a GREASE value, which
+// browsers send and crypto/tls does not.
+func hasGREASE(values []uint16) bool {
+	for _, value := range values {
+		if value&0x0f0f == 0x0a0a {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTLSClientFingerprint(t *testing.T) {
+	hellos := make(chan *tls.ClientHelloInfo, 1)
+	server := helloServer(hellos)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	for _, fingerprint := range []string{TLSFingerprintGo, TLSFingerprintChrome,
+		TLSFingerprintFirefox, TLSFingerprintSafari, TLSFingerprintEdge} {
+
+		rawConn, err := net.Dial("tcp", address)
+		if err != nil {
+			t.Fatalf("Dial: %v", err)
+		}
+		conn, err := tlsClient(rawConn, &tls.Config{
+			ServerName:         "gserver.example.com",
+			InsecureSkipVerify: true,
+		}, fingerprint, []string{"http/1.1"})
+		if err != nil {
+			t.Fatalf("tlsClient(%s): %v", fingerprint, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = conn.HandshakeContext(ctx)
+		cancel()
+		conn.Close()
+		if err != nil {
+			t.Errorf("HandshakeContext(%s): %v", fingerprint, err)
+			continue
+		}
+
+		hello := <-hellos
+		if hello.ServerName != "gserver.example.com" ||
+			strings.Join(hello.SupportedProtos, ",") != "http/1.1" {
+			t.Errorf("%s: Got: %s %v Want: gserver.example.com [http/1.1]",
+				fingerprint, hello.ServerName, hello.SupportedProtos)
+		}
+		if grease := hasGREASE(hello.CipherSuites); grease != (fingerprint == TLSFingerprintChrome ||
+			fingerprint == TLSFingerprintEdge || fingerprint == TLSFingerprintSafari) {
+			t.Errorf("%s: Got: GREASE %v in %x", fingerprint, grease, hello.CipherSuites)
+		}
+	}
+
+	if _, err := tlsClient(nil, &tls.Config{}, "netscape", nil); err == nil {
+		t.Errorf("tlsClient: Got no error for an unknown fingerprint")
+	}
+}
+
+func TestTLSCredentials(t *testing.T) {
+	hellos := make(chan *tls.ClientHelloInfo, 1)
+	server := helloServer(hellos)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+
+	creds, err := NewTLSCredentials(&tls.Config{InsecureSkipVerify: true},
+		TLSFingerprintChrome)
+	if err != nil {
+		t.Fatalf("NewTLSCredentials: %v", err)
+	}
+
+	// The server name is taken from the authority and gRPC gets h2
+	rawConn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, authInfo, err := creds.ClientHandshake(ctx, "gserver.example.com:443", rawConn)
+	if err != nil {
+		t.Fatalf("ClientHandshake: %v", err)
+	}
+	defer conn.Close()
+
+	hello := <-hellos
+	if hello.ServerName != "gserver.example.com" || !hasGREASE(hello.CipherSuites) {
+		t.Errorf("ClientHello: Got: %s %x Want: a chrome ClientHello for gserver.example.com",
+			hello.ServerName, hello.CipherSuites)
+	}
+	info, ok := authInfo.(credentials.TLSInfo)
+	if !ok || info.State.NegotiatedProtocol != "h2" {
+		t.Errorf("AuthInfo: Got: %+v Want: h2", authInfo)
+	}
+
+	if _, err := NewTLSCredentials(&tls.Config{}, "netscape"); err == nil {
+		t.Errorf("NewTLSCredentials: Got no error for an unknown fingerprint")
+	}
+}
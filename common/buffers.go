@@ -0,0 +1,28 @@
+package common
+
+import (
+	"sync"
+)
+
+// bufferPools holds a pool of read buffers for every buffer size in
+// use, since the MTU can differ between tunnels.
+var bufferPools sync.Map
+
+// getBuffer returns a buffer of size bytes from the pool of that size.
+func getBuffer(size uint32) []byte {
+	pool, _ := bufferPools.LoadOrStore(size, new(sync.Pool))
+	if buffer, ok := pool.(*sync.Pool).Get().(*[]byte); ok {
+		return (*buffer)[:size]
+	}
+	return make([]byte, size)
+}
+
+// putBuffer returns a buffer obtained from getBuffer to its pool. The
+// buffer must not be used afterwards.
+func putBuffer(buffer []byte) {
+	size := uint32(cap(buffer))
+	if pool, ok := bufferPools.Load(size); ok {
+		buffer = buffer[:0]
+		pool.(*sync.Pool).Put(&buffer)
+	}
+}
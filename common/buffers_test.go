@@ -0,0 +1,21 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestBufferPool(t *testing.T) {
+	buffer := getBuffer(1024)
+	if len(buffer) != 1024 {
+		t.Fatalf("getBuffer: Got: %d bytes Want: 1024", len(buffer))
+	}
+	putBuffer(buffer[:10])
+
+	if buffer := getBuffer(1024); len(buffer) != 1024 {
+		t.Errorf("getBuffer: Got: %d bytes Want: 1024 for a reused buffer",
+			len(buffer))
+	}
+	if buffer := getBuffer(16); len(buffer) != 16 {
+		t.Errorf("getBuffer: Got: %d bytes Want: 16", len(buffer))
+	}
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// buildTargets are the GOARCH values for which the builder image can
+// build gClients on each GOOS, which needs a C compiler for each.
+var buildTargets = map[string][]string{
+	"linux": {"386", "amd64", "arm", "arm64", "mips", "mipsle", "mips64",
+		"mips64le", "ppc64le", "riscv64", "s390x"},
+	"windows": {"386", "amd64"},
+}
+
+// BuildTarget is an operating system and architecture a gClient is built
+// for, with the GOOS and GOARCH names of Go.
+type BuildTarget struct {
+	GOOS   string
+	GOARCH string
+}
+
+// String returns the target as GOOS/GOARCH, such as linux/arm64.
+func (t BuildTarget) String() string {
+	return t.GOOS + "/" + t.GOARCH
+}
+
+// ArtifactName returns the name of the file a gClient built for the
+// target is written to, the name of output followed by the target and
+// the extension of executables or shared libraries, if lib, of the
+// target. For example, gclient.exe becomes gclient_windows_386.exe and
+// gclient_linux_arm64.
+func (t BuildTarget) ArtifactName(output string, lib bool) string {
+	base := strings.TrimSuffix(output, filepath.Ext(output))
+	name := fmt.Sprintf("%s_%s_%s", base, t.GOOS, t.GOARCH)
+	switch {
+	case t.GOOS == "windows" && lib:
+		return name + ".dll"
+	case t.GOOS == "windows":
+		return name + ".exe"
+	case lib:
+		return name + ".so"
+	}
+	return name
+}
+
+// ParseBuildTarget parses a target such as linux/arm64.
+func ParseBuildTarget(target string) (BuildTarget, error) {
+	target = strings.ToLower(strings.TrimSpace(target))
+	i := strings.Index(target, "/")
+	if i < 0 {
+		return BuildTarget{}, fmt.Errorf("invalid target %q: not GOOS/GOARCH", target)
+	}
+	t := BuildTarget{GOOS: target[:i], GOARCH: target[i+1:]}
+
+	archs, ok := buildTargets[t.GOOS]
+	if !ok {
+		return BuildTarget{}, fmt.Errorf("invalid target %q: unsupported GOOS %s",
+			target, t.GOOS)
+	}
+	for _, arch := range archs {
+		if arch == t.GOARCH {
+			return t, nil
+		}
+	}
+	return BuildTarget{}, fmt.Errorf("invalid target %q: unsupported GOARCH %s for %s, one of %s",
+		target, t.GOARCH, t.GOOS, strings.Join(archs, ", "))
+}
+
+// ParseBuildTargets parses a comma separated list of targets, such as
+// "linux/arm64,linux/riscv64,windows/386,linux/mips". A target that is
+// listed twice is built once.
+func ParseBuildTargets(list string) ([]BuildTarget, error) {
+	var targets []BuildTarget
+	seen := make(map[BuildTarget]bool)
+	for _, target := range strings.Split(list, ",") {
+		if strings.TrimSpace(target) == "" {
+			continue
+		}
+		t, err := ParseBuildTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets")
+	}
+	return targets, nil
+}
@@ -0,0 +1,33 @@
+package common
+
+import "testing"
+
+func TestBuildTargets(t *testing.T) {
+	targets, err := ParseBuildTargets("linux/arm64, linux/riscv64,windows/386,linux/mips,linux/arm64")
+	if err != nil {
+		t.Fatalf("ParseBuildTargets: %v", err)
+	}
+	if len(targets) != 4 || targets[3] != (BuildTarget{GOOS: "linux", GOARCH: "mips"}) {
+		t.Errorf("ParseBuildTargets: Got: %v", targets)
+	}
+
+	for _, list := range []string{"", "linux", "plan9/amd64", "darwin/arm64", "windows/arm64", "linux/arm64/v8"} {
+		if _, err := ParseBuildTargets(list); err == nil {
+			t.Errorf("ParseBuildTargets(%s): Want: error", list)
+		}
+	}
+
+	names := map[string]BuildTarget{
+		"gclient_windows_386.exe": {GOOS: "windows", GOARCH: "386"},
+		"gclient_linux_arm64":     {GOOS: "linux", GOARCH: "arm64"},
+		"gclient_linux_mips":      {GOOS: "linux", GOARCH: "mips"},
+	}
+	for want, target := range names {
+		if name := target.ArtifactName("gclient.exe", false); name != want {
+			t.Errorf("ArtifactName(%s): Got: %s Want: %s", target, name, want)
+		}
+	}
+	if name := names["gclient_linux_arm64"].ArtifactName("gclient", true); name != "gclient_linux_arm64.so" {
+		t.Errorf("ArtifactName: Got: %s for a library", name)
+	}
+}
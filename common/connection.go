@@ -1,17 +1,22 @@
 package common
 
 import (
+	"crypto/subtle"
+	"io"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	cs "github.com/kai5263499/gtunnel/grpc/client"
 )
 
 // A structure to handle the TCP connection
-// and map them to the gRPC byte stream.
+// and map them to the gRPC byte stream. TCPConn is usually
+// a *net.TCPConn but may be any net.Conn, such as a ProcessConn.
 type Connection struct {
 	ID          string
-	TCPConn     net.TCPConn
+	TCPConn     net.Conn
 	Kill        chan bool
 	Status      int32
 	Connected   chan bool
@@ -20,21 +25,64 @@ type Connection struct {
 	byteStream  ByteStream
 	bytesTx     uint64
 	bytesRx     uint64
-	remoteClose bool
+	remoteClose int32
+	mtu         uint32
+	warnings    []string
+	onFirstRead func([]byte)
+	streamToken string
+	setupSpan   *Span
+	startTime   time.Time
+	origin      string
+	destination string
+	healthCheck bool
+	failure     *ConnectionFailure
+	resume      *streamResume
+	flow        *flowControl
+	limiters    []*RateLimiter
+	idleTimeout time.Duration
+	onIdle      func()
+	lastActive  int64
+	halfClose   bool
+	halfClosed  int
+	compression string
+	cipher      *tunnelCipher
+	sealCount   uint64
+	openCount   uint64
+	padSize     uint32
+	sendJitter  time.Duration
+	sendMutex   sync.Mutex
+	thaw        chan struct{}
+	started     chan struct{}
+	relays      sync.WaitGroup
 	mutex       sync.Mutex
 }
 
 // NewConnection is a constructor function for Connection.
-func NewConnection(tcpConn net.TCPConn) *Connection {
+func NewConnection(tcpConn net.Conn) *Connection {
 	c := new(Connection)
 	c.TCPConn = tcpConn
 	c.Status = 0
+	c.mtu = DefaultMTU
 	c.Connected = make(chan bool)
 	c.Kill = make(chan bool)
+	c.started = make(chan struct{})
+	c.warnings = make([]string, 0)
+	c.streamToken = GenerateStreamToken()
+	c.startTime = time.Now()
+	c.touch()
 
 	return c
 }
 
+// AddWarning records a warning about the connection, such as
+// the destination looking like a tarpit or honeypot.
+func (c *Connection) AddWarning(warning string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.warnings = append(c.warnings, warning)
+}
+
 // Close will close a TCP connection and close the
 // Kill channel.
 func (c *Connection) Close() {
@@ -48,6 +96,128 @@ func (c *Connection) Close() {
 	}
 }
 
+// ConsumeStreamToken returns true if token is the stream token of the
+// connection. A token can only be consumed once so that a byte stream
+// setup cannot be replayed.
+func (c *Connection) ConsumeStreamToken(token string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.streamToken == "" ||
+		subtle.ConstantTimeCompare([]byte(c.streamToken), []byte(token)) != 1 {
+		return false
+	}
+	c.streamToken = ""
+	return true
+}
+
+// Freeze stops relaying data over the connection until Thaw is called.
+// The connection stays open.
+func (c *Connection) Freeze() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.thaw == nil {
+		c.thaw = make(chan struct{})
+	}
+}
+
+// Thaw lets a frozen connection relay data again.
+func (c *Connection) Thaw() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.thaw != nil {
+		close(c.thaw)
+		c.thaw = nil
+	}
+}
+
+// waitThawed blocks while the connection is frozen. It returns false
+// if the connection was closed in the meantime.
+func (c *Connection) waitThawed() bool {
+	c.mutex.Lock()
+	thaw := c.thaw
+	c.mutex.Unlock()
+
+	if thaw == nil {
+		return true
+	}
+	select {
+	case <-thaw:
+		return true
+	case <-c.Kill:
+		return false
+	}
+}
+
+// GetBytesReceived returns the number of bytes read from the TCP
+// connection and sent over the byte stream.
+func (c *Connection) GetBytesReceived() uint64 {
+	return atomic.LoadUint64(&c.bytesRx)
+}
+
+// GetBytesSent returns the number of bytes received over the byte
+// stream and written to the TCP connection.
+func (c *Connection) GetBytesSent() uint64 {
+	return atomic.LoadUint64(&c.bytesTx)
+}
+
+// GetStartTime returns the time at which the connection was created.
+func (c *Connection) GetStartTime() time.Time {
+	return c.startTime
+}
+
+// SetOriginAddress records the address of the client that opened the
+// connection, as accepted by the listener of the tunnel.
+func (c *Connection) SetOriginAddress(addr string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.origin = addr
+}
+
+// GetOriginAddress returns the address of the client that opened the
+// connection or an empty string if it is not known.
+func (c *Connection) GetOriginAddress() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.origin
+}
+
+// SetDestinationAddress records the destination that the dialing side
+// of the tunnel connected the connection to.
+func (c *Connection) SetDestinationAddress(addr string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.destination = addr
+}
+
+// GetDestinationAddress returns the destination that the connection was
+// connected to or an empty string if it is not known, such as for the
+// connections of command tunnels.
+func (c *Connection) GetDestinationAddress() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.destination
+}
+
+// GetStreamToken returns the token that the remote side must present
+// when it opens the byte stream of the connection.
+func (c *Connection) GetStreamToken() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.streamToken
+}
+
+// GetWarnings returns all warnings recorded for the connection.
+func (c *Connection) GetWarnings() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]string(nil), c.warnings...)
+}
+
 // GetStream will return the byteStream for a connection
 func (c *Connection) GetStream() ByteStream {
 	return c.byteStream
@@ -58,37 +228,84 @@ func (c *Connection) GetStream() ByteStream {
 func (c *Connection) handleEgressData() {
 	inputChan := make(chan []byte, 4096)
 
-	go func(t net.TCPConn) {
+	// Messages and buffers of resumable connections are kept until the
+	// remote side acknowledges them, so only the others are reused.
+	reuse := c.resume == nil
+	message := new(cs.BytesMessage)
+
+	// readErr is the error that ended the reads, it is set before the
+	// input channel is closed
+	var readErr error
+
+	go func(t net.Conn, input chan<- []byte) {
+		firstRead := c.onFirstRead
 		for {
-			bytes := make([]byte, 4096)
-			bytesRead, err := t.Read(bytes)
+			if !c.waitThawed() {
+				break
+			}
+			// Reads pause while the send window is exhausted
+			size := c.mtu
+			if c.flow != nil {
+				var ok bool
+				if size, ok = c.flow.reserve(c.mtu, c.Kill); !ok {
+					break
+				}
+			}
+			bytes := getBuffer(c.mtu)
+			bytesRead, err := t.Read(bytes[:size])
+			if c.flow != nil {
+				c.flow.release(size - uint32(bytesRead))
+			}
+			atomic.AddUint64(&c.bytesRx, uint64(bytesRead))
+			atomic.AddUint64(&bytesReceivedTotal, uint64(bytesRead))
+			if bytesRead > 0 {
+				c.touch()
+			}
+			if !c.throttle(bytesRead) {
+				break
+			}
+			if bytesRead > 0 && firstRead != nil {
+				firstRead(bytes[:bytesRead])
+				firstRead = nil
+			}
 			if err != nil {
-				if !c.remoteClose {
+				readErr = err
+				if atomic.LoadInt32(&c.remoteClose) == 0 {
 					break
 				}
 			}
-			inputChan <- bytes[:bytesRead]
+			input <- bytes[:bytesRead]
 			if err != nil {
 				break
 			}
 		}
-		if inputChan != nil {
-			close(inputChan)
-		}
-	}(c.TCPConn)
+		close(input)
+	}(c.TCPConn, inputChan)
 
+	eof := false
 	for {
 		select {
 		case bytes, ok := <-inputChan:
 			if !ok {
+				eof = readErr == io.EOF
 				inputChan = nil
 				break
 			}
-			message := new(cs.BytesMessage)
-			message.Content = bytes
-
-			c.byteStream.Send(message)
-			if len(message.Content) == 0 {
+			if !reuse {
+				message = new(cs.BytesMessage)
+			}
+			if !c.jitter() {
+				inputChan = nil
+			} else if err := c.setContent(message, bytes); err != nil {
+				Log.WithConnection(c.ID).Errorf("Failed to encrypt data: %v", err)
+				inputChan = nil
+			} else if err := c.sendMessage(message); err != nil {
+				atomic.AddUint64(&streamErrorsTotal, 1)
+			}
+			if reuse {
+				putBuffer(bytes)
+			}
+			if len(bytes) == 0 || inputChan == nil {
 				inputChan = nil
 				break
 			}
@@ -97,7 +314,15 @@ func (c *Connection) handleEgressData() {
 			break
 		}
 		if inputChan == nil {
-			if !c.remoteClose {
+			// The local peer finished sending but may still receive
+			if eof && c.halfClose && atomic.LoadInt32(&c.remoteClose) == 0 {
+				c.sendHalfClose()
+				if !c.halfCloseDone() {
+					return
+				}
+				break
+			}
+			if atomic.LoadInt32(&c.remoteClose) == 0 {
 				c.SendCloseMessage()
 			}
 			break
@@ -113,19 +338,15 @@ func (c *Connection) handleIngressData() {
 
 	inputChan := make(chan *cs.BytesMessage)
 
-	go func(s ByteStream) {
-		for {
-			message, err := s.Recv()
-			if err != nil {
-				c.Close()
-				break
-			}
-			inputChan <- message
-		}
-		if inputChan != nil {
-			close(inputChan)
-		}
-	}(c.byteStream)
+	if c.flow != nil {
+		received := make(chan *cs.BytesMessage)
+		done := make(chan struct{})
+		defer close(done)
+		go c.readStream(c.byteStream, received)
+		go c.bufferMessages(received, inputChan, done)
+	} else {
+		go c.readStream(c.byteStream, inputChan)
+	}
 
 	for {
 		select {
@@ -137,18 +358,50 @@ func (c *Connection) handleIngressData() {
 			if bytesMessage == nil {
 				inputChan = nil
 				break
+			}
+			if bytesMessage.HalfClose {
+				// Data for the other direction and window updates keep
+				// arriving until that direction is finished as well
+				c.closeWrite()
+				if c.halfCloseDone() {
+					c.Close()
+				}
+				continue
 			} else if len(bytesMessage.Content) == 0 {
-				c.remoteClose = true
+				atomic.StoreInt32(&c.remoteClose, 1)
+				if c.halfClose {
+					c.Close()
+				}
+				inputChan = nil
+				break
+			}
+
+			data, err := c.content(bytesMessage)
+			if err != nil {
+				Log.WithConnection(c.ID).Errorf("Failed to decode data: %v", err)
+				c.SendCloseMessage()
+				inputChan = nil
+				break
+			}
+
+			if !c.waitThawed() || !c.throttle(len(data)) {
 				inputChan = nil
 				break
 			} else {
-				bytesSent, err := c.TCPConn.Write(bytesMessage.Content)
+				bytesSent, err := c.TCPConn.Write(data)
 				if err != nil {
 					c.SendCloseMessage()
 					inputChan = nil
 					break
 				} else {
-					c.bytesTx += uint64(bytesSent)
+					c.touch()
+					atomic.AddUint64(&c.bytesTx, uint64(bytesSent))
+					atomic.AddUint64(&bytesSentTotal, uint64(bytesSent))
+					if c.flow != nil {
+						if update := c.flow.wrote(uint32(bytesSent)); update != 0 {
+							c.sendWindowUpdate(update)
+						}
+					}
 				}
 			}
 		case <-c.Kill:
@@ -162,18 +415,95 @@ func (c *Connection) handleIngressData() {
 
 }
 
+// readStream forwards the messages received on a byte stream to
+// inputChan. A resumable connection carries on with the stream that
+// replaces a broken one. Acknowledgements and window updates are
+// processed here, so that they do not wait behind data that waits for
+// the TCP connection.
+func (c *Connection) readStream(s ByteStream,
+	inputChan chan<- *cs.BytesMessage) {
+
+	for {
+		message, err := s.Recv()
+		if err != nil && c.resume != nil {
+			if next := c.awaitStream(s); next != nil {
+				s = next
+				continue
+			}
+		}
+		if err != nil {
+			if err != io.EOF && c.Status != ConnectionStatusClosed {
+				atomic.AddUint64(&streamErrorsTotal, 1)
+			}
+			// Buffered data is written before the connection closes
+			if err != io.EOF || c.flow == nil {
+				c.Close()
+			}
+			break
+		}
+
+		if !c.acceptMessage(message) {
+			continue
+		}
+		if message.Window != 0 {
+			if c.flow != nil {
+				c.flow.release(message.Window)
+			}
+			if len(message.Content) == 0 && !message.HalfClose {
+				continue
+			}
+		}
+
+		select {
+		case inputChan <- message:
+		case <-c.Kill:
+			return
+		}
+	}
+	close(inputChan)
+}
+
 // SendCloseMessage will send a zero sized
 // message to the remote endpoint, indicating
 // that a TCP connection has been closed locally.
 func (c *Connection) SendCloseMessage() {
 	closeMessage := new(cs.BytesMessage)
 	closeMessage.Content = make([]byte, 0)
-	c.byteStream.Send(closeMessage)
+	c.sendMessage(closeMessage)
+}
+
+// SetFirstReadHandler sets a function that is called with the first
+// data read from the TCP connection.
+func (c *Connection) SetFirstReadHandler(handler func([]byte)) {
+	c.onFirstRead = handler
+}
+
+// SetMTU sets the maximum number of bytes read from the TCP
+// connection and sent in a single byte stream message.
+func (c *Connection) SetMTU(mtu uint32) {
+	c.mtu = mtu
 }
 
 // SetStream will set the byteStream for a connection
 func (c *Connection) SetStream(s ByteStream) {
 	c.byteStream = s
+	if s != nil {
+		c.setupSpan.Finish()
+	}
+}
+
+// AttachStream sets the byte stream of a connection that the other
+// side opened, unless the connection already has one. It returns false
+// if it has, so that concurrent streams cannot both be attached.
+func (c *Connection) AttachStream(s ByteStream) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.byteStream != nil {
+		return false
+	}
+	c.SetStream(s)
+	return true
 }
 
 // Start will start two goroutines for handling the TCP socket
@@ -184,7 +514,22 @@ func (c *Connection) Start() {
 
 	if c.Status == ConnectionStatusCreated {
 		c.Status = ConnectionStatusConnected
-		go c.handleIngressData()
-		go c.handleEgressData()
+		close(c.started)
+		c.relays.Add(2)
+		go func() {
+			defer c.relays.Done()
+			c.handleIngressData()
+		}()
+		go func() {
+			defer c.relays.Done()
+			c.handleEgressData()
+		}()
+		if c.idleTimeout > 0 {
+			c.relays.Add(1)
+			go func() {
+				defer c.relays.Done()
+				c.watchIdle()
+			}()
+		}
 	}
 }
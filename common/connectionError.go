@@ -0,0 +1,68 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ConnectionFailure describes a connection of a tunnel that could not
+// be established, either locally or on the remote side.
+type ConnectionFailure struct {
+	ConnectionID  string
+	OriginAddress string
+	Code          cs.ConnectionError
+	Message       string
+	Time          time.Time
+}
+
+// String returns the code and message of the failure, such as
+// "DIAL_REFUSED: dial tcp 10.0.0.1:80: connect: connection refused".
+func (f ConnectionFailure) String() string {
+	if f.Message == "" {
+		return f.Code.String()
+	}
+	return fmt.Sprintf("%s: %s", f.Code, f.Message)
+}
+
+// dialErrorCode classifies the error of a failed dial for the side of
+// the tunnel that requested the connection.
+func dialErrorCode(err error) cs.ConnectionError {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, ErrPolicyDenied):
+		return cs.ConnectionError_POLICY_DENIED
+	case errors.As(err, &dnsErr):
+		return cs.ConnectionError_DNS_FAILURE
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return cs.ConnectionError_DIAL_TIMEOUT
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return cs.ConnectionError_DIAL_REFUSED
+	case errors.Is(err, syscall.ENETUNREACH),
+		errors.Is(err, syscall.EHOSTUNREACH):
+		return cs.ConnectionError_NO_ROUTE
+	}
+	return cs.ConnectionError_DIAL_FAILED
+}
+
+// failConnection answers a connect control message with a failed
+// acknowledgement that tells the initiating side why.
+func (t *Tunnel) failConnection(ctrlMessage *cs.TunnelControlMessage,
+	code cs.ConnectionError, reason string) {
+
+	message := new(cs.TunnelControlMessage)
+	message.Operation = TunnelCtrlAck
+	message.ErrorStatus = code
+	message.ErrorMessage = reason
+	message.TunnelId = t.id
+	message.ConnectionId = ctrlMessage.ConnectionId
+	t.SendCtrlMessage(message)
+}
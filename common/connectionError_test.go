@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestDialErrorCode(t *testing.T) {
+	// A port that was just freed refuses connections
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	_, refused := net.Dial("tcp", addr)
+
+	tests := []struct {
+		err  error
+		want cs.ConnectionError
+	}{
+		{refused, cs.ConnectionError_DIAL_REFUSED},
+		{&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host",
+			Name: "invalid.", IsNotFound: true}}, cs.ConnectionError_DNS_FAILURE},
+		{&net.OpError{Op: "dial", Err: context.DeadlineExceeded},
+			cs.ConnectionError_DIAL_TIMEOUT},
+		{errors.New("exec: not found"), cs.ConnectionError_DIAL_FAILED},
+		{fmt.Errorf("10.0.0.1:22 is %w", ErrPolicyDenied),
+			cs.ConnectionError_POLICY_DENIED},
+	}
+	for _, test := range tests {
+		if got := dialErrorCode(test.err); got != test.want {
+			t.Errorf("dialErrorCode(%v): Got: %s Want: %s", test.err, got, test.want)
+		}
+	}
+}
+
+func TestDialFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	port := uint32(ln.Addr().(*net.TCPAddr).Port)
+	ln.Close()
+
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("127.0.0.1"), port)
+	defer tunnel.Stop()
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	defer close(ctrl.recv)
+	tunnel.Start()
+
+	// The initiating side learns why the dial failed
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlConnect,
+		TunnelId: "tunnel", ConnectionId: "connection"}
+	select {
+	case message := <-ctrl.sent:
+		if message.ErrorStatus != cs.ConnectionError_DIAL_REFUSED ||
+			message.ErrorMessage == "" {
+			t.Errorf("connect: unexpected reply %v", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("connect: no reply")
+	}
+
+	failures, last := tunnel.GetFailedConnections()
+	if failures != 1 || last.ConnectionID != "connection" ||
+		last.Code != cs.ConnectionError_DIAL_REFUSED {
+		t.Errorf("GetFailedConnections: Got: %d %v Want: 1 DIAL_REFUSED",
+			failures, last)
+	}
+}
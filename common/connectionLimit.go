@@ -0,0 +1,49 @@
+package common
+
+import (
+	"fmt"
+)
+
+// connectionLimitReached returns why the tunnel cannot take another
+// connection, or an empty string if it can.
+func (t *Tunnel) connectionLimitReached() string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.limitReason()
+}
+
+// reserveConnection counts a connection that the remote side requested
+// against the limit while its destination is dialed. It returns why
+// the tunnel cannot take another connection instead, or an empty
+// string if it can.
+func (t *Tunnel) reserveConnection() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if reason := t.limitReason(); reason != "" {
+		return reason
+	}
+	t.dialing++
+	return ""
+}
+
+// releaseConnection stops counting a connection that was reserved,
+// once it was added to the tunnel or failed.
+func (t *Tunnel) releaseConnection() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.dialing--
+}
+
+// limitReason returns why the tunnel cannot take another connection,
+// or an empty string if it can. The caller must hold the mutex.
+func (t *Tunnel) limitReason() string {
+	max := t.options.MaxConnections
+	if max == 0 || uint32(len(t.connections))+t.dialing < max {
+		return ""
+	}
+	return fmt.Sprintf("tunnel %s has reached its limit of %d connections",
+		t.id, max)
+}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ctrlPipe is an in memory tunnel control stream.
+type ctrlPipe struct {
+	sent chan *cs.TunnelControlMessage
+	recv chan *cs.TunnelControlMessage
+}
+
+func (s *ctrlPipe) Send(message *cs.TunnelControlMessage) error {
+	s.sent <- message
+	return nil
+}
+
+func (s *ctrlPipe) Recv() (*cs.TunnelControlMessage, error) {
+	message, ok := <-s.recv
+	if !ok {
+		return nil, io.EOF
+	}
+	return message, nil
+}
+
+func TestMaxConnections(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("127.0.0.1"), 1)
+	defer tunnel.Stop()
+	tunnel.SetOptions(TunnelOptions{MaxConnections: 1})
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	defer close(ctrl.recv)
+	tunnel.Start()
+
+	local, remote := net.Pipe()
+	defer remote.Close()
+	tunnel.AddConnection(NewConnection(local))
+
+	// Connections requested by the remote side are refused with a reason
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlConnect,
+		TunnelId: "tunnel", ConnectionId: "connection"}
+	select {
+	case message := <-ctrl.sent:
+		if message.Operation != TunnelCtrlAck ||
+			message.ErrorStatus != cs.ConnectionError_POLICY_DENIED ||
+			message.ErrorMessage == "" {
+			t.Errorf("connect: unexpected reply %v", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("connect: no reply")
+	}
+
+	// Accepted connections are reset
+	if !tunnel.AddListener("") {
+		t.Fatalf("AddListener failed")
+	}
+	conn, err := net.Dial("tcp", tunnel.listeners[0].Addr().String())
+	if err == nil {
+		defer conn.Close()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			err = nil
+		}
+	}
+	if err == nil {
+		t.Errorf("connection was not refused")
+	}
+	if len(tunnel.GetConnections()) != 1 {
+		t.Errorf("refused connection was added to the tunnel")
+	}
+}
+
+func TestSlowDial(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("127.0.0.1"), 1)
+	defer tunnel.Stop()
+	tunnel.SetOptions(TunnelOptions{MaxConnections: 1})
+	tunnel.SetDialer(func(ctx context.Context, network, address string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	defer close(ctrl.recv)
+	tunnel.Start()
+
+	// The control messages of other connections are handled while the
+	// first one dials, which counts against the limit meanwhile
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlConnect,
+		TunnelId: "tunnel", ConnectionId: "slow"}
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlConnect,
+		TunnelId: "tunnel", ConnectionId: "limited"}
+	select {
+	case message := <-ctrl.sent:
+		if message.ConnectionId != "limited" ||
+			message.ErrorStatus != cs.ConnectionError_POLICY_DENIED {
+			t.Errorf("connect: unexpected reply %v", message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("connect: no reply while another connection dials")
+	}
+}
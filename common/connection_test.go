@@ -0,0 +1,280 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestConsumeStreamToken(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	c := NewConnection(local)
+	token := c.GetStreamToken()
+
+	if c.ConsumeStreamToken("invalid") {
+		t.Errorf("ConsumeStreamToken: Got: true Want: false for an invalid token")
+	}
+
+	if !c.ConsumeStreamToken(token) {
+		t.Errorf("ConsumeStreamToken: Got: false Want: true for the issued token")
+	}
+
+	if c.ConsumeStreamToken(token) {
+		t.Errorf("ConsumeStreamToken: Got: true Want: false for a replayed token")
+	}
+}
+
+func TestTunnelByteTotals(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward, nil, 0, nil, 0)
+	c := NewConnection(local)
+	tunnel.AddConnection(c)
+
+	c.bytesTx = 10
+	c.bytesRx = 20
+	tunnel.RemoveConnection(c.ID)
+
+	if tunnel.GetBytesSent() != 10 || tunnel.GetBytesReceived() != 20 {
+		t.Errorf("tunnel bytes: Got: %d/%d Want: 10/20",
+			tunnel.GetBytesSent(), tunnel.GetBytesReceived())
+	}
+}
+
+// recordingStream is a ByteStream that records the messages sent on it.
+type recordingStream struct {
+	sent []*cs.BytesMessage
+}
+
+func (s *recordingStream) Send(message *cs.BytesMessage) error {
+	s.sent = append(s.sent, message)
+	return nil
+}
+
+func (s *recordingStream) Recv() (*cs.BytesMessage, error) {
+	return nil, io.EOF
+}
+
+func TestStreamResume(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	c := NewConnection(local)
+	c.EnableResume("tunnel", "token", nil)
+	first := new(recordingStream)
+	c.SetStream(first)
+
+	c.sendMessage(&cs.BytesMessage{Content: []byte("a")})
+	c.sendMessage(&cs.BytesMessage{Content: []byte("b")})
+	if len(first.sent) != 2 || first.sent[1].Sequence != 2 {
+		t.Fatalf("sendMessage: unexpected messages %v", first.sent)
+	}
+
+	if c.acceptMessage(&cs.BytesMessage{Ack: 1}) {
+		t.Errorf("acceptMessage: Got: true Want: false for an acknowledgement")
+	}
+	if !c.acceptMessage(&cs.BytesMessage{Sequence: 1, Content: []byte("x")}) {
+		t.Errorf("acceptMessage: Got: false Want: true for new data")
+	}
+	if c.acceptMessage(&cs.BytesMessage{Sequence: 1, Content: []byte("x")}) {
+		t.Errorf("acceptMessage: Got: true Want: false for a duplicate")
+	}
+
+	if _, err := c.ResumeStream(new(recordingStream),
+		&cs.BytesMessage{StreamToken: "invalid", Resume: true}); err == nil {
+		t.Errorf("ResumeStream: accepted an invalid token")
+	}
+
+	// The remote side only received the first message
+	second := new(recordingStream)
+	if _, err := c.ResumeStream(second,
+		&cs.BytesMessage{StreamToken: "token", Resume: true, Ack: 1}); err != nil {
+		t.Fatalf("ResumeStream: %v", err)
+	}
+	if len(second.sent) != 2 || !second.sent[0].Resume || second.sent[0].Ack != 1 ||
+		string(second.sent[1].Content) != "b" {
+		t.Errorf("ResumeStream: unexpected messages %v", second.sent)
+	}
+	if c.GetStream() != second {
+		t.Errorf("ResumeStream: stream was not replaced")
+	}
+}
+
+func TestTunnelPause(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, nil, 0)
+	if !tunnel.AddListener("") {
+		t.Fatalf("AddListener failed")
+	}
+	defer tunnel.Stop()
+
+	local, remote := net.Pipe()
+	defer remote.Close()
+	c := NewConnection(local)
+	tunnel.AddConnection(c)
+
+	tunnel.Pause(true)
+	if !tunnel.IsPaused() || len(tunnel.listeners) != 0 {
+		t.Fatalf("Pause: tunnel is still listening")
+	}
+	if c.thaw == nil {
+		t.Errorf("Pause: connection was not frozen")
+	}
+
+	if err := tunnel.Unpause(); err != nil {
+		t.Fatalf("Unpause: %v", err)
+	}
+	if tunnel.IsPaused() || len(tunnel.listeners) != 1 {
+		t.Errorf("Unpause: tunnel is not listening")
+	}
+	if !c.waitThawed() {
+		t.Errorf("Unpause: connection is still frozen")
+	}
+}
+
+func TestEndpointConcurrentAccess(t *testing.T) {
+	e := NewEndpoint()
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward, nil, 0, nil, 0)
+	e.AddTunnel("tunnel", tunnel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				local, remote := net.Pipe()
+				c := NewConnection(local)
+				tunnel.AddConnection(c)
+				tunnel.RemoveConnection(c.ID)
+				remote.Close()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for range e.GetTunnels() {
+					for range tunnel.GetConnections() {
+					}
+				}
+				e.SetMTU(uint32(j))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tunnel.ConnectionCount() != 0 {
+		t.Errorf("ConnectionCount: Got: %d Want: 0", tunnel.ConnectionCount())
+	}
+}
+
+func TestTunnelContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tunnel := NewTunnelWithContext(ctx, "tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, nil, 0)
+	if !tunnel.AddListener("") {
+		t.Fatalf("AddListener failed")
+	}
+
+	local, remote := net.Pipe()
+	defer remote.Close()
+	c := NewConnection(local)
+	tunnel.AddConnection(c)
+
+	cancel()
+	select {
+	case <-c.Kill:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("cancel: connection was not closed")
+	}
+	if _, _, err := tunnel.dial(); err == nil {
+		t.Errorf("dial: Got: nil Want: an error after the tunnel stopped")
+	}
+}
+
+func TestTunnelStop(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, nil, 0)
+	if !tunnel.AddListener("") {
+		t.Fatalf("AddListener failed")
+	}
+
+	if !tunnel.Stop() {
+		t.Fatalf("Stop: goroutines did not exit")
+	}
+	if !tunnel.Stop() {
+		t.Errorf("Stop: second call did not return")
+	}
+	if tunnel.AddListener("") {
+		t.Errorf("AddListener: Got: true Want: false after Stop")
+	}
+}
+
+func TestTunnelStopControlStream(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward, nil, 0, nil, 0)
+
+	// The receive on the control stream blocks until the stream is
+	// closed, which happens after the tunnel stopped
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	defer close(ctrl.recv)
+	tunnel.Start()
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlDisconnect,
+		TunnelId: "tunnel", ConnectionId: "connection"}
+
+	start := time.Now()
+	if !tunnel.Stop() {
+		t.Fatalf("Stop: goroutines did not exit")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Stop: Got: %s Want: no wait for the control stream", elapsed)
+	}
+}
+
+func TestTunnelChunkSize(t *testing.T) {
+	e := NewEndpoint()
+	bulk := NewTunnel("bulk", TunnelDirectionForward, nil, 0, nil, 0)
+	bulk.SetOptions(TunnelOptions{ChunkSize: 65536})
+	e.AddTunnel("bulk", bulk)
+	other := NewTunnel("other", TunnelDirectionForward, nil, 0, nil, 0)
+	e.AddTunnel("other", other)
+
+	e.SetMTU(1024)
+	if bulk.GetMTU() != 65536 || other.GetMTU() != 1024 {
+		t.Errorf("SetMTU: Got: %d %d Want: 65536 1024",
+			bulk.GetMTU(), other.GetMTU())
+	}
+}
+
+func TestAttachStream(t *testing.T) {
+	c := NewConnection(nil)
+
+	// Only one of concurrent streams is attached
+	var attached int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if c.AttachStream(new(recordingStream)) {
+				atomic.AddInt32(&attached, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	if attached != 1 || c.GetStream() == nil {
+		t.Errorf("AttachStream: Got %d attached streams Want: 1", attached)
+	}
+}
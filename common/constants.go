@@ -1,5 +1,7 @@
 package common
 
+import "time"
+
 const (
 	EndpointCtrlDisconnect = iota
 	EndpointCtrlAddRTunnel
@@ -8,6 +10,21 @@ const (
 	EndpointCtrlSocksProxyAck
 	EndpointCtrlSocksKill
 	EndpointCtrlDeleteTunnel
+	EndpointCtrlConfigure
+	EndpointCtrlKeepalive
+	EndpointCtrlShutdown
+	EndpointCtrlPauseTunnel
+	EndpointCtrlResumeTunnel
+	EndpointCtrlDialPolicy
+	EndpointCtrlAddRelay
+	EndpointCtrlDeleteRelay
+	EndpointCtrlPing
+	EndpointCtrlTestStream
+	EndpointCtrlScan
+	EndpointCtrlPutFile
+	EndpointCtrlGetFile
+	EndpointCtrlShell
+	EndpointCtrlLogLevel
 )
 
 const (
@@ -19,6 +36,11 @@ const (
 	TunnelCtrlConnect = iota
 	TunnelCtrlAck
 	TunnelCtrlDisconnect
+	TunnelCtrlKeepalive
+	TunnelCtrlWarning
+	TunnelCtrlRekey
+	TunnelCtrlRekeyAck
+	TunnelCtrlRekeyDone
 )
 
 const (
@@ -26,3 +48,40 @@ const (
 	ConnectionStatusConnected
 	ConnectionStatusClosed
 )
+
+// Ways in which an endpoint handles a test stream that gServer asks it
+// to open. An echo stream sends every message back. A sink stream
+// discards the bytes it receives and sends an empty message once it
+// has the number of bytes asked for. A source stream sends the number
+// of bytes asked for once it receives a message.
+const (
+	StreamTestEcho   = "echo"
+	StreamTestSink   = "sink"
+	StreamTestSource = "source"
+)
+
+// StreamTestChunkSize is the number of bytes carried by a single
+// message of a sink or source test stream.
+const StreamTestChunkSize = 32 * 1024
+
+// DefaultMTU is the maximum number of bytes carried by a single
+// byte stream message when no MTU has been configured.
+const DefaultMTU = 4096
+
+// MaxChunkSize is the largest chunk size that can be configured for a
+// tunnel, and the largest MTU of an endpoint. It stays well below the
+// default gRPC message size limit.
+const MaxChunkSize = 1 << 20
+
+// DialTimeout is how long dialing the destination of a tunnel may
+// take.
+const DialTimeout = 10 * time.Second
+
+// ConnectAckTimeout is how long a new connection waits for the remote
+// side of the tunnel to acknowledge it. It leaves room for the remote
+// side to dial.
+const ConnectAckTimeout = 3 * DialTimeout
+
+// TunnelStopTimeout is how long stopping a tunnel waits for its
+// goroutines and connections to finish.
+const TunnelStopTimeout = 5 * time.Second
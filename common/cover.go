@@ -0,0 +1,22 @@
+package common
+
+import "math/rand"
+
+// MaxCoverBytes is the most padding that a cover message carries in
+// either direction.
+const MaxCoverBytes = 16384
+
+// CoverPadding returns n random bytes of padding for a cover message,
+// up to MaxCoverBytes. They are random so that they do not compress
+// away.
+func CoverPadding(n int) []byte {
+	if n > MaxCoverBytes {
+		n = MaxCoverBytes
+	}
+	if n <= 0 {
+		return nil
+	}
+	padding := make([]byte, n)
+	rand.Read(padding)
+	return padding
+}
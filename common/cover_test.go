@@ -0,0 +1,15 @@
+package common
+
+import "testing"
+
+func TestCoverPadding(t *testing.T) {
+	if padding := CoverPadding(100); len(padding) != 100 {
+		t.Errorf("CoverPadding(100): Got: %d bytes", len(padding))
+	}
+	if padding := CoverPadding(MaxCoverBytes + 1); len(padding) != MaxCoverBytes {
+		t.Errorf("CoverPadding: Got: %d bytes Want: at most %d", len(padding), MaxCoverBytes)
+	}
+	if padding := CoverPadding(0); padding != nil {
+		t.Errorf("CoverPadding(0): Got: %d bytes Want: none", len(padding))
+	}
+}
@@ -0,0 +1,111 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// SlowAcceptThreshold is the connect latency above which a
+	// destination is flagged as a possible tarpit.
+	SlowAcceptThreshold = 3 * time.Second
+
+	// uniformLatencySamples is the number of connect latencies that
+	// are kept and required before the latency variance is checked.
+	uniformLatencySamples = 8
+
+	// uniformLatencyVariation is the coefficient of variation below
+	// which connect latencies are considered suspiciously uniform.
+	uniformLatencyVariation = 0.01
+)
+
+// honeypotBanners are the default banners of common low interaction
+// honeypots. A destination sending one of these verbatim is likely a
+// honeypot that was deployed without customization.
+var honeypotBanners = [][]byte{
+	// Cowrie
+	[]byte("SSH-2.0-OpenSSH_6.0p1 Debian-4+deb7u2"),
+	// Kippo
+	[]byte("SSH-2.0-OpenSSH_5.1p1 Debian-5"),
+	// Dionaea ftp
+	[]byte("220 DiskStation FTP server ready."),
+	// Conpot
+	[]byte("Siemens, SIMATIC, S7-200"),
+}
+
+// DeceptionDetector applies heuristics to the connections dialed by a
+// tunnel in order to warn the operator about destinations that are
+// likely tarpits or honeypots.
+type DeceptionDetector struct {
+	latencies []time.Duration
+	mutex     sync.Mutex
+}
+
+// NewDeceptionDetector is a constructor for DeceptionDetector.
+func NewDeceptionDetector() *DeceptionDetector {
+	d := new(DeceptionDetector)
+	d.latencies = make([]time.Duration, 0, uniformLatencySamples)
+	return d
+}
+
+// CheckConnect records the time it took to connect to the destination
+// and returns warnings for slow accepts and for connect latencies that
+// are too uniform to come from a real network stack.
+func (d *DeceptionDetector) CheckConnect(latency time.Duration) []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	warnings := make([]string, 0)
+
+	if latency > SlowAcceptThreshold {
+		warnings = append(warnings,
+			fmt.Sprintf("slow accept (%s), possible tarpit", latency))
+	}
+
+	if len(d.latencies) == uniformLatencySamples {
+		d.latencies = d.latencies[1:]
+	}
+	d.latencies = append(d.latencies, latency)
+
+	if len(d.latencies) < uniformLatencySamples {
+		return warnings
+	}
+
+	var mean float64
+	for _, l := range d.latencies {
+		mean += float64(l)
+	}
+	mean /= float64(len(d.latencies))
+
+	var variance float64
+	for _, l := range d.latencies {
+		variance += math.Pow(float64(l)-mean, 2)
+	}
+	variance /= float64(len(d.latencies))
+
+	if mean > 0 && math.Sqrt(variance)/mean < uniformLatencyVariation {
+		warnings = append(warnings,
+			fmt.Sprintf("uniform connect latency over %d connections, possible emulated host",
+				len(d.latencies)))
+	}
+
+	return warnings
+}
+
+// CheckBanner inspects the first data received from the destination
+// and returns warnings for known honeypot banners.
+func (d *DeceptionDetector) CheckBanner(banner []byte) []string {
+	warnings := make([]string, 0)
+
+	for _, known := range honeypotBanners {
+		if bytes.Contains(banner, known) {
+			warnings = append(warnings,
+				fmt.Sprintf("default honeypot banner %q", known))
+		}
+	}
+
+	return warnings
+}
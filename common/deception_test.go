@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// streamHandler sets up byte streams that only record what is sent.
+type streamHandler struct{}
+
+func (h *streamHandler) GetByteStream(tunnel *Tunnel,
+	ctrlMessage *cs.TunnelControlMessage) ByteStream {
+	return &pipeStream{send: make(chan *cs.BytesMessage, 16),
+		recv: make(chan *cs.BytesMessage)}
+}
+
+func (h *streamHandler) CloseStream(tunnel *Tunnel, connID string) {}
+
+func (h *streamHandler) Acknowledge(tunnel *Tunnel,
+	ctrlMessage *cs.TunnelControlMessage) ByteStream {
+	return nil
+}
+
+func TestCheckConnectSlowAccept(t *testing.T) {
+	d := NewDeceptionDetector()
+
+	warnings := d.CheckConnect(10 * time.Millisecond)
+	if len(warnings) != 0 {
+		t.Errorf("CheckConnect: Got: %v Want: no warnings", warnings)
+	}
+
+	warnings = d.CheckConnect(SlowAcceptThreshold + time.Second)
+	if len(warnings) != 1 {
+		t.Errorf("CheckConnect: Got: %v Want: slow accept warning", warnings)
+	}
+}
+
+func TestCheckConnectUniformLatency(t *testing.T) {
+	d := NewDeceptionDetector()
+
+	var warnings []string
+	for i := 0; i < uniformLatencySamples; i++ {
+		warnings = d.CheckConnect(5 * time.Millisecond)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("CheckConnect: Got: %v Want: uniform latency warning", warnings)
+	}
+
+	d = NewDeceptionDetector()
+	for i := 0; i < uniformLatencySamples; i++ {
+		warnings = d.CheckConnect(time.Duration(i+1) * time.Millisecond)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("CheckConnect: Got: %v Want: no warnings", warnings)
+	}
+}
+
+func TestCheckBanner(t *testing.T) {
+	d := NewDeceptionDetector()
+
+	warnings := d.CheckBanner([]byte("SSH-2.0-OpenSSH_6.0p1 Debian-4+deb7u2\r\n"))
+	if len(warnings) != 1 {
+		t.Errorf("CheckBanner: Got: %v Want: honeypot warning", warnings)
+	}
+
+	warnings = d.CheckBanner([]byte("SSH-2.0-OpenSSH_8.9p1 Ubuntu-3\r\n"))
+	if len(warnings) != 0 {
+		t.Errorf("CheckBanner: Got: %v Want: no warnings", warnings)
+	}
+}
+
+func TestTunnelDetectDeception(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("127.0.0.1"), 22)
+	defer tunnel.Stop()
+	tunnel.SetOptions(TunnelOptions{DetectDeception: true})
+	destination, remote := net.Pipe()
+	defer remote.Close()
+	tunnel.SetDialer(func(ctx context.Context, network, address string) (net.Conn, error) {
+		return destination, nil
+	})
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	tunnel.ConnectionHandler = new(streamHandler)
+	defer close(ctrl.recv)
+	tunnel.Start()
+
+	// The dialing side warns about a destination with a honeypot banner
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlConnect,
+		TunnelId: "tunnel", ConnectionId: "connection"}
+	go remote.Write([]byte("SSH-2.0-OpenSSH_6.0p1 Debian-4+deb7u2\r\n"))
+
+	select {
+	case message := <-ctrl.sent:
+		if message.Operation != TunnelCtrlWarning ||
+			message.ConnectionId != "connection" ||
+			!strings.Contains(message.Warning, "honeypot") {
+			t.Errorf("warning: unexpected message %v", message)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("warning: no warning sent for the honeypot banner")
+	}
+	if warnings := tunnel.GetConnection("connection").GetWarnings(); len(warnings) != 1 {
+		t.Errorf("GetWarnings: Got: %v Want: the honeypot warning", warnings)
+	}
+
+	// The other side records the warnings it is sent
+	local, peer := net.Pipe()
+	defer peer.Close()
+	conn := NewConnection(local)
+	tunnel.AddConnection(conn)
+	ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlWarning,
+		TunnelId: "tunnel", ConnectionId: conn.ID, Warning: "possible tarpit"}
+
+	deadline := time.Now().Add(time.Second)
+	for len(conn.GetWarnings()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if warnings := conn.GetWarnings(); len(warnings) != 1 || warnings[0] != "possible tarpit" {
+		t.Errorf("GetWarnings: Got: %v Want: [possible tarpit]", warnings)
+	}
+}
@@ -0,0 +1,156 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrPolicyDenied is wrapped by the errors of dials that a dial policy
+// forbids.
+var ErrPolicyDenied = errors.New("denied by the dial policy")
+
+// DialCheck decides whether an endpoint may dial ip:port. It is called
+// with a nil ip for command tunnels, which spawn a process instead of
+// dialing.
+type DialCheck func(ip net.IP, port uint32) error
+
+// DialPolicy restricts the destinations that an endpoint may dial. A
+// destination is allowed if it matches no deny rule and, if there are
+// allow rules, at least one of them.
+type DialPolicy struct {
+	allow []dialRule
+	deny  []dialRule
+}
+
+// dialRule matches destinations in a network and a port range. A nil
+// network matches all addresses.
+type dialRule struct {
+	network *net.IPNet
+	ports   PortRange
+}
+
+// ParseDialPolicy is a constructor for DialPolicy. allow and deny are
+// comma separated rules such as "10.0.0.0/8", "192.168.1.5:22",
+// "*:443" or "[fd00::/8]:8000-9000". Rules without a port match all
+// ports.
+func ParseDialPolicy(allow string, deny string) (*DialPolicy, error) {
+	p := new(DialPolicy)
+
+	var err error
+	if p.allow, err = parseDialRules(allow); err != nil {
+		return nil, err
+	}
+	if p.deny, err = parseDialRules(deny); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseDialRules parses a comma separated list of rules.
+func parseDialRules(rules string) ([]dialRule, error) {
+	parsed := make([]dialRule, 0)
+	for _, rule := range strings.Split(rules, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		r, err := parseDialRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dial rule %q: %v", rule, err)
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+// parseDialRule parses a single rule in the format of ParseDialPolicy.
+func parseDialRule(rule string) (dialRule, error) {
+	r := dialRule{ports: AllPorts}
+
+	host, ports := rule, ""
+	if h, p, err := net.SplitHostPort(rule); err == nil {
+		host, ports = h, p
+	}
+
+	if host != "*" {
+		network, err := ParseNetwork(host)
+		if err != nil {
+			return r, err
+		}
+		r.network = network
+	}
+
+	if ports == "" || ports == "*" {
+		return r, nil
+	}
+	var err error
+	r.ports, err = ParsePortRange(ports)
+	return r, err
+}
+
+// matches returns true if ip:port is within the rule.
+func (r dialRule) matches(ip net.IP, port uint32) bool {
+	if r.network != nil && !r.network.Contains(ip) {
+		return false
+	}
+	return r.ports.Contains(port)
+}
+
+// Check returns an error wrapping ErrPolicyDenied if the policy does
+// not allow dialing ip:port. Command tunnels, checked with a nil ip,
+// could reach anything, so they are only allowed by policies without
+// allow rules. A nil policy allows everything.
+func (p *DialPolicy) Check(ip net.IP, port uint32) error {
+	if p == nil {
+		return nil
+	}
+	if ip == nil {
+		if len(p.allow) > 0 {
+			return fmt.Errorf("command tunnels are %w", ErrPolicyDenied)
+		}
+		return nil
+	}
+
+	for _, r := range p.deny {
+		if r.matches(ip, port) {
+			return fmt.Errorf("%s:%d is %w", ip, port, ErrPolicyDenied)
+		}
+	}
+	for _, r := range p.allow {
+		if r.matches(ip, port) {
+			return nil
+		}
+	}
+	if len(p.allow) > 0 {
+		return fmt.Errorf("%s:%d is %w", ip, port, ErrPolicyDenied)
+	}
+	return nil
+}
+
+// SetDialCheck sets a function that every dial of the tunnel has to
+// pass.
+func (t *Tunnel) SetDialCheck(check DialCheck) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.dialCheck = check
+}
+
+// checkDial applies the dial check of the tunnel, if any, to one of its
+// destinations.
+func (t *Tunnel) checkDial(ip net.IP, port uint32) error {
+	t.mutex.RLock()
+	check := t.dialCheck
+	command := t.options.Command
+	t.mutex.RUnlock()
+
+	if check == nil {
+		return nil
+	}
+	if command != "" {
+		return check(nil, 0)
+	}
+	return check(ip, port)
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestDialPolicy(t *testing.T) {
+	policy, err := ParseDialPolicy("10.0.0.0/8, 192.168.1.5:22, *:443, [fd00::/8]:8000-9000",
+		"10.0.0.1, *:25")
+	if err != nil {
+		t.Fatalf("ParseDialPolicy: %v", err)
+	}
+
+	tests := []struct {
+		ip      string
+		port    uint32
+		allowed bool
+	}{
+		{"10.1.2.3", 80, true},
+		{"10.0.0.1", 80, false},
+		{"10.1.2.3", 25, false},
+		{"192.168.1.5", 22, true},
+		{"192.168.1.5", 23, false},
+		{"8.8.8.8", 443, true},
+		{"8.8.8.8", 80, false},
+		{"fd00::1", 8080, true},
+		{"fd00::1", 9001, false},
+	}
+	for _, test := range tests {
+		err := policy.Check(net.ParseIP(test.ip), test.port)
+		if (err == nil) != test.allowed {
+			t.Errorf("Check(%s, %d): Got: %v Want allowed: %t",
+				test.ip, test.port, err, test.allowed)
+		}
+		if err != nil && !errors.Is(err, ErrPolicyDenied) {
+			t.Errorf("Check(%s, %d): Got: %v Want: ErrPolicyDenied",
+				test.ip, test.port, err)
+		}
+	}
+
+	// Commands could reach anything
+	if err := policy.Check(nil, 0); err == nil {
+		t.Errorf("Command tunnel allowed by an allowlist")
+	}
+
+	denyOnly, _ := ParseDialPolicy("", "169.254.169.254")
+	if err := denyOnly.Check(nil, 0); err != nil {
+		t.Errorf("Command tunnel denied by a denylist: %v", err)
+	}
+	if err := denyOnly.Check(net.ParseIP("169.254.169.254"), 80); err == nil {
+		t.Errorf("Denied address allowed")
+	}
+
+	var none *DialPolicy
+	if err := none.Check(net.ParseIP("1.2.3.4"), 22); err != nil {
+		t.Errorf("Nil policy denied a dial: %v", err)
+	}
+
+	for _, invalid := range []string{"example.com", "10.0.0.0/33", "*:70000", "*:90-80"} {
+		if _, err := ParseDialPolicy(invalid, ""); err == nil {
+			t.Errorf("ParseDialPolicy(%s): Got no error", invalid)
+		}
+	}
+}
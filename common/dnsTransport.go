@@ -0,0 +1,186 @@
+package common
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DNS transport queries carry at most DNSUpstreamBytes to gServer and
+// their TXT answers at most DNSDownstreamBytes back, so that both fit
+// in a plain 512 byte UDP message.
+const (
+	DNSUpstreamBytes   = 100
+	DNSDownstreamBytes = 150
+)
+
+// DNSOpenLabel takes the place of the session in the name of the query
+// that opens a session.
+const DNSOpenLabel = "open"
+
+// dnsMaxDomain is the longest domain that leaves room for the labels
+// of a query within the 253 characters of a name.
+const dnsMaxDomain = 60
+
+// Names are case insensitive and resolvers may change the case.
+var dnsEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").
+	WithPadding(base32.NoPadding)
+
+// DNSQueryName returns the name of the query number seq of a session,
+// which carries data: session.seq.data.domain. data is split into
+// labels of up to 63 characters.
+func DNSQueryName(session string, seq uint32, data []byte, domain string) string {
+	labels := []string{session, strconv.FormatUint(uint64(seq), 10)}
+	encoded := dnsEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := len(encoded)
+		if n > 63 {
+			n = 63
+		}
+		labels = append(labels, encoded[:n])
+		encoded = encoded[n:]
+	}
+	labels = append(labels, strings.Trim(domain, "."))
+	return strings.Join(labels, ".") + "."
+}
+
+// ParseDNSQueryName returns the session, number and data of the name
+// of a query for domain.
+func ParseDNSQueryName(name string, domain string) (string, uint32, []byte, error) {
+	name = strings.ToLower(strings.Trim(name, "."))
+	suffix := "." + strings.ToLower(strings.Trim(domain, "."))
+	if !strings.HasSuffix(name, suffix) {
+		return "", 0, nil, fmt.Errorf("%s is not within %s", name, domain)
+	}
+
+	labels := strings.Split(strings.TrimSuffix(name, suffix), ".")
+	if len(labels) < 2 {
+		return "", 0, nil, fmt.Errorf("malformed query name %s", name)
+	}
+	seq, err := strconv.ParseUint(labels[1], 10, 32)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("malformed query number: %v", err)
+	}
+	data, err := dnsEncoding.DecodeString(strings.Join(labels[2:], ""))
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("malformed query data: %v", err)
+	}
+	return labels[0], uint32(seq), data, nil
+}
+
+// EncodeDNSAnswer returns the TXT record that carries data. more tells
+// the gClient that more data is waiting.
+func EncodeDNSAnswer(data []byte, more bool) string {
+	flag := "0"
+	if more {
+		flag = "1"
+	}
+	return flag + base64.RawStdEncoding.EncodeToString(data)
+}
+
+// DecodeDNSAnswer returns the data of a TXT record and whether more
+// data is waiting.
+func DecodeDNSAnswer(txt string) ([]byte, bool, error) {
+	if txt == "" {
+		return nil, false, errors.New("empty answer")
+	}
+	data, err := base64.RawStdEncoding.DecodeString(txt[1:])
+	return data, txt[0] == '1', err
+}
+
+// DNSTransport carries gRPC in TXT queries for names within a domain
+// for which gServer is the authoritative name server. It is meant as a
+// last resort where nothing but DNS leaves the network, at a few
+// kilobytes per second at best.
+type DNSTransport struct {
+	Domain string
+
+	// Resolver is the address of the resolver, such as 10.0.0.1:53.
+	// The resolver of the system is used if empty.
+	Resolver string
+}
+
+// Name returns the name of the transport.
+func (t *DNSTransport) Name() string {
+	return TransportDNS
+}
+
+// Dialer returns Dial.
+func (t *DNSTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return t.Dial
+}
+
+// Dial opens a session with gServer. address is not used, the queries
+// reach gServer through the resolver.
+func (t *DNSTransport) Dial(ctx context.Context, address string) (net.Conn, error) {
+	if len(strings.Trim(t.Domain, ".")) > dnsMaxDomain {
+		return nil, fmt.Errorf("the domain of the dns transport is longer than %d characters",
+			dnsMaxDomain)
+	}
+
+	e := new(dnsExchanger)
+	e.domain = t.Domain
+	e.resolver = net.DefaultResolver
+	if t.Resolver != "" {
+		e.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, t.Resolver)
+			},
+		}
+	}
+
+	// The nonce keeps resolvers from answering from their cache
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	name := DNSQueryName(DNSOpenLabel, 0, nonce, t.Domain)
+	records, err := e.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[0] == "" {
+		return nil, errors.New("failed to open dns session")
+	}
+
+	e.session = records[0]
+	return newPollConn(e, &transportAddr{TransportDNS, t.Domain}), nil
+}
+
+// dnsExchanger carries the exchanges of a DNS transport session in TXT
+// queries.
+type dnsExchanger struct {
+	domain   string
+	session  string
+	resolver *net.Resolver
+}
+
+// exchange sends the query number seq with data.
+func (e *dnsExchanger) exchange(seq uint32, data []byte) ([]byte, bool, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	records, err := e.resolver.LookupTXT(ctx, DNSQueryName(e.session, seq, data, e.domain))
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if len(records) == 0 {
+		return nil, false, 0, errors.New("empty answer")
+	}
+	answer, more, err := DecodeDNSAnswer(records[0])
+	return answer, more, len(data), err
+}
+
+// chunkSize returns DNSUpstreamBytes.
+func (e *dnsExchanger) chunkSize() int {
+	return DNSUpstreamBytes
+}
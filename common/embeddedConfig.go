@@ -0,0 +1,69 @@
+package common
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// embeddedConfigData binds sealed configurations to their purpose.
+const embeddedConfigData = "gtunnel embedded client configuration"
+
+// SealConfig encrypts the settings of a gClient, its variables by name,
+// with XChaCha20-Poly1305 under a random key. It returns the key, nonce
+// and ciphertext as a single base64 blob that the builder embeds in
+// place of the plaintext settings, so that the server address, token
+// and other options are not found in the strings of the binary. The key
+// travels with the blob, which hinders static extraction rather than
+// preventing it.
+func SealConfig(config map[string]string) (string, error) {
+	plaintext, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	blob := make([]byte, chacha20poly1305.KeySize+chacha20poly1305.NonceSizeX,
+		chacha20poly1305.KeySize+chacha20poly1305.NonceSizeX+
+			len(plaintext)+chacha20poly1305.Overhead)
+	if _, err := rand.Read(blob); err != nil {
+		return "", err
+	}
+	aead, err := chacha20poly1305.NewX(blob[:chacha20poly1305.KeySize])
+	if err != nil {
+		return "", err
+	}
+	nonce := blob[chacha20poly1305.KeySize:]
+	blob = aead.Seal(blob, nonce, plaintext, []byte(embeddedConfigData))
+	return base64.StdEncoding.EncodeToString(blob), nil
+}
+
+// OpenConfig decrypts the settings sealed by SealConfig.
+func OpenConfig(sealed string) (map[string]string, error) {
+	blob, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	header := chacha20poly1305.KeySize + chacha20poly1305.NonceSizeX
+	if len(blob) < header+chacha20poly1305.Overhead {
+		return nil, fmt.Errorf("invalid configuration: too short")
+	}
+
+	aead, err := chacha20poly1305.NewX(blob[:chacha20poly1305.KeySize])
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, blob[chacha20poly1305.KeySize:header],
+		blob[header:], []byte(embeddedConfigData))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	var config map[string]string
+	if err := json.Unmarshal(plaintext, &config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestEmbeddedConfig(t *testing.T) {
+	config := map[string]string{
+		"serverAddress": "c2.example.com",
+		"clientToken":   "0123456789abcdef",
+	}
+	sealed, err := SealConfig(config)
+	if err != nil {
+		t.Fatalf("SealConfig: %v", err)
+	}
+	if strings.Contains(sealed, "c2.example.com") {
+		t.Errorf("SealConfig: the server address is in plaintext")
+	}
+	blob, _ := base64.StdEncoding.DecodeString(sealed)
+	if strings.Contains(string(blob), "c2.example.com") {
+		t.Errorf("SealConfig: the server address is in the blob")
+	}
+
+	opened, err := OpenConfig(sealed)
+	if err != nil {
+		t.Fatalf("OpenConfig: %v", err)
+	}
+	if len(opened) != 2 || opened["serverAddress"] != "c2.example.com" ||
+		opened["clientToken"] != "0123456789abcdef" {
+		t.Errorf("OpenConfig: Got: %v", opened)
+	}
+
+	// Tampered blobs are refused
+	blob[len(blob)-1] ^= 1
+	if _, err := OpenConfig(base64.StdEncoding.EncodeToString(blob)); err == nil {
+		t.Errorf("OpenConfig: opened a tampered configuration")
+	}
+	for _, sealed := range []string{"", "not base64!", "c2hvcnQ="} {
+		if _, err := OpenConfig(sealed); err == nil {
+			t.Errorf("OpenConfig(%s): Want: error", sealed)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+package common
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+// errNoStreamKey is returned when data of an encrypted tunnel is
+// relayed before its sides exchanged keys.
+var errNoStreamKey = errors.New("the tunnel key is not established")
+
+// ServerKey is the static X25519 key of gServer. gClients are built
+// with its public key, which authenticates the key exchange of
+// encrypted tunnels with a key that a party terminating TLS in between
+// never sees. Only gServer holds the private key.
+type ServerKey struct {
+	private []byte
+	public  []byte
+}
+
+// NewServerKey generates a server key.
+func NewServerKey() (*ServerKey, error) {
+	private, public, err := newKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	return &ServerKey{private: private, public: public}, nil
+}
+
+// LoadServerKey reads the server key from path, which holds the base64
+// encoded private key. The key is generated and written to path if the
+// file does not exist.
+func LoadServerKey(path string) (*ServerKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		key, err := NewServerKey()
+		if err != nil {
+			return nil, err
+		}
+		encoded := base64.StdEncoding.EncodeToString(key.private)
+		if err := ioutil.WriteFile(path, []byte(encoded+"\n"), 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	private, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(private) != curve25519.ScalarSize {
+		return nil, fmt.Errorf("%s holds no server key", path)
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return &ServerKey{private: private, public: public}, nil
+}
+
+// ParseServerKey parses the base64 encoded public key of gServer, as
+// returned by String.
+func ParseServerKey(public string) (*ServerKey, error) {
+	key, err := base64.StdEncoding.DecodeString(public)
+	if err != nil || len(key) != curve25519.PointSize {
+		return nil, errors.New("invalid server key")
+	}
+	return &ServerKey{public: key}, nil
+}
+
+// String returns the base64 encoded public key of the server key.
+func (s *ServerKey) String() string {
+	return base64.StdEncoding.EncodeToString(s.public)
+}
+
+// tunnelCipher encrypts the byte stream payloads of a tunnel with
+// XChaCha20-Poly1305. Both sides of the tunnel derive the keys from an
+// X25519 exchange over the control streams, which the endpoint mixes
+// with the server key it was built with and gServer with its private
+// server key, so that a party that terminates TLS in between can
+// neither learn the keys nor replace them with its own. Rekeys replace
+// the keys with ones from a new exchange, each of which is a new
+// generation.
+type tunnelCipher struct {
+	used       uint64
+	private    []byte
+	public     []byte
+	server     *ServerKey
+	generation uint32
+	keys       map[uint32]*streamKeys
+	pending    *pendingRekey
+	rekeyed    time.Time
+	mutex      sync.RWMutex
+}
+
+// streamKeys are the keys of a generation. Each direction has its own
+// key, so that data cannot be reflected back to its sender. chain is
+// the secret that the keys of the next generation are derived with.
+type streamKeys struct {
+	send  cipher.AEAD
+	recv  cipher.AEAD
+	chain []byte
+}
+
+// newTunnelCipher is a constructor for tunnelCipher. It generates the
+// key pair of this side of the tunnel.
+func newTunnelCipher() (*tunnelCipher, error) {
+	k := new(tunnelCipher)
+	var err error
+	k.private, k.public, err = newKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	k.keys = make(map[uint32]*streamKeys)
+	return k, nil
+}
+
+// newKeyPair generates an X25519 key pair.
+func newKeyPair() ([]byte, []byte, error) {
+	private := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(private); err != nil {
+		return nil, nil, err
+	}
+
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return private, public, nil
+}
+
+// deriveKeys derives the keys of a generation from the X25519 exchange
+// of private and peer. The first generation mixes in the exchange with
+// the server key, which only gServer can complete, and later ones the
+// chain secret of the previous generation, so that only the two sides
+// of the first exchange can take part in rekeys. salt binds the keys
+// to the tunnel.
+func deriveKeys(private []byte, peer []byte, server *ServerKey,
+	chain []byte, salt string, generation uint32) (*streamKeys, error) {
+
+	if server == nil {
+		return nil, errors.New("no server key is configured")
+	}
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := curve25519.X25519(private, peer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer key: %v", err)
+	}
+
+	// gServer holds the private server key, the endpoint only its
+	// public key
+	endpoint, gserver := public, peer
+	if server.private != nil {
+		endpoint, gserver = peer, public
+	}
+
+	secret := append([]byte{}, chain...)
+	if chain == nil {
+		var static []byte
+		if server.private != nil {
+			static, err = curve25519.X25519(server.private, endpoint)
+		} else {
+			static, err = curve25519.X25519(private, server.public)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer key: %v", err)
+		}
+		secret = append(secret, static...)
+	}
+	secret = append(secret, shared...)
+
+	// The keys are bound to both public keys of the exchange
+	transcript := append(append([]byte(salt), endpoint...), gserver...)
+	info := fmt.Sprintf("gtunnel stream encryption %d", generation)
+	kdf := hkdf.New(sha256.New, secret, transcript, []byte(info))
+	material := make([]byte, 3*chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(kdf, material); err != nil {
+		return nil, err
+	}
+
+	toServer, err := chacha20poly1305.NewX(material[:chacha20poly1305.KeySize])
+	if err != nil {
+		return nil, err
+	}
+	toEndpoint, err := chacha20poly1305.NewX(
+		material[chacha20poly1305.KeySize : 2*chacha20poly1305.KeySize])
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &streamKeys{send: toServer, recv: toEndpoint,
+		chain: material[2*chacha20poly1305.KeySize:]}
+	if server.private != nil {
+		keys.send, keys.recv = toEndpoint, toServer
+	}
+	return keys, nil
+}
+
+// setPeerKey derives the first keys of the tunnel from the public key
+// of the other side, authenticated with server. Keys of earlier
+// exchanges are dropped.
+func (k *tunnelCipher) setPeerKey(peer []byte, server *ServerKey,
+	salt string) error {
+
+	keys, err := deriveKeys(k.private, peer, server, nil, salt, 0)
+	if err != nil {
+		return err
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	k.server = server
+	k.keys = make(map[uint32]*streamKeys)
+	k.pending = nil
+	k.install(0, keys, true)
+	return nil
+}
+
+// install adds the keys of a generation, which are used for sealing if
+// send is true. Keys older than the previous generation are dropped,
+// so that data already in flight can still be opened while a rekey
+// takes effect. It is called with the mutex held.
+func (k *tunnelCipher) install(generation uint32, keys *streamKeys,
+	send bool) {
+
+	k.keys[generation] = keys
+	for g := range k.keys {
+		if g+1 < generation {
+			delete(k.keys, g)
+		}
+	}
+	if send {
+		k.generation = generation
+		atomic.StoreUint64(&k.used, 0)
+		k.rekeyed = time.Now()
+	}
+}
+
+// seal encrypts data with the key of this side and authenticates it
+// together with ad. The random nonce is prepended to the result, which
+// is encrypted with the returned key generation.
+func (k *tunnelCipher) seal(data []byte, ad []byte) ([]byte, uint32, error) {
+	k.mutex.RLock()
+	generation := k.generation
+	keys := k.keys[generation]
+	k.mutex.RUnlock()
+
+	if keys == nil {
+		return nil, 0, errNoStreamKey
+	}
+	aead := keys.send
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(data)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, 0, err
+	}
+	atomic.AddUint64(&k.used, uint64(len(data)))
+	return aead.Seal(nonce, nonce, data, ad), generation, nil
+}
+
+// open decrypts data that the other side encrypted by seal with the
+// same ad and key generation.
+func (k *tunnelCipher) open(data []byte, ad []byte,
+	generation uint32) ([]byte, error) {
+
+	k.mutex.RLock()
+	keys := k.keys[generation]
+	k.mutex.RUnlock()
+
+	if keys == nil {
+		if generation == 0 {
+			return nil, errNoStreamKey
+		}
+		return nil, fmt.Errorf("unknown key generation %d", generation)
+	}
+	aead := keys.recv
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted message is too short")
+	}
+	atomic.AddUint64(&k.used, uint64(len(data)))
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], ad)
+}
+
+// PublicKey returns the X25519 key of this side of an encrypted
+// tunnel, which the other side needs to derive the key of the tunnel.
+// It returns nil if the tunnel is not encrypted.
+func (t *Tunnel) PublicKey() []byte {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if t.cipher == nil {
+		return nil
+	}
+	return t.cipher.public
+}
+
+// SetPeerKey derives the keys of an encrypted tunnel from the X25519
+// key of the other side. server is the server key, which gServer holds
+// with its private key and the endpoint was built with. It does
+// nothing if the tunnel is not encrypted.
+func (t *Tunnel) SetPeerKey(peer []byte, server *ServerKey) error {
+	t.mutex.RLock()
+	k := t.cipher
+	t.mutex.RUnlock()
+
+	if k == nil {
+		return nil
+	}
+	if len(peer) == 0 {
+		return errors.New("the other side of the tunnel sent no key")
+	}
+	return k.setPeerKey(peer, server, t.id)
+}
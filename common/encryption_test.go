@@ -0,0 +1,224 @@
+package common
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestEncryption(t *testing.T) {
+	newEncrypted := func() *Tunnel {
+		tun := NewTunnel("tunnel", TunnelDirectionForward, net.IPv4zero, 0,
+			net.IPv4(127, 0, 0, 1), 80)
+		tun.SetOptions(TunnelOptions{Encrypt: true})
+		return tun
+	}
+	server := newEncrypted()
+	client := newEncrypted()
+	serverKey, err := NewServerKey()
+	if err != nil {
+		t.Fatalf("NewServerKey: %v", err)
+	}
+	pinned, err := ParseServerKey(serverKey.String())
+	if err != nil {
+		t.Fatalf("ParseServerKey: %v", err)
+	}
+
+	if len(server.PublicKey()) == 0 || bytes.Equal(server.PublicKey(), client.PublicKey()) {
+		t.Fatalf("PublicKey: the tunnels have no distinct keys")
+	}
+	if err := server.SetPeerKey(nil, serverKey); err == nil {
+		t.Errorf("SetPeerKey: Got no error for a missing key")
+	}
+	if err := client.SetPeerKey(server.PublicKey(), nil); err == nil {
+		t.Errorf("SetPeerKey: Got no error without a server key")
+	}
+	if err := server.SetPeerKey(client.PublicKey(), serverKey); err != nil {
+		t.Fatalf("SetPeerKey: %v", err)
+	}
+	if err := client.SetPeerKey(server.PublicKey(), pinned); err != nil {
+		t.Fatalf("SetPeerKey: %v", err)
+	}
+
+	newConnection := func(tun *Tunnel) *Connection {
+		c := NewConnection(nil)
+		c.ID = "connection"
+		c.cipher = tun.cipher
+		return c
+	}
+	sender := newConnection(server)
+	receiver := newConnection(client)
+
+	text := []byte("GET /index.html HTTP/1.1\r\n")
+	message := new(cs.BytesMessage)
+	if err := sender.setContent(message, text); err != nil {
+		t.Fatalf("setContent: %v", err)
+	}
+	if bytes.Contains(message.Content, text) {
+		t.Errorf("setContent: the text was sent in the clear")
+	}
+	if data, err := receiver.content(message); err != nil || !bytes.Equal(data, text) {
+		t.Errorf("content: Got: %q, %v Want: %q", data, err, text)
+	}
+
+	// Data can be neither replayed nor reordered
+	if _, err := receiver.content(message); err == nil {
+		t.Errorf("content: Got no error for a replayed message")
+	}
+	first, second := new(cs.BytesMessage), new(cs.BytesMessage)
+	sender.setContent(first, text)
+	sender.setContent(second, text)
+	if _, err := receiver.content(second); err == nil {
+		t.Errorf("content: Got no error for a reordered message")
+	}
+	for _, m := range []*cs.BytesMessage{first, second} {
+		if _, err := receiver.content(m); err != nil {
+			t.Errorf("content: %v", err)
+		}
+	}
+
+	// Data is bound to its direction and connection
+	reflected := new(cs.BytesMessage)
+	newConnection(server).setContent(reflected, text)
+	if _, err := newConnection(server).content(reflected); err == nil {
+		t.Errorf("content: Got no error for a reflected message")
+	}
+	other := newConnection(client)
+	other.ID = "other"
+	reflected = new(cs.BytesMessage)
+	newConnection(server).setContent(reflected, text)
+	if _, err := other.content(reflected); err == nil {
+		t.Errorf("content: Got no error for another connection")
+	}
+
+	// A party in between that replaces the keys without the private
+	// server key cannot talk to the endpoint
+	attacker := newEncrypted()
+	victim := newEncrypted()
+	fake, _ := NewServerKey()
+	victim.SetPeerKey(attacker.PublicKey(), pinned)
+	attacker.SetPeerKey(victim.PublicKey(), fake)
+	forged := new(cs.BytesMessage)
+	newConnection(attacker).setContent(forged, text)
+	if _, err := newConnection(victim).content(forged); err == nil {
+		t.Errorf("content: Got no error for a replaced key")
+	}
+
+	plain := NewTunnel("plain", TunnelDirectionForward, net.IPv4zero, 0,
+		net.IPv4(127, 0, 0, 1), 80)
+	if plain.PublicKey() != nil || plain.SetPeerKey(nil, nil) != nil {
+		t.Errorf("PublicKey: an unencrypted tunnel has a key")
+	}
+}
+
+func TestLoadServerKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtunnel")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "server_key")
+	generated, err := LoadServerKey(path)
+	if err != nil {
+		t.Fatalf("LoadServerKey: %v", err)
+	}
+	loaded, err := LoadServerKey(path)
+	if err != nil || loaded.String() != generated.String() {
+		t.Errorf("LoadServerKey: Got: %v, %v Want: %v", loaded, err, generated)
+	}
+	if _, err := ParseServerKey("c2hvcnQ="); err == nil {
+		t.Errorf("ParseServerKey: Got no error for a short key")
+	}
+}
+
+func TestRekey(t *testing.T) {
+	server, err := newTunnelCipher()
+	if err != nil {
+		t.Fatalf("newTunnelCipher: %v", err)
+	}
+	client, _ := newTunnelCipher()
+	serverKey, _ := NewServerKey()
+	pinned, _ := ParseServerKey(serverKey.String())
+	server.setPeerKey(client.public, serverKey, "tunnel")
+	client.setPeerKey(server.public, pinned, "tunnel")
+
+	if server.rekeyDue(0, 0) || !server.rekeyDue(time.Nanosecond, 0) {
+		t.Errorf("rekeyDue: Got the wrong answer for the interval")
+	}
+	old, _, _ := server.seal([]byte("before"), nil)
+	if !server.rekeyDue(0, 6) {
+		t.Errorf("rekeyDue: Got false after the byte limit")
+	}
+
+	rekey := func() uint32 {
+		generation, offer, err := server.offerRekey()
+		if err != nil {
+			t.Fatalf("offerRekey: %v", err)
+		}
+		if server.rekeyDue(time.Nanosecond, 0) {
+			t.Errorf("rekeyDue: Got true during a rekey")
+		}
+		answer, err := client.acceptRekey(generation, offer, "tunnel")
+		if err != nil {
+			t.Fatalf("acceptRekey: %v", err)
+		}
+		if err := server.completeRekey(generation, answer, "tunnel"); err != nil {
+			t.Fatalf("completeRekey: %v", err)
+		}
+		if err := client.useGeneration(generation); err != nil {
+			t.Fatalf("useGeneration: %v", err)
+		}
+		return generation
+	}
+
+	if generation := rekey(); generation != 1 {
+		t.Errorf("rekey: Got generation %d Want: 1", generation)
+	}
+	for _, k := range []*tunnelCipher{server, client} {
+		sealed, generation, _ := k.seal([]byte("after"), nil)
+		peer := client
+		if k == client {
+			peer = server
+		}
+		if data, err := peer.open(sealed, nil, generation); generation != 1 ||
+			err != nil || string(data) != "after" {
+			t.Errorf("open: Got: %q, %v generation %d", data, err, generation)
+		}
+	}
+
+	// Data of the previous generation is still accepted, older data is not
+	if _, err := client.open(old, nil, 0); err != nil {
+		t.Errorf("open: Got %v for the previous generation", err)
+	}
+	rekey()
+	if _, err := client.open(old, nil, 0); err == nil {
+		t.Errorf("open: Got no error for a dropped generation")
+	}
+	if _, err := client.acceptRekey(1, server.public, "tunnel"); err == nil {
+		t.Errorf("acceptRekey: Got no error for a stale generation")
+	}
+
+	// A party in between that offers its own key does not know the
+	// chain secret that the new key is derived with
+	private, public, _ := newKeyPair()
+	answer, err := client.acceptRekey(3, public, "tunnel")
+	if err != nil {
+		t.Fatalf("acceptRekey: %v", err)
+	}
+	forged, err := deriveKeys(private, answer, serverKey, make([]byte, 32), "tunnel", 3)
+	if err != nil {
+		t.Fatalf("deriveKeys: %v", err)
+	}
+	sealed := forged.send.Seal(make([]byte, forged.send.NonceSize()),
+		make([]byte, forged.send.NonceSize()), []byte("forged"), nil)
+	if _, err := client.open(sealed, nil, 3); err == nil {
+		t.Errorf("open: Got no error for a key offered without the chain secret")
+	}
+}
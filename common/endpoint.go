@@ -1,6 +1,10 @@
 package common
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	cs "github.com/kai5263499/gtunnel/grpc/client"
 )
 
@@ -14,6 +18,11 @@ type Endpoint struct {
 	killClient         chan bool
 	tunnels            map[string]*Tunnel
 	endpointCtrlStream chan cs.EndpointControlMessage
+	mtu                uint32
+	keepalive          time.Duration
+	ctx                context.Context
+	cancel             context.CancelFunc
+	mutex              sync.RWMutex
 }
 
 type gInterface interface {
@@ -33,26 +42,104 @@ func NewEndpoint() *Endpoint {
 	e.Id = ""
 	e.endpointCtrlStream = make(chan cs.EndpointControlMessage)
 	e.tunnels = make(map[string]*Tunnel)
+	e.mtu = DefaultMTU
+	e.ctx, e.cancel = context.WithCancel(context.Background())
 	return e
 }
 
 // AddTunnel adds a tunnel instance to the list of tunnels
-// maintained by the endpoint
+// maintained by the endpoint. The tunnel inherits the MTU and
+// keepalive interval of the endpoint, unless it has its own
+// chunk size.
 func (e *Endpoint) AddTunnel(id string, t *Tunnel) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if t.GetOptions().ChunkSize == 0 {
+		t.SetMTU(e.mtu)
+	}
+	t.SetKeepalive(e.keepalive)
 	e.tunnels[id] = t
 }
 
+// Context returns the context of the endpoint, which is done once the
+// endpoint is stopped. Tunnels created with it are stopped along with
+// the endpoint.
+func (e *Endpoint) Context() context.Context {
+	return e.ctx
+}
+
 // GetTunnel will take in a tunnel ID string as an argument
 // and return a Tunnel pointer of the corresponding ID.
 func (e *Endpoint) GetTunnel(tunID string) (*Tunnel, bool) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
 	t, ok := e.tunnels[tunID]
 	return t, ok
 }
 
-// GetTunnels returns all of the active tunnels
+// GetKeepalive returns the interval at which keepalive messages
+// are sent on the control streams of the endpoint.
+func (e *Endpoint) GetKeepalive() time.Duration {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.keepalive
+}
+
+// GetMTU returns the maximum number of bytes carried by a single
+// byte stream message for the endpoint.
+func (e *Endpoint) GetMTU() uint32 {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.mtu
+}
+
+// GetTunnels returns a copy of all of the active tunnels
 // maintained by the endpoint
 func (e *Endpoint) GetTunnels() map[string]*Tunnel {
-	return e.tunnels
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	tunnels := make(map[string]*Tunnel, len(e.tunnels))
+	for id, t := range e.tunnels {
+		tunnels[id] = t
+	}
+	return tunnels
+}
+
+// SetKeepalive sets the interval at which keepalive messages are sent
+// on the control streams of the endpoint and all of its tunnels. An
+// interval of 0 disables keepalives.
+func (e *Endpoint) SetKeepalive(interval time.Duration) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.keepalive = interval
+	for _, t := range e.tunnels {
+		t.SetKeepalive(interval)
+	}
+}
+
+// SetMTU sets the maximum number of bytes carried by a single byte
+// stream message for the endpoint and all of its tunnels that do not
+// have their own chunk size. An MTU of 0 resets it to the default.
+func (e *Endpoint) SetMTU(mtu uint32) {
+	if mtu == 0 {
+		mtu = DefaultMTU
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.mtu = mtu
+	for _, t := range e.tunnels {
+		if t.GetOptions().ChunkSize == 0 {
+			t.SetMTU(mtu)
+		}
+	}
 }
 
 // SetID takes in a string and will set the Id to that string
@@ -63,9 +150,10 @@ func (e *Endpoint) SetID(id string) {
 // Stop will close all tunnels and the associated TCP
 // connections with each tunnel.
 func (e *Endpoint) Stop() {
-	for id, _ := range e.tunnels {
+	for id := range e.GetTunnels() {
 		e.StopAndDeleteTunnel(id)
 	}
+	e.cancel()
 	close(e.endpointCtrlStream)
 }
 
@@ -73,11 +161,14 @@ func (e *Endpoint) Stop() {
 // and removes the tunnel from the endpoint. Returns
 // true if successful and false otherwise.
 func (e *Endpoint) StopAndDeleteTunnel(tunID string) bool {
+	e.mutex.Lock()
 	tun, ok := e.tunnels[tunID]
+	delete(e.tunnels, tunID)
+	e.mutex.Unlock()
+
 	if !ok {
 		return false
 	}
 	tun.Stop()
-	delete(e.tunnels, tunID)
 	return true
 }
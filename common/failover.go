@@ -0,0 +1,58 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Destination is an address that a tunnel dials.
+type Destination struct {
+	IP   net.IP
+	Port uint32
+}
+
+// String returns the destination as ip:port.
+func (d Destination) String() string {
+	return net.JoinHostPort(d.IP.String(), strconv.FormatUint(uint64(d.Port), 10))
+}
+
+// ParseDestinations parses a comma separated list of IPv4 destinations,
+// such as "10.0.0.6:80,10.0.0.7:80".
+func ParseDestinations(list string) ([]Destination, error) {
+	var destinations []Destination
+	for _, destination := range strings.Split(list, ",") {
+		destination = strings.TrimSpace(destination)
+		if destination == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(destination)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination %q: %v", destination, err)
+		}
+		ip := net.ParseIP(host).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid destination %q: %s is not an IPv4 address",
+				destination, host)
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || p == 0 {
+			return nil, fmt.Errorf("invalid destination %q: invalid port %s",
+				destination, port)
+		}
+		destinations = append(destinations, Destination{IP: ip, Port: uint32(p)})
+	}
+	return destinations, nil
+}
+
+// destinations returns the destination of the tunnel followed by its
+// failover destinations, in the order in which they are dialed.
+func (t *Tunnel) destinations() []Destination {
+	destinations := []Destination{{IP: t.destinationIP, Port: t.destinationPort}}
+	failover, err := ParseDestinations(t.GetOptions().Failover)
+	if err != nil {
+		Log.WithTunnel(t.id).Warnf("Ignoring failover destinations: %v", err)
+	}
+	return append(destinations, failover...)
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"testing"
+)
+
+func TestParseDestinations(t *testing.T) {
+	destinations, err := ParseDestinations("10.0.0.6:80, 10.0.0.7:8080,")
+	if err != nil {
+		t.Fatalf("ParseDestinations: %v", err)
+	}
+	if len(destinations) != 2 || destinations[1].String() != "10.0.0.7:8080" {
+		t.Errorf("ParseDestinations: Got: %v", destinations)
+	}
+	for _, list := range []string{"10.0.0.6", "host:80", "10.0.0.6:0", "[::1]:80"} {
+		if _, err := ParseDestinations(list); err == nil {
+			t.Errorf("ParseDestinations(%q): Got: nil Want: an error", list)
+		}
+	}
+}
+
+func TestFailover(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	// Nothing listens on the port of a closed listener
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	closed.Close()
+	denied := "127.0.0.2:80"
+
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward, nil, 0,
+		net.ParseIP("127.0.0.1"), uint32(closed.Addr().(*net.TCPAddr).Port))
+	defer tunnel.Stop()
+	tunnel.SetOptions(TunnelOptions{
+		Failover: fmt.Sprintf("%s,%s", denied, ln.Addr()),
+	})
+	tunnel.SetDialCheck(func(ip net.IP, port uint32) error {
+		if ip.Equal(net.ParseIP("127.0.0.2")) {
+			return ErrPolicyDenied
+		}
+		return nil
+	})
+
+	conn, destination, err := tunnel.dial()
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.Close()
+	if destination != ln.Addr().String() {
+		t.Errorf("dial: Got: %s Want: %s", destination, ln.Addr())
+	}
+
+	ln.Close()
+	if _, _, err := tunnel.dial(); err == nil {
+		t.Errorf("dial: Got: nil Want: an error when every destination fails")
+	}
+}
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// FileChunkSize is the number of bytes of a file carried by a single
+// message of a file transfer stream.
+const FileChunkSize = 32 * 1024
+
+// DefaultFileMode is the permission bits of a file that is put on an
+// endpoint without any.
+const DefaultFileMode = 0644
+
+// ReceiveFile writes the size bytes that arrive over a file transfer
+// stream to path and sends back their SHA-256 hash, or why they could
+// not be written. A file that is not complete is removed again. It
+// returns once the stream fails, which is how gServer ends it.
+func ReceiveFile(stream ByteStream, path string, size uint64,
+	mode os.FileMode) error {
+
+	if mode = mode.Perm(); mode == 0 {
+		mode = DefaultFileMode
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return failStream(stream, err)
+	}
+
+	hash := sha256.New()
+	w := io.MultiWriter(f, hash)
+	var received uint64
+	for received < size {
+		message, err := stream.Recv()
+		if err != nil {
+			f.Close()
+			os.Remove(path)
+			return err
+		}
+		received += uint64(len(message.Content))
+		if received > size {
+			err = fmt.Errorf("got more than %d bytes", size)
+		} else {
+			_, err = w.Write(message.Content)
+		}
+		if err != nil {
+			f.Close()
+			os.Remove(path)
+			return failStream(stream, err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return failStream(stream, err)
+	}
+
+	done := new(cs.BytesMessage)
+	done.FileHash = hash.Sum(nil)
+	if err := stream.Send(done); err != nil {
+		return err
+	}
+	return drainStream(stream)
+}
+
+// SendFile sends the size of the file at path, its content and its
+// SHA-256 hash over a file transfer stream, or why it could not be
+// read. It returns once the stream fails, which is how gServer ends it.
+func SendFile(stream ByteStream, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return failStream(stream, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return failStream(stream, err)
+	}
+	if !info.Mode().IsRegular() {
+		return failStream(stream, fmt.Errorf("%s is not a regular file", path))
+	}
+
+	header := new(cs.BytesMessage)
+	header.FileSize = uint64(info.Size())
+	if err := stream.Send(header); err != nil {
+		return err
+	}
+
+	// The size is as announced even if the file changes meanwhile
+	hash := sha256.New()
+	r := io.TeeReader(io.LimitReader(f, info.Size()), hash)
+	chunk := make([]byte, FileChunkSize)
+	var sent int64
+	for sent < info.Size() {
+		n, err := io.ReadFull(r, chunk)
+		if n == 0 && err != nil {
+			if err == io.EOF {
+				err = fmt.Errorf("%s shrank while it was read", path)
+			}
+			return failStream(stream, err)
+		}
+		message := new(cs.BytesMessage)
+		message.Content = chunk[:n]
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+		sent += int64(n)
+	}
+
+	done := new(cs.BytesMessage)
+	done.FileHash = hash.Sum(nil)
+	if err := stream.Send(done); err != nil {
+		return err
+	}
+	return drainStream(stream)
+}
+
+// failStream tells gServer why the work on a stream failed.
+func failStream(stream ByteStream, err error) error {
+	message := new(cs.BytesMessage)
+	message.Error = err.Error()
+	if err := stream.Send(message); err != nil {
+		return err
+	}
+	return drainStream(stream)
+}
+
+// drainStream discards the messages of a stream until it fails.
+func drainStream(stream ByteStream) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// transferPair returns the gServer end of a file transfer stream and
+// the endpoint end of it.
+func transferPair() (*pipeStream, *pipeStream) {
+	toEndpoint := make(chan *cs.BytesMessage, 4)
+	toServer := make(chan *cs.BytesMessage, 4)
+	return &pipeStream{send: toEndpoint, recv: toServer},
+		&pipeStream{send: toServer, recv: toEndpoint}
+}
+
+func TestFileTransfer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtunnel")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	content := make([]byte, 2*FileChunkSize+100)
+	rand.Read(content)
+	sum := sha256.Sum256(content)
+	source := filepath.Join(dir, "source")
+	if err := ioutil.WriteFile(source, content, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// Get
+	server, endpoint := transferPair()
+	go SendFile(endpoint, source)
+	header, _ := server.Recv()
+	if header.FileSize != uint64(len(content)) {
+		t.Fatalf("SendFile: announced %d bytes, want %d", header.FileSize, len(content))
+	}
+	got := make([]byte, 0)
+	for len(got) < len(content) {
+		message, _ := server.Recv()
+		got = append(got, message.Content...)
+	}
+	done, _ := server.Recv()
+	if !bytes.Equal(got, content) || !bytes.Equal(done.FileHash, sum[:]) {
+		t.Errorf("SendFile: sent other content or hash")
+	}
+	close(server.send)
+
+	// Put
+	target := filepath.Join(dir, "target")
+	server, endpoint = transferPair()
+	go ReceiveFile(endpoint, target, uint64(len(content)), 0750)
+	for i := 0; i < len(content); i += FileChunkSize {
+		end := i + FileChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+		server.Send(&cs.BytesMessage{Content: content[i:end]})
+	}
+	done, _ = server.Recv()
+	if done.Error != "" || !bytes.Equal(done.FileHash, sum[:]) {
+		t.Errorf("ReceiveFile: answered %q with hash %x", done.Error, done.FileHash)
+	}
+	close(server.send)
+	written, err := ioutil.ReadFile(target)
+	if err != nil || !bytes.Equal(written, content) {
+		t.Errorf("ReceiveFile: wrote other content: %v", err)
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0750 {
+		t.Errorf("ReceiveFile: wrong mode: %v", err)
+	}
+
+	// A file with more bytes than announced is not kept
+	server, endpoint = transferPair()
+	go ReceiveFile(endpoint, target, 10, 0)
+	server.Send(&cs.BytesMessage{Content: content[:20]})
+	done, _ = server.Recv()
+	if done.Error == "" {
+		t.Errorf("ReceiveFile: accepted more bytes than announced")
+	}
+	close(server.send)
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("ReceiveFile: kept an incomplete file")
+	}
+
+	// Missing files are reported
+	server, endpoint = transferPair()
+	go SendFile(endpoint, filepath.Join(dir, "missing"))
+	if done, _ = server.Recv(); done.Error == "" {
+		t.Errorf("SendFile: sent a missing file")
+	}
+	close(server.send)
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"sync"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+const (
+	// flowWindow is the number of bytes a connection may send before
+	// the remote side reports that it wrote them to its TCP
+	// connection. It bounds the data buffered for a slow destination.
+	flowWindow = 1 << 20
+
+	// flowUpdateSize is the number of bytes written to the TCP
+	// connection after which a window update is sent.
+	flowUpdateSize = flowWindow / 4
+)
+
+// flowControl holds the send window of a connection and the number of
+// received bytes that were not reported back yet.
+type flowControl struct {
+	available uint32
+	written   uint32
+	opened    chan struct{}
+	mutex     sync.Mutex
+}
+
+// EnableFlowControl limits the data in flight on the byte stream of the
+// connection to a window that the remote side reopens as it writes
+// the data to its TCP connection. Both sides of the connection have to
+// enable it before the connection is started.
+func (c *Connection) EnableFlowControl() {
+	f := new(flowControl)
+	f.available = flowWindow
+	f.opened = make(chan struct{}, 1)
+	c.flow = f
+}
+
+// reserve takes up to size bytes from the send window. It blocks while
+// the window is exhausted and returns false if kill is closed first.
+func (f *flowControl) reserve(size uint32, kill <-chan bool) (uint32, bool) {
+	for {
+		f.mutex.Lock()
+		if f.available > 0 {
+			if size > f.available {
+				size = f.available
+			}
+			f.available -= size
+			f.mutex.Unlock()
+			return size, true
+		}
+		f.mutex.Unlock()
+
+		select {
+		case <-f.opened:
+		case <-kill:
+			return 0, false
+		}
+	}
+}
+
+// release returns size bytes to the send window.
+func (f *flowControl) release(size uint32) {
+	if size == 0 {
+		return
+	}
+	f.mutex.Lock()
+	f.available += size
+	f.mutex.Unlock()
+
+	select {
+	case f.opened <- struct{}{}:
+	default:
+	}
+}
+
+// wrote records that size received bytes were written to the TCP
+// connection. It returns the size of the window update to send, or 0
+// if it is too early for one.
+func (f *flowControl) wrote(size uint32) uint32 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.written += size
+	if f.written < flowUpdateSize {
+		return 0
+	}
+	update := f.written
+	f.written = 0
+	return update
+}
+
+// sendWindowUpdate tells the remote side that size more bytes may be
+// sent.
+func (c *Connection) sendWindowUpdate(size uint32) error {
+	message := new(cs.BytesMessage)
+	message.Window = size
+	return c.sendMessage(message)
+}
+
+// bufferMessages passes the messages received on the byte stream from
+// in to out and holds those that wait for the TCP connection, so that
+// the byte stream is read while writing to the TCP connection blocks.
+// Otherwise a local peer that echoes the data back deadlocks the
+// connection, as neither side reads the window update that lets the
+// other side carry on. The send window of the remote side bounds the
+// data held. Messages are dropped once done is closed. The connection
+// is closed after the byte stream ended and every message was passed
+// on.
+func (c *Connection) bufferMessages(in <-chan *cs.BytesMessage,
+	out chan<- *cs.BytesMessage, done <-chan struct{}) {
+
+	var queue []*cs.BytesMessage
+	for in != nil || len(queue) > 0 {
+		var next chan<- *cs.BytesMessage
+		var first *cs.BytesMessage
+		if len(queue) > 0 {
+			next = out
+			first = queue[0]
+		}
+
+		select {
+		case message, ok := <-in:
+			if !ok {
+				in = nil
+			} else if done != nil {
+				queue = append(queue, message)
+			}
+		case next <- first:
+			queue[0] = nil
+			queue = queue[1:]
+		case <-done:
+			done = nil
+			queue = nil
+		case <-c.Kill:
+			return
+		}
+	}
+	close(out)
+	c.Close()
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestFlowControl(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	c := NewConnection(local)
+	c.EnableFlowControl()
+	f := c.flow
+
+	if size, ok := f.reserve(flowWindow-10, c.Kill); !ok || size != flowWindow-10 {
+		t.Fatalf("reserve: Got: %d %t Want: %d true", size, ok, flowWindow-10)
+	}
+	if size, _ := f.reserve(100, c.Kill); size != 10 {
+		t.Fatalf("reserve: Got: %d Want: 10 for the rest of the window", size)
+	}
+
+	// Reads pause until the remote side reopens the window
+	reserved := make(chan uint32)
+	go func() {
+		size, _ := f.reserve(100, c.Kill)
+		reserved <- size
+	}()
+	select {
+	case size := <-reserved:
+		t.Fatalf("reserve: Got: %d Want: blocked on an exhausted window", size)
+	case <-time.After(50 * time.Millisecond):
+	}
+	f.release(50)
+	if size := <-reserved; size != 50 {
+		t.Errorf("reserve: Got: %d Want: 50", size)
+	}
+
+	if update := f.wrote(flowUpdateSize - 1); update != 0 {
+		t.Errorf("wrote: Got: %d Want: 0 below the update size", update)
+	}
+	if update := f.wrote(1); update != flowUpdateSize {
+		t.Errorf("wrote: Got: %d Want: %d", update, flowUpdateSize)
+	}
+
+	c.Close()
+	if _, ok := f.reserve(100, c.Kill); ok {
+		t.Errorf("reserve: Got: true Want: false for a closed connection")
+	}
+}
+
+func TestFlowControlEcho(t *testing.T) {
+	client, local := tcpPair(t)
+	remote, server := tcpPair(t)
+	defer client.Close()
+	defer server.Close()
+
+	// The small buffers stand in for the flow control of gRPC, which
+	// blocks a sender while the other side does not read
+	forward := make(chan *cs.BytesMessage, 4)
+	backward := make(chan *cs.BytesMessage, 4)
+	a := NewConnection(local)
+	a.EnableFlowControl()
+	a.SetStream(&pipeStream{send: forward, recv: backward})
+	b := NewConnection(remote)
+	b.EnableFlowControl()
+	b.SetStream(&pipeStream{send: backward, recv: forward})
+	a.Start()
+	b.Start()
+	defer a.Close()
+	defer b.Close()
+
+	// The destination echoes everything back, which only goes on while
+	// both directions keep moving
+	go io.Copy(server, server)
+
+	data := make([]byte, 4*flowWindow)
+	rand.Read(data)
+	go client.Write(data)
+
+	client.SetDeadline(time.Now().Add(10 * time.Second))
+	echoed := make([]byte, len(data))
+	if n, err := io.ReadFull(client, echoed); err != nil {
+		t.Fatalf("ReadFull: Got: %d bytes %v Want: %d bytes", n, err, len(data))
+	}
+	if !bytes.Equal(echoed, data) {
+		t.Errorf("the echoed data differs from the data sent")
+	}
+}
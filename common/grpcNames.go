@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ClientServiceName is the full name of the gRPC service of gClients.
+var ClientServiceName = string(clientService().FullName())
+
+// clientService returns the descriptor of the client service.
+func clientService() protoreflect.ServiceDescriptor {
+	return cs.File_client_proto.Services().ByName("ClientService")
+}
+
+// GRPCNames renames the client service and its methods in the paths of
+// the calls of gClients, such as /client.ClientService/Heartbeat,
+// which are seen on the wire and identify gtunnel.
+type GRPCNames struct {
+	service string
+
+	// Renamed methods by method and methods by their new name
+	renamed  map[string]string
+	original map[string]string
+}
+
+// ParseGRPCNames parses a comma separated list of a service name and
+// renamed methods, such as
+// "google.pubsub.v1.Publisher,Heartbeat=Publish". Names that are not
+// given keep their own.
+func ParseGRPCNames(list string) (*GRPCNames, error) {
+	n := new(GRPCNames)
+	n.service = ClientServiceName
+	n.renamed = make(map[string]string)
+	n.original = make(map[string]string)
+
+	methods := clientService().Methods()
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		i := strings.Index(entry, "=")
+		if i < 0 {
+			if strings.Contains(entry, "/") {
+				return nil, fmt.Errorf("invalid service name %s", entry)
+			}
+			n.service = entry
+			continue
+		}
+
+		method, name := entry[:i], entry[i+1:]
+		if methods.ByName(protoreflect.Name(method)) == nil {
+			return nil, fmt.Errorf("unknown method %s", method)
+		}
+		if name == "" || strings.Contains(name, "/") {
+			return nil, fmt.Errorf("invalid name %s of method %s", name, method)
+		}
+		n.renamed[method] = name
+	}
+
+	for i := 0; i < methods.Len(); i++ {
+		method := string(methods.Get(i).Name())
+		name := n.Method(method)
+		if _, ok := n.original[name]; ok {
+			return nil, fmt.Errorf("the name %s is used by more than one method", name)
+		}
+		n.original[name] = method
+	}
+	return n, nil
+}
+
+// Method returns the name of method.
+func (n *GRPCNames) Method(method string) string {
+	if name, ok := n.renamed[method]; ok {
+		return name
+	}
+	return method
+}
+
+// Path returns the path of the call to the method with path, such as
+// /client.ClientService/Heartbeat.
+func (n *GRPCNames) Path(path string) string {
+	i := strings.LastIndex(path, "/")
+	return fmt.Sprintf("/%s/%s", n.service, n.Method(path[i+1:]))
+}
+
+// Original returns the method that a call to path renames, or false if
+// path is not a renamed call.
+func (n *GRPCNames) Original(path string) (string, bool) {
+	i := strings.LastIndex(path, "/")
+	if i < 1 || path[1:i] != n.service {
+		return "", false
+	}
+	method, ok := n.original[path[i+1:]]
+	// Calls by the names of the client service are not renamed
+	if !ok || path == fmt.Sprintf("/%s/%s", ClientServiceName, method) {
+		return "", false
+	}
+	return method, true
+}
+
+// UnaryClientInterceptor makes unary calls by the new names.
+func (n *GRPCNames) UnaryClientInterceptor(ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption) error {
+
+	return invoker(ctx, n.Path(method), req, reply, cc, opts...)
+}
+
+// StreamClientInterceptor makes stream calls by the new names.
+func (n *GRPCNames) StreamClientInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+	return streamer(ctx, desc, cc, n.Path(method), opts...)
+}
@@ -0,0 +1,39 @@
+package common
+
+import "testing"
+
+func TestGRPCNames(t *testing.T) {
+	n, err := ParseGRPCNames("google.pubsub.v1.Publisher,Heartbeat=Publish")
+	if err != nil {
+		t.Fatalf("ParseGRPCNames: %v", err)
+	}
+
+	path := n.Path("/client.ClientService/Heartbeat")
+	if path != "/google.pubsub.v1.Publisher/Publish" {
+		t.Errorf("Path: Got: %s", path)
+	}
+	if method, ok := n.Original(path); !ok || method != "Heartbeat" {
+		t.Errorf("Original: Got: %s, %v Want: Heartbeat", method, ok)
+	}
+
+	// Methods that are not renamed only move to the new service
+	path = n.Path("/client.ClientService/CreateConnectionStream")
+	if method, ok := n.Original(path); !ok || method != "CreateConnectionStream" {
+		t.Errorf("Original(%s): Got: %s, %v", path, method, ok)
+	}
+	if _, ok := n.Original("/client.ClientService/Heartbeat"); ok {
+		t.Errorf("Original: the own names are not renamed calls")
+	}
+
+	for _, list := range []string{"Unknown=Foo", "Heartbeat=a/b", "Heartbeat=Publish,PollControlMessages=Publish"} {
+		if _, err := ParseGRPCNames(list); err == nil {
+			t.Errorf("ParseGRPCNames(%s): Want: error", list)
+		}
+	}
+
+	// Renamed methods within the own service are renamed calls
+	n, _ = ParseGRPCNames("Heartbeat=Ping")
+	if method, ok := n.Original("/client.ClientService/Ping"); !ok || method != "Heartbeat" {
+		t.Errorf("Original: Got: %s, %v Want: Heartbeat", method, ok)
+	}
+}
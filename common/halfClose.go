@@ -0,0 +1,50 @@
+package common
+
+import (
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// closeWriter is implemented by connections that can stop sending
+// while they keep receiving, such as *net.TCPConn.
+type closeWriter interface {
+	CloseWrite() error
+}
+
+// EnableHalfClose makes the connection relay a FIN from one peer as a
+// half-close instead of closing the connection, so that the other
+// direction keeps carrying data until it is finished as well. Both
+// sides of the connection have to enable it before the connection is
+// started.
+func (c *Connection) EnableHalfClose() {
+	c.halfClose = true
+}
+
+// sendHalfClose tells the remote side that the local peer finished
+// sending.
+func (c *Connection) sendHalfClose() error {
+	message := new(cs.BytesMessage)
+	message.HalfClose = true
+	return c.sendMessage(message)
+}
+
+// closeWrite finishes the direction towards the local peer after the
+// remote peer finished sending. Connections that cannot half-close
+// are closed.
+func (c *Connection) closeWrite() {
+	if conn, ok := c.TCPConn.(closeWriter); ok {
+		if err := conn.CloseWrite(); err == nil {
+			return
+		}
+	}
+	c.Close()
+}
+
+// halfCloseDone records that one direction of a half-closed connection
+// is finished. It returns true once both directions are.
+func (c *Connection) halfCloseDone() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.halfClosed++
+	return c.halfClosed == 2
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+	"google.golang.org/protobuf/proto"
+)
+
+// pipeStream is one end of an in memory byte stream.
+type pipeStream struct {
+	send chan<- *cs.BytesMessage
+	recv <-chan *cs.BytesMessage
+}
+
+func (s *pipeStream) Send(message *cs.BytesMessage) error {
+	// Messages are copied since senders reuse them
+	s.send <- proto.Clone(message).(*cs.BytesMessage)
+	return nil
+}
+
+func (s *pipeStream) Recv() (*cs.BytesMessage, error) {
+	message, ok := <-s.recv
+	if !ok {
+		return nil, io.EOF
+	}
+	return message, nil
+}
+
+// tcpPair returns both ends of a loopback TCP connection.
+func tcpPair(t *testing.T) (*net.TCPConn, *net.TCPConn) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+
+	dialed, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	accepted, err := ln.Accept()
+	if err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	return dialed.(*net.TCPConn), accepted.(*net.TCPConn)
+}
+
+func TestHalfClose(t *testing.T) {
+	client, local := tcpPair(t)
+	remote, server := tcpPair(t)
+	defer client.Close()
+	defer server.Close()
+
+	forward := make(chan *cs.BytesMessage, 16)
+	backward := make(chan *cs.BytesMessage, 16)
+	a := NewConnection(local)
+	a.EnableHalfClose()
+	a.SetStream(&pipeStream{send: forward, recv: backward})
+	b := NewConnection(remote)
+	b.EnableHalfClose()
+	b.SetStream(&pipeStream{send: backward, recv: forward})
+	a.Start()
+	b.Start()
+
+	// The client sends a request and shuts down writing, then waits
+	// for the response
+	client.Write([]byte("request"))
+	client.CloseWrite()
+
+	server.SetDeadline(time.Now().Add(5 * time.Second))
+	request, err := ioutil.ReadAll(server)
+	if err != nil || string(request) != "request" {
+		t.Fatalf("server: Got: %q %v Want: request", request, err)
+	}
+	server.Write([]byte("response"))
+	server.CloseWrite()
+
+	client.SetDeadline(time.Now().Add(5 * time.Second))
+	response, err := ioutil.ReadAll(client)
+	if err != nil || string(response) != "response" {
+		t.Fatalf("client: Got: %q %v Want: response", response, err)
+	}
+
+	select {
+	case <-a.Kill:
+	case <-time.After(5 * time.Second):
+		t.Errorf("connection was not closed after both directions finished")
+	}
+}
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// HealthCheckOrigin is the origin address of the connections with which
+// health checks probe the destination of a tunnel.
+const HealthCheckOrigin = "health check"
+
+// TunnelHealth is the result of the last health check of a tunnel.
+type TunnelHealth struct {
+	Up      bool
+	Message string
+	Time    time.Time
+}
+
+// String returns "up" or why the destination is down, such as
+// "down: DIAL_REFUSED: ...". It is empty if the tunnel was not checked.
+func (h TunnelHealth) String() string {
+	if h.Time.IsZero() {
+		return ""
+	}
+	if h.Up {
+		return "up"
+	}
+	return "down: " + h.Message
+}
+
+// CheckHealth opens a connection through a forward tunnel to check that
+// its destination is up. If banner is not empty, the destination has to
+// send it first. The result is kept until the next check.
+func (t *Tunnel) CheckHealth(banner string) TunnelHealth {
+	health := TunnelHealth{Time: time.Now()}
+	if err := t.probe(banner); err != nil {
+		health.Message = err.Error()
+	} else {
+		health.Up = true
+	}
+
+	t.mutex.Lock()
+	t.health = health
+	t.mutex.Unlock()
+	return health
+}
+
+// GetHealth returns the result of the last health check of the tunnel.
+func (t *Tunnel) GetHealth() TunnelHealth {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.health
+}
+
+// probe asks the remote side of the tunnel to connect a connection
+// that is not accepted by a listener, and reads banner from it.
+func (t *Tunnel) probe(banner string) error {
+	if t.GetControlStream() == nil {
+		return errors.New("the endpoint has not acknowledged the tunnel")
+	}
+
+	local, remote := net.Pipe()
+	defer remote.Close()
+	c := NewConnection(local)
+	c.SetOriginAddress(HealthCheckOrigin)
+	c.healthCheck = true
+	t.AddConnection(c)
+
+	message := new(cs.TunnelControlMessage)
+	message.Operation = TunnelCtrlConnect
+	message.TunnelId = t.id
+	message.ConnectionId = c.ID
+	message.StreamToken = c.GetStreamToken()
+	message.OriginAddress = HealthCheckOrigin
+	if err := t.SendCtrlMessage(message); err != nil {
+		t.RemoveConnection(c.ID)
+		return err
+	}
+
+	timer := time.NewTimer(ConnectAckTimeout)
+	defer timer.Stop()
+	select {
+	case <-c.started:
+	case <-c.Kill:
+		t.RemoveConnection(c.ID)
+		if c.failure != nil {
+			return errors.New(c.failure.String())
+		}
+		return errors.New("the connection was closed")
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	case <-timer.C:
+		t.dropConnection(c)
+		return fmt.Errorf("no acknowledgement within %s", ConnectAckTimeout)
+	}
+	defer t.dropConnection(c)
+
+	if banner == "" {
+		return nil
+	}
+	remote.SetReadDeadline(time.Now().Add(DialTimeout))
+	received := make([]byte, 0, len(banner))
+	buf := make([]byte, len(banner))
+	for len(received) < len(banner) {
+		n, err := remote.Read(buf[:len(banner)-len(received)])
+		received = append(received, buf[:n]...)
+		if err != nil {
+			return fmt.Errorf("no banner: %v", err)
+		}
+	}
+	if !bytes.Equal(received, []byte(banner)) {
+		return fmt.Errorf("unexpected banner %q", received)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// bannerHandler acknowledges connections with a byte stream that sends
+// a banner.
+type bannerHandler struct {
+	banner string
+}
+
+func (h *bannerHandler) GetByteStream(tunnel *Tunnel,
+	ctrlMessage *cs.TunnelControlMessage) ByteStream {
+	return nil
+}
+
+func (h *bannerHandler) CloseStream(tunnel *Tunnel, connID string) {}
+
+func (h *bannerHandler) Acknowledge(tunnel *Tunnel,
+	ctrlMessage *cs.TunnelControlMessage) ByteStream {
+	recv := make(chan *cs.BytesMessage, 1)
+	recv <- &cs.BytesMessage{Content: []byte(h.banner)}
+	return &pipeStream{send: make(chan *cs.BytesMessage, 16), recv: recv}
+}
+
+func TestHealthCheck(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward, nil, 0, nil, 0)
+	defer tunnel.Stop()
+	if health := tunnel.CheckHealth(""); health.Up {
+		t.Errorf("CheckHealth: up before the tunnel was acknowledged")
+	}
+
+	ctrl := &ctrlPipe{
+		sent: make(chan *cs.TunnelControlMessage, 4),
+		recv: make(chan *cs.TunnelControlMessage),
+	}
+	tunnel.SetControlStream(ctrl)
+	tunnel.ConnectionHandler = &bannerHandler{banner: "SSH-2.0-OpenSSH"}
+	defer close(ctrl.recv)
+	tunnel.Start()
+
+	// The remote side answers every connect with reply
+	check := func(banner string, reply func(*cs.TunnelControlMessage)) TunnelHealth {
+		result := make(chan TunnelHealth)
+		go func() { result <- tunnel.CheckHealth(banner) }()
+		message := <-ctrl.sent
+		if message.Operation != TunnelCtrlConnect || message.OriginAddress != HealthCheckOrigin {
+			t.Fatalf("unexpected message %v", message)
+		}
+		reply(message)
+		return <-result
+	}
+	refuse := func(message *cs.TunnelControlMessage) {
+		ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlAck,
+			ConnectionId: message.ConnectionId,
+			ErrorStatus:  cs.ConnectionError_DIAL_REFUSED, ErrorMessage: "refused"}
+	}
+	accept := func(message *cs.TunnelControlMessage) {
+		ctrl.recv <- &cs.TunnelControlMessage{Operation: TunnelCtrlAck,
+			ConnectionId: message.ConnectionId}
+		// The connection is dropped after the check
+		<-ctrl.sent
+	}
+
+	health := check("", refuse)
+	if health.Up || !strings.Contains(health.String(), "DIAL_REFUSED") {
+		t.Errorf("refused: Got: %s", health)
+	}
+	if failures, _ := tunnel.GetFailedConnections(); failures != 0 {
+		t.Errorf("refused: the health check was counted as a failed connection")
+	}
+	if health := check("SSH-", accept); !health.Up {
+		t.Errorf("banner: Got: %s Want: up", health)
+	}
+	if health := check("220 ", accept); health.Up {
+		t.Errorf("wrong banner: Got: up")
+	}
+	if tunnel.GetHealth().Up || tunnel.ConnectionCount() != 0 {
+		t.Errorf("GetHealth: Got: %s with %d connections", tunnel.GetHealth(),
+			tunnel.ConnectionCount())
+	}
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// TunnelHop is an endpoint that a multi-hop tunnel passes. It accepts
+// the connections of the previous hop on IP and Port and relays them to
+// the next hop or the destination of the tunnel.
+type TunnelHop struct {
+	EndpointID string
+	IP         net.IP
+	Port       uint32
+}
+
+// ParseTunnelHops parses a comma separated list of hops, such as
+// "clientB@10.0.0.5:4444,clientC@10.1.0.7:4444", in the order in which
+// connections pass them after the endpoint of the tunnel. The address
+// of a hop has to be reachable from the previous one, and a hop
+// without one, such as "clientB@:4444", listens on loopback.
+func ParseTunnelHops(list string) ([]TunnelHop, error) {
+	var hops []TunnelHop
+	for _, hop := range strings.Split(list, ",") {
+		hop = strings.TrimSpace(hop)
+		if hop == "" {
+			continue
+		}
+		i := strings.LastIndex(hop, "@")
+		if i < 1 {
+			return nil, fmt.Errorf("invalid hop %q: no endpoint", hop)
+		}
+		host, port, err := net.SplitHostPort(hop[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hop %q: %v", hop, err)
+		}
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		ip := net.ParseIP(host).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid hop %q: %s is not an IPv4 address", hop, host)
+		}
+		if ip.IsUnspecified() {
+			return nil, fmt.Errorf("invalid hop %q: the previous hop cannot dial %s", hop, host)
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || p == 0 {
+			return nil, fmt.Errorf("invalid hop %q: invalid port %s", hop, port)
+		}
+		hops = append(hops, TunnelHop{EndpointID: hop[:i], IP: ip, Port: uint32(p)})
+	}
+	return hops, nil
+}
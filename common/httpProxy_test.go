@@ -0,0 +1,158 @@
+package common
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ntlmChallenge is an NTLM challenge message without target
+// information.
+func ntlmChallenge() []byte {
+	m := make([]byte, 48)
+	copy(m, "NTLMSSP\x00")
+	binary.LittleEndian.PutUint32(m[8:], 2)
+	binary.LittleEndian.PutUint32(m[16:], 48)
+	binary.LittleEndian.PutUint32(m[20:], 0x00008201)
+	copy(m[24:], "12345678")
+	binary.LittleEndian.PutUint32(m[44:], 48)
+	return m
+}
+
+// ntlmMessageType returns the type of the NTLM message in the
+// Proxy-Authorization header of req, or 0.
+func ntlmMessageType(req *http.Request) uint32 {
+	value := req.Header.Get("Proxy-Authorization")
+	if !strings.HasPrefix(value, "NTLM ") {
+		return 0
+	}
+	m, err := base64.StdEncoding.DecodeString(value[5:])
+	if err != nil || len(m) < 12 || string(m[:8]) != "NTLMSSP\x00" {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(m[8:])
+}
+
+// serveConnectProxy accepts CONNECT requests with the credentials
+// user:password on l, or after an NTLM handshake on the connection.
+func serveConnectProxy(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			reader := bufio.NewReader(conn)
+			req, err := http.ReadRequest(reader)
+			if err != nil {
+				return
+			}
+			if ntlmMessageType(req) == 1 {
+				challenge := base64.StdEncoding.EncodeToString(ntlmChallenge())
+				io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n"+
+					"Proxy-Authenticate: NTLM "+challenge+"\r\n"+
+					"Content-Length: 6\r\n\r\ndenied")
+				req, err = http.ReadRequest(reader)
+				if err != nil || ntlmMessageType(req) != 3 {
+					io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					return
+				}
+			} else if req.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNzd29yZA==" {
+				io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+				return
+			}
+			target, err := net.Dial("tcp", req.Host)
+			if err != nil {
+				io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+				return
+			}
+			defer target.Close()
+			io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+			go io.Copy(target, conn)
+			io.Copy(conn, target)
+		}()
+	}
+}
+
+func TestHTTPProxy(t *testing.T) {
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer proxyListener.Close()
+	go serveConnectProxy(proxyListener)
+
+	// The destination greets first
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer target.Close()
+	go func() {
+		for {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			io.WriteString(conn, "hello")
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	proxy, err := ParseHTTPProxy("http://user:password@" + proxyListener.Addr().String())
+	if err != nil {
+		t.Fatalf("ParseHTTPProxy: %v", err)
+	}
+	conn, err := proxy.DialContext(ctx, target.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	greeting, err := ioutil.ReadAll(conn)
+	conn.Close()
+	if err != nil || string(greeting) != "hello" {
+		t.Errorf("Read: Got: %q, %v Want: hello", greeting, err)
+	}
+
+	proxy.Password = "wrong"
+	if _, err := proxy.DialContext(ctx, target.Addr().String()); err == nil {
+		t.Errorf("DialContext: Got no error with the wrong password")
+	}
+
+	// The proxy takes any NTLM credentials after the handshake
+	proxy.Username = `DOMAIN\user`
+	proxy.Auth = ProxyAuthNTLM
+	conn, err = proxy.DialContext(ctx, target.Addr().String())
+	if err != nil {
+		t.Fatalf("DialContext with NTLM: %v", err)
+	}
+	greeting, err = ioutil.ReadAll(conn)
+	conn.Close()
+	if err != nil || string(greeting) != "hello" {
+		t.Errorf("Read with NTLM: Got: %q, %v Want: hello", greeting, err)
+	}
+
+	proxy.Auth = "digest"
+	if _, err := proxy.DialContext(ctx, target.Addr().String()); err == nil {
+		t.Errorf("DialContext: Got no error for digest")
+	}
+
+	proxy, err = ParseHTTPProxy("proxy.example.com")
+	if err != nil || proxy.Address != "proxy.example.com:8080" {
+		t.Errorf("ParseHTTPProxy: Got: %v, %v Want: the default port", proxy, err)
+	}
+	if _, err := ParseHTTPProxy("socks5://proxy:1080"); err == nil {
+		t.Errorf("ParseHTTPProxy: Got no error for socks5")
+	}
+}
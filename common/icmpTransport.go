@@ -0,0 +1,293 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// The payloads of the echo requests and replies of the ICMP transport
+// start with magic values, which tell them apart from ordinary pings
+// and from the echo replies of the operating system of gServer.
+var (
+	icmpRequestMagic = []byte("gtq1")
+	icmpReplyMagic   = []byte("gtr1")
+)
+
+// Sizes of the headers of the ICMP transport. Echo requests carry
+// magic, session, seq and the largest reply, echo replies magic,
+// session, seq, more and the number of accepted bytes.
+const (
+	icmpRequestHeader = 18
+	icmpReplyHeader   = 19
+
+	// icmpPacketOverhead is the size of the IPv4 and ICMP headers.
+	icmpPacketOverhead = 28
+)
+
+// ICMPDefaultMTU is the MTU with which ICMP transport sessions start.
+// ICMPMinMTU is the least MTU they fall back to when echoes get lost.
+const (
+	ICMPDefaultMTU = 1400
+	ICMPMinMTU     = 576
+)
+
+// icmpTimeout is how long the ICMP transport waits for an echo reply.
+const icmpTimeout = 2 * time.Second
+
+// ICMPRequest is the payload of an echo request of an ICMP transport
+// session.
+type ICMPRequest struct {
+	// Session is zero in the request that opens a session, whose data
+	// is a nonce.
+	Session  [8]byte
+	Seq      uint32
+	MaxReply uint16
+	Data     []byte
+}
+
+// Marshal encodes the request.
+func (r *ICMPRequest) Marshal() []byte {
+	b := make([]byte, icmpRequestHeader, icmpRequestHeader+len(r.Data))
+	copy(b, icmpRequestMagic)
+	copy(b[4:], r.Session[:])
+	binary.BigEndian.PutUint32(b[12:], r.Seq)
+	binary.BigEndian.PutUint16(b[16:], r.MaxReply)
+	return append(b, r.Data...)
+}
+
+// ParseICMPRequest decodes an echo request payload. It fails if the
+// payload does not belong to the ICMP transport.
+func ParseICMPRequest(b []byte) (*ICMPRequest, error) {
+	if len(b) < icmpRequestHeader || !bytes.Equal(b[:4], icmpRequestMagic) {
+		return nil, errors.New("not an icmp transport request")
+	}
+	r := new(ICMPRequest)
+	copy(r.Session[:], b[4:])
+	r.Seq = binary.BigEndian.Uint32(b[12:])
+	r.MaxReply = binary.BigEndian.Uint16(b[16:])
+	r.Data = b[icmpRequestHeader:]
+	return r, nil
+}
+
+// ICMPReply is the payload of an echo reply of an ICMP transport
+// session.
+type ICMPReply struct {
+	// Session is the opened session in the reply to the request that
+	// opens one.
+	Session  [8]byte
+	Seq      uint32
+	More     bool
+	Accepted uint16
+	Data     []byte
+}
+
+// Marshal encodes the reply.
+func (r *ICMPReply) Marshal() []byte {
+	b := make([]byte, icmpReplyHeader, icmpReplyHeader+len(r.Data))
+	copy(b, icmpReplyMagic)
+	copy(b[4:], r.Session[:])
+	binary.BigEndian.PutUint32(b[12:], r.Seq)
+	if r.More {
+		b[16] = 1
+	}
+	binary.BigEndian.PutUint16(b[17:], r.Accepted)
+	return append(b, r.Data...)
+}
+
+// ParseICMPReply decodes an echo reply payload. It fails if the payload
+// does not belong to the ICMP transport.
+func ParseICMPReply(b []byte) (*ICMPReply, error) {
+	if len(b) < icmpReplyHeader || !bytes.Equal(b[:4], icmpReplyMagic) {
+		return nil, errors.New("not an icmp transport reply")
+	}
+	r := new(ICMPReply)
+	copy(r.Session[:], b[4:])
+	r.Seq = binary.BigEndian.Uint32(b[12:])
+	r.More = b[16] == 1
+	r.Accepted = binary.BigEndian.Uint16(b[17:])
+	r.Data = b[icmpReplyHeader:]
+	return r, nil
+}
+
+// ICMPTransport carries gRPC in the payloads of ICMP echo requests and
+// replies, for networks that let nothing but ping out. gServer has to
+// answer the echo requests itself. Raw ICMP sockets need privileges,
+// so unprivileged ping sockets are tried first where the system offers
+// them.
+type ICMPTransport struct {
+	// MTU is the largest packet sent, ICMPDefaultMTU if 0. Sessions
+	// lower it down to ICMPMinMTU while echoes get lost, so that
+	// chunks fit the path to gServer.
+	MTU int
+}
+
+// Name returns the name of the transport.
+func (t *ICMPTransport) Name() string {
+	return TransportICMP
+}
+
+// Dialer returns Dial.
+func (t *ICMPTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return t.Dial
+}
+
+// Dial opens a session with gServer. The port of address is not used.
+func (t *ICMPTransport) Dial(ctx context.Context, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	ip, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var dst net.IP
+	for _, addr := range ip {
+		if addr.IP.To4() != nil {
+			dst = addr.IP
+			break
+		}
+	}
+	if dst == nil {
+		return nil, fmt.Errorf("the icmp transport needs an ipv4 address for %s", host)
+	}
+
+	e := new(icmpExchanger)
+	e.mtu = t.MTU
+	if e.mtu == 0 {
+		e.mtu = ICMPDefaultMTU
+	}
+	if e.mtu < ICMPMinMTU {
+		e.mtu = ICMPMinMTU
+	}
+	e.buf = make([]byte, 65536)
+
+	// Ping sockets are addressed by udp address
+	e.conn, err = icmp.ListenPacket("udp4", "0.0.0.0")
+	if err == nil {
+		e.dst = &net.UDPAddr{IP: dst}
+	} else {
+		e.conn, err = icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+		if err != nil {
+			return nil, err
+		}
+		e.dst = &net.IPAddr{IP: dst}
+	}
+
+	id := make([]byte, 2)
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		e.conn.Close()
+		return nil, err
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		e.conn.Close()
+		return nil, err
+	}
+	e.id = int(binary.BigEndian.Uint16(id))
+
+	for attempt := 0; ; attempt++ {
+		reply, err := e.roundTrip(&ICMPRequest{Data: nonce})
+		if err == nil {
+			e.session = reply.Session
+			break
+		}
+		if attempt == pollAttempts || ctx.Err() != nil {
+			e.conn.Close()
+			return nil, err
+		}
+	}
+
+	c := newPollConn(e, &transportAddr{TransportICMP, dst.String()})
+	go func() {
+		<-c.closed
+		e.conn.Close()
+	}()
+	return c, nil
+}
+
+// icmpExchanger carries the exchanges of an ICMP transport session in
+// echo requests.
+type icmpExchanger struct {
+	conn    *icmp.PacketConn
+	dst     net.Addr
+	id      int
+	session [8]byte
+	buf     []byte
+	mtu     int
+}
+
+// roundTrip sends r and waits for its reply.
+func (e *icmpExchanger) roundTrip(r *ICMPRequest) (*ICMPReply, error) {
+	r.Session = e.session
+	r.MaxReply = uint16(e.mtu - icmpPacketOverhead - icmpReplyHeader)
+
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{
+			ID:   e.id,
+			Seq:  int(r.Seq & 0xffff),
+			Data: r.Marshal(),
+		},
+	}
+	b, err := msg.Marshal(nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.conn.WriteTo(b, e.dst); err != nil {
+		return nil, err
+	}
+
+	e.conn.SetReadDeadline(time.Now().Add(icmpTimeout))
+	for {
+		n, _, err := e.conn.ReadFrom(e.buf)
+		if err != nil {
+			return nil, err
+		}
+		// The protocol number of ICMP for IPv4
+		msg, err := icmp.ParseMessage(1, e.buf[:n])
+		if err != nil || msg.Type != ipv4.ICMPTypeEchoReply {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok {
+			continue
+		}
+		reply, err := ParseICMPReply(echo.Data)
+		if err != nil || reply.Seq != r.Seq {
+			continue
+		}
+		if r.Session != [8]byte{} && reply.Session != r.Session {
+			continue
+		}
+		return reply, nil
+	}
+}
+
+// exchange sends the echo request number seq with data. A lost echo
+// lowers the MTU for the following attempts.
+func (e *icmpExchanger) exchange(seq uint32, data []byte) ([]byte, bool, int, error) {
+	reply, err := e.roundTrip(&ICMPRequest{Seq: seq, Data: data})
+	if err != nil {
+		if e.mtu = e.mtu * 3 / 4; e.mtu < ICMPMinMTU {
+			e.mtu = ICMPMinMTU
+		}
+		return nil, false, 0, err
+	}
+	return reply.Data, reply.More, int(reply.Accepted), nil
+}
+
+// chunkSize returns the most data that fits an echo request at the
+// current MTU.
+func (e *icmpExchanger) chunkSize() int {
+	return e.mtu - icmpPacketOverhead - icmpRequestHeader
+}
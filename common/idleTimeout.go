@@ -0,0 +1,75 @@
+package common
+
+import (
+	"sync/atomic"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// touch records that data was relayed over the connection.
+func (c *Connection) touch() {
+	atomic.StoreInt64(&c.lastActive, time.Now().UnixNano())
+}
+
+// idleFor returns how long the connection has not relayed any data.
+// Frozen connections are never idle.
+func (c *Connection) idleFor() time.Duration {
+	c.mutex.Lock()
+	frozen := c.thaw != nil
+	c.mutex.Unlock()
+
+	if frozen {
+		c.touch()
+		return 0
+	}
+	return time.Since(time.Unix(0, atomic.LoadInt64(&c.lastActive)))
+}
+
+// watchIdle closes the connection once it relayed no data for its idle
+// timeout. Closing it sends the close message on the byte stream.
+func (c *Connection) watchIdle() {
+	timer := time.NewTimer(c.idleTimeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			idle := c.idleFor()
+			if idle < c.idleTimeout {
+				timer.Reset(c.idleTimeout - idle)
+				continue
+			}
+			Log.WithConnection(c.ID).Infof("Closing connection idle for %s",
+				c.idleTimeout)
+			if c.onIdle != nil {
+				c.onIdle()
+			}
+			c.Close()
+			return
+		case <-c.Kill:
+			return
+		}
+	}
+}
+
+// setIdleTimeout makes the connection close after relaying no data for
+// timeout, unless it is 0. The tunnel sends a disconnect control
+// message for it.
+func (t *Tunnel) setIdleTimeout(c *Connection, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	c.idleTimeout = timeout
+	c.onIdle = func() {
+		t.RemoveConnection(c.ID)
+		if t.GetControlStream() == nil {
+			return
+		}
+		message := new(cs.TunnelControlMessage)
+		message.Operation = TunnelCtrlDisconnect
+		message.TunnelId = t.id
+		message.ConnectionId = c.ID
+		t.SendCtrlMessage(message)
+	}
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, nil, 0)
+	defer tunnel.Stop()
+	tunnel.SetOptions(TunnelOptions{IdleTimeout: 100 * time.Millisecond})
+
+	client, local := tcpPair(t)
+	defer client.Close()
+	sent := make(chan *cs.BytesMessage, 16)
+	c := NewConnection(local)
+	tunnel.AddConnection(c)
+	c.SetStream(&pipeStream{send: sent, recv: make(chan *cs.BytesMessage)})
+	c.Start()
+
+	// Traffic keeps the connection open
+	for i := 0; i < 3; i++ {
+		client.Write([]byte("x"))
+		time.Sleep(50 * time.Millisecond)
+	}
+	select {
+	case <-c.Kill:
+		t.Fatalf("connection closed while active")
+	default:
+	}
+
+	select {
+	case <-c.Kill:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("idle connection was not closed")
+	}
+	if tunnel.GetConnection(c.ID) != nil {
+		t.Errorf("idle connection was not removed from the tunnel")
+	}
+}
@@ -0,0 +1,26 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// listenConfig is used for all tunnel listeners. Its Control hook sets
+// SO_REUSEADDR and, where the platform supports it, SO_REUSEPORT so a
+// port can be bound again right after a tunnel is deleted and several
+// accept loops can share the same port.
+var listenConfig = net.ListenConfig{Control: reuseControl}
+
+// listenTCP opens a tcp listener on ip:port with listenConfig.
+func listenTCP(ctx context.Context, ip net.IP, port uint32) (*net.TCPListener, error) {
+	addr := net.JoinHostPort(ip.String(), fmt.Sprintf("%d", port))
+	if ip == nil {
+		addr = fmt.Sprintf(":%d", port)
+	}
+	ln, err := listenConfig.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return ln.(*net.TCPListener), nil
+}
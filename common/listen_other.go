@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package common
+
+import "syscall"
+
+// reuseControl leaves the socket untouched. On windows SO_REUSEADDR
+// would let other processes take over the port, and the remaining
+// platforms have no SO_REUSEPORT.
+func reuseControl(network, address string, c syscall.RawConn) error {
+	return nil
+}
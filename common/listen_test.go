@@ -0,0 +1,43 @@
+package common
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"testing"
+)
+
+func TestListenReusePort(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("SO_REUSEPORT is not supported on windows")
+	}
+
+	first, err := listenTCP(context.Background(), net.ParseIP("127.0.0.1"), 0)
+	if err != nil {
+		t.Fatalf("listenTCP: %v", err)
+	}
+	defer first.Close()
+	port := uint32(first.Addr().(*net.TCPAddr).Port)
+
+	second, err := listenTCP(context.Background(), net.ParseIP("127.0.0.1"), port)
+	if err != nil {
+		t.Fatalf("listenTCP: second listener on port %d: %v", port, err)
+	}
+	second.Close()
+
+	// A tunnel can be recreated on the port of a deleted one
+	first.Close()
+	tunnel := NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), port, nil, 0)
+	if !tunnel.AddListener("") {
+		t.Fatalf("AddListener: failed to listen on port %d", port)
+	}
+	tunnel.Stop()
+
+	tunnel = NewTunnel("tunnel", TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), port, nil, 0)
+	defer tunnel.Stop()
+	if !tunnel.AddListener("") {
+		t.Errorf("AddListener: failed to rebind port %d", port)
+	}
+}
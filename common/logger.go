@@ -0,0 +1,158 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// LogLevel is the severity of a log message.
+type LogLevel int32
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+	// LogLevelNone disables all logging.
+	LogLevelNone
+)
+
+// Field names used to attach gTunnel identifiers to log messages.
+const (
+	LogFieldEndpoint   = "endpoint"
+	LogFieldTunnel     = "tunnel"
+	LogFieldConnection = "connection"
+)
+
+var logLevelNames = map[LogLevel]string{
+	LogLevelDebug: "debug",
+	LogLevelInfo:  "info",
+	LogLevelWarn:  "warn",
+	LogLevelError: "error",
+	LogLevelNone:  "none",
+}
+
+// currentLogLevel is the minimum level that gets logged. It is
+// accessed atomically so that it can be changed at runtime.
+var currentLogLevel = int32(LogLevelInfo)
+
+// String returns the name of the log level.
+func (l LogLevel) String() string {
+	if name, ok := logLevelNames[l]; ok {
+		return name
+	}
+	return strconv.Itoa(int(l))
+}
+
+// ParseLogLevel converts the name of a log level into a LogLevel.
+func ParseLogLevel(name string) (LogLevel, error) {
+	for level, levelName := range logLevelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return LogLevelInfo, fmt.Errorf("invalid log level: %s", name)
+}
+
+// SetLogLevel sets the minimum level of messages that get logged.
+// It is safe to call at any time.
+func SetLogLevel(level LogLevel) {
+	atomic.StoreInt32(&currentLogLevel, int32(level))
+}
+
+// GetLogLevel returns the minimum level of messages that get logged.
+func GetLogLevel() LogLevel {
+	return LogLevel(atomic.LoadInt32(&currentLogLevel))
+}
+
+// Logger writes leveled messages in logfmt style through the standard
+// log package, so anything set with log.SetOutput receives them.
+// A Logger carries a set of fields, such as the endpoint, tunnel and
+// connection IDs, that are included in every message.
+//
+// Logger is used instead of a logging library such as zap or zerolog
+// because gClient is built for many targets and kept small, and
+// because the log rotation and shipping of gServer are plugged in
+// with log.SetOutput, which a separate library would bypass.
+type Logger struct {
+	fields string
+}
+
+// Log is the root logger without any fields.
+var Log = new(Logger)
+
+// WithField returns a new logger that includes key=value in every
+// message in addition to the fields of l.
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	newLogger := new(Logger)
+	newLogger.fields = l.fields + key + "=" + quoteLogValue(fmt.Sprint(value)) + " "
+	return newLogger
+}
+
+// WithEndpoint returns a new logger tagged with an endpoint ID.
+func (l *Logger) WithEndpoint(id string) *Logger {
+	return l.WithField(LogFieldEndpoint, id)
+}
+
+// WithTunnel returns a new logger tagged with a tunnel ID.
+func (l *Logger) WithTunnel(id string) *Logger {
+	return l.WithField(LogFieldTunnel, id)
+}
+
+// WithConnection returns a new logger tagged with a connection ID.
+func (l *Logger) WithConnection(id string) *Logger {
+	return l.WithField(LogFieldConnection, id)
+}
+
+// Debugf logs a message at the debug level.
+func (l *Logger) Debugf(format string, v ...interface{}) {
+	l.output(LogLevelDebug, format, v...)
+}
+
+// Infof logs a message at the info level.
+func (l *Logger) Infof(format string, v ...interface{}) {
+	l.output(LogLevelInfo, format, v...)
+}
+
+// Warnf logs a message at the warn level.
+func (l *Logger) Warnf(format string, v ...interface{}) {
+	l.output(LogLevelWarn, format, v...)
+}
+
+// Errorf logs a message at the error level.
+func (l *Logger) Errorf(format string, v ...interface{}) {
+	l.output(LogLevelError, format, v...)
+}
+
+// Fatalf logs a message regardless of the log level and exits.
+func (l *Logger) Fatalf(format string, v ...interface{}) {
+	log.Output(2, l.line("fatal", format, v...))
+	os.Exit(1)
+}
+
+// output formats and writes the message if level is enabled.
+func (l *Logger) output(level LogLevel, format string, v ...interface{}) {
+	if level < GetLogLevel() {
+		return
+	}
+	log.Output(3, l.line(level.String(), format, v...))
+}
+
+// line formats a message and the fields of l as a logfmt line.
+func (l *Logger) line(level string, format string, v ...interface{}) string {
+	message := strings.TrimSpace(fmt.Sprintf(format, v...))
+	return "level=" + level + " " + l.fields + "msg=" + quoteLogValue(message)
+}
+
+// quoteLogValue quotes a value if it contains characters that
+// would make the line ambiguous to parse.
+func quoteLogValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		return strconv.Quote(value)
+	}
+	return value
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoggerLevelAndFields(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	defer SetLogLevel(GetLogLevel())
+
+	SetLogLevel(LogLevelWarn)
+	Log.WithEndpoint("e1").Infof("dropped")
+	if buf.Len() != 0 {
+		t.Errorf("Infof at warn level: Got: %q Want: no output", buf.String())
+	}
+
+	Log.WithEndpoint("e1").WithTunnel("t1").Warnf("slow accept %d", 3)
+	want := `level=warn endpoint=e1 tunnel=t1 msg="slow accept 3"`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("Warnf: Got: %q Want: %q", buf.String(), want)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := ParseLogLevel("DEBUG")
+	if err != nil || level != LogLevelDebug {
+		t.Errorf("ParseLogLevel: Got: %v, %v Want: debug", level, err)
+	}
+
+	_, err = ParseLogLevel("verbose")
+	if err == nil {
+		t.Errorf("ParseLogLevel: Got: nil Want: error")
+	}
+}
@@ -0,0 +1,244 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LongPollPath is the path at which gServer accepts long-poll
+// transports.
+const LongPollPath = "/poll"
+
+// LongPollWait is how long gServer holds a poll until it answers
+// without data.
+const LongPollWait = 20 * time.Second
+
+// LongPollTransport carries gRPC over plain HTTPS requests, for
+// networks that only let short-lived requests through a proxy. Data
+// to the server is posted as it is written, data from the server is
+// fetched by long polls, which adds latency.
+type LongPollTransport struct {
+	// Port is the port of the long-poll listener of gServer. The port
+	// of the server address is used if empty.
+	Port string
+
+	// ServerName is the TLS server name and Host the HTTP Host that
+	// are sent instead of the host of the server address. Behind a
+	// CDN, the server address is a front domain and Host the domain
+	// that the CDN routes to gServer.
+	ServerName string
+	Host       string
+
+	// Fingerprint is the TLS fingerprint, such as chrome, whose
+	// ClientHello is sent. The one of Go if empty.
+	Fingerprint string
+
+	// Proxy, if set, is the HTTP proxy through which requests are
+	// sent.
+	Proxy *HTTPProxy
+}
+
+// Name returns the name of the transport.
+func (t *LongPollTransport) Name() string {
+	return TransportLongPoll
+}
+
+// Dialer returns Dial.
+func (t *LongPollTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return t.Dial
+}
+
+// Dial opens a long-poll session with gServer. Requests go through
+// Proxy, if set.
+func (t *LongPollTransport) Dial(ctx context.Context, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if t.Port != "" {
+		port = t.Port
+	}
+	address = net.JoinHostPort(host, port)
+
+	// The gRPC connection runs its own TLS within the requests
+	tlsConfig := &tls.Config{
+		ServerName:         serverName(host, t.ServerName),
+		InsecureSkipVerify: true,
+	}
+	transport := &http.Transport{
+		DialTLSContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var conn net.Conn
+			var err error
+			if t.Proxy != nil {
+				// The proxy dials itself, as http only does basic
+				// authentication
+				conn, err = t.Proxy.DialContext(ctx, address)
+			} else {
+				var dialer net.Dialer
+				conn, err = dialer.DialContext(ctx, network, address)
+			}
+			if err != nil {
+				return nil, err
+			}
+			tlsConn, err := tlsClient(conn, tlsConfig, t.Fingerprint, []string{"http/1.1"})
+			if err == nil {
+				err = tlsConn.HandshakeContext(ctx)
+			}
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		},
+	}
+	client := &http.Client{Transport: transport}
+
+	url := fmt.Sprintf("https://%s%s", address, LongPollPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = t.Host
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	session, err := ioutil.ReadAll(io.LimitReader(resp.Body, 256))
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK || len(session) == 0 {
+		return nil, fmt.Errorf("failed to open long-poll session: %s", resp.Status)
+	}
+
+	c := new(longPollConn)
+	c.client = client
+	c.url = fmt.Sprintf("%s?session=%s", url, session)
+	c.host = t.Host
+	c.remoteAddr = &transportAddr{TransportLongPoll, address}
+	c.reader, c.writer = io.Pipe()
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	go c.poll()
+	return c, nil
+}
+
+// longPollConn is the gClient side of a long-poll session.
+type longPollConn struct {
+	client     *http.Client
+	url        string
+	host       string
+	remoteAddr net.Addr
+	reader     *io.PipeReader
+	writer     *io.PipeWriter
+	writeMutex sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	closeOnce  sync.Once
+}
+
+// do sends a request of the session.
+func (c *longPollConn) do(ctx context.Context, method string,
+	body io.Reader) (*http.Response, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Host = c.host
+	return c.client.Do(req)
+}
+
+// poll fetches the data of the server until the session ends.
+func (c *longPollConn) poll() {
+	for {
+		resp, err := c.do(c.ctx, http.MethodGet, nil)
+		if err != nil {
+			c.writer.CloseWithError(err)
+			return
+		}
+		if resp.StatusCode == http.StatusOK {
+			_, err = io.Copy(c.writer, resp.Body)
+		} else if resp.StatusCode == http.StatusGone {
+			err = io.EOF
+		} else if resp.StatusCode != http.StatusNoContent {
+			err = fmt.Errorf("long poll failed: %s", resp.Status)
+		}
+		resp.Body.Close()
+		if err != nil {
+			c.writer.CloseWithError(err)
+			return
+		}
+	}
+}
+
+// Read reads data from the server.
+func (c *longPollConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// Write posts b to the server.
+func (c *longPollConn) Write(b []byte) (int, error) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	resp, err := c.do(c.ctx, http.MethodPost, bytes.NewReader(b))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return 0, fmt.Errorf("long-poll post failed: %s", resp.Status)
+	}
+	return len(b), nil
+}
+
+// Close ends the session.
+func (c *longPollConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		c.reader.Close()
+
+		// Tell the server, which would otherwise wait for the
+		// session to expire
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if resp, err := c.do(ctx, http.MethodDelete, nil); err == nil {
+			resp.Body.Close()
+		}
+	})
+	return nil
+}
+
+// LocalAddr returns nil, the requests have no fixed local address.
+func (c *longPollConn) LocalAddr() net.Addr {
+	return nil
+}
+
+// RemoteAddr returns the address of the server.
+func (c *longPollConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// SetDeadline is not supported. gRPC does not depend on deadlines.
+func (c *longPollConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline is not supported.
+func (c *longPollConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is not supported.
+func (c *longPollConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package common
+
+import "sync/atomic"
+
+// Counters of the data path shared by all tunnels of the process.
+// They are updated atomically and exported for monitoring.
+var (
+	bytesReceivedTotal uint64
+	bytesSentTotal     uint64
+	dialFailuresTotal  uint64
+	streamErrorsTotal  uint64
+)
+
+// MetricCounters is a snapshot of the data path counters.
+type MetricCounters struct {
+	// BytesReceived is the number of bytes read from local sockets
+	// and sent over byte streams.
+	BytesReceived uint64
+	// BytesSent is the number of bytes received over byte streams
+	// and written to local sockets.
+	BytesSent uint64
+	// DialFailures is the number of tunnel destinations that could
+	// not be connected to.
+	DialFailures uint64
+	// StreamErrors is the number of byte streams that failed.
+	StreamErrors uint64
+}
+
+// GetMetricCounters returns a snapshot of the data path counters.
+func GetMetricCounters() MetricCounters {
+	return MetricCounters{
+		BytesReceived: atomic.LoadUint64(&bytesReceivedTotal),
+		BytesSent:     atomic.LoadUint64(&bytesSentTotal),
+		DialFailures:  atomic.LoadUint64(&dialFailuresTotal),
+		StreamErrors:  atomic.LoadUint64(&streamErrorsTotal),
+	}
+}
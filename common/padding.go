@@ -0,0 +1,42 @@
+package common
+
+import (
+	"math/rand"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// pad pads the content of a message that is to be sent to a multiple
+// of the pad size of the connection. The padding is random so that it
+// does not compress away.
+func (c *Connection) pad(message *cs.BytesMessage) {
+	if c.padSize == 0 {
+		return
+	}
+
+	n := len(message.Content) % int(c.padSize)
+	if n == 0 {
+		return
+	}
+	message.Padding = make([]byte, int(c.padSize)-n)
+	rand.Read(message.Padding)
+}
+
+// jitter holds back the next message by a random time of up to the
+// send jitter of the connection. It returns false if the connection
+// is killed meanwhile.
+func (c *Connection) jitter() bool {
+	if c.sendJitter <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(c.sendJitter))))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.Kill:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestPadding(t *testing.T) {
+	c := NewConnection(nil)
+	c.padSize = 512
+	message := new(cs.BytesMessage)
+
+	for _, size := range []int{1, 511, 512, 513} {
+		data := bytes.Repeat([]byte("x"), size)
+		c.setContent(message, data)
+		if n := len(message.Content) + len(message.Padding); n%512 != 0 {
+			t.Errorf("setContent(%d bytes): Got: %d bytes Want: a multiple of 512", size, n)
+		}
+		if got, err := c.content(message); err != nil || !bytes.Equal(got, data) {
+			t.Errorf("content: Got: %d bytes, %v Want: the data", len(got), err)
+		}
+	}
+
+	// Reused messages lose their padding once the tunnel does not pad
+	c.padSize = 0
+	c.setContent(message, []byte("x"))
+	if len(message.Padding) != 0 {
+		t.Errorf("setContent: Got: %d bytes of padding Want: none", len(message.Padding))
+	}
+
+	// Messages that are held back are dropped once the connection is
+	// killed
+	c.sendJitter = time.Hour
+	close(c.Kill)
+	if c.jitter() {
+		t.Errorf("jitter: Got: true for a killed connection")
+	}
+}
@@ -0,0 +1,203 @@
+package common
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// pollInterval is the longest a polled session idles between
+// exchanges. Exchanges follow each other without delay while there is
+// data.
+const pollInterval = 2 * time.Second
+
+// pollAttempts is the number of times an exchange is tried before the
+// session is given up.
+const pollAttempts = 10
+
+// errPollClosed is returned by a closed polled session.
+var errPollClosed = errors.New("session closed")
+
+// pollExchanger carries the exchanges of a polled session, such as in
+// DNS queries.
+type pollExchanger interface {
+	// exchange sends exchange number seq with data to gServer. It
+	// returns the data of the answer, whether more data is waiting on
+	// gServer and how many bytes of data gServer took. Exchanges are
+	// repeated with the same seq until one succeeds.
+	exchange(seq uint32, data []byte) (answer []byte, more bool, accepted int, err error)
+
+	// chunkSize returns the most data the next exchange may carry.
+	chunkSize() int
+}
+
+// pollConn is the gClient side of a transport that cannot keep a
+// connection open, so it polls gServer in numbered exchanges. They are
+// sent one at a time and each answer acknowledges the data of its
+// exchange.
+type pollConn struct {
+	exchanger  pollExchanger
+	remoteAddr net.Addr
+	seq        uint32
+	in         chan []byte
+	pending    []byte
+	readErr    error
+	out        []byte
+	outMutex   sync.Mutex
+	wake       chan struct{}
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// newPollConn is a constructor for pollConn. It starts polling.
+func newPollConn(exchanger pollExchanger, remoteAddr net.Addr) *pollConn {
+	c := new(pollConn)
+	c.exchanger = exchanger
+	c.remoteAddr = remoteAddr
+	c.in = make(chan []byte, 64)
+	c.wake = make(chan struct{}, 1)
+	c.closed = make(chan struct{})
+	go c.poll()
+	return c
+}
+
+// send carries the next exchange, trying it again if it fails.
+func (c *pollConn) send() ([]byte, bool, error) {
+	var err error
+	for attempt := 0; attempt < pollAttempts; attempt++ {
+		c.outMutex.Lock()
+		chunk := c.out
+		if size := c.exchanger.chunkSize(); len(chunk) > size {
+			chunk = chunk[:size]
+		}
+		c.outMutex.Unlock()
+
+		var data []byte
+		var more bool
+		var accepted int
+		data, more, accepted, err = c.exchanger.exchange(c.seq, chunk)
+		if err == nil {
+			c.seq++
+			c.outMutex.Lock()
+			c.out = c.out[accepted:]
+			c.outMutex.Unlock()
+			return data, more, nil
+		}
+
+		select {
+		case <-time.After(time.Duration(attempt+1) * 200 * time.Millisecond):
+		case <-c.closed:
+			return nil, false, errPollClosed
+		}
+	}
+	return nil, false, err
+}
+
+// poll exchanges data with gServer until the session ends.
+func (c *pollConn) poll() {
+	defer close(c.in)
+
+	interval := 50 * time.Millisecond
+	for {
+		data, more, err := c.send()
+		if err != nil {
+			c.readErr = err
+			return
+		}
+
+		if len(data) > 0 {
+			select {
+			case c.in <- data:
+			case <-c.closed:
+				return
+			}
+		}
+
+		c.outMutex.Lock()
+		waiting := len(c.out) > 0
+		c.outMutex.Unlock()
+
+		// Back off while the session is idle
+		if len(data) > 0 || more || waiting {
+			interval = 50 * time.Millisecond
+			continue
+		}
+		select {
+		case <-time.After(interval):
+		case <-c.wake:
+		case <-c.closed:
+			return
+		}
+		if interval *= 2; interval > pollInterval {
+			interval = pollInterval
+		}
+	}
+}
+
+// Read reads data from gServer.
+func (c *pollConn) Read(b []byte) (int, error) {
+	if len(c.pending) == 0 {
+		data, ok := <-c.in
+		if !ok {
+			if c.readErr != nil {
+				return 0, c.readErr
+			}
+			return 0, errPollClosed
+		}
+		c.pending = data
+	}
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// Write queues b for the next exchanges.
+func (c *pollConn) Write(b []byte) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, errPollClosed
+	default:
+	}
+
+	c.outMutex.Lock()
+	c.out = append(c.out, b...)
+	c.outMutex.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+	return len(b), nil
+}
+
+// Close ends the session. gServer lets it expire.
+func (c *pollConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// LocalAddr returns nil, the exchanges have no fixed local address.
+func (c *pollConn) LocalAddr() net.Addr {
+	return nil
+}
+
+// RemoteAddr returns the address of gServer.
+func (c *pollConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// SetDeadline is not supported. gRPC does not depend on deadlines.
+func (c *pollConn) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline is not supported.
+func (c *pollConn) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is not supported.
+func (c *pollConn) SetWriteDeadline(t time.Time) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortRange is an inclusive range of TCP ports.
+type PortRange struct {
+	Low  uint32
+	High uint32
+}
+
+// AllPorts is the range of every port.
+var AllPorts = PortRange{Low: 0, High: 65535}
+
+// ParsePortRange parses a port, such as "443", or a range of ports,
+// such as "10000-20000".
+func ParsePortRange(ports string) (PortRange, error) {
+	bounds := strings.SplitN(ports, "-", 2)
+	low, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 16)
+	if err != nil {
+		return PortRange{}, fmt.Errorf("invalid port %q", bounds[0])
+	}
+	high := low
+	if len(bounds) == 2 {
+		high, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 16)
+		if err != nil {
+			return PortRange{}, fmt.Errorf("invalid port %q", bounds[1])
+		}
+	}
+	if low > high {
+		return PortRange{}, fmt.Errorf("invalid port range %s", ports)
+	}
+	return PortRange{Low: uint32(low), High: uint32(high)}, nil
+}
+
+// ParsePortRanges parses a comma separated list of ports and ranges,
+// such as "80,443,10000-20000".
+func ParsePortRanges(ports string) ([]PortRange, error) {
+	ranges := make([]PortRange, 0)
+	for _, p := range strings.Split(ports, ",") {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		r, err := ParsePortRange(p)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// Contains returns true if port is within the range.
+func (r PortRange) Contains(port uint32) bool {
+	return port >= r.Low && port <= r.High
+}
+
+// String returns the range in the format of ParsePortRange.
+func (r PortRange) String() string {
+	if r.Low == r.High {
+		return fmt.Sprint(r.Low)
+	}
+	return fmt.Sprintf("%d-%d", r.Low, r.High)
+}
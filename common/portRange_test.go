@@ -0,0 +1,23 @@
+package common
+
+import "testing"
+
+func TestParsePortRanges(t *testing.T) {
+	ranges, err := ParsePortRanges("22, 10000-20000")
+	if err != nil {
+		t.Fatalf("ParsePortRanges: %v", err)
+	}
+	if len(ranges) != 2 || ranges[0].String() != "22" ||
+		ranges[1].String() != "10000-20000" {
+		t.Fatalf("ParsePortRanges: Got: %v", ranges)
+	}
+	if !ranges[1].Contains(20000) || ranges[1].Contains(9999) {
+		t.Errorf("Contains: wrong bounds of %s", ranges[1])
+	}
+
+	for _, invalid := range []string{"http", "65536", "20-10", "1-2-3"} {
+		if _, err := ParsePortRanges(invalid); err == nil {
+			t.Errorf("ParsePortRanges(%s): Got no error", invalid)
+		}
+	}
+}
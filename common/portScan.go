@@ -0,0 +1,168 @@
+package common
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxScanTargets limits the number of host and port pairs of a scan.
+const MaxScanTargets = 1 << 20
+
+// DefaultScanTimeout is how long a scan waits for a port to accept a
+// connection unless configured otherwise.
+const DefaultScanTimeout = time.Second
+
+// scanWorkers is the number of ports that are connected to at once.
+const scanWorkers = 64
+
+// PortScan connects to a list of ports on a list of hosts to find the
+// ones that are open.
+type PortScan struct {
+	hosts     []net.IP
+	ports     []PortRange
+	timeout   time.Duration
+	dialCheck DialCheck
+	dialFunc  DialFunc
+}
+
+// NewPortScan is a constructor for the PortScan struct. Hosts is a comma
+// separated list of addresses and networks in the format of
+// ParseNetworks, ports one of ports and ranges in the format of
+// ParsePortRanges. Timeout is DefaultScanTimeout if 0.
+func NewPortScan(hosts string, ports string,
+	timeout time.Duration) (*PortScan, error) {
+
+	networks, err := ParseNetworks(hosts)
+	if err != nil {
+		return nil, err
+	}
+	ranges, err := ParsePortRanges(ports)
+	if err != nil {
+		return nil, err
+	}
+	if len(networks) == 0 || len(ranges) == 0 {
+		return nil, fmt.Errorf("nothing to scan")
+	}
+
+	var portCount uint64
+	for _, r := range ranges {
+		portCount += uint64(r.High-r.Low) + 1
+	}
+
+	p := new(PortScan)
+	p.ports = ranges
+	p.timeout = timeout
+	if p.timeout <= 0 {
+		p.timeout = DefaultScanTimeout
+	}
+	for _, network := range networks {
+		ones, bits := network.Mask.Size()
+		if bits-ones > 20 ||
+			uint64(len(p.hosts)+1<<uint(bits-ones))*portCount > MaxScanTargets {
+			return nil, fmt.Errorf("more than %d targets to scan", MaxScanTargets)
+		}
+		p.hosts = append(p.hosts, networkHosts(network)...)
+	}
+	return p, nil
+}
+
+// networkHosts returns the addresses of an IPv4 network, or the single
+// address of an IPv6 one.
+func networkHosts(network *net.IPNet) []net.IP {
+	ip4 := network.IP.To4()
+	if ip4 == nil {
+		return []net.IP{network.IP}
+	}
+	ones, bits := network.Mask.Size()
+	first := binary.BigEndian.Uint32(ip4)
+	hosts := make([]net.IP, 0, 1<<uint(bits-ones))
+	for i := uint32(0); i < 1<<uint(bits-ones); i++ {
+		ip := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(ip, first+i)
+		hosts = append(hosts, ip)
+	}
+	return hosts
+}
+
+// SetDialCheck sets a function that every target of the scan has to
+// pass. Targets that do not are skipped. It must be called before Run.
+func (p *PortScan) SetDialCheck(check DialCheck) {
+	p.dialCheck = check
+}
+
+// SetDialer sets the function with which the scan connects, such as
+// that of an upstream socks proxy. It must be called before Run.
+func (p *PortScan) SetDialer(dial DialFunc) {
+	p.dialFunc = dial
+}
+
+// Run scans the targets until all are done or ctx is cancelled and
+// calls open with the address of each open port. Open is called from
+// one goroutine at a time.
+func (p *PortScan) Run(ctx context.Context, open func(address string)) {
+	targets := make(chan string)
+	found := make(chan string)
+
+	var wg sync.WaitGroup
+	for i := 0; i < scanWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for address := range targets {
+				if p.isOpen(ctx, address) {
+					found <- address
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(targets)
+		for _, host := range p.hosts {
+			for _, r := range p.ports {
+				for port := r.Low; port <= r.High; port++ {
+					if p.dialCheck != nil && p.dialCheck(host, port) != nil {
+						continue
+					}
+					address := net.JoinHostPort(host.String(),
+						strconv.Itoa(int(port)))
+					select {
+					case targets <- address:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(found)
+	}()
+
+	for address := range found {
+		open(address)
+	}
+}
+
+// isOpen returns true if address accepts a TCP connection within the
+// timeout of the scan.
+func (p *PortScan) isOpen(ctx context.Context, address string) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	dial := p.dialFunc
+	if dial == nil {
+		dial = new(net.Dialer).DialContext
+	}
+	conn, err := dial(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+)
+
+func TestPortScan(t *testing.T) {
+	if _, err := NewPortScan("10.0.0.0/8", "1-65535", 0); err == nil {
+		t.Errorf("NewPortScan: accepted a scan of too many targets")
+	}
+	if _, err := NewPortScan("10.0.0.1", "", 0); err == nil {
+		t.Errorf("NewPortScan: accepted a scan without ports")
+	}
+	scan, err := NewPortScan("10.0.0.0/30,10.0.1.1", "80", 0)
+	if err != nil {
+		t.Fatalf("NewPortScan: %v", err)
+	}
+	if len(scan.hosts) != 5 {
+		t.Errorf("NewPortScan: got %d hosts, want 5", len(scan.hosts))
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer ln.Close()
+	_, port, _ := net.SplitHostPort(ln.Addr().String())
+
+	// A port that is closed and denied by the dial check is skipped
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	_, closedPort, _ := net.SplitHostPort(closed.Addr().String())
+	closed.Close()
+
+	scan, err = NewPortScan("127.0.0.1", port+","+closedPort, 0)
+	if err != nil {
+		t.Fatalf("NewPortScan: %v", err)
+	}
+	denied, _ := strconv.Atoi(closedPort)
+	checked := 0
+	scan.SetDialCheck(func(ip net.IP, p uint32) error {
+		checked++
+		if int(p) == denied {
+			return fmt.Errorf("denied")
+		}
+		return nil
+	})
+
+	open := make([]string, 0)
+	scan.Run(context.Background(), func(address string) {
+		open = append(open, address)
+	})
+	if len(open) != 1 || open[0] != ln.Addr().String() {
+		t.Errorf("Run: found %v, want %s", open, ln.Addr())
+	}
+	if checked != 2 {
+		t.Errorf("Run: checked %d targets, want 2", checked)
+	}
+}
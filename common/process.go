@@ -0,0 +1,134 @@
+package common
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// processAddr is the net.Addr used by a ProcessConn. It
+// reports the command that was spawned.
+type processAddr struct {
+	command string
+}
+
+// Network returns the name of the network.
+func (a processAddr) Network() string {
+	return "exec"
+}
+
+// String returns the command line of the process.
+func (a processAddr) String() string {
+	return a.command
+}
+
+// ProcessConn is a net.Conn that is backed by the stdin and
+// stdout of a spawned process. It allows a tunnel to bridge
+// a connection to a local helper in the style of inetd.
+type ProcessConn struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    io.ReadCloser
+	addr      processAddr
+	closeOnce sync.Once
+}
+
+// StartProcess will start the provided command line using the
+// platform shell and return a ProcessConn wired to its stdin
+// and stdout. Stderr is discarded.
+func StartProcess(command string) (*ProcessConn, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", command)
+	} else {
+		cmd = exec.Command("/bin/sh", "-c", command)
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := new(ProcessConn)
+	p.cmd = cmd
+	p.stdin = stdin
+	p.stdout = stdout
+	p.addr = processAddr{command: command}
+	return p, nil
+}
+
+// Read reads from the stdout of the process.
+func (p *ProcessConn) Read(b []byte) (int, error) {
+	return p.stdout.Read(b)
+}
+
+// Write writes to the stdin of the process.
+func (p *ProcessConn) Write(b []byte) (int, error) {
+	return p.stdin.Write(b)
+}
+
+// Close will close stdin, kill the process if it is still
+// running and reap it.
+func (p *ProcessConn) Close() error {
+	p.closeOnce.Do(func() {
+		p.stdin.Close()
+		p.cmd.Process.Kill()
+		p.cmd.Wait()
+		p.stdout.Close()
+	})
+	return nil
+}
+
+// CloseWrite closes the stdin of the process so that it sees the end
+// of its input while its output is still read.
+func (p *ProcessConn) CloseWrite() error {
+	return p.stdin.Close()
+}
+
+// LocalAddr returns the command line of the process.
+func (p *ProcessConn) LocalAddr() net.Addr {
+	return p.addr
+}
+
+// RemoteAddr returns the command line of the process.
+func (p *ProcessConn) RemoteAddr() net.Addr {
+	return p.addr
+}
+
+// SetDeadline sets the read and write deadlines of the pipes
+// where the platform supports it.
+func (p *ProcessConn) SetDeadline(t time.Time) error {
+	if err := p.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return p.SetWriteDeadline(t)
+}
+
+// SetReadDeadline sets the deadline on the stdout pipe.
+func (p *ProcessConn) SetReadDeadline(t time.Time) error {
+	if f, ok := p.stdout.(*os.File); ok {
+		return f.SetReadDeadline(t)
+	}
+	return nil
+}
+
+// SetWriteDeadline sets the deadline on the stdin pipe.
+func (p *ProcessConn) SetWriteDeadline(t time.Time) error {
+	if f, ok := p.stdin.(*os.File); ok {
+		return f.SetWriteDeadline(t)
+	}
+	return nil
+}
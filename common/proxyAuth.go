@@ -0,0 +1,66 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/Azure/go-ntlmssp"
+)
+
+// proxyAuthenticator produces the tokens of a connection based proxy
+// authentication handshake such as NTLM.
+type proxyAuthenticator interface {
+	// scheme returns the name of the scheme in the headers.
+	scheme() string
+
+	// token answers the challenge of the proxy, which is nil for the
+	// first request.
+	token(challenge []byte) ([]byte, error)
+
+	// close releases the security context of the handshake.
+	close()
+}
+
+// authScheme returns the name of scheme in the headers.
+func authScheme(scheme string) string {
+	if scheme == ProxyAuthNegotiate {
+		return "Negotiate"
+	}
+	return "NTLM"
+}
+
+// splitDomain splits DOMAIN\user and user@domain into the user and
+// domain.
+func splitDomain(username string) (string, string) {
+	user, domain := ntlmssp.GetDomain(username)
+	if i := strings.LastIndex(user, "@"); domain == "" && i >= 0 {
+		return user[:i], user[i+1:]
+	}
+	return user, domain
+}
+
+// ntlmAuthenticator authenticates with NTLMv2 with the given
+// credentials. Proxies that offer Negotiate accept its tokens too.
+type ntlmAuthenticator struct {
+	name     string
+	username string
+	password string
+}
+
+// scheme returns the name of the scheme in the headers.
+func (a *ntlmAuthenticator) scheme() string {
+	return a.name
+}
+
+// token returns the negotiate message, and then the authenticate
+// message that answers the challenge.
+func (a *ntlmAuthenticator) token(challenge []byte) ([]byte, error) {
+	user, domain := splitDomain(a.username)
+	if challenge == nil {
+		return ntlmssp.NewNegotiateMessage(domain, "")
+	}
+	return ntlmssp.ProcessChallenge(challenge, user, a.password)
+}
+
+// close does nothing.
+func (a *ntlmAuthenticator) close() {
+}
@@ -0,0 +1,19 @@
+//go:build !windows
+// +build !windows
+
+package common
+
+import "errors"
+
+// newProxyAuthenticator authenticates with NTLM, which needs the
+// credentials of the user.
+func newProxyAuthenticator(scheme, host, username, password string) (proxyAuthenticator, error) {
+	if username == "" {
+		return nil, errors.New("proxy authentication as the current user needs windows")
+	}
+	a := new(ntlmAuthenticator)
+	a.name = authScheme(scheme)
+	a.username = username
+	a.password = password
+	return a, nil
+}
@@ -0,0 +1,82 @@
+//go:build windows
+// +build windows
+
+package common
+
+import (
+	"github.com/alexbrainman/sspi"
+	"github.com/alexbrainman/sspi/negotiate"
+	"github.com/alexbrainman/sspi/ntlm"
+)
+
+// newProxyAuthenticator authenticates through SSPI, as the current
+// user if username is empty, so that Negotiate may use Kerberos.
+func newProxyAuthenticator(scheme, host, username, password string) (proxyAuthenticator, error) {
+	var credentials *sspi.Credentials
+	var err error
+	user, domain := splitDomain(username)
+	switch {
+	case scheme == ProxyAuthNegotiate && username == "":
+		credentials, err = negotiate.AcquireCurrentUserCredentials()
+	case scheme == ProxyAuthNegotiate:
+		credentials, err = negotiate.AcquireUserCredentials(domain, user, password)
+	case username == "":
+		credentials, err = ntlm.AcquireCurrentUserCredentials()
+	default:
+		credentials, err = ntlm.AcquireUserCredentials(domain, user, password)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a := new(sspiAuthenticator)
+	a.name = authScheme(scheme)
+	a.credentials = credentials
+	a.target = "HTTP/" + host
+	return a, nil
+}
+
+// sspiAuthenticator authenticates with the security packages of
+// windows.
+type sspiAuthenticator struct {
+	name        string
+	credentials *sspi.Credentials
+	target      string
+
+	negotiate *negotiate.ClientContext
+	ntlm      *ntlm.ClientContext
+}
+
+// scheme returns the name of the scheme in the headers.
+func (a *sspiAuthenticator) scheme() string {
+	return a.name
+}
+
+// token starts the security context, and then updates it with the
+// challenges of the proxy.
+func (a *sspiAuthenticator) token(challenge []byte) ([]byte, error) {
+	var token []byte
+	var err error
+	switch {
+	case a.name == "Negotiate" && a.negotiate == nil:
+		a.negotiate, token, err = negotiate.NewClientContext(a.credentials, a.target)
+	case a.name == "Negotiate":
+		_, token, err = a.negotiate.Update(challenge)
+	case a.ntlm == nil:
+		a.ntlm, token, err = ntlm.NewClientContext(a.credentials)
+	default:
+		token, err = a.ntlm.Update(challenge)
+	}
+	return token, err
+}
+
+// close releases the security context and the credentials.
+func (a *sspiAuthenticator) close() {
+	if a.negotiate != nil {
+		a.negotiate.Release()
+	}
+	if a.ntlm != nil {
+		a.ntlm.Release()
+	}
+	a.credentials.Release()
+}
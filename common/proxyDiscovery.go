@@ -0,0 +1,54 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mattn/go-ieproxy"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Ways in which the client finds its proxy when it has none of its own.
+const (
+	ProxyDiscoveryNone = "none"
+	ProxyDiscoveryEnv  = "env"
+	ProxyDiscoveryPAC  = "pac"
+)
+
+// ParseProxyDiscovery checks the name of a way to find a proxy, none
+// if empty.
+func ParseProxyDiscovery(discovery string) (string, error) {
+	switch discovery {
+	case "":
+		return ProxyDiscoveryNone, nil
+	case ProxyDiscoveryNone, ProxyDiscoveryEnv, ProxyDiscoveryPAC:
+		return discovery, nil
+	}
+	return "", fmt.Errorf("unknown proxy discovery %s", discovery)
+}
+
+// DiscoverHTTPProxy finds the proxy through which to reach address, a
+// host and port. With env it follows the HTTPS_PROXY and NO_PROXY
+// variables of the environment. With pac it also follows the WPAD or
+// PAC configuration of windows when the environment sets no proxy. It
+// returns nil if address is reached directly.
+func DiscoverHTTPProxy(address string, discovery string) (*HTTPProxy, error) {
+	discovery, err := ParseProxyDiscovery(discovery)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &url.URL{Scheme: "https", Host: address}
+	var proxy *url.URL
+	switch discovery {
+	case ProxyDiscoveryEnv:
+		proxy, err = httpproxy.FromEnvironment().ProxyFunc()(target)
+	case ProxyDiscoveryPAC:
+		proxy, err = ieproxy.GetProxyFunc()(&http.Request{URL: target})
+	}
+	if err != nil || proxy == nil {
+		return nil, err
+	}
+	return proxyFromURL(proxy)
+}
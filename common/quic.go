@@ -0,0 +1,150 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+// QUICProtocol is the ALPN protocol of the QUIC transport. It is the
+// one of HTTP/3, so that the transport passes for it on the wire.
+const QUICProtocol = "h3"
+
+// QUICKeepalive is how often both ends of the QUIC transport send a
+// packet, so that NATs keep the UDP flow open while tunnels are idle.
+const QUICKeepalive = 15 * time.Second
+
+// QUICMaxStreams is the largest number of QUIC streams that a gClient
+// may have open. Each byte stream takes one.
+const QUICMaxStreams = 10000
+
+// QUICConfig returns the settings of both ends of the QUIC transport.
+func QUICConfig() *quic.Config {
+	return &quic.Config{
+		KeepAlivePeriod:    QUICKeepalive,
+		MaxIncomingStreams: QUICMaxStreams,
+	}
+}
+
+// QUICTransport carries gRPC over QUIC, for lossy or high latency
+// networks. Every gRPC connection is a stream of a single QUIC
+// connection, which is dialed along with the first one. gClient opens
+// a gRPC connection for each byte stream, so that a lost packet only
+// holds up the byte stream it belongs to.
+type QUICTransport struct {
+	// Port is the UDP port of the QUIC listener of gServer. The port
+	// of the server address is used if empty.
+	Port string
+
+	// ServerName is the TLS server name that is sent instead of the
+	// host of the server address.
+	ServerName string
+
+	conns map[string]*quic.Conn
+	mutex sync.Mutex
+}
+
+// Name returns the name of the transport.
+func (t *QUICTransport) Name() string {
+	return TransportQUIC
+}
+
+// Dialer returns Dial.
+func (t *QUICTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return t.Dial
+}
+
+// SeparateStreams returns true, byte streams are carried by gRPC
+// connections of their own.
+func (t *QUICTransport) SeparateStreams() bool {
+	return true
+}
+
+// Dial opens a stream to gServer, on the QUIC connection to address if
+// there is one.
+func (t *QUICTransport) Dial(ctx context.Context, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if t.Port != "" {
+		port = t.Port
+	}
+	address = net.JoinHostPort(host, port)
+
+	conn, err := t.connection(ctx, address, host)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewQUICConn(conn, stream), nil
+}
+
+// connection returns the QUIC connection to address, which is dialed
+// if there is none or it was closed.
+func (t *QUICTransport) connection(ctx context.Context, address string,
+	host string) (*quic.Conn, error) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if conn, ok := t.conns[address]; ok {
+		if conn.Context().Err() == nil {
+			return conn, nil
+		}
+		delete(t.conns, address)
+	}
+
+	// The gRPC connections run their own TLS within the streams
+	conn, err := quic.DialAddr(ctx, address, &tls.Config{
+		ServerName:         serverName(host, t.ServerName),
+		InsecureSkipVerify: true,
+		NextProtos:         []string{QUICProtocol},
+	}, QUICConfig())
+	if err != nil {
+		return nil, err
+	}
+	if t.conns == nil {
+		t.conns = make(map[string]*quic.Conn)
+	}
+	t.conns[address] = conn
+	return conn, nil
+}
+
+// quicConn is a stream of a QUIC connection.
+type quicConn struct {
+	*quic.Stream
+	conn *quic.Conn
+}
+
+// NewQUICConn returns a stream of the QUIC connection conn as a
+// net.Conn.
+func NewQUICConn(conn *quic.Conn, stream *quic.Stream) net.Conn {
+	c := new(quicConn)
+	c.Stream = stream
+	c.conn = conn
+	return c
+}
+
+// Close closes both directions of the stream.
+func (c *quicConn) Close() error {
+	c.Stream.CancelRead(0)
+	return c.Stream.Close()
+}
+
+// LocalAddr returns the address of the local end of the connection.
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr returns the address of the remote end of the connection.
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
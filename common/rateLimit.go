@@ -0,0 +1,101 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that limits the bandwidth of the
+// connections it is attached to. The bucket holds up to one second
+// worth of bytes, so a quiet connection may send a short burst.
+type RateLimiter struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+	mutex  sync.Mutex
+}
+
+// NewRateLimiter is a constructor for RateLimiter. rate is in bytes
+// per second.
+func NewRateLimiter(rate uint64) *RateLimiter {
+	l := new(RateLimiter)
+	l.rate = float64(rate)
+	l.tokens = l.rate
+	l.last = time.Now()
+
+	return l
+}
+
+// SetRate changes the rate of the limiter. A rate of 0 lifts the
+// limit.
+func (l *RateLimiter) SetRate(rate uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.rate = float64(rate)
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+}
+
+// GetRate returns the rate of the limiter in bytes per second.
+func (l *RateLimiter) GetRate() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return uint64(l.rate)
+}
+
+// delay takes size bytes from the bucket and returns how long the
+// caller has to wait before they are covered. The bucket goes into
+// debt so that chunks larger than the burst still get through.
+func (l *RateLimiter) delay(size int) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if l.rate == 0 {
+		l.last = now
+		return 0
+	}
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.rate {
+		l.tokens = l.rate
+	}
+	l.last = now
+	l.tokens -= float64(size)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until size bytes may be sent. It returns false if kill
+// is closed first.
+func (l *RateLimiter) wait(size int, kill <-chan bool) bool {
+	d := l.delay(size)
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-kill:
+		return false
+	}
+}
+
+// throttle waits until size bytes may be relayed by all the rate
+// limiters of the connection. It returns false if the connection is
+// closed first.
+func (c *Connection) throttle(size int) bool {
+	for _, l := range c.limiters {
+		if !l.wait(size, c.Kill) {
+			return false
+		}
+	}
+	return true
+}
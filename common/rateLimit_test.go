@@ -0,0 +1,29 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := NewRateLimiter(1000)
+
+	// The first second worth of bytes is a burst
+	if d := l.delay(1000); d != 0 {
+		t.Errorf("delay: Got: %v Want: 0 for the burst", d)
+	}
+	if d := l.delay(500); d < 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Errorf("delay: Got: %v Want: about 500ms", d)
+	}
+
+	kill := make(chan bool)
+	close(kill)
+	if l.wait(1000, kill) {
+		t.Errorf("wait: Got: true Want: false after kill")
+	}
+
+	l.SetRate(0)
+	if d := l.delay(1 << 20); d != 0 {
+		t.Errorf("delay: Got: %v Want: 0 without a limit", d)
+	}
+}
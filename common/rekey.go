@@ -0,0 +1,227 @@
+package common
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// RekeyTimeout is how long gServer waits for the answer to a rekey
+// before it starts over with a new one.
+const RekeyTimeout = 30 * time.Second
+
+// pendingRekey is a rekey that was offered to the other side of the
+// tunnel and is waiting for its key.
+type pendingRekey struct {
+	generation uint32
+	private    []byte
+	chain      []byte
+	started    time.Time
+}
+
+// rekeyDue returns true if the key of the tunnel was used for longer
+// than interval or for more than limit bytes. Both are ignored if 0.
+func (k *tunnelCipher) rekeyDue(interval time.Duration, limit uint64) bool {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	if len(k.keys) == 0 {
+		return false
+	}
+	if k.pending != nil && time.Since(k.pending.started) < RekeyTimeout {
+		return false
+	}
+	if interval > 0 && time.Since(k.rekeyed) >= interval {
+		return true
+	}
+	return limit > 0 && k.usedBytes() >= limit
+}
+
+// usedBytes returns the number of bytes sealed and opened with the
+// current key.
+func (k *tunnelCipher) usedBytes() uint64 {
+	return atomic.LoadUint64(&k.used)
+}
+
+// offerRekey starts a rekey. It returns the generation of the new key
+// and the public key to send to the other side.
+func (k *tunnelCipher) offerRekey() (uint32, []byte, error) {
+	private, public, err := newKeyPair()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	current, ok := k.keys[k.generation]
+	if !ok {
+		return 0, nil, errNoStreamKey
+	}
+	k.pending = &pendingRekey{
+		generation: k.generation + 1,
+		private:    private,
+		chain:      current.chain,
+		started:    time.Now(),
+	}
+	return k.pending.generation, public, nil
+}
+
+// acceptRekey derives the key of a generation offered by the other
+// side and returns the public key to answer with. The key is derived
+// with the chain secret of the previous generation, so that a party
+// that does not know it cannot offer its own key. The new key only
+// opens data until the other side confirms that it uses it as well.
+func (k *tunnelCipher) acceptRekey(generation uint32, peer []byte,
+	salt string) ([]byte, error) {
+
+	k.mutex.RLock()
+	current := k.generation
+	server := k.server
+	previous, ok := k.keys[generation-1]
+	k.mutex.RUnlock()
+	if generation <= current {
+		return nil, fmt.Errorf("stale key generation %d", generation)
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key generation %d", generation-1)
+	}
+
+	private, public, err := newKeyPair()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := deriveKeys(private, peer, server, previous.chain, salt,
+		generation)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mutex.Lock()
+	k.install(generation, keys, false)
+	k.mutex.Unlock()
+	return public, nil
+}
+
+// completeRekey derives the key of the pending rekey from the answer
+// of the other side and starts to use it.
+func (k *tunnelCipher) completeRekey(generation uint32, peer []byte,
+	salt string) error {
+
+	k.mutex.Lock()
+	pending := k.pending
+	server := k.server
+	k.mutex.Unlock()
+	if pending == nil || pending.generation != generation {
+		return fmt.Errorf("unexpected key generation %d", generation)
+	}
+
+	keys, err := deriveKeys(pending.private, peer, server, pending.chain,
+		salt, generation)
+	if err != nil {
+		return err
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.pending == pending {
+		k.pending = nil
+	}
+	k.install(generation, keys, true)
+	return nil
+}
+
+// useGeneration starts to seal with the key of a generation that was
+// accepted before.
+func (k *tunnelCipher) useGeneration(generation uint32) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	keys, ok := k.keys[generation]
+	if !ok {
+		return fmt.Errorf("unknown key generation %d", generation)
+	}
+	if generation > k.generation {
+		k.install(generation, keys, true)
+	}
+	return nil
+}
+
+// handleRekey is the loop function of the side of an encrypted tunnel
+// that replaces its key once the key was used for RekeyInterval or
+// RekeyBytes.
+func (t *Tunnel) handleRekey(k *tunnelCipher) {
+	for {
+		select {
+		case <-time.After(time.Second):
+			options := t.GetOptions()
+			if t.GetControlStream() == nil ||
+				!k.rekeyDue(options.RekeyInterval, options.RekeyBytes) {
+				continue
+			}
+
+			generation, public, err := k.offerRekey()
+			if err != nil {
+				Log.WithTunnel(t.id).Errorf("Failed to start rekey: %v", err)
+				continue
+			}
+			message := new(cs.TunnelControlMessage)
+			message.Operation = TunnelCtrlRekey
+			message.TunnelId = t.id
+			message.KeyGeneration = generation
+			message.PublicKey = public
+			t.SendCtrlMessage(message)
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// handleRekeyMessage takes part in a rekey of the tunnel key. The side
+// that offers the rekey sends TunnelCtrlRekey, which the other side
+// answers with TunnelCtrlRekeyAck. Both sides switch to the new key
+// once the first one sent TunnelCtrlRekeyDone.
+func (t *Tunnel) handleRekeyMessage(message *cs.TunnelControlMessage) {
+	t.mutex.RLock()
+	k := t.cipher
+	t.mutex.RUnlock()
+
+	if k == nil {
+		Log.WithTunnel(t.id).Warnf("Received rekey for a tunnel that is not encrypted")
+		return
+	}
+
+	generation := message.KeyGeneration
+	var err error
+	switch message.Operation {
+	case TunnelCtrlRekey:
+		var public []byte
+		if public, err = k.acceptRekey(generation, message.PublicKey, t.id); err == nil {
+			reply := new(cs.TunnelControlMessage)
+			reply.Operation = TunnelCtrlRekeyAck
+			reply.TunnelId = t.id
+			reply.KeyGeneration = generation
+			reply.PublicKey = public
+			err = t.SendCtrlMessage(reply)
+		}
+	case TunnelCtrlRekeyAck:
+		if err = k.completeRekey(generation, message.PublicKey, t.id); err == nil {
+			reply := new(cs.TunnelControlMessage)
+			reply.Operation = TunnelCtrlRekeyDone
+			reply.TunnelId = t.id
+			reply.KeyGeneration = generation
+			err = t.SendCtrlMessage(reply)
+			Log.WithTunnel(t.id).Debugf("Rekeyed to key generation %d", generation)
+		}
+	case TunnelCtrlRekeyDone:
+		err = k.useGeneration(generation)
+	}
+
+	if err != nil {
+		Log.WithTunnel(t.id).Warnf("Rekey to key generation %d failed: %v",
+			generation, err)
+	}
+}
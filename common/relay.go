@@ -0,0 +1,173 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// Relay accepts connections on an endpoint that is a hop of a
+// multi-hop tunnel and relays each to the next hop or the destination
+// of the tunnel. Only connections from the previous hop are accepted.
+type Relay struct {
+	listenAddress string
+	destination   string
+	sources       []net.IP
+	dialCheck     DialCheck
+	dialFunc      DialFunc
+	listener      net.Listener
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewRelay is a constructor for the Relay struct. It listens on
+// listenIP:listenPort and dials destinationIP:destinationPort once
+// started.
+func NewRelay(listenIP net.IP, listenPort uint32, destinationIP net.IP,
+	destinationPort uint32) *Relay {
+	r := new(Relay)
+	r.listenAddress = net.JoinHostPort(listenIP.String(),
+		strconv.Itoa(int(listenPort)))
+	r.destination = net.JoinHostPort(destinationIP.String(),
+		strconv.Itoa(int(destinationPort)))
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	return r
+}
+
+// SetDialCheck sets a function that the destination of the relay has
+// to pass. It must be called before Start.
+func (r *Relay) SetDialCheck(check DialCheck) {
+	r.dialCheck = check
+}
+
+// SetSources sets the addresses of the previous hop, the only ones
+// from which the relay accepts connections. A relay that listens on
+// loopback accepts connections from the same host. It must be called
+// before Start.
+func (r *Relay) SetSources(sources []net.IP) {
+	r.sources = sources
+}
+
+// SetDialer sets the function with which the relay dials, such as that
+// of an upstream socks proxy. It must be called before Start.
+func (r *Relay) SetDialer(dial DialFunc) {
+	r.dialFunc = dial
+}
+
+// Start starts to listen and relay connections.
+func (r *Relay) Start() error {
+	host, port, _ := net.SplitHostPort(r.destination)
+	destinationPort, _ := strconv.Atoi(port)
+	if r.dialCheck != nil {
+		if err := r.dialCheck(net.ParseIP(host), uint32(destinationPort)); err != nil {
+			return err
+		}
+	}
+
+	listenHost, _, _ := net.SplitHostPort(r.listenAddress)
+	loopback := net.ParseIP(listenHost).IsLoopback()
+	if len(r.sources) == 0 && !loopback {
+		return fmt.Errorf("the addresses of the previous hop are unknown")
+	}
+
+	var err error
+	r.listener, err = net.Listen("tcp", r.listenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", r.listenAddress, err)
+	}
+
+	go func() {
+		for {
+			conn, err := r.listener.Accept()
+			if err != nil {
+				return
+			}
+			if !loopback && !r.fromSource(conn) {
+				Log.Warnf("Refused to relay %s, which is not the previous hop",
+					conn.RemoteAddr())
+				conn.Close()
+				continue
+			}
+			go r.relay(conn)
+		}
+	}()
+	return nil
+}
+
+// fromSource returns true if conn comes from one of the sources of the
+// relay.
+func (r *Relay) fromSource(conn net.Conn) bool {
+	addr, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, source := range r.sources {
+		if source.Equal(addr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop stops listening and closes the relayed connections.
+func (r *Relay) Stop() {
+	r.cancel()
+	if r.listener != nil {
+		r.listener.Close()
+	}
+}
+
+// relay connects conn to the destination and copies between the two
+// until both directions are finished.
+func (r *Relay) relay(conn net.Conn) {
+	defer conn.Close()
+
+	dial := r.dialFunc
+	if dial == nil {
+		dialer := net.Dialer{Timeout: DialTimeout}
+		dial = dialer.DialContext
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, DialTimeout)
+	target, err := dial(ctx, "tcp", r.destination)
+	cancel()
+	if err != nil {
+		Log.Warnf("Failed to relay %s to %s: %v", conn.RemoteAddr(),
+			r.destination, err)
+		return
+	}
+	defer target.Close()
+
+	// Stopping the relay interrupts the copies
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-r.ctx.Done():
+			conn.Close()
+			target.Close()
+		case <-done:
+		}
+	}()
+
+	finished := make(chan struct{})
+	go func() {
+		relayCopy(target, conn)
+		close(finished)
+	}()
+	relayCopy(conn, target)
+	<-finished
+}
+
+// relayCopy copies from src to dst and then passes the end of src on as
+// a half-close of dst, or closes dst if it cannot half-close.
+func relayCopy(dst net.Conn, src net.Conn) {
+	io.Copy(dst, src)
+	if conn, ok := dst.(closeWriter); ok {
+		if err := conn.CloseWrite(); err == nil {
+			return
+		}
+	}
+	dst.Close()
+}
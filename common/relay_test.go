@@ -0,0 +1,88 @@
+package common
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+)
+
+func TestRelay(t *testing.T) {
+	// The destination echoes until the relay half-closes it
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer target.Close()
+	go func() {
+		conn, err := target.Accept()
+		if err == nil {
+			io.Copy(conn, conn)
+			conn.Close()
+		}
+	}()
+	targetAddr := target.Addr().(*net.TCPAddr)
+
+	// Find a free port for the relay
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	relay := NewRelay(net.ParseIP("127.0.0.1"), uint32(port),
+		targetAddr.IP, uint32(targetAddr.Port))
+	if err := relay.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer relay.Stop()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	io.WriteString(conn, "hello")
+	conn.(*net.TCPConn).CloseWrite()
+	echo, err := ioutil.ReadAll(conn)
+	if err != nil || string(echo) != "hello" {
+		t.Errorf("Read: Got: %q, %v Want: hello", echo, err)
+	}
+
+	hops, err := ParseTunnelHops("clientB@10.0.0.5:4444, clientC@10.1.0.7:4445")
+	if err != nil || len(hops) != 2 || hops[1].EndpointID != "clientC" || hops[1].Port != 4445 {
+		t.Errorf("ParseTunnelHops: Got: %v, %v", hops, err)
+	}
+	if hops, err := ParseTunnelHops("clientB@:4444"); err != nil || !hops[0].IP.IsLoopback() {
+		t.Errorf("ParseTunnelHops: Got: %v, %v Want: a loopback hop", hops, err)
+	}
+	for _, invalid := range []string{"10.0.0.5:4444", "clientB@10.0.0.5", "clientB@fd00::1:4444",
+		"clientB@0.0.0.0:4444"} {
+		if _, err := ParseTunnelHops(invalid); err == nil {
+			t.Errorf("ParseTunnelHops(%s): Got no error", invalid)
+		}
+	}
+
+	// Relays on other addresses only accept the previous hop
+	relay = NewRelay(net.ParseIP("10.0.0.5"), 4444, targetAddr.IP, 22)
+	if err := relay.Start(); err == nil {
+		relay.Stop()
+		t.Errorf("Start: Got no error without the previous hop")
+	}
+	relay.SetSources([]net.IP{net.ParseIP("10.0.0.4")})
+	if relay.fromSource(conn) {
+		t.Errorf("fromSource: Got true for another address")
+	}
+	relay.SetSources([]net.IP{net.ParseIP("127.0.0.1")})
+	if !relay.fromSource(conn) {
+		t.Errorf("fromSource: Got false for the previous hop")
+	}
+
+	relay = NewRelay(net.ParseIP("127.0.0.1"), 0, targetAddr.IP, 22)
+	relay.SetDialCheck(func(ip net.IP, port uint32) error { return ErrPolicyDenied })
+	if err := relay.Start(); err == nil {
+		relay.Stop()
+		t.Errorf("Start: Got no error for a denied destination")
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package common
+
+import (
+	"os"
+)
+
+// RemoveExecutable deletes the executable of the running process from
+// disk. The process keeps running until it exits.
+func RemoveExecutable() error {
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
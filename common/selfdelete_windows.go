@@ -0,0 +1,23 @@
+package common
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// RemoveExecutable deletes the executable of the running process from
+// disk. Windows does not allow deleting a running executable, so a
+// hidden shell is started that deletes it shortly after the process
+// exits.
+func RemoveExecutable() error {
+	path, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("cmd.exe", "/C",
+		"ping -n 3 127.0.0.1 > nul & del /F /Q \""+path+"\"")
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+	return cmd.Start()
+}
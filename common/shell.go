@@ -0,0 +1,51 @@
+package common
+
+import (
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ServeShell runs an interactive shell over a stream until the shell
+// exits or the stream fails. What arrives over the stream is written to
+// the shell and its output is sent back. A message with half_close set
+// closes the input of the shell.
+func ServeShell(stream ByteStream) error {
+	shell, err := StartShell()
+	if err != nil {
+		return failStream(stream, err)
+	}
+	defer shell.Close()
+
+	go func() {
+		for {
+			message, err := stream.Recv()
+			if err != nil {
+				shell.Close()
+				return
+			}
+			if len(message.Content) > 0 {
+				if _, err := shell.Write(message.Content); err != nil {
+					shell.Close()
+					return
+				}
+			}
+			if message.HalfClose {
+				shell.CloseWrite()
+			}
+		}
+	}()
+
+	buf := make([]byte, DefaultMTU)
+	for {
+		n, err := shell.Read(buf)
+		if n > 0 {
+			message := new(cs.BytesMessage)
+			message.Content = buf[:n]
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !shell
+// +build !shell
+
+package common
+
+import "fmt"
+
+// ShellSupported is true in builds with the shell build tag.
+const ShellSupported = false
+
+// StartShell fails since this build has no shell support. Builds with
+// the shell build tag start an interactive shell instead.
+func StartShell() (*ProcessConn, error) {
+	return nil, fmt.Errorf("this build has no shell support")
+}
@@ -0,0 +1,54 @@
+//go:build shell
+// +build shell
+
+package common
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ShellSupported is true in builds with the shell build tag.
+const ShellSupported = true
+
+// StartShell starts an interactive shell of the platform and returns a
+// ProcessConn wired to its stdin and to its stdout and stderr, which
+// are merged.
+func StartShell() (*ProcessConn, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe")
+	} else {
+		cmd = exec.Command("/bin/sh", "-i")
+	}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	output, w, err := os.Pipe()
+	if err != nil {
+		stdin.Close()
+		return nil, err
+	}
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	err = cmd.Start()
+	// The shell holds its own copy of the write end
+	w.Close()
+	if err != nil {
+		stdin.Close()
+		output.Close()
+		return nil, err
+	}
+
+	p := new(ProcessConn)
+	p.cmd = cmd
+	p.stdin = stdin
+	p.stdout = output
+	p.addr = processAddr{command: cmd.Path}
+	return p, nil
+}
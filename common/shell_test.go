@@ -0,0 +1,46 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestServeShell(t *testing.T) {
+	toEndpoint := make(chan *cs.BytesMessage, 4)
+	toServer := make(chan *cs.BytesMessage, 16)
+	server := &pipeStream{send: toEndpoint, recv: toServer}
+	endpoint := &pipeStream{send: toServer, recv: toEndpoint}
+	defer close(toEndpoint)
+
+	served := make(chan error, 1)
+	go func() { served <- ServeShell(endpoint) }()
+
+	if !ShellSupported {
+		if message, _ := server.Recv(); message.Error == "" {
+			t.Errorf("ServeShell: started a shell without shell support")
+		}
+		return
+	}
+
+	server.Send(&cs.BytesMessage{Content: []byte("echo gtunnel-$((40+2))\n")})
+	server.Send(&cs.BytesMessage{HalfClose: true})
+
+	// The shell exits at the end of its input
+	output := ""
+	for !strings.Contains(output, "gtunnel-42") {
+		select {
+		case message := <-toServer:
+			output += string(message.Content)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ServeShell: no output of the command in %q", output)
+		}
+	}
+	select {
+	case <-served:
+	case <-time.After(5 * time.Second):
+		t.Errorf("ServeShell: did not return once the shell exited")
+	}
+}
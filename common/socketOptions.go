@@ -0,0 +1,42 @@
+package common
+
+import (
+	"net"
+)
+
+// applySocketOptions sets the socket options of the tunnel on an
+// accepted or dialed connection. Connections that are not TCP
+// connections, such as spawned processes, are left alone.
+func (o TunnelOptions) applySocketOptions(conn net.Conn) error {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+
+	if err := tcpConn.SetNoDelay(!o.Nagle); err != nil {
+		return err
+	}
+	if o.TCPKeepalive < 0 {
+		if err := tcpConn.SetKeepAlive(false); err != nil {
+			return err
+		}
+	} else if o.TCPKeepalive > 0 {
+		if err := tcpConn.SetKeepAlive(true); err != nil {
+			return err
+		}
+		if err := tcpConn.SetKeepAlivePeriod(o.TCPKeepalive); err != nil {
+			return err
+		}
+	}
+	if o.ReadBuffer > 0 {
+		if err := tcpConn.SetReadBuffer(o.ReadBuffer); err != nil {
+			return err
+		}
+	}
+	if o.WriteBuffer > 0 {
+		if err := tcpConn.SetWriteBuffer(o.WriteBuffer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package common
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestApplySocketOptions(t *testing.T) {
+	client, server := tcpPair(t)
+	defer client.Close()
+	defer server.Close()
+
+	options := TunnelOptions{
+		Nagle:        true,
+		TCPKeepalive: 30 * time.Second,
+		ReadBuffer:   65536,
+		WriteBuffer:  65536,
+	}
+	if err := options.applySocketOptions(client); err != nil {
+		t.Errorf("applySocketOptions: %v", err)
+	}
+
+	options.TCPKeepalive = -1
+	if err := options.applySocketOptions(server); err != nil {
+		t.Errorf("applySocketOptions: %v with keepalives disabled", err)
+	}
+
+	local, remote := net.Pipe()
+	defer local.Close()
+	defer remote.Close()
+	if err := options.applySocketOptions(local); err != nil {
+		t.Errorf("applySocketOptions: %v for a connection that is not TCP", err)
+	}
+}
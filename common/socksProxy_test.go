@@ -0,0 +1,130 @@
+package common
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// serveSOCKSProxy accepts SOCKS5 connect requests with the credentials
+// user:password on l.
+func serveSOCKSProxy(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+
+			// Greeting with the methods, then the credentials
+			header := make([]byte, 2)
+			if _, err := io.ReadFull(r, header); err != nil {
+				return
+			}
+			if _, err := io.ReadFull(r, make([]byte, header[1])); err != nil {
+				return
+			}
+			conn.Write([]byte{5, 2})
+			var credentials []string
+			r.ReadByte()
+			for i := 0; i < 2; i++ {
+				n, _ := r.ReadByte()
+				b := make([]byte, n)
+				if _, err := io.ReadFull(r, b); err != nil {
+					return
+				}
+				credentials = append(credentials, string(b))
+			}
+			if credentials[0] != "user" || credentials[1] != "password" {
+				conn.Write([]byte{1, 1})
+				return
+			}
+			conn.Write([]byte{1, 0})
+
+			request := make([]byte, 4)
+			if _, err := io.ReadFull(r, request); err != nil || request[3] != 3 {
+				return
+			}
+			n, _ := r.ReadByte()
+			host := make([]byte, int(n)+2)
+			if _, err := io.ReadFull(r, host); err != nil {
+				return
+			}
+			port := binary.BigEndian.Uint16(host[n:])
+			target, err := net.Dial("tcp", net.JoinHostPort(string(host[:n]), strconv.Itoa(int(port))))
+			if err != nil {
+				conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+				return
+			}
+			defer target.Close()
+			conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+			go io.Copy(target, r)
+			io.Copy(conn, target)
+		}()
+	}
+}
+
+func TestSOCKSProxy(t *testing.T) {
+	proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer proxyListener.Close()
+	go serveSOCKSProxy(proxyListener)
+
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer target.Close()
+	go func() {
+		for {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			io.WriteString(conn, "hello")
+			conn.Close()
+		}
+	}()
+	_, port, _ := net.SplitHostPort(target.Addr().String())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	proxy, err := ParseSOCKSProxy("socks5://user:password@" + proxyListener.Addr().String())
+	if err != nil {
+		t.Fatalf("ParseSOCKSProxy: %v", err)
+	}
+	// The proxy resolves the name
+	conn, err := proxy.DialContext(ctx, "tcp", net.JoinHostPort("localhost", port))
+	if err != nil {
+		t.Fatalf("DialContext: %v", err)
+	}
+	greeting, err := ioutil.ReadAll(conn)
+	conn.Close()
+	if err != nil || string(greeting) != "hello" {
+		t.Errorf("Read: Got: %q, %v Want: hello", greeting, err)
+	}
+
+	proxy.Password = "wrong"
+	if _, err := proxy.DialContext(ctx, "tcp", net.JoinHostPort("localhost", port)); err == nil {
+		t.Errorf("DialContext: Got no error with the wrong password")
+	}
+
+	proxy, err = ParseSOCKSProxy("proxy.example.com")
+	if err != nil || proxy.Address != "proxy.example.com:1080" {
+		t.Errorf("ParseSOCKSProxy: Got: %v, %v Want: the default port", proxy, err)
+	}
+	if _, err := ParseSOCKSProxy("http://proxy:8080"); err == nil {
+		t.Errorf("ParseSOCKSProxy: Got no error for http")
+	}
+}
@@ -1,9 +1,11 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"net"
-	"time"
+	"strconv"
+	"sync"
 
 	"github.com/fangdingjun/socks-go"
 )
@@ -14,6 +16,9 @@ type SocksServer struct {
 	listener    net.Listener
 	connections []socks.Conn
 	servePort   uint32
+	dialCheck   DialCheck
+	dialFunc    DialFunc
+	mutex       sync.Mutex
 }
 
 // NewSocksServer is a constructor for the SocksServer struct.
@@ -41,18 +46,71 @@ func (s *SocksServer) Start() bool {
 			if err != nil {
 				break
 			}
-			d := net.Dialer{Timeout: 10 * time.Second}
-			newConn := socks.Conn{Conn: conn, Dial: d.Dial}
+			newConn := socks.Conn{Conn: conn, Dial: s.dial}
+			s.mutex.Lock()
 			s.connections = append(s.connections, newConn)
+			s.mutex.Unlock()
 			go newConn.Serve()
 		}
 	}()
 	return true
 }
 
+// SetDialCheck sets a function that every destination requested
+// through the socks server has to pass. It must be called before
+// Start.
+func (s *SocksServer) SetDialCheck(check DialCheck) {
+	s.dialCheck = check
+}
+
+// SetDialer sets the function with which the socks server dials, such
+// as that of an upstream socks proxy. It must be called before Start.
+func (s *SocksServer) SetDialer(dial DialFunc) {
+	s.dialFunc = dial
+}
+
+// dial connects to a destination requested by a socks client. When a
+// dial check is set the destination is resolved first, so that the
+// address that is checked is the one that is dialed.
+func (s *SocksServer) dial(network string, address string) (net.Conn, error) {
+	dial := s.dialFunc
+	if dial == nil {
+		d := net.Dialer{Timeout: DialTimeout}
+		dial = d.DialContext
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), DialTimeout)
+	defer cancel()
+	if s.dialCheck == nil {
+		return dial(ctx, network, address)
+	}
+
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if err = s.dialCheck(ip, uint32(port)); err != nil {
+			return nil, err
+		}
+	}
+	return dial(ctx, network, net.JoinHostPort(ips[0].String(), portString))
+}
+
 // Stop - You'll never guess what this does.
 func (s *SocksServer) Stop() {
 	s.listener.Close()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	for _, conn := range s.connections {
 		conn.Close()
 	}
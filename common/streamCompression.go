@@ -0,0 +1,82 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/golang/snappy"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// StreamCompressionSnappy compresses the payloads of byte streams with
+// snappy.
+const StreamCompressionSnappy = "snappy"
+
+// CheckStreamCompression returns an error if algorithm is not a
+// supported stream compression. No compression is supported.
+func CheckStreamCompression(algorithm string) error {
+	if algorithm != "" && algorithm != StreamCompressionSnappy {
+		return fmt.Errorf("unsupported stream compression %q", algorithm)
+	}
+	return nil
+}
+
+// setContent sets the content of a message that is to be sent, which
+// is compressed if the connection compresses its payloads and the data
+// shrinks, then encrypted if the tunnel is encrypted and padded to the
+// pad size of the tunnel.
+func (c *Connection) setContent(message *cs.BytesMessage, data []byte) error {
+	message.Content = data
+	message.Compressed = false
+	message.Padding = nil
+
+	if len(data) == 0 {
+		return nil
+	}
+	if c.compression == StreamCompressionSnappy {
+		if encoded := snappy.Encode(nil, data); len(encoded) < len(data) {
+			message.Content = encoded
+			message.Compressed = true
+		}
+	}
+	if c.cipher != nil {
+		sealed, generation, err := c.cipher.seal(message.Content,
+			c.streamAD(c.sealCount))
+		if err != nil {
+			return err
+		}
+		c.sealCount++
+		message.Content = sealed
+		message.KeyGeneration = generation
+	}
+	c.pad(message)
+	return nil
+}
+
+// content returns the payload of a received message.
+func (c *Connection) content(message *cs.BytesMessage) ([]byte, error) {
+	data := message.Content
+	if c.cipher != nil {
+		var err error
+		if data, err = c.cipher.open(data, c.streamAD(c.openCount),
+			message.KeyGeneration); err != nil {
+			return nil, err
+		}
+		c.openCount++
+	}
+	if !message.Compressed {
+		return data, nil
+	}
+	return snappy.Decode(nil, data)
+}
+
+// streamAD returns the additional data with which the payload at count
+// in one direction of the connection is encrypted. It binds the
+// payload to the connection and its position, so that payloads cannot
+// be replayed, reordered or dropped without the connection failing.
+func (c *Connection) streamAD(count uint64) []byte {
+	ad := make([]byte, 8, 8+len(c.ID))
+	binary.BigEndian.PutUint64(ad, count)
+	return append(ad, c.ID...)
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+func TestStreamCompression(t *testing.T) {
+	c := NewConnection(nil)
+	c.compression = StreamCompressionSnappy
+	message := new(cs.BytesMessage)
+
+	text := bytes.Repeat([]byte("GET /index.html HTTP/1.1\r\n"), 100)
+	c.setContent(message, text)
+	if !message.Compressed || len(message.Content) >= len(text) {
+		t.Errorf("setContent: text was not compressed")
+	}
+	if data, err := c.content(message); err != nil || !bytes.Equal(data, text) {
+		t.Errorf("content: Got: %d bytes, %v Want: the text", len(data), err)
+	}
+
+	// Data that does not shrink is sent as it is
+	random := make([]byte, 1024)
+	rand.Read(random)
+	c.setContent(message, random)
+	if message.Compressed || !bytes.Equal(message.Content, random) {
+		t.Errorf("setContent: random data was compressed")
+	}
+
+	c.compression = ""
+	c.setContent(message, text)
+	if message.Compressed {
+		t.Errorf("setContent: compressed without stream compression")
+	}
+
+	if err := CheckStreamCompression("lz4"); err == nil {
+		t.Errorf("CheckStreamCompression: Got no error for lz4")
+	}
+}
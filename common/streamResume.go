@@ -0,0 +1,351 @@
+package common
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+const (
+	// resumeTimeout is how long a connection waits for a broken byte
+	// stream to be replaced before the connection is closed.
+	resumeTimeout = 30 * time.Second
+
+	// resumeBufferSize is the number of unacknowledged messages kept
+	// for retransmission. A stream that falls further behind cannot
+	// be resumed.
+	resumeBufferSize = 1024
+
+	// resumeAckInterval is the number of received messages after
+	// which an acknowledgement is sent if no data went the other way.
+	resumeAckInterval = 32
+)
+
+// streamResume holds the state that allows the byte stream of a
+// connection to be replaced without losing data.
+type streamResume struct {
+	tunnelID  string
+	token     string
+	open      func() (ByteStream, error)
+	sendSeq   uint64
+	recvSeq   uint64
+	unacked   []*cs.BytesMessage
+	pending   int
+	ackQueued bool
+	acking    bool
+	broken    ByteStream
+	replaced  chan struct{}
+	mutex     sync.Mutex
+	sendMutex sync.Mutex
+}
+
+// EnableResume makes the byte stream of the connection resumable. Both
+// sides of the connection have to enable it before the connection is
+// started. The side that opens byte streams provides open, which is
+// used to open a new stream when the current one breaks. The other
+// side waits for the new stream to arrive through ResumeStream.
+func (c *Connection) EnableResume(tunnelID string, token string,
+	open func() (ByteStream, error)) {
+
+	r := new(streamResume)
+	r.tunnelID = tunnelID
+	r.token = token
+	r.open = open
+	r.replaced = make(chan struct{})
+	c.resume = r
+}
+
+// StreamReplaced returns a channel that is closed when the current byte
+// stream is replaced, or nil if the connection is not resumable.
+func (c *Connection) StreamReplaced() <-chan struct{} {
+	r := c.resume
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.replaced
+}
+
+// ResumeStream replaces the byte stream of the connection with a stream
+// that the remote side opened with message. It returns a channel that
+// is closed when this stream is replaced in turn.
+func (c *Connection) ResumeStream(stream ByteStream,
+	message *cs.BytesMessage) (<-chan struct{}, error) {
+
+	r := c.resume
+	if r == nil || r.token == "" ||
+		subtle.ConstantTimeCompare([]byte(r.token), []byte(message.StreamToken)) != 1 {
+		return nil, errors.New("connection is not resumable")
+	}
+
+	r.mutex.Lock()
+	reply := new(cs.BytesMessage)
+	reply.Resume = true
+	reply.Ack = r.recvSeq
+	r.mutex.Unlock()
+
+	if err := stream.Send(reply); err != nil {
+		return nil, err
+	}
+	return c.replaceStream(stream, message.Ack)
+}
+
+// currentStream returns the byte stream in use.
+func (c *Connection) currentStream() ByteStream {
+	r := c.resume
+	if r == nil {
+		return c.byteStream
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return c.byteStream
+}
+
+// sendMessage sends a message on the byte stream. Messages of a
+// resumable connection are numbered and kept until they are
+// acknowledged, so that they can be sent again on a new stream.
+func (c *Connection) sendMessage(message *cs.BytesMessage) error {
+	r := c.resume
+	if r == nil {
+		// Window updates are sent alongside the data
+		c.sendMutex.Lock()
+		defer c.sendMutex.Unlock()
+		return c.byteStream.Send(message)
+	}
+
+	r.sendMutex.Lock()
+	r.mutex.Lock()
+	r.sendSeq++
+	message.Sequence = r.sendSeq
+	message.Ack = r.recvSeq
+	r.pending = 0
+	if len(r.unacked) == resumeBufferSize {
+		r.unacked = r.unacked[1:]
+	}
+	r.unacked = append(r.unacked, message)
+	stream := c.byteStream
+	r.mutex.Unlock()
+
+	err := stream.Send(message)
+	r.sendMutex.Unlock()
+	if err == nil {
+		return nil
+	}
+
+	// The message is sent again once the stream is replaced
+	if c.awaitStream(stream) == nil {
+		return err
+	}
+	return nil
+}
+
+// acceptMessage processes the sequence number and acknowledgement of a
+// received message. It returns false for messages that carry nothing
+// for the TCP connection, such as acknowledgements and duplicates.
+func (c *Connection) acceptMessage(message *cs.BytesMessage) bool {
+	r := c.resume
+	if r == nil {
+		return true
+	}
+
+	r.mutex.Lock()
+	r.acknowledged(message.Ack)
+	if message.Sequence == 0 || message.Sequence <= r.recvSeq {
+		r.mutex.Unlock()
+		return false
+	}
+	r.recvSeq = message.Sequence
+	r.pending++
+	if r.pending == resumeAckInterval {
+		r.pending = 0
+		c.queueAck()
+	}
+	r.mutex.Unlock()
+	return true
+}
+
+// queueAck acknowledges the received messages. The acknowledgement is
+// sent by a goroutine of its own, so that receiving does not wait for
+// a send that the remote side only lets through once it receives in
+// turn. It must be called with the mutex held.
+func (c *Connection) queueAck() {
+	r := c.resume
+	r.ackQueued = true
+	if !r.acking {
+		r.acking = true
+		go c.sendAcks()
+	}
+}
+
+// sendAcks sends the latest acknowledgement until no more are queued.
+func (c *Connection) sendAcks() {
+	r := c.resume
+	for {
+		r.mutex.Lock()
+		if !r.ackQueued {
+			r.acking = false
+			r.mutex.Unlock()
+			return
+		}
+		r.ackQueued = false
+		ackMessage := new(cs.BytesMessage)
+		ackMessage.Ack = r.recvSeq
+		stream := c.byteStream
+		r.mutex.Unlock()
+
+		r.sendMutex.Lock()
+		stream.Send(ackMessage)
+		r.sendMutex.Unlock()
+	}
+}
+
+// acknowledged drops the messages that the remote side has received.
+// It must be called with the mutex held.
+func (r *streamResume) acknowledged(ack uint64) {
+	i := 0
+	for i < len(r.unacked) && r.unacked[i].Sequence <= ack {
+		i++
+	}
+	r.unacked = r.unacked[i:]
+}
+
+// awaitStream is called when sending or receiving on a byte stream
+// failed. It waits for the stream to be replaced and returns the new
+// one, or nil if the connection is closed instead.
+func (c *Connection) awaitStream(failed ByteStream) ByteStream {
+	r := c.resume
+
+	c.mutex.Lock()
+	closed := c.Status == ConnectionStatusClosed
+	c.mutex.Unlock()
+	if closed {
+		return nil
+	}
+
+	r.mutex.Lock()
+	if c.byteStream != failed {
+		stream := c.byteStream
+		r.mutex.Unlock()
+		return stream
+	}
+	replaced := r.replaced
+	if r.broken != failed {
+		r.broken = failed
+		Log.WithConnection(c.ID).Warnf(
+			"Byte stream broke, waiting %s for it to resume", resumeTimeout)
+		if r.open != nil {
+			go c.reopenStream()
+		}
+	}
+	r.mutex.Unlock()
+
+	select {
+	case <-replaced:
+		return c.currentStream()
+	case <-c.Kill:
+		return nil
+	case <-time.After(resumeTimeout):
+		Log.WithConnection(c.ID).Warnf("Byte stream was not resumed")
+		c.Close()
+		return nil
+	}
+}
+
+// reopenStream opens byte streams until one of them is resumed, the
+// connection is closed or the resume timeout passes.
+func (c *Connection) reopenStream() {
+	r := c.resume
+	delay := 500 * time.Millisecond
+	deadline := time.Now().Add(resumeTimeout)
+
+	for time.Now().Before(deadline) {
+		stream, err := r.open()
+		if err == nil {
+			err = c.resumeHandshake(stream)
+			if err == nil {
+				return
+			}
+		}
+		Log.WithConnection(c.ID).Debugf("Failed to resume byte stream: %v", err)
+
+		select {
+		case <-time.After(delay):
+		case <-c.Kill:
+			return
+		}
+		if delay < 5*time.Second {
+			delay *= 2
+		}
+	}
+}
+
+// resumeHandshake asks the remote side to continue the connection on a
+// newly opened byte stream.
+func (c *Connection) resumeHandshake(stream ByteStream) error {
+	r := c.resume
+
+	r.mutex.Lock()
+	message := new(cs.BytesMessage)
+	message.TunnelId = r.tunnelID
+	message.ConnectionId = c.ID
+	message.StreamToken = r.token
+	message.Resume = true
+	message.Ack = r.recvSeq
+	r.mutex.Unlock()
+
+	if err := stream.Send(message); err != nil {
+		return err
+	}
+	reply, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if !reply.Resume {
+		return errors.New("byte stream was not resumed")
+	}
+	_, err = c.replaceStream(stream, reply.Ack)
+	return err
+}
+
+// replaceStream switches the connection to a new byte stream and sends
+// every message that the remote side has not received yet.
+func (c *Connection) replaceStream(stream ByteStream,
+	ack uint64) (<-chan struct{}, error) {
+
+	r := c.resume
+	r.sendMutex.Lock()
+	defer r.sendMutex.Unlock()
+
+	r.mutex.Lock()
+	r.acknowledged(ack)
+	first := r.sendSeq + 1 - uint64(len(r.unacked))
+	if first > ack+1 {
+		r.mutex.Unlock()
+		c.Close()
+		return nil, fmt.Errorf("messages %d to %d are no longer buffered",
+			ack+1, first-1)
+	}
+
+	unacked := append([]*cs.BytesMessage(nil), r.unacked...)
+	c.byteStream = stream
+	r.broken = nil
+	close(r.replaced)
+	r.replaced = make(chan struct{})
+	replaced := r.replaced
+	r.mutex.Unlock()
+
+	Log.WithConnection(c.ID).Infof("Resumed byte stream, resending %d messages",
+		len(unacked))
+
+	for _, message := range unacked {
+		if err := stream.Send(message); err != nil {
+			return replaced, err
+		}
+	}
+	return replaced, nil
+}
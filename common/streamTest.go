@@ -0,0 +1,70 @@
+package common
+
+import (
+	"fmt"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ServeTestStream handles a test stream on the endpoint side as test
+// says. Sink and source streams receive or send size bytes. It returns
+// once the stream fails, which is how gServer ends a test stream.
+func ServeTestStream(stream ByteStream, test string, size uint64) error {
+	switch test {
+	case StreamTestEcho:
+	case StreamTestSink:
+		var received uint64
+		for received < size {
+			message, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			received += uint64(len(message.Content))
+		}
+		if err := stream.Send(new(cs.BytesMessage)); err != nil {
+			return err
+		}
+	case StreamTestSource:
+		// gServer starts the clock with its first message
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+		if err := SendTestBytes(stream, size); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown stream test %q", test)
+	}
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if test != StreamTestEcho {
+			continue
+		}
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+	}
+}
+
+// SendTestBytes sends size bytes over a test stream in messages of
+// StreamTestChunkSize bytes.
+func SendTestBytes(stream ByteStream, size uint64) error {
+	chunk := make([]byte, StreamTestChunkSize)
+	for size > 0 {
+		n := uint64(len(chunk))
+		if size < n {
+			n = size
+		}
+		message := new(cs.BytesMessage)
+		message.Content = chunk[:n]
+		if err := stream.Send(message); err != nil {
+			return err
+		}
+		size -= n
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdays maps day abbreviations to time.Weekday.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// TimeWindow is a recurring period during which a client may be
+// active, such as business hours in the timezone of a target.
+type TimeWindow struct {
+	days     [7]bool
+	start    int
+	end      int
+	location *time.Location
+}
+
+// ParseTimeWindow parses a window such as "Mon-Fri 08:00-18:00" or
+// "Mon,Wed,Sat 22:00-06:00". Windows that end before they start run
+// past midnight. If the days are omitted every day is included. The
+// timezone is an IANA name such as "America/New_York", which requires
+// zoneinfo on the host, or a fixed offset such as "-05:00". An empty
+// timezone means UTC.
+func ParseTimeWindow(spec string, timezone string) (*TimeWindow, error) {
+	w := new(TimeWindow)
+
+	location, err := parseLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	w.location = location
+
+	fields := strings.Fields(spec)
+	var hours string
+	switch len(fields) {
+	case 1:
+		for i := range w.days {
+			w.days[i] = true
+		}
+		hours = fields[0]
+	case 2:
+		if err := w.parseDays(fields[0]); err != nil {
+			return nil, err
+		}
+		hours = fields[1]
+	default:
+		return nil, fmt.Errorf("invalid time window %q", spec)
+	}
+
+	bounds := strings.Split(hours, "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid hours %q", hours)
+	}
+	if w.start, err = parseClock(bounds[0]); err != nil {
+		return nil, err
+	}
+	if w.end, err = parseClock(bounds[1]); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// parseDays parses a comma separated list of days and day ranges.
+func (w *TimeWindow) parseDays(days string) error {
+	for _, part := range strings.Split(days, ",") {
+		bounds := strings.Split(strings.ToLower(part), "-")
+		first, ok := weekdays[bounds[0]]
+		if !ok {
+			return fmt.Errorf("invalid day %q", bounds[0])
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, ok = weekdays[bounds[1]]; !ok {
+				return fmt.Errorf("invalid day %q", bounds[1])
+			}
+		} else if len(bounds) > 2 {
+			return fmt.Errorf("invalid days %q", part)
+		}
+
+		for day := first; ; day = (day + 1) % 7 {
+			w.days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// parseClock parses a time of day such as 08:30 into minutes after
+// midnight. 24:00 is accepted as the end of the day.
+func parseClock(clock string) (int, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q", clock)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", clock)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 || hour < 0 || hour > 24 ||
+		(hour == 24 && minute != 0) {
+		return 0, fmt.Errorf("invalid time %q", clock)
+	}
+	return hour*60 + minute, nil
+}
+
+// parseLocation parses an IANA timezone name or a fixed offset such
+// as +05:30.
+func parseLocation(timezone string) (*time.Location, error) {
+	if timezone == "" || strings.EqualFold(timezone, "UTC") {
+		return time.UTC, nil
+	}
+
+	if timezone[0] == '+' || timezone[0] == '-' {
+		offset, err := time.Parse("-07:00", timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset %q", timezone)
+		}
+		_, seconds := offset.Zone()
+		return time.FixedZone(timezone, seconds), nil
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+// Contains returns true if t falls inside the window.
+func (w *TimeWindow) Contains(t time.Time) bool {
+	local := t.In(w.location)
+	minute := local.Hour()*60 + local.Minute()
+	day := local.Weekday()
+
+	if w.start == w.end {
+		return w.days[day]
+	}
+	if w.start < w.end {
+		return w.days[day] && minute >= w.start && minute < w.end
+	}
+
+	// The window runs past midnight and belongs to the day it started
+	previous := (day + 6) % 7
+	return (w.days[day] && minute >= w.start) ||
+		(w.days[previous] && minute < w.end)
+}
+
+// Next returns the earliest time at or after t that falls inside the
+// window, or the zero time if the window is never open.
+func (w *TimeWindow) Next(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+	return w.scan(t, true)
+}
+
+// End returns the time at which the window that contains t closes, or
+// the zero time if it never does.
+func (w *TimeWindow) End(t time.Time) time.Time {
+	return w.scan(t, false)
+}
+
+// scan returns the first whole minute after t at which the window is
+// open (or closed), looking ahead one week.
+func (w *TimeWindow) scan(t time.Time, open bool) time.Time {
+	candidate := t.Truncate(time.Minute)
+	for i := 0; i <= 7*24*60; i++ {
+		candidate = candidate.Add(time.Minute)
+		if w.Contains(candidate) == open {
+			return candidate
+		}
+	}
+	return time.Time{}
+}
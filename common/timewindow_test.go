@@ -0,0 +1,60 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeWindow(t *testing.T) {
+	window, err := ParseTimeWindow("Mon-Fri 08:00-18:00", "-05:00")
+	if err != nil {
+		t.Fatalf("ParseTimeWindow: %v", err)
+	}
+
+	// Friday 2021-01-08 12:00 at -05:00
+	inside := time.Date(2021, 1, 8, 17, 0, 0, 0, time.UTC)
+	if !window.Contains(inside) {
+		t.Fatalf("expected %s to be inside the window", inside)
+	}
+	if end := window.End(inside); !end.Equal(time.Date(2021, 1, 8, 23, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected end %s", end)
+	}
+
+	// Friday 19:00 at -05:00, the window opens again on Monday
+	outside := time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC)
+	if window.Contains(outside) {
+		t.Fatalf("expected %s to be outside the window", outside)
+	}
+	if next := window.Next(outside); !next.Equal(time.Date(2021, 1, 11, 13, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected next %s", next)
+	}
+}
+
+func TestTimeWindowOvernight(t *testing.T) {
+	window, err := ParseTimeWindow("Sat 22:00-06:00", "")
+	if err != nil {
+		t.Fatalf("ParseTimeWindow: %v", err)
+	}
+
+	tests := []struct {
+		time     time.Time
+		expected bool
+	}{
+		{time.Date(2021, 1, 9, 23, 0, 0, 0, time.UTC), true},   // Saturday night
+		{time.Date(2021, 1, 10, 5, 59, 0, 0, time.UTC), true},  // Sunday morning
+		{time.Date(2021, 1, 10, 6, 0, 0, 0, time.UTC), false},  // Sunday after
+		{time.Date(2021, 1, 9, 5, 0, 0, 0, time.UTC), false},   // Saturday morning
+		{time.Date(2021, 1, 10, 23, 0, 0, 0, time.UTC), false}, // Sunday night
+	}
+	for _, test := range tests {
+		if window.Contains(test.time) != test.expected {
+			t.Errorf("Contains(%s) != %v", test.time, test.expected)
+		}
+	}
+
+	for _, spec := range []string{"Mon-Fri", "Mon 8-18", "Foo 08:00-09:00", "25:00-26:00"} {
+		if _, err := ParseTimeWindow(spec, ""); err == nil {
+			t.Errorf("expected %q to be invalid", spec)
+		}
+	}
+}
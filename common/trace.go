@@ -0,0 +1,113 @@
+package common
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanExporter receives every span once it has ended.
+type SpanExporter func(span *Span)
+
+// spanExporter holds the registered SpanExporter. Tracing is disabled
+// while it is nil, in which case spans cost next to nothing.
+var spanExporter atomic.Value
+
+// SetSpanExporter registers the exporter that receives ended spans.
+func SetSpanExporter(exporter SpanExporter) {
+	spanExporter.Store(exporter)
+}
+
+// TracingEnabled returns true if a span exporter is registered.
+func TracingEnabled() bool {
+	exporter, _ := spanExporter.Load().(SpanExporter)
+	return exporter != nil
+}
+
+// Span is a timed operation in the lifecycle of a tunnel, following
+// the OpenTelemetry data model.
+type Span struct {
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	Name       string
+	Start      time.Time
+	End        time.Time
+	Error      string
+	Attributes map[string]string
+	ended      bool
+	mutex      sync.Mutex
+}
+
+// StartSpan starts a span. If parent is not nil the span becomes its
+// child, otherwise a new trace is started. StartSpan returns nil if
+// tracing is disabled, all Span methods accept a nil receiver.
+func StartSpan(parent *Span, name string) *Span {
+	if !TracingEnabled() {
+		return nil
+	}
+
+	s := new(Span)
+	s.Name = name
+	s.Start = time.Now()
+	s.SpanID = randomHex(8)
+	s.Attributes = make(map[string]string)
+
+	if parent != nil {
+		s.TraceID = parent.TraceID
+		s.ParentID = parent.SpanID
+	} else {
+		s.TraceID = randomHex(16)
+	}
+	return s
+}
+
+// SetAttribute records a key/value pair on the span.
+func (s *Span) SetAttribute(key string, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Error = err.Error()
+}
+
+// Finish ends the span and hands it to the exporter. Only the first
+// call has an effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mutex.Unlock()
+
+	if exporter, _ := spanExporter.Load().(SpanExporter); exporter != nil {
+		exporter(s)
+	}
+}
+
+// randomHex returns n random bytes encoded as hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	crand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSpans(t *testing.T) {
+	if span := StartSpan(nil, "disabled"); span != nil {
+		t.Errorf("StartSpan: Got: %v Want: nil while tracing is disabled", span)
+	}
+
+	exported := make([]*Span, 0)
+	SetSpanExporter(func(span *Span) {
+		exported = append(exported, span)
+	})
+	defer SetSpanExporter(nil)
+
+	parent := StartSpan(nil, "parent")
+	child := StartSpan(parent, "child")
+	child.SetError(errors.New("failed"))
+	child.Finish()
+	child.Finish()
+	parent.Finish()
+
+	if len(exported) != 2 {
+		t.Fatalf("exported: Got: %d spans Want: 2", len(exported))
+	}
+	if child.TraceID != parent.TraceID || child.ParentID != parent.SpanID {
+		t.Errorf("child: Got: trace %s parent %s Want: trace %s parent %s",
+			child.TraceID, child.ParentID, parent.TraceID, parent.SpanID)
+	}
+	if child.Error != "failed" {
+		t.Errorf("child.Error: Got: %q Want: %q", child.Error, "failed")
+	}
+}
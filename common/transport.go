@@ -0,0 +1,263 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Transports over which a gClient can reach gServer.
+const (
+	TransportGRPC      = "grpc"
+	TransportWebSocket = "websocket"
+	TransportLongPoll  = "longpoll"
+	TransportDNS       = "dns"
+	TransportICMP      = "icmp"
+	TransportQUIC      = "quic"
+)
+
+// WebSocketPath is the path at which gServer accepts WebSocket
+// transports.
+const WebSocketPath = "/ws"
+
+// Transport carries the gRPC connection of a gClient to gServer.
+type Transport interface {
+	// Name returns the name of the transport, such as websocket.
+	Name() string
+
+	// Dialer returns the function with which gRPC connects to gServer
+	// at address, a host and port, or nil if gRPC connects on its own.
+	Dialer() func(ctx context.Context, address string) (net.Conn, error)
+}
+
+// SeparateStreams returns true if byte streams are carried by gRPC
+// connections of their own over the transport t. Over QUIC, these
+// connections are streams that do not hold each other up.
+func SeparateStreams(t Transport) bool {
+	s, ok := t.(interface{ SeparateStreams() bool })
+	return ok && s.SeparateStreams()
+}
+
+// TransportConfig holds the settings of the transports. Ports that are
+// empty default to the port of the server.
+type TransportConfig struct {
+	WebSocketPort string
+	LongPollPort  string
+	QUICPort      string
+
+	// DNSDomain is the domain for which gServer is authoritative and
+	// DNSResolver the address of the resolver that is queried, or the
+	// resolver of the system if empty.
+	DNSDomain   string
+	DNSResolver string
+
+	// ICMPMTU is the MTU with which the icmp transport starts,
+	// ICMPDefaultMTU if 0.
+	ICMPMTU int
+
+	// ServerName and Host override the TLS server name and HTTP Host
+	// of the websocket and longpoll transports for domain fronting.
+	// The quic transport sends ServerName as well.
+	ServerName string
+	Host       string
+
+	// Fingerprint is the TLS fingerprint, such as chrome, whose
+	// ClientHello the websocket and longpoll transports send.
+	Fingerprint string
+
+	// Proxy, if set, is the HTTP proxy through which the grpc,
+	// websocket and longpoll transports connect.
+	Proxy *HTTPProxy
+}
+
+// ParseTransports parses a comma separated list of transports, such as
+// "grpc,websocket", in the order in which they are tried.
+func ParseTransports(list string, config TransportConfig) ([]Transport, error) {
+	var transports []Transport
+	for _, name := range strings.Split(list, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case TransportGRPC:
+			t := new(grpcTransport)
+			t.proxy = config.Proxy
+			transports = append(transports, t)
+		case TransportWebSocket:
+			t := new(WebSocketTransport)
+			t.Port = config.WebSocketPort
+			t.ServerName = config.ServerName
+			t.Host = config.Host
+			t.Fingerprint = config.Fingerprint
+			t.Proxy = config.Proxy
+			transports = append(transports, t)
+		case TransportLongPoll:
+			t := new(LongPollTransport)
+			t.Port = config.LongPollPort
+			t.ServerName = config.ServerName
+			t.Host = config.Host
+			t.Fingerprint = config.Fingerprint
+			t.Proxy = config.Proxy
+			transports = append(transports, t)
+		case TransportDNS:
+			if config.DNSDomain == "" {
+				return nil, fmt.Errorf("the %s transport needs a domain", name)
+			}
+			t := new(DNSTransport)
+			t.Domain = config.DNSDomain
+			t.Resolver = config.DNSResolver
+			transports = append(transports, t)
+		case TransportICMP:
+			t := new(ICMPTransport)
+			t.MTU = config.ICMPMTU
+			transports = append(transports, t)
+		case TransportQUIC:
+			t := new(QUICTransport)
+			t.Port = config.QUICPort
+			t.ServerName = config.ServerName
+			transports = append(transports, t)
+		default:
+			return nil, fmt.Errorf("unknown transport %s", name)
+		}
+	}
+	if len(transports) == 0 {
+		transports = append(transports, new(grpcTransport))
+	}
+	return transports, nil
+}
+
+// transportAddr is the address of the server end of a transport that
+// is not a plain TCP connection.
+type transportAddr struct {
+	network string
+	address string
+}
+
+// Network returns the name of the transport.
+func (a *transportAddr) Network() string {
+	return a.network
+}
+
+// String returns the address.
+func (a *transportAddr) String() string {
+	return a.address
+}
+
+// serverName returns override, or host if it is empty.
+func serverName(host string, override string) string {
+	if override != "" {
+		return override
+	}
+	return host
+}
+
+// grpcTransport is gRPC over HTTP/2 directly on a TCP connection. It
+// honors the proxy environment of the process unless it has a proxy of
+// its own.
+type grpcTransport struct {
+	proxy *HTTPProxy
+}
+
+// Name returns the name of the transport.
+func (t *grpcTransport) Name() string {
+	return TransportGRPC
+}
+
+// Dialer returns nil, gRPC connects on its own, or the dialer of the
+// proxy.
+func (t *grpcTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	if t.proxy == nil {
+		return nil
+	}
+	return t.proxy.DialContext
+}
+
+// WebSocketTransport tunnels gRPC through a wss:// connection, which
+// passes proxies and middleboxes that only let HTTP/1.1 through.
+type WebSocketTransport struct {
+	// Port is the port of the WebSocket listener of gServer. The port
+	// of the server address is used if empty.
+	Port string
+
+	// ServerName is the TLS server name and Host the HTTP Host that
+	// are sent instead of the host of the server address. Behind a
+	// CDN, the server address is a front domain and Host the domain
+	// that the CDN routes to gServer.
+	ServerName string
+	Host       string
+
+	// Fingerprint is the TLS fingerprint, such as chrome, whose
+	// ClientHello is sent. The one of Go if empty.
+	Fingerprint string
+
+	// Proxy, if set, is the HTTP proxy through which to connect.
+	Proxy *HTTPProxy
+}
+
+// Name returns the name of the transport.
+func (t *WebSocketTransport) Name() string {
+	return TransportWebSocket
+}
+
+// Dialer returns Dial.
+func (t *WebSocketTransport) Dialer() func(ctx context.Context, address string) (net.Conn, error) {
+	return t.Dial
+}
+
+// Dial connects to the WebSocket listener of gServer.
+func (t *WebSocketTransport) Dial(ctx context.Context, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if t.Port != "" {
+		port = t.Port
+	}
+	address = net.JoinHostPort(host, port)
+
+	// The Host header is taken from the url
+	urlHost := address
+	if t.Host != "" {
+		urlHost = t.Host
+	}
+	url := fmt.Sprintf("wss://%s%s", urlHost, WebSocketPath)
+	config, err := websocket.NewConfig(url, fmt.Sprintf("https://%s", urlHost))
+	if err != nil {
+		return nil, err
+	}
+
+	var conn net.Conn
+	if t.Proxy != nil {
+		conn, err = t.Proxy.DialContext(ctx, address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// The gRPC connection runs its own TLS within the WebSocket
+	tlsConn, err := tlsClient(conn, &tls.Config{
+		ServerName:         serverName(host, t.ServerName),
+		InsecureSkipVerify: true,
+	}, t.Fingerprint, []string{"http/1.1"})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	ws, err := websocket.NewClient(config, tlsConn)
+	if err != nil {
+		tlsConn.Close()
+		return nil, err
+	}
+	ws.PayloadType = websocket.BinaryFrame
+	conn.SetDeadline(time.Time{})
+	return ws, nil
+}
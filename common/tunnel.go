@@ -1,9 +1,12 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	cs "github.com/kai5263499/gtunnel/grpc/client"
 	"github.com/segmentio/ksuid"
@@ -15,6 +18,130 @@ type ConnectionStreamHandler interface {
 	Acknowledge(tunnel *Tunnel, ctrlMessage *cs.TunnelControlMessage) ByteStream
 }
 
+// TunnelOptions holds the optional settings of a tunnel.
+type TunnelOptions struct {
+	// Command, if set, is spawned for every new connection and the
+	// connection is bridged to its stdin and stdout instead of
+	// dialing the destination.
+	Command string
+
+	// DetectDeception enables heuristics on the dialing side that
+	// flag destinations which look like tarpits or honeypots.
+	DetectDeception bool
+
+	// Hostname, if set, is published by the gServer name services
+	// for the listen address of a forward tunnel.
+	Hostname string
+
+	// TTL, if set, is the lifetime of the tunnel after which gServer
+	// deletes it.
+	TTL time.Duration
+
+	// ByteLimit, if set, is the number of bytes the tunnel may carry
+	// in both directions before gServer deletes it.
+	ByteLimit uint64
+
+	// Schedule, if set, is a time window such as "Mon-Fri 09:00-17:00"
+	// outside of which gServer keeps the tunnel closed. The window is
+	// in Timezone, or UTC if it is empty.
+	Schedule string
+	Timezone string
+
+	// ChunkSize, if set, is the number of bytes read from a TCP
+	// connection and carried by a single byte stream message for the
+	// tunnel, instead of the MTU of the endpoint. Small chunks suit
+	// interactive sessions and large chunks bulk transfers.
+	ChunkSize uint32
+
+	// Nagle enables Nagle's algorithm on the TCP connections of the
+	// tunnel instead of setting TCP_NODELAY, trading latency for
+	// fewer small packets.
+	Nagle bool
+
+	// TCPKeepalive, if set, is the keepalive period of the TCP
+	// connections of the tunnel. A negative period disables TCP
+	// keepalives.
+	TCPKeepalive time.Duration
+
+	// ReadBuffer and WriteBuffer, if set, are the sizes in bytes of
+	// the socket receive and send buffers of the TCP connections of
+	// the tunnel.
+	ReadBuffer  int
+	WriteBuffer int
+
+	// RateLimit, if set, is the bandwidth in bytes per second that the
+	// connections of the tunnel may use together, in both directions.
+	RateLimit uint64
+
+	// IdleTimeout, if set, is how long a connection of the tunnel may
+	// relay no data before it is closed.
+	IdleTimeout time.Duration
+
+	// MaxConnections, if set, is the number of connections the tunnel
+	// may carry at the same time. Further connections are refused.
+	MaxConnections uint32
+
+	// Compress enables gzip compression of the byte streams of the
+	// tunnel. It suits text heavy protocols over slow links but only
+	// costs time for data that is already compressed.
+	Compress bool
+
+	// StreamCompression, if set, is the algorithm with which the
+	// payloads of the byte streams of the tunnel are compressed by
+	// gtunnel itself, independent of Compress. Only "snappy" is
+	// supported. It is fast enough to pay off on most slow links.
+	StreamCompression string
+
+	// Encrypt adds a layer of ChaCha20-Poly1305 encryption to the
+	// byte streams of the tunnel with a key that only its two sides
+	// know, so that the data stays protected where TLS is terminated
+	// before gServer, such as at a redirector.
+	Encrypt bool
+
+	// RekeyInterval, if set, is how often gServer replaces the key of
+	// an encrypted tunnel with one from a new key exchange. Data sent
+	// before the rekey stays protected if a later key is compromised.
+	RekeyInterval time.Duration
+
+	// RekeyBytes, if set, is the number of bytes an encrypted tunnel
+	// carries with the same key before gServer replaces it.
+	RekeyBytes uint64
+
+	// PadSize, if set, pads the data messages of the tunnel with
+	// random bytes to a multiple of PadSize bytes, so that their sizes
+	// do not give away those of the writes they carry.
+	PadSize uint32
+
+	// SendJitter, if set, delays each data message of the tunnel by a
+	// random time of up to SendJitter, which blurs the timing that
+	// correlates the two sides of a tunneled session at the cost of
+	// throughput.
+	SendJitter time.Duration
+
+	// Hops, if set, are endpoints that a forward tunnel passes after its
+	// own, such as "clientB@10.0.0.5:4444", which relay its connections
+	// to its destination. See ParseTunnelHops.
+	Hops string
+
+	// Balance, if set, is BalanceRoundRobin or BalanceLeastConnections.
+	// Forward tunnels of different endpoints with the same listen address
+	// and Balance spread their new connections across each other, which
+	// requires a server that supports SO_REUSEPORT.
+	Balance string
+
+	// Failover, if set, are destinations such as "10.0.0.6:80,10.0.0.7:80"
+	// that are dialed in order when the destination of the tunnel cannot
+	// be. See ParseDestinations.
+	Failover string
+
+	// HealthCheck, if set, is how often gServer opens a connection through
+	// a forward tunnel to check that its destination is up. If HealthBanner
+	// is set as well, the destination must send it first for the check to
+	// pass.
+	HealthCheck  time.Duration
+	HealthBanner string
+}
+
 type Tunnel struct {
 	id                string
 	direction         uint32
@@ -22,18 +149,59 @@ type Tunnel struct {
 	listenPort        uint32
 	destinationIP     net.IP
 	destinationPort   uint32
+	options           TunnelOptions
+	deception         *DeceptionDetector
+	limiter           *RateLimiter
+	dialCheck         DialCheck
+	dialFunc          DialFunc
+	balancer          *Balancer
+	health            TunnelHealth
+	cipher            *tunnelCipher
+	sharedLimiters    []*RateLimiter
+	mtu               uint32
+	keepalive         time.Duration
 	connections       map[string]*Connection
+	dialing           uint32
+	closedBytesSent   uint64
+	closedBytesRecv   uint64
+	startTime         time.Time
+	onConnFailed      func(failure ConnectionFailure)
+	failures          uint64
+	lastFailure       ConnectionFailure
+	paused            bool
+	frozen            bool
+	pausedListeners   int
 	listeners         []net.TCPListener
-	Kill              chan bool
+	ctx               context.Context
+	cancel            context.CancelFunc
+	stopped           bool
+	stopOnce          sync.Once
+	routines          sync.WaitGroup
 	ctrlStream        TunnelControlStream
+	ctrlReady         chan struct{}
 	ConnectionHandler ConnectionStreamHandler
-	mutex             sync.Mutex
+	mutex             sync.RWMutex
+	ctrlMutex         sync.Mutex
 }
 
 // NewTunnel is a constructor for the tunnel struct. It takes
 // in id, direction, listenIP, lisetnPort, destinationIP, and
 // destinationPort as parameters.
 func NewTunnel(id string,
+	direction uint32,
+	listenIP net.IP,
+	listenPort uint32,
+	destinationIP net.IP,
+	destinationPort uint32) *Tunnel {
+	return NewTunnelWithContext(context.Background(), id, direction,
+		listenIP, listenPort, destinationIP, destinationPort)
+}
+
+// NewTunnelWithContext is like NewTunnel but the tunnel is stopped
+// when ctx is done. Dials and streams of the tunnel are cancelled
+// with it.
+func NewTunnelWithContext(ctx context.Context,
+	id string,
 	direction uint32,
 	listenIP net.IP,
 	listenPort uint32,
@@ -46,9 +214,17 @@ func NewTunnel(id string,
 	t.listenPort = listenPort
 	t.destinationIP = destinationIP
 	t.destinationPort = destinationPort
+	t.mtu = DefaultMTU
 	t.connections = make(map[string]*Connection)
-	t.Kill = make(chan bool)
+	t.ctx, t.cancel = context.WithCancel(ctx)
 	t.listeners = make([]net.TCPListener, 0)
+	t.ctrlReady = make(chan struct{})
+	t.startTime = time.Now()
+
+	go func() {
+		<-t.ctx.Done()
+		t.shutdown()
+	}()
 	return t
 }
 
@@ -59,58 +235,258 @@ func (t *Tunnel) AddConnection(c *Connection) {
 	defer t.mutex.Unlock()
 
 	c.ID = ksuid.New().String()
+	c.SetMTU(t.mtu)
+	c.limiters = t.rateLimiters()
+	c.compression = t.options.StreamCompression
+	c.cipher = t.cipher
+	c.padSize = t.options.PadSize
+	c.sendJitter = t.options.SendJitter
+	t.setIdleTimeout(c, t.options.IdleTimeout)
 	t.connections[c.ID] = c
 }
 
+// putConnection adds a connection with an ID that was generated by
+// the remote side of the tunnel. It takes the place of the connection
+// that was reserved for it.
+func (t *Tunnel) putConnection(c *Connection) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.dialing--
+
+	c.limiters = t.rateLimiters()
+	c.compression = t.options.StreamCompression
+	c.cipher = t.cipher
+	c.padSize = t.options.PadSize
+	c.sendJitter = t.options.SendJitter
+	t.setIdleTimeout(c, t.options.IdleTimeout)
+	t.connections[c.ID] = c
+}
+
+// rateLimiters returns the rate limiters that the connections of the
+// tunnel are subject to. The caller must hold the mutex.
+func (t *Tunnel) rateLimiters() []*RateLimiter {
+	if t.limiter == nil {
+		return t.sharedLimiters
+	}
+	return append([]*RateLimiter{t.limiter}, t.sharedLimiters...)
+}
+
+// AddRateLimiter subjects the connections added to the tunnel from now
+// on to a rate limiter that may be shared with other tunnels, such as
+// a server-wide bandwidth cap.
+func (t *Tunnel) AddRateLimiter(l *RateLimiter) {
+	if l == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.sharedLimiters = append(t.sharedLimiters, l)
+}
+
 // AddListener will start a tcp listener on a specific port and forward
 // all accepted TCP connections to the associated tunnel.
 func (t *Tunnel) AddListener(clientID string) bool {
 
-	addr, _ := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d", t.listenIP, t.listenPort))
-	ln, err := net.ListenTCP("tcp", addr)
+	ln, err := listenTCP(t.ctx, t.listenIP, t.listenPort)
 	if err != nil {
+		Log.WithTunnel(t.id).Warnf("Failed to listen on port %d: %v", t.listenPort, err)
 		return false
 	}
 
+	t.mutex.Lock()
 	t.listeners = append(t.listeners, *ln)
+	t.mutex.Unlock()
 
 	newConns := make(chan *net.TCPConn)
 
-	go func(l *net.TCPListener) {
+	// The connections are handled until the listener is closed
+	acceptConns := func() {
+		defer close(newConns)
 		for {
-			c, err := l.AcceptTCP()
-			if err == nil {
-				newConns <- c
-			} else {
+			c, err := ln.AcceptTCP()
+			if err != nil {
+				return
+			}
+			select {
+			case newConns <- c:
+			case <-t.ctx.Done():
+				c.Close()
 				return
 			}
 		}
-	}(ln)
-	go func() {
+	}
+	handleConns := func() {
 		for {
 			select {
-			case conn := <-newConns:
-				gConn := NewConnection(*conn)
-				t.AddConnection(gConn)
-				newMessage := new(cs.TunnelControlMessage)
-				newMessage.Operation = TunnelCtrlConnect
-				newMessage.TunnelId = t.id
-				newMessage.ConnectionId = gConn.ID
-				t.ctrlStream.Send(newMessage)
-
-			case <-t.Kill:
+			case conn, ok := <-newConns:
+				if !ok {
+					return
+				}
+				if t.ctx.Err() != nil {
+					conn.Close()
+					return
+				}
+				t.balanced().acceptConnection(conn)
+
+			case <-t.ctx.Done():
 				return
 			}
 		}
+	}
+
+	if !t.spawn(acceptConns) || !t.spawn(handleConns) {
+		ln.Close()
+		return false
+	}
+	return true
+}
+
+// balanced returns the tunnel that takes the next connection accepted
+// by the tunnel, which is another tunnel of its balancer group if it
+// has one.
+func (t *Tunnel) balanced() *Tunnel {
+	t.mutex.RLock()
+	b := t.balancer
+	t.mutex.RUnlock()
+
+	if b != nil {
+		if picked := b.Pick(); picked != nil {
+			return picked
+		}
+	}
+	return t
+}
+
+// acceptConnection adds a connection that was accepted by a listener
+// to the tunnel and asks the remote side to connect it.
+func (t *Tunnel) acceptConnection(conn *net.TCPConn) {
+	if reason := t.connectionLimitReached(); reason != "" {
+		Log.WithTunnel(t.id).Warnf("Refusing connection from %s: %s",
+			conn.RemoteAddr(), reason)
+		// A reset tells the peer that it was refused
+		conn.SetLinger(0)
+		conn.Close()
+		return
+	}
+	if err := t.GetOptions().applySocketOptions(conn); err != nil {
+		Log.WithTunnel(t.id).Warnf("Failed to set socket options: %v", err)
+	}
+	gConn := NewConnection(conn)
+	gConn.SetOriginAddress(conn.RemoteAddr().String())
+	t.AddConnection(gConn)
+	Log.WithTunnel(t.id).WithConnection(gConn.ID).Infof(
+		"Accepted connection from %s", gConn.GetOriginAddress())
+	gConn.setupSpan = StartSpan(nil, "connection.setup")
+	gConn.setupSpan.SetAttribute("tunnel.id", t.id)
+	gConn.setupSpan.SetAttribute("connection.id", gConn.ID)
+	gConn.setupSpan.SetAttribute("source", gConn.GetOriginAddress())
+	newMessage := new(cs.TunnelControlMessage)
+	newMessage.Operation = TunnelCtrlConnect
+	newMessage.TunnelId = t.id
+	newMessage.ConnectionId = gConn.ID
+	newMessage.StreamToken = gConn.GetStreamToken()
+	newMessage.OriginAddress = gConn.GetOriginAddress()
+	t.SendCtrlMessage(newMessage)
+	t.spawn(func() { t.awaitAck(gConn) })
+}
+
+// spawn runs f in a goroutine that Stop waits for. It returns false
+// without running f if the tunnel is stopped.
+func (t *Tunnel) spawn(f func()) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.stopped {
+		return false
+	}
+	t.routines.Add(1)
+	go func() {
+		defer t.routines.Done()
+		f()
 	}()
 	return true
 }
 
+// dial will connect to the destination of the tunnel, or to the first
+// of its failover destinations that can be dialed. It returns the
+// address that it connected to. If the tunnel has a command configured,
+// the command is spawned instead.
+func (t *Tunnel) dial() (net.Conn, string, error) {
+	if command := t.GetOptions().Command; command != "" {
+		if err := t.checkDial(nil, 0); err != nil {
+			return nil, "", err
+		}
+		conn, err := StartProcess(command)
+		return conn, "", err
+	}
+
+	t.mutex.RLock()
+	dial := t.dialFunc
+	t.mutex.RUnlock()
+	if dial == nil {
+		dialer := net.Dialer{Timeout: DialTimeout}
+		dial = dialer.DialContext
+	}
+
+	// Failing over must not take longer than the remote side waits for
+	// the acknowledgement
+	ctx, cancel := context.WithTimeout(t.ctx, ConnectAckTimeout-DialTimeout)
+	defer cancel()
+
+	var err error
+	destinations := t.destinations()
+	for i, destination := range destinations {
+		if i > 0 {
+			Log.WithTunnel(t.id).Warnf("Failed to connect to %s, failing over to %s: %v",
+				destinations[i-1], destination, err)
+		}
+		var conn net.Conn
+		if conn, err = t.dialDestination(ctx, dial, destination); err == nil {
+			return conn, destination.String(), nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", err
+}
+
+// dialDestination connects to one of the destinations of the tunnel.
+func (t *Tunnel) dialDestination(ctx context.Context, dial DialFunc,
+	destination Destination) (net.Conn, error) {
+
+	if err := t.checkDial(destination.IP, destination.Port); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DialTimeout)
+	defer cancel()
+	conn, err := dial(ctx, "tcp", destination.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := t.GetOptions().applySocketOptions(conn); err != nil {
+		Log.WithTunnel(t.id).Warnf("Failed to set socket options: %v", err)
+	}
+	return conn, nil
+}
+
+// SetDialer sets the function with which the tunnel dials its
+// destination, such as that of an upstream socks proxy.
+func (t *Tunnel) SetDialer(dial DialFunc) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.dialFunc = dial
+}
+
 // GetConnection will return a Connection object
 // with the given connection id
 func (t *Tunnel) GetConnection(connID string) *Connection {
-	t.mutex.Lock()
-	defer t.mutex.Unlock()
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 
 	if conn, ok := t.connections[connID]; ok {
 		return conn
@@ -118,9 +494,18 @@ func (t *Tunnel) GetConnection(connID string) *Connection {
 	return nil
 }
 
+// Context returns the context of the tunnel, which is done once the
+// tunnel is stopped.
+func (t *Tunnel) Context() context.Context {
+	return t.ctx
+}
+
 // GetControlStream will return the control stream for
 // the associated tunnel
 func (t *Tunnel) GetControlStream() TunnelControlStream {
+	t.ctrlMutex.Lock()
+	defer t.ctrlMutex.Unlock()
+
 	return t.ctrlStream
 }
 
@@ -149,28 +534,105 @@ func (t *Tunnel) GetListenPort() uint32 {
 	return t.listenPort
 }
 
-// GetConnections will return the connection map
+// GetKeepalive gets the interval at which keepalive messages are
+// sent on the control stream of the tunnel.
+func (t *Tunnel) GetKeepalive() time.Duration {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.keepalive
+}
+
+// GetMTU gets the maximum number of bytes carried by a single byte
+// stream message for new connections of the tunnel.
+func (t *Tunnel) GetMTU() uint32 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.mtu
+}
+
+// GetOptions gets the optional settings of the tunnel.
+func (t *Tunnel) GetOptions() TunnelOptions {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.options
+}
+
+// GetBytesReceived returns the number of bytes read from the TCP
+// connections of the tunnel, including connections that are closed.
+func (t *Tunnel) GetBytesReceived() uint64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	total := t.closedBytesRecv
+	for _, conn := range t.connections {
+		total += conn.GetBytesReceived()
+	}
+	return total
+}
+
+// GetBytesSent returns the number of bytes written to the TCP
+// connections of the tunnel, including connections that are closed.
+func (t *Tunnel) GetBytesSent() uint64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	total := t.closedBytesSent
+	for _, conn := range t.connections {
+		total += conn.GetBytesSent()
+	}
+	return total
+}
+
+// GetStartTime returns the time at which the tunnel was created.
+func (t *Tunnel) GetStartTime() time.Time {
+	return t.startTime
+}
+
+// GetConnections will return a copy of the connection map
 func (t *Tunnel) GetConnections() map[string]*Connection {
-	t.mutex.Lock()
-	defer t.mutex.Unlock()
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	connections := make(map[string]*Connection, len(t.connections))
+	for id, conn := range t.connections {
+		connections[id] = conn
+	}
+	return connections
+}
+
+// ConnectionCount returns the number of active connections.
+func (t *Tunnel) ConnectionCount() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
 
-	return t.connections
+	return len(t.connections)
 }
 
 // handleIngressCtrlMessages is the loop function responsible
 // for receiving control messages from the gRPC stream.
 func (t *Tunnel) handleIngressCtrlMessages() {
 	ingressMessages := make(chan *cs.TunnelControlMessage)
-	go func(s TunnelControlStream) {
+	s := t.GetControlStream()
+
+	// Stop does not wait for the receive, which only returns once the
+	// stream is closed, and that may come after the tunnel stopped
+	go func() {
 		for {
-			ingressMessage, err := t.ctrlStream.Recv()
+			ingressMessage, err := s.Recv()
 			if err != nil {
 				close(ingressMessages)
 				return
 			}
-			ingressMessages <- ingressMessage
+			select {
+			case ingressMessages <- ingressMessage:
+			case <-t.ctx.Done():
+				return
+			}
 		}
-	}(t.ctrlStream)
+	}()
 	for {
 		select {
 		case ctrlMessage, ok := <-ingressMessages:
@@ -182,32 +644,52 @@ func (t *Tunnel) handleIngressCtrlMessages() {
 				ingressMessages = nil
 				break
 			}
+			span := StartSpan(nil, "tunnel.control")
+			span.SetAttribute("tunnel.id", t.id)
+			span.SetAttribute("connection.id", ctrlMessage.ConnectionId)
+			span.SetAttribute("operation", fmt.Sprint(ctrlMessage.Operation))
+
 			// handle control message
 			if ctrlMessage.Operation == TunnelCtrlConnect {
 
-				rAddr, _ := net.ResolveTCPAddr("tcp", fmt.Sprintf("%s:%d",
-					t.destinationIP,
-					t.destinationPort))
-
-				conn, err := net.DialTCP("tcp", nil, rAddr)
-
-				if err != nil {
-					ctrlMessage.ErrorStatus = 1
-				} else {
-					var gConn *Connection
-					if gConn, ok = t.connections[ctrlMessage.ConnectionId]; !ok {
-						gConn = NewConnection(*conn)
-						gConn.ID = ctrlMessage.ConnectionId
-						t.connections[ctrlMessage.ConnectionId] = gConn
-					}
-					stream := t.ConnectionHandler.GetByteStream(t, ctrlMessage)
-					gConn.SetStream(stream)
-					gConn.Start()
-
+				if reason := t.reserveConnection(); reason != "" {
+					Log.WithTunnel(t.id).WithConnection(ctrlMessage.ConnectionId).Warnf(
+						"Rejecting connection for %s: %s", ctrlMessage.OriginAddress, reason)
+					t.failConnection(ctrlMessage, cs.ConnectionError_POLICY_DENIED, reason)
+					span.Finish()
+					continue
 				}
 
+				// Dialing and failing over take their time, which must
+				// not hold up the control messages of other connections
+				if !t.spawn(func() { t.connect(ctrlMessage, span) }) {
+					t.releaseConnection()
+					span.Finish()
+				}
+				continue
 			} else if ctrlMessage.Operation == TunnelCtrlAck {
 				if ctrlMessage.ErrorStatus != 0 {
+					failure := ConnectionFailure{
+						ConnectionID: ctrlMessage.ConnectionId,
+						Code:         ctrlMessage.ErrorStatus,
+						Message:      ctrlMessage.ErrorMessage,
+					}
+					if conn := t.GetConnection(ctrlMessage.ConnectionId); conn != nil {
+						failure.OriginAddress = conn.GetOriginAddress()
+						if conn.healthCheck {
+							// The health check reports the failure
+							conn.failure = &failure
+							conn.Close()
+							t.RemoveConnection(conn.ID)
+							span.Finish()
+							continue
+						}
+						conn.Close()
+					}
+					Log.WithTunnel(t.id).WithConnection(ctrlMessage.ConnectionId).Warnf(
+						"Connection for %s failed on the remote side: %s",
+						failure.OriginAddress, failure)
+					t.connectionFailed(failure)
 					t.RemoveConnection(ctrlMessage.ConnectionId)
 				} else {
 					// Now that we know we are connected, we need to create a new byte
@@ -216,18 +698,55 @@ func (t *Tunnel) handleIngressCtrlMessages() {
 					conn := t.GetConnection(ctrlMessage.ConnectionId)
 
 					if conn != nil {
+						conn.SetDestinationAddress(ctrlMessage.DestinationAddress)
+						if t.GetOptions().Failover != "" {
+							Log.WithTunnel(t.id).WithConnection(conn.ID).Infof(
+								"Connection for %s connected to %s",
+								conn.GetOriginAddress(), ctrlMessage.DestinationAddress)
+						}
 						// Waiting until the byte stream gets set up
-						conn.SetStream(t.ConnectionHandler.Acknowledge(t, ctrlMessage))
-						if ok {
-							conn.Start()
+						streamSpan := StartSpan(span, "connection.stream_setup")
+						stream := t.ConnectionHandler.Acknowledge(t, ctrlMessage)
+						streamSpan.Finish()
+						if stream == nil {
+							failure := ConnectionFailure{
+								ConnectionID:  conn.ID,
+								OriginAddress: conn.GetOriginAddress(),
+								Code:          cs.ConnectionError_DIAL_TIMEOUT,
+								Message:       "the byte stream was not set up",
+							}
+							Log.WithTunnel(t.id).WithConnection(conn.ID).Warnf(
+								"Connection for %s failed: %s", failure.OriginAddress,
+								failure.Message)
+							t.connectionFailed(failure)
+							t.dropConnection(conn)
+						} else {
+							conn.SetStream(stream)
+							if ok {
+								conn.Start()
+							}
 						}
 					}
 				}
 			} else if ctrlMessage.Operation == TunnelCtrlDisconnect {
+				if conn := t.GetConnection(ctrlMessage.ConnectionId); conn != nil {
+					conn.Close()
+				}
 				t.RemoveConnection(ctrlMessage.ConnectionId)
+			} else if ctrlMessage.Operation == TunnelCtrlWarning {
+				if conn := t.GetConnection(ctrlMessage.ConnectionId); conn != nil {
+					conn.AddWarning(ctrlMessage.Warning)
+				}
+				Log.WithTunnel(t.id).WithConnection(ctrlMessage.ConnectionId).Warnf(
+					"%s", ctrlMessage.Warning)
+			} else if ctrlMessage.Operation == TunnelCtrlRekey ||
+				ctrlMessage.Operation == TunnelCtrlRekeyAck ||
+				ctrlMessage.Operation == TunnelCtrlRekeyDone {
+				t.handleRekeyMessage(ctrlMessage)
 			}
-		case <-t.Kill:
-			break
+			span.Finish()
+		case <-t.ctx.Done():
+			return
 		}
 		if ingressMessages == nil {
 			break
@@ -235,43 +754,391 @@ func (t *Tunnel) handleIngressCtrlMessages() {
 	}
 }
 
+// connect dials the destination of a connection that the remote side of
+// the tunnel requested and sets up its byte stream. The connection was
+// reserved, so it counts against the limit of the tunnel while it is
+// dialed.
+func (t *Tunnel) connect(ctrlMessage *cs.TunnelControlMessage, span *Span) {
+	defer span.Finish()
+
+	dialSpan := StartSpan(span, "tunnel.dial")
+	dialStart := time.Now()
+	conn, destination, err := t.dial()
+	dialSpan.SetError(err)
+	dialSpan.Finish()
+
+	if err != nil {
+		t.releaseConnection()
+		atomic.AddUint64(&dialFailuresTotal, 1)
+		Log.WithTunnel(t.id).WithConnection(ctrlMessage.ConnectionId).Warnf(
+			"Failed to connect for %s: %v", ctrlMessage.OriginAddress, err)
+		t.connectionFailed(ConnectionFailure{
+			ConnectionID:  ctrlMessage.ConnectionId,
+			OriginAddress: ctrlMessage.OriginAddress,
+			Code:          dialErrorCode(err),
+			Message:       err.Error(),
+		})
+		t.failConnection(ctrlMessage, dialErrorCode(err), err.Error())
+		return
+	}
+
+	gConn := t.GetConnection(ctrlMessage.ConnectionId)
+	if gConn == nil {
+		gConn = NewConnection(conn)
+		gConn.ID = ctrlMessage.ConnectionId
+		gConn.SetOriginAddress(ctrlMessage.OriginAddress)
+		gConn.SetMTU(t.GetMTU())
+		Log.WithTunnel(t.id).WithConnection(gConn.ID).Infof(
+			"Connected to %s for %s", conn.RemoteAddr(),
+			ctrlMessage.OriginAddress)
+		gConn.SetDestinationAddress(destination)
+		t.putConnection(gConn)
+		if t.deception != nil {
+			t.inspectConnection(gConn, time.Since(dialStart))
+		}
+	} else {
+		t.releaseConnection()
+	}
+	ctrlMessage.DestinationAddress = destination
+	streamSpan := StartSpan(span, "connection.stream_setup")
+	stream := t.ConnectionHandler.GetByteStream(t, ctrlMessage)
+	streamSpan.Finish()
+	if stream == nil {
+		Log.WithTunnel(t.id).WithConnection(gConn.ID).Warnf(
+			"Failed to set up the byte stream for %s",
+			ctrlMessage.OriginAddress)
+		t.dropConnection(gConn)
+		return
+	}
+	gConn.SetStream(stream)
+	gConn.Start()
+}
+
+// handleKeepalive is the loop function responsible for sending
+// keepalive messages on the control stream so that idle streams
+// are not reaped by middleboxes.
+func (t *Tunnel) handleKeepalive() {
+	for {
+		interval := t.GetKeepalive()
+		wait := interval
+		if wait == 0 {
+			// Keepalives are disabled, check again later in
+			// case they get enabled.
+			wait = time.Second
+		}
+
+		select {
+		case <-time.After(wait):
+			if interval == 0 || t.GetControlStream() == nil {
+				continue
+			}
+			message := new(cs.TunnelControlMessage)
+			message.Operation = TunnelCtrlKeepalive
+			message.TunnelId = t.id
+			t.SendCtrlMessage(message)
+		case <-t.ctx.Done():
+			return
+		}
+	}
+}
+
+// SendCtrlMessage will send a message on the control stream. Sends
+// are serialized since gRPC streams do not support concurrent sends.
+func (t *Tunnel) SendCtrlMessage(message *cs.TunnelControlMessage) error {
+	t.ctrlMutex.Lock()
+	defer t.ctrlMutex.Unlock()
+
+	return t.ctrlStream.Send(message)
+}
+
+// inspectConnection runs the deception heuristics against a newly
+// dialed connection and warns about anything suspicious.
+func (t *Tunnel) inspectConnection(c *Connection, latency time.Duration) {
+	for _, warning := range t.deception.CheckConnect(latency) {
+		t.warnConnection(c, warning)
+	}
+
+	c.SetFirstReadHandler(func(banner []byte) {
+		for _, warning := range t.deception.CheckBanner(banner) {
+			t.warnConnection(c, warning)
+		}
+	})
+}
+
+// warnConnection records a warning on the connection and forwards it
+// to the remote side of the tunnel so the operator can see it.
+func (t *Tunnel) warnConnection(c *Connection, warning string) {
+	c.AddWarning(warning)
+	Log.WithTunnel(t.id).WithConnection(c.ID).Warnf("%s", warning)
+
+	message := new(cs.TunnelControlMessage)
+	message.Operation = TunnelCtrlWarning
+	message.TunnelId = t.id
+	message.ConnectionId = c.ID
+	message.Warning = warning
+	t.SendCtrlMessage(message)
+}
+
 // RemoveConnection will remove the Connection object
 // from the connections map.
 func (t *Tunnel) RemoveConnection(connID string) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
+	// Keep the bytes of closed connections in the tunnel totals
+	if conn, ok := t.connections[connID]; ok {
+		t.closedBytesSent += conn.GetBytesSent()
+		t.closedBytesRecv += conn.GetBytesReceived()
+	}
 	delete(t.connections, connID)
 }
 
+// SetKeepalive sets the interval at which keepalive messages are sent
+// on the control stream of the tunnel. An interval of 0 disables them.
+func (t *Tunnel) SetKeepalive(interval time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.keepalive = interval
+}
+
+// SetMTU sets the maximum number of bytes carried by a single byte
+// stream message for new connections of the tunnel.
+func (t *Tunnel) SetMTU(mtu uint32) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.mtu = mtu
+}
+
+// SetConnectionFailedHandler sets a function that is called when a
+// connection of the tunnel could not be established, either locally
+// or on the remote side.
+func (t *Tunnel) SetConnectionFailedHandler(handler func(failure ConnectionFailure)) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.onConnFailed = handler
+}
+
+// connectionFailed records a failed connection and calls the
+// connection failed handler if one is set.
+func (t *Tunnel) connectionFailed(failure ConnectionFailure) {
+	failure.Time = time.Now()
+
+	t.mutex.Lock()
+	t.failures++
+	t.lastFailure = failure
+	onConnFailed := t.onConnFailed
+	t.mutex.Unlock()
+
+	if onConnFailed != nil {
+		onConnFailed(failure)
+	}
+}
+
+// GetFailedConnections returns the number of connections of the tunnel
+// that could not be established and the last of them. The last failure
+// is the zero value if there were none.
+func (t *Tunnel) GetFailedConnections() (uint64, ConnectionFailure) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.failures, t.lastFailure
+}
+
+// SetOptions will set the optional settings of the tunnel.
+func (t *Tunnel) SetOptions(options TunnelOptions) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.options = options
+	if options.ChunkSize != 0 {
+		t.mtu = options.ChunkSize
+	}
+	t.limiter = nil
+	if options.RateLimit != 0 {
+		t.limiter = NewRateLimiter(options.RateLimit)
+	}
+	t.deception = nil
+	if options.DetectDeception {
+		t.deception = NewDeceptionDetector()
+	}
+	// The key pair is kept when the options change
+	if !options.Encrypt {
+		t.cipher = nil
+	} else if t.cipher == nil {
+		var err error
+		if t.cipher, err = newTunnelCipher(); err != nil {
+			Log.WithTunnel(t.id).Errorf("Failed to generate tunnel key: %v", err)
+		}
+	}
+}
+
 // SetControlStream will set the provided control stream for
 // the associated tunnel
 func (t *Tunnel) SetControlStream(s TunnelControlStream) {
+	t.ctrlMutex.Lock()
+	defer t.ctrlMutex.Unlock()
+
 	t.ctrlStream = s
+	select {
+	case <-t.ctrlReady:
+	default:
+		if s != nil {
+			close(t.ctrlReady)
+		}
+	}
 }
 
 // Start receiving control messages for the tunnel
 func (t *Tunnel) Start() {
 	// A thread for handling the established tcp connections
-	go t.handleIngressCtrlMessages()
+	t.spawn(t.handleIngressCtrlMessages)
+	t.spawn(t.handleKeepalive)
 
+	t.mutex.RLock()
+	k := t.cipher
+	rekey := t.options.RekeyInterval > 0 || t.options.RekeyBytes > 0
+	t.mutex.RUnlock()
+	if k != nil && rekey {
+		t.spawn(func() { t.handleRekey(k) })
+	}
 }
 
-// Stop will stop all associated goroutines for the tunnel
-// and disconnect any associated TCP connections
-func (t *Tunnel) Stop() {
+// ReplaceControlStream replaces the control stream of a started tunnel,
+// such as when its endpoint reconnects, and receives control messages
+// from it.
+func (t *Tunnel) ReplaceControlStream(s TunnelControlStream) {
+	t.ctrlMutex.Lock()
+	t.ctrlStream = s
+	t.ctrlMutex.Unlock()
+
+	t.spawn(t.handleIngressCtrlMessages)
+}
+
+// CloseListeners stops accepting new connections on the tunnel while
+// existing connections carry on.
+func (t *Tunnel) CloseListeners() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	// First, stop all the listeners
 	for _, ln := range t.listeners {
 		ln.Close()
 	}
+	t.listeners = nil
+}
+
+// Pause stops accepting new connections on the tunnel. If freeze is
+// true the existing connections stop relaying data as well, without
+// being closed.
+func (t *Tunnel) Pause(freeze bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.paused {
+		t.pausedListeners = len(t.listeners)
+		for _, ln := range t.listeners {
+			ln.Close()
+		}
+		t.listeners = nil
+	}
+	t.paused = true
+
+	if freeze {
+		for _, conn := range t.connections {
+			conn.Freeze()
+		}
+	}
+	t.frozen = t.frozen || freeze
+}
 
-	// Close all existing tcp connections
+// Unpause undoes Pause. The listeners of the tunnel are opened again
+// and frozen connections carry on.
+func (t *Tunnel) Unpause() error {
+	t.mutex.Lock()
+	if !t.paused {
+		t.mutex.Unlock()
+		return nil
+	}
+	listeners := t.pausedListeners
 	for _, conn := range t.connections {
-		conn.Close()
+		conn.Thaw()
+	}
+	t.paused = false
+	t.frozen = false
+	t.pausedListeners = 0
+	t.mutex.Unlock()
+
+	for i := 0; i < listeners; i++ {
+		if !t.AddListener("") {
+			return fmt.Errorf("failed to listen on %s:%d", t.listenIP, t.listenPort)
+		}
 	}
-	// Lastly, signal that the tunnel stream should be killed
-	close(t.Kill)
+	return nil
+}
+
+// IsPaused returns true if the tunnel is paused.
+func (t *Tunnel) IsPaused() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.paused
+}
+
+// Stop will stop all associated goroutines for the tunnel
+// and disconnect any associated TCP connections. It waits up to
+// TunnelStopTimeout for the goroutines to exit and returns false
+// if they did not. Stop may be called more than once.
+func (t *Tunnel) Stop() bool {
+	connections := t.shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		t.routines.Wait()
+		for _, conn := range connections {
+			conn.relays.Wait()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(TunnelStopTimeout):
+		Log.WithTunnel(t.id).Warnf("Tunnel did not stop within %s",
+			TunnelStopTimeout)
+		return false
+	}
+}
+
+// shutdown closes the listeners and connections of the tunnel and
+// cancels its context. It returns the connections that were closed.
+func (t *Tunnel) shutdown() []*Connection {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	connections := make([]*Connection, 0, len(t.connections))
+	for _, conn := range t.connections {
+		connections = append(connections, conn)
+	}
+
+	t.stopOnce.Do(func() {
+		t.stopped = true
+
+		// First, stop all the listeners
+		for _, ln := range t.listeners {
+			ln.Close()
+		}
+		t.listeners = nil
+
+		// Close all existing tcp connections
+		for _, conn := range connections {
+			conn.Close()
+		}
+
+		// Lastly, cancel the context of the tunnel so that its
+		// streams and pending dials are torn down
+		t.cancel()
+	})
+	return connections
 }
@@ -1,9 +1,14 @@
 package common
 
 import (
+	crand "crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
+	"fmt"
 	"math/rand"
 	"net"
+	"os/user"
+	"strings"
 	"time"
 )
 
@@ -30,6 +35,21 @@ func GenerateString(length int) string {
 	return string(b)
 }
 
+// StreamTokenSize is the number of random bytes in a stream token
+const StreamTokenSize = 16
+
+// GenerateStreamToken returns a random token that authenticates the
+// setup of a single byte stream.
+func GenerateStreamToken() string {
+	b := make([]byte, StreamTokenSize)
+	if _, err := crand.Read(b); err != nil {
+		// Fall back to the weaker generator rather than handing out
+		// an empty token
+		return GenerateString(StreamTokenSize * 2)
+	}
+	return hex.EncodeToString(b)
+}
+
 // Int32ToIP converts a uint32 to a net.IP
 func Int32ToIP(i uint32) net.IP {
 	ip := make(net.IP, 4)
@@ -45,3 +65,99 @@ func IpToInt32(ip net.IP) uint32 {
 	}
 	return binary.BigEndian.Uint32(ip)
 }
+
+// AddrToIPPort returns the IP address and port of a net.Addr.
+// Addresses that are not TCP addresses, such as the address of
+// a ProcessConn, return an unspecified IP and a port of 0.
+func AddrToIPPort(addr net.Addr) (net.IP, uint32) {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return net.IPv4zero, 0
+	}
+	return tcpAddr.IP, uint32(tcpAddr.Port)
+}
+
+// ParseNetwork parses a CIDR, such as "10.0.0.0/8", or a single IP
+// address, which is a network of just that address.
+func ParseNetwork(network string) (*net.IPNet, error) {
+	if !strings.Contains(network, "/") {
+		ip := net.ParseIP(network)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid address %q", network)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(network)
+	return ipNet, err
+}
+
+// ParseNetworks parses a comma separated list of networks in the
+// format of ParseNetwork.
+func ParseNetworks(networks string) ([]*net.IPNet, error) {
+	parsed := make([]*net.IPNet, 0)
+	for _, network := range strings.Split(networks, ",") {
+		if network = strings.TrimSpace(network); network == "" {
+			continue
+		}
+		ipNet, err := ParseNetwork(network)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, ipNet)
+	}
+	return parsed, nil
+}
+
+// JitterDuration returns d varied randomly by up to percent percent in
+// either direction.
+func JitterDuration(d time.Duration, percent int) time.Duration {
+	if percent <= 0 || d <= 0 {
+		return d
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	spread := int64(d) * int64(percent) / 100
+	if spread == 0 {
+		return d
+	}
+	return d - time.Duration(spread) + time.Duration(rand.Int63n(2*spread+1))
+}
+
+// CurrentUsername returns the name of the user the process runs as,
+// or an empty string if it cannot be determined.
+func CurrentUsername() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return ""
+}
+
+// LocalAddresses returns the addresses of all local network
+// interfaces that are up, excluding loopback addresses.
+func LocalAddresses() []string {
+	addresses := make([]string, 0)
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return addresses
+	}
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			addresses = append(addresses,
+				fmt.Sprintf("%s %s", iface.Name, addr.String()))
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,19 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJitterDuration(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		d := JitterDuration(time.Minute, 20)
+		if d < 48*time.Second || d > 72*time.Second {
+			t.Fatalf("JitterDuration: %s is outside of 20%% of 1m", d)
+		}
+	}
+
+	if d := JitterDuration(time.Minute, 0); d != time.Minute {
+		t.Errorf("JitterDuration: Got: %s Want: 1m0s", d)
+	}
+}
@@ -0,0 +1,128 @@
+package common
+
+const (
+	// ProtocolVersion is the version of the client/server protocol
+	// implemented by this build. It is exchanged during the
+	// configuration handshake. Version 2 authenticates the key
+	// exchange of encrypted tunnels with the server key and binds
+	// each payload to its connection, direction and position.
+	ProtocolVersion = 2
+
+	// MinProtocolVersion is the oldest protocol version a peer may
+	// speak and still be accepted. Older peers cannot complete the
+	// key exchange of version 2 and peers from before the handshake
+	// existed report version 0.
+	MinProtocolVersion = 2
+)
+
+// Capabilities are optional features that a peer may or may not
+// support. They are exchanged during the configuration handshake
+// and only features supported by both sides are used.
+const (
+	CapabilityCommandTunnel = "command-tunnel"
+	CapabilityKeepalive     = "keepalive"
+	CapabilityDeception     = "deception"
+	CapabilityStreamAuth    = "stream-auth"
+	CapabilityHeartbeat     = "heartbeat"
+	CapabilityBeacon        = "beacon"
+	CapabilityStreamResume  = "stream-resume"
+	CapabilityTunnelPause   = "tunnel-pause"
+	CapabilityFlowControl   = "flow-control"
+	CapabilityHalfClose     = "half-close"
+	CapabilityDialPolicy    = "dial-policy"
+	CapabilityCompression   = "gzip"
+	CapabilitySnappy        = "snappy"
+	CapabilityEncryption    = "encryption"
+	CapabilityRekey         = "rekey"
+	CapabilityCover         = "cover"
+	CapabilityRelay         = "relay"
+	CapabilityFailover      = "failover"
+	CapabilityPing          = "ping"
+	CapabilitySpeedTest     = "speedtest"
+	CapabilityScan          = "scan"
+	CapabilityFileTransfer  = "file-transfer"
+	CapabilityShell         = "shell"
+	CapabilityLogLevel      = "log-level"
+)
+
+// SupportedCapabilities returns all capabilities supported by this build.
+func SupportedCapabilities() []string {
+	return []string{
+		CapabilityCommandTunnel,
+		CapabilityKeepalive,
+		CapabilityDeception,
+		CapabilityStreamAuth,
+		CapabilityHeartbeat,
+		CapabilityBeacon,
+		CapabilityStreamResume,
+		CapabilityTunnelPause,
+		CapabilityFlowControl,
+		CapabilityHalfClose,
+		CapabilityDialPolicy,
+		CapabilityCompression,
+		CapabilitySnappy,
+		CapabilityEncryption,
+		CapabilityRekey,
+		CapabilityCover,
+		CapabilityRelay,
+		CapabilityFailover,
+		CapabilityPing,
+		CapabilitySpeedTest,
+		CapabilityScan,
+		CapabilityFileTransfer,
+		CapabilityShell,
+		CapabilityLogLevel,
+	}
+}
+
+// EndpointCapabilities returns the capabilities that an endpoint of
+// this build offers. Only builds with the shell build tag offer a
+// shell.
+func EndpointCapabilities() []string {
+	capabilities := make([]string, 0)
+	for _, capability := range SupportedCapabilities() {
+		if capability == CapabilityShell && !ShellSupported {
+			continue
+		}
+		capabilities = append(capabilities, capability)
+	}
+	return capabilities
+}
+
+// NegotiateCapabilities returns the capabilities supported by both
+// this build and the remote peer.
+func NegotiateCapabilities(remote []string) []string {
+	remoteSet := make(map[string]bool)
+	for _, capability := range remote {
+		remoteSet[capability] = true
+	}
+
+	negotiated := make([]string, 0)
+	for _, capability := range SupportedCapabilities() {
+		if remoteSet[capability] {
+			negotiated = append(negotiated, capability)
+		}
+	}
+	return negotiated
+}
+
+// RemoveCapability returns capabilities without capability.
+func RemoveCapability(capabilities []string, capability string) []string {
+	remaining := make([]string, 0, len(capabilities))
+	for _, c := range capabilities {
+		if c != capability {
+			remaining = append(remaining, c)
+		}
+	}
+	return remaining
+}
+
+// HasCapability returns true if capability is in capabilities.
+func HasCapability(capabilities []string, capability string) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	as "github.com/kai5263499/gtunnel/grpc/admin"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var (
+	serverAddress = flag.String("server", "127.0.0.1:443", "The address of the gServer client port")
+	adminAddress  = flag.String("admin", "127.0.0.1:1337", "The address of the gServer admin port")
+	metricsURL    = flag.String("metrics", "", "The url of the gServer metrics endpoint, such as http://127.0.0.1:9090/metrics")
+	token         = flag.String("token", "", "The token of a configured client")
+	endpoints     = flag.Int("endpoints", 100, "The number of simulated endpoints")
+	concurrency   = flag.Int("connections", 1000, "The number of concurrent connections through the tunnels")
+	payloadSize   = flag.Int("payload", 1024, "The number of bytes echoed per connection")
+	duration      = flag.Duration("duration", 30*time.Second, "How long connections are generated")
+	listenIP      = flag.String("listenip", "127.0.0.1", "The IP on which gServer listens for the simulated tunnels")
+	listenBase    = flag.Int("listenbase", 20000, "The first listen port of the simulated tunnels")
+	timeout       = flag.Duration("timeout", 10*time.Second, "The timeout of a single connection")
+)
+
+// simulatedEndpoint is a minimal gClient that answers every tunneled
+// connection by echoing the data back instead of dialing a destination.
+type simulatedEndpoint struct {
+	id         string
+	conn       *grpc.ClientConn
+	grpcClient cs.ClientServiceClient
+	ctx        context.Context
+}
+
+// stats collects the results of the load test.
+type stats struct {
+	endpointsUp     int64
+	endpointsFailed int64
+	connectionsOK   int64
+	connectionsFail int64
+	bytes           int64
+	latencies       []time.Duration
+	errors          map[string]int
+	mutex           sync.Mutex
+}
+
+func (s *stats) recordConnection(latency time.Duration, n int, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err != nil {
+		s.connectionsFail++
+		s.errors[classifyError(err)]++
+		return
+	}
+	s.connectionsOK++
+	s.bytes += int64(n)
+	s.latencies = append(s.latencies, latency)
+}
+
+func (s *stats) recordEndpointError(err error) {
+	atomic.AddInt64(&s.endpointsFailed, 1)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errors["endpoint: "+classifyError(err)]++
+}
+
+// classifyError groups errors by the resource that most likely ran out.
+func classifyError(err error) string {
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "too many open files"):
+		return "file descriptor limit reached (too many open files)"
+	case strings.Contains(message, "cannot assign requested address"):
+		return "ephemeral ports exhausted"
+	case strings.Contains(message, "connection refused"):
+		return "connection refused"
+	case strings.Contains(message, "connection reset"):
+		return "connection reset"
+	case strings.Contains(message, "timeout"), strings.Contains(message, "deadline"):
+		return "timeout (server overloaded or lock contention)"
+	case err == io.EOF || strings.Contains(message, "EOF"):
+		return "connection closed early"
+	}
+	return message
+}
+
+func newSimulatedEndpoint() (*simulatedEndpoint, error) {
+	e := new(simulatedEndpoint)
+	e.id = ksuid.New().String()
+	e.ctx = context.Background()
+
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+	}
+
+	var err error
+	e.conn, err = grpc.Dial(*serverAddress,
+		grpc.WithTransportCredentials(credentials.NewTLS(config)),
+		grpc.WithPerRPCCredentials(common.NewToken(*token+"-"+e.id)))
+	if err != nil {
+		return nil, err
+	}
+
+	e.grpcClient = cs.NewClientServiceClient(e.conn)
+
+	req := new(cs.GetConfigurationMessageRequest)
+	req.Hostname = "gloadtest"
+	req.ProtocolVersion = common.ProtocolVersion
+	req.Capabilities = common.SupportedCapabilities()
+
+	if _, err := e.grpcClient.GetConfigurationMessage(e.ctx, req); err != nil {
+		e.conn.Close()
+		return nil, err
+	}
+
+	ctrlStream, err := e.grpcClient.CreateEndpointControlStream(e.ctx,
+		new(cs.EndpointControlMessage))
+	if err != nil {
+		e.conn.Close()
+		return nil, err
+	}
+
+	go e.handleControlMessages(ctrlStream)
+	return e, nil
+}
+
+// handleControlMessages opens a tunnel control stream for every tunnel
+// added to the endpoint.
+func (e *simulatedEndpoint) handleControlMessages(
+	stream cs.ClientService_CreateEndpointControlStreamClient) {
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return
+		}
+
+		if message.Operation != common.EndpointCtrlAddTunnel {
+			continue
+		}
+
+		tunnelStream, err := e.grpcClient.CreateTunnelControlStream(e.ctx)
+		if err != nil {
+			continue
+		}
+
+		initial := new(cs.TunnelControlMessage)
+		initial.TunnelId = message.TunnelId
+		tunnelStream.Send(initial)
+
+		go e.handleTunnel(tunnelStream)
+	}
+}
+
+// handleTunnel answers every new connection of a tunnel with an
+// echoing byte stream.
+func (e *simulatedEndpoint) handleTunnel(stream cs.ClientService_CreateTunnelControlStreamClient) {
+	var sendMutex sync.Mutex
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return
+		}
+
+		if message.Operation != common.TunnelCtrlConnect {
+			continue
+		}
+
+		go func(message *cs.TunnelControlMessage) {
+			byteStream, err := e.grpcClient.CreateConnectionStream(e.ctx)
+			if err != nil {
+				message.ErrorStatus = 1
+			} else {
+				initial := new(cs.BytesMessage)
+				initial.TunnelId = message.TunnelId
+				initial.ConnectionId = message.ConnectionId
+				initial.StreamToken = message.StreamToken
+				byteStream.Send(initial)
+			}
+
+			message.Operation = common.TunnelCtrlAck
+			sendMutex.Lock()
+			stream.Send(message)
+			sendMutex.Unlock()
+
+			if byteStream != nil {
+				echo(byteStream)
+			}
+		}(message)
+	}
+}
+
+// echo sends every received message back until the remote side closes.
+func echo(stream cs.ClientService_CreateConnectionStreamClient) {
+	defer stream.CloseSend()
+
+	for {
+		message, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		reply := new(cs.BytesMessage)
+		reply.Content = message.Content
+		if err := stream.Send(reply); err != nil || len(message.Content) == 0 {
+			return
+		}
+	}
+}
+
+// runConnection opens a single connection through a tunnel and checks
+// that the payload is echoed back.
+func runConnection(address string, payload []byte) (int, error) {
+	conn, err := net.DialTimeout("tcp", address, *timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(*timeout))
+
+	if _, err := conn.Write(payload); err != nil {
+		return 0, err
+	}
+
+	received := make([]byte, len(payload))
+	if _, err := io.ReadFull(conn, received); err != nil {
+		return 0, err
+	}
+
+	if !bytes.Equal(payload, received) {
+		return 0, fmt.Errorf("payload corrupted")
+	}
+	return len(payload), nil
+}
+
+// percentile returns the p-th percentile of sorted latencies.
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	return latencies[int(float64(len(latencies)-1)*p)]
+}
+
+// printServerMetrics prints the gtunnel metrics of the server.
+func printServerMetrics(url string) {
+	resp, err := http.Get(url)
+	if err != nil {
+		fmt.Printf("  failed to fetch: %s\n", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "gtunnel_") &&
+			!strings.HasPrefix(line, "gtunnel_tunnel_connections") {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if *token == "" {
+		fmt.Println("[!] token not provided")
+		os.Exit(1)
+	}
+
+	conn, err := grpc.Dial(*adminAddress, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("[!] Failed to connect to admin server: %s", err)
+	}
+	adminClient := as.NewAdminServiceClient(conn)
+
+	results := new(stats)
+	results.errors = make(map[string]int)
+
+	// Bring up the simulated endpoints, each with one tunnel
+	start := time.Now()
+	addresses := make([]string, 0, *endpoints)
+	simulated := make([]*simulatedEndpoint, 0, *endpoints)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	for i := 0; i < *endpoints; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			endpoint, err := newSimulatedEndpoint()
+			if err != nil {
+				results.recordEndpointError(err)
+				return
+			}
+
+			tunnel := new(as.Tunnel)
+			tunnel.Id = common.GenerateString(common.TunnelIDSize)
+			tunnel.Direction = common.TunnelDirectionForward
+			tunnel.ListenIp = common.IpToInt32(net.ParseIP(*listenIP))
+			tunnel.ListenPort = uint32(*listenBase + i)
+			tunnel.DestinationIp = common.IpToInt32(net.ParseIP("127.0.0.1"))
+			tunnel.DestinationPort = 7
+
+			req := new(as.TunnelAddRequest)
+			req.ClientId = endpoint.id
+			req.Tunnel = tunnel
+
+			if _, err := adminClient.TunnelAdd(context.Background(), req); err != nil {
+				results.recordEndpointError(err)
+				return
+			}
+
+			atomic.AddInt64(&results.endpointsUp, 1)
+			mutex.Lock()
+			addresses = append(addresses, fmt.Sprintf("%s:%d", *listenIP, *listenBase+i))
+			simulated = append(simulated, endpoint)
+			mutex.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	setupTime := time.Since(start)
+
+	if len(addresses) == 0 {
+		log.Fatalf("[!] No endpoints could be brought up: %v", results.errors)
+	}
+
+	// Generate connections until the duration has passed
+	payload := make([]byte, *payloadSize)
+	rand.Read(payload)
+
+	deadline := time.Now().Add(*duration)
+	start = time.Now()
+	for i := 0; i < *concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				address := addresses[rand.Intn(len(addresses))]
+				connStart := time.Now()
+				n, err := runConnection(address, payload)
+				results.recordConnection(time.Since(connStart), n, err)
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(results.latencies, func(i, j int) bool {
+		return results.latencies[i] < results.latencies[j]
+	})
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	fmt.Printf("Endpoints: %d up, %d failed (setup took %s)\n",
+		results.endpointsUp, results.endpointsFailed, setupTime)
+	fmt.Printf("Connections: %d ok, %d failed (%.1f/s)\n",
+		results.connectionsOK, results.connectionsFail,
+		float64(results.connectionsOK)/elapsed.Seconds())
+	fmt.Printf("Throughput: %.1f KiB/s\n",
+		float64(results.bytes)/1024/elapsed.Seconds())
+	fmt.Printf("Latency: p50 %s, p95 %s, p99 %s\n",
+		percentile(results.latencies, 0.50),
+		percentile(results.latencies, 0.95),
+		percentile(results.latencies, 0.99))
+	fmt.Printf("Load generator: %d goroutines, %d MiB heap, file limit %s\n",
+		runtime.NumGoroutine(), memStats.HeapAlloc/1024/1024, fileLimit())
+
+	if len(results.errors) > 0 {
+		fmt.Println("Errors:")
+		for message, count := range results.errors {
+			fmt.Printf("  %6d %s\n", count, message)
+		}
+	}
+
+	if *metricsURL != "" {
+		fmt.Println("Server metrics:")
+		printServerMetrics(*metricsURL)
+	}
+
+	for _, endpoint := range simulated {
+		endpoint.conn.Close()
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// fileLimit returns the open file limit of the load generator.
+func fileLimit() string {
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d/%d", limit.Cur, limit.Max)
+}
@@ -0,0 +1,6 @@
+package main
+
+// fileLimit returns the open file limit of the load generator.
+func fileLimit() string {
+	return "unknown"
+}
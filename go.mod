@@ -1,20 +1,37 @@
 module github.com/kai5263499/gtunnel
 
-go 1.14
+go 1.24
 
 require (
+	github.com/Azure/go-ntlmssp v0.0.0-20211209120228-48547f28849e
 	github.com/abiosoft/ishell v2.0.0+incompatible
 	github.com/abiosoft/readline v0.0.0-20180607040430-155bce2042db // indirect
+	github.com/alexbrainman/sspi v0.0.0-20210105120005-909beea2cc74
 	github.com/fangdingjun/socks-go v0.0.0-20200720061557-213a2e52db0d
 	github.com/fatih/color v1.10.0 // indirect
 	github.com/flynn-archive/go-shlex v0.0.0-20150515145356-3f9db97f8568 // indirect
 	github.com/go-redis/redis/v8 v8.4.10
-	github.com/golang/protobuf v1.4.3
+	github.com/golang/protobuf v1.5.4
+	github.com/golang/snappy v0.0.4
+	github.com/mattn/go-ieproxy v0.0.1
 	github.com/olekukonko/tablewriter v0.0.4
+	github.com/quic-go/quic-go v0.54.0
+	github.com/refraction-networking/utls v1.8.2
 	github.com/segmentio/ksuid v1.0.3
-	golang.org/x/net v0.0.0-20201224014010-6772e930b67b // indirect
-	golang.org/x/sys v0.0.0-20210113181707-4bcb84eeeb78 // indirect
-	golang.org/x/text v0.3.5 // indirect
+	golang.org/x/crypto v0.36.0
+	golang.org/x/net v0.38.0
+	golang.org/x/sys v0.31.0
 	google.golang.org/genproto v0.0.0-20210114201628-6edceaf6022f // indirect
 	google.golang.org/grpc v1.35.0
+	google.golang.org/protobuf v1.33.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.6 // indirect
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/mattn/go-runewidth v0.0.7 // indirect
+	go.opentelemetry.io/otel v0.16.0 // indirect
+	golang.org/x/text v0.23.0 // indirect
 )
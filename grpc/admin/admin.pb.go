@@ -24,6 +24,99 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Alias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DestinationIp uint32 `protobuf:"varint,1,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
+	AliasIp       uint32 `protobuf:"varint,2,opt,name=alias_ip,json=aliasIp,proto3" json:"alias_ip,omitempty"`
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Alias) GetDestinationIp() uint32 {
+	if x != nil {
+		return x.DestinationIp
+	}
+	return 0
+}
+
+func (x *Alias) GetAliasIp() uint32 {
+	if x != nil {
+		return x.AliasIp
+	}
+	return 0
+}
+
+type AliasListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AliasListRequest) Reset() {
+	*x = AliasListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AliasListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AliasListRequest) ProtoMessage() {}
+
+func (x *AliasListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AliasListRequest.ProtoReflect.Descriptor instead.
+func (*AliasListRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{1}
+}
+
 type ByteStream struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -35,7 +128,7 @@ type ByteStream struct {
 func (x *ByteStream) Reset() {
 	*x = ByteStream{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[0]
+		mi := &file_admin_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -48,7 +141,7 @@ func (x *ByteStream) String() string {
 func (*ByteStream) ProtoMessage() {}
 
 func (x *ByteStream) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[0]
+	mi := &file_admin_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -61,7 +154,7 @@ func (x *ByteStream) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ByteStream.ProtoReflect.Descriptor instead.
 func (*ByteStream) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{0}
+	return file_admin_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ByteStream) GetData() []byte {
@@ -76,18 +169,31 @@ type Client struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ClientId      string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Status        uint32 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
-	RemoteAddress string `protobuf:"bytes,4,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
-	ConnectDate   string `protobuf:"bytes,5,opt,name=connect_date,json=connectDate,proto3" json:"connect_date,omitempty"`
-	Hostname      string `protobuf:"bytes,6,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Name            string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClientId        string   `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Status          uint32   `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	RemoteAddress   string   `protobuf:"bytes,4,opt,name=remote_address,json=remoteAddress,proto3" json:"remote_address,omitempty"`
+	ConnectDate     string   `protobuf:"bytes,5,opt,name=connect_date,json=connectDate,proto3" json:"connect_date,omitempty"`
+	Hostname        string   `protobuf:"bytes,6,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	ProtocolVersion uint32   `protobuf:"varint,7,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	Capabilities    []string `protobuf:"bytes,8,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Country         string   `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	Asn             uint32   `protobuf:"varint,10,opt,name=asn,proto3" json:"asn,omitempty"`
+	AsOrganization  string   `protobuf:"bytes,11,opt,name=as_organization,json=asOrganization,proto3" json:"as_organization,omitempty"`
+	Os              string   `protobuf:"bytes,12,opt,name=os,proto3" json:"os,omitempty"`
+	Arch            string   `protobuf:"bytes,13,opt,name=arch,proto3" json:"arch,omitempty"`
+	Username        string   `protobuf:"bytes,14,opt,name=username,proto3" json:"username,omitempty"`
+	Addresses       []string `protobuf:"bytes,15,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	LastSeen        int64    `protobuf:"varint,16,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	State           string   `protobuf:"bytes,17,opt,name=state,proto3" json:"state,omitempty"`
+	Alias           string   `protobuf:"bytes,18,opt,name=alias,proto3" json:"alias,omitempty"`
+	Tags            []string `protobuf:"bytes,19,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
 func (x *Client) Reset() {
 	*x = Client{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[1]
+		mi := &file_admin_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +206,7 @@ func (x *Client) String() string {
 func (*Client) ProtoMessage() {}
 
 func (x *Client) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[1]
+	mi := &file_admin_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,7 +219,7 @@ func (x *Client) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Client.ProtoReflect.Descriptor instead.
 func (*Client) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{1}
+	return file_admin_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *Client) GetName() string {
@@ -158,6 +264,97 @@ func (x *Client) GetHostname() string {
 	return ""
 }
 
+func (x *Client) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *Client) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Client) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Client) GetAsn() uint32 {
+	if x != nil {
+		return x.Asn
+	}
+	return 0
+}
+
+func (x *Client) GetAsOrganization() string {
+	if x != nil {
+		return x.AsOrganization
+	}
+	return ""
+}
+
+func (x *Client) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *Client) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *Client) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Client) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *Client) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *Client) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Client) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Client) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type ClientRegisterRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -176,7 +373,7 @@ type ClientRegisterRequest struct {
 func (x *ClientRegisterRequest) Reset() {
 	*x = ClientRegisterRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[2]
+		mi := &file_admin_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -189,7 +386,7 @@ func (x *ClientRegisterRequest) String() string {
 func (*ClientRegisterRequest) ProtoMessage() {}
 
 func (x *ClientRegisterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[2]
+	mi := &file_admin_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -202,7 +399,7 @@ func (x *ClientRegisterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientRegisterRequest.ProtoReflect.Descriptor instead.
 func (*ClientRegisterRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{2}
+	return file_admin_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ClientRegisterRequest) GetClientId() string {
@@ -272,7 +469,7 @@ type ClientRegisterResponse struct {
 func (x *ClientRegisterResponse) Reset() {
 	*x = ClientRegisterResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[3]
+		mi := &file_admin_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -285,7 +482,7 @@ func (x *ClientRegisterResponse) String() string {
 func (*ClientRegisterResponse) ProtoMessage() {}
 
 func (x *ClientRegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[3]
+	mi := &file_admin_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -298,7 +495,7 @@ func (x *ClientRegisterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientRegisterResponse.ProtoReflect.Descriptor instead.
 func (*ClientRegisterResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{3}
+	return file_admin_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ClientRegisterResponse) GetError() string {
@@ -314,12 +511,14 @@ type ClientDisconnectRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Ban      bool   `protobuf:"varint,2,opt,name=ban,proto3" json:"ban,omitempty"`
+	BanToken bool   `protobuf:"varint,3,opt,name=ban_token,json=banToken,proto3" json:"ban_token,omitempty"`
 }
 
 func (x *ClientDisconnectRequest) Reset() {
 	*x = ClientDisconnectRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[4]
+		mi := &file_admin_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -332,7 +531,7 @@ func (x *ClientDisconnectRequest) String() string {
 func (*ClientDisconnectRequest) ProtoMessage() {}
 
 func (x *ClientDisconnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[4]
+	mi := &file_admin_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -345,7 +544,7 @@ func (x *ClientDisconnectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientDisconnectRequest.ProtoReflect.Descriptor instead.
 func (*ClientDisconnectRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{4}
+	return file_admin_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ClientDisconnectRequest) GetClientId() string {
@@ -355,6 +554,20 @@ func (x *ClientDisconnectRequest) GetClientId() string {
 	return ""
 }
 
+func (x *ClientDisconnectRequest) GetBan() bool {
+	if x != nil {
+		return x.Ban
+	}
+	return false
+}
+
+func (x *ClientDisconnectRequest) GetBanToken() bool {
+	if x != nil {
+		return x.BanToken
+	}
+	return false
+}
+
 type ClientDisconnectResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -364,7 +577,7 @@ type ClientDisconnectResponse struct {
 func (x *ClientDisconnectResponse) Reset() {
 	*x = ClientDisconnectResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[5]
+		mi := &file_admin_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -377,7 +590,7 @@ func (x *ClientDisconnectResponse) String() string {
 func (*ClientDisconnectResponse) ProtoMessage() {}
 
 func (x *ClientDisconnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[5]
+	mi := &file_admin_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -390,32 +603,40 @@ func (x *ClientDisconnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientDisconnectResponse.ProtoReflect.Descriptor instead.
 func (*ClientDisconnectResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{5}
+	return file_admin_proto_rawDescGZIP(), []int{7}
 }
 
-type ClientListRequest struct {
+type ClientConfigureRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ClientId          string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Mtu               uint32 `protobuf:"varint,2,opt,name=mtu,proto3" json:"mtu,omitempty"`
+	KeepaliveInterval uint32 `protobuf:"varint,3,opt,name=keepalive_interval,json=keepaliveInterval,proto3" json:"keepalive_interval,omitempty"`
+	// Dial policy sent to the client, which can only tighten the
+	// policy that is embedded in it
+	DialAllow string `protobuf:"bytes,4,opt,name=dial_allow,json=dialAllow,proto3" json:"dial_allow,omitempty"`
+	DialDeny  string `protobuf:"bytes,5,opt,name=dial_deny,json=dialDeny,proto3" json:"dial_deny,omitempty"`
 }
 
-func (x *ClientListRequest) Reset() {
-	*x = ClientListRequest{}
+func (x *ClientConfigureRequest) Reset() {
+	*x = ClientConfigureRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[6]
+		mi := &file_admin_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientListRequest) String() string {
+func (x *ClientConfigureRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientListRequest) ProtoMessage() {}
+func (*ClientConfigureRequest) ProtoMessage() {}
 
-func (x *ClientListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[6]
+func (x *ClientConfigureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,108 +647,69 @@ func (x *ClientListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientListRequest.ProtoReflect.Descriptor instead.
-func (*ClientListRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{6}
-}
-
-type Connection struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	SourceIp        uint32 `protobuf:"varint,1,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
-	SourcePort      uint32 `protobuf:"varint,2,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
-	DestinationIp   uint32 `protobuf:"varint,3,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
-	DestinationPort uint32 `protobuf:"varint,4,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
-}
-
-func (x *Connection) Reset() {
-	*x = Connection{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *Connection) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ClientConfigureRequest.ProtoReflect.Descriptor instead.
+func (*ClientConfigureRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{8}
 }
 
-func (*Connection) ProtoMessage() {}
-
-func (x *Connection) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ClientConfigureRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
-func (*Connection) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{7}
+	return ""
 }
 
-func (x *Connection) GetSourceIp() uint32 {
+func (x *ClientConfigureRequest) GetMtu() uint32 {
 	if x != nil {
-		return x.SourceIp
+		return x.Mtu
 	}
 	return 0
 }
 
-func (x *Connection) GetSourcePort() uint32 {
+func (x *ClientConfigureRequest) GetKeepaliveInterval() uint32 {
 	if x != nil {
-		return x.SourcePort
+		return x.KeepaliveInterval
 	}
 	return 0
 }
 
-func (x *Connection) GetDestinationIp() uint32 {
+func (x *ClientConfigureRequest) GetDialAllow() string {
 	if x != nil {
-		return x.DestinationIp
+		return x.DialAllow
 	}
-	return 0
+	return ""
 }
 
-func (x *Connection) GetDestinationPort() uint32 {
+func (x *ClientConfigureRequest) GetDialDeny() string {
 	if x != nil {
-		return x.DestinationPort
+		return x.DialDeny
 	}
-	return 0
+	return ""
 }
 
-type ConnectionListRequest struct {
+type ClientConfigureResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
 }
 
-func (x *ConnectionListRequest) Reset() {
-	*x = ConnectionListRequest{}
+func (x *ClientConfigureResponse) Reset() {
+	*x = ClientConfigureResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[8]
+		mi := &file_admin_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConnectionListRequest) String() string {
+func (x *ClientConfigureResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionListRequest) ProtoMessage() {}
+func (*ClientConfigureResponse) ProtoMessage() {}
 
-func (x *ConnectionListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[8]
+func (x *ClientConfigureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -538,51 +720,36 @@ func (x *ConnectionListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionListRequest.ProtoReflect.Descriptor instead.
-func (*ConnectionListRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *ConnectionListRequest) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
-}
-
-func (x *ConnectionListRequest) GetTunnelId() string {
-	if x != nil {
-		return x.TunnelId
-	}
-	return ""
+// Deprecated: Use ClientConfigureResponse.ProtoReflect.Descriptor instead.
+func (*ClientConfigureResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{9}
 }
 
-type SocksStartRequest struct {
+type ClientUnbanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientId  string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	SocksPort uint32 `protobuf:"varint,2,opt,name=socks_port,json=socksPort,proto3" json:"socks_port,omitempty"`
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 }
 
-func (x *SocksStartRequest) Reset() {
-	*x = SocksStartRequest{}
+func (x *ClientUnbanRequest) Reset() {
+	*x = ClientUnbanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[9]
+		mi := &file_admin_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SocksStartRequest) String() string {
+func (x *ClientUnbanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SocksStartRequest) ProtoMessage() {}
+func (*ClientUnbanRequest) ProtoMessage() {}
 
-func (x *SocksStartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[9]
+func (x *ClientUnbanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,73 +760,26 @@ func (x *SocksStartRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SocksStartRequest.ProtoReflect.Descriptor instead.
-func (*SocksStartRequest) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ClientUnbanRequest.ProtoReflect.Descriptor instead.
+func (*ClientUnbanRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SocksStartRequest) GetClientId() string {
+func (x *ClientUnbanRequest) GetClientId() string {
 	if x != nil {
 		return x.ClientId
 	}
 	return ""
 }
 
-func (x *SocksStartRequest) GetSocksPort() uint32 {
-	if x != nil {
-		return x.SocksPort
-	}
-	return 0
-}
-
-type SocksStartResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-}
-
-func (x *SocksStartResponse) Reset() {
-	*x = SocksStartResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_admin_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *SocksStartResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*SocksStartResponse) ProtoMessage() {}
-
-func (x *SocksStartResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_admin_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SocksStartResponse.ProtoReflect.Descriptor instead.
-func (*SocksStartResponse) Descriptor() ([]byte, []int) {
-	return file_admin_proto_rawDescGZIP(), []int{10}
-}
-
-type SocksStopRequest struct {
+type ClientUnbanResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
 }
 
-func (x *SocksStopRequest) Reset() {
-	*x = SocksStopRequest{}
+func (x *ClientUnbanResponse) Reset() {
+	*x = ClientUnbanResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,13 +787,13 @@ func (x *SocksStopRequest) Reset() {
 	}
 }
 
-func (x *SocksStopRequest) String() string {
+func (x *ClientUnbanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SocksStopRequest) ProtoMessage() {}
+func (*ClientUnbanResponse) ProtoMessage() {}
 
-func (x *SocksStopRequest) ProtoReflect() protoreflect.Message {
+func (x *ClientUnbanResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -685,26 +805,19 @@ func (x *SocksStopRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SocksStopRequest.ProtoReflect.Descriptor instead.
-func (*SocksStopRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientUnbanResponse.ProtoReflect.Descriptor instead.
+func (*ClientUnbanResponse) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SocksStopRequest) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
-}
-
-type SocksStopResponse struct {
+type BanListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *SocksStopResponse) Reset() {
-	*x = SocksStopResponse{}
+func (x *BanListRequest) Reset() {
+	*x = BanListRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -712,13 +825,13 @@ func (x *SocksStopResponse) Reset() {
 	}
 }
 
-func (x *SocksStopResponse) String() string {
+func (x *BanListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SocksStopResponse) ProtoMessage() {}
+func (*BanListRequest) ProtoMessage() {}
 
-func (x *SocksStopResponse) ProtoReflect() protoreflect.Message {
+func (x *BanListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -730,26 +843,23 @@ func (x *SocksStopResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SocksStopResponse.ProtoReflect.Descriptor instead.
-func (*SocksStopResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BanListRequest.ProtoReflect.Descriptor instead.
+func (*BanListRequest) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{12}
 }
 
-type Tunnel struct {
+type Ban struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Direction       uint32 `protobuf:"varint,2,opt,name=direction,proto3" json:"direction,omitempty"`
-	ListenIp        uint32 `protobuf:"varint,3,opt,name=listen_ip,json=listenIp,proto3" json:"listen_ip,omitempty"`
-	ListenPort      uint32 `protobuf:"varint,4,opt,name=listen_port,json=listenPort,proto3" json:"listen_port,omitempty"`
-	DestinationIp   uint32 `protobuf:"varint,5,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
-	DestinationPort uint32 `protobuf:"varint,6,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	ClientId    string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TokenBanned bool   `protobuf:"varint,2,opt,name=token_banned,json=tokenBanned,proto3" json:"token_banned,omitempty"`
+	Date        int64  `protobuf:"varint,3,opt,name=date,proto3" json:"date,omitempty"`
 }
 
-func (x *Tunnel) Reset() {
-	*x = Tunnel{}
+func (x *Ban) Reset() {
+	*x = Ban{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -757,13 +867,13 @@ func (x *Tunnel) Reset() {
 	}
 }
 
-func (x *Tunnel) String() string {
+func (x *Ban) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tunnel) ProtoMessage() {}
+func (*Ban) ProtoMessage() {}
 
-func (x *Tunnel) ProtoReflect() protoreflect.Message {
+func (x *Ban) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,64 +885,43 @@ func (x *Tunnel) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tunnel.ProtoReflect.Descriptor instead.
-func (*Tunnel) Descriptor() ([]byte, []int) {
+// Deprecated: Use Ban.ProtoReflect.Descriptor instead.
+func (*Ban) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Tunnel) GetId() string {
+func (x *Ban) GetClientId() string {
 	if x != nil {
-		return x.Id
+		return x.ClientId
 	}
 	return ""
 }
 
-func (x *Tunnel) GetDirection() uint32 {
-	if x != nil {
-		return x.Direction
-	}
-	return 0
-}
-
-func (x *Tunnel) GetListenIp() uint32 {
-	if x != nil {
-		return x.ListenIp
-	}
-	return 0
-}
-
-func (x *Tunnel) GetListenPort() uint32 {
-	if x != nil {
-		return x.ListenPort
-	}
-	return 0
-}
-
-func (x *Tunnel) GetDestinationIp() uint32 {
+func (x *Ban) GetTokenBanned() bool {
 	if x != nil {
-		return x.DestinationIp
+		return x.TokenBanned
 	}
-	return 0
+	return false
 }
 
-func (x *Tunnel) GetDestinationPort() uint32 {
+func (x *Ban) GetDate() int64 {
 	if x != nil {
-		return x.DestinationPort
+		return x.Date
 	}
 	return 0
 }
 
-type TunnelAddRequest struct {
+type ClientRenameRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientId string  `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	Tunnel   *Tunnel `protobuf:"bytes,2,opt,name=tunnel,proto3" json:"tunnel,omitempty"`
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Alias    string `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
 }
 
-func (x *TunnelAddRequest) Reset() {
-	*x = TunnelAddRequest{}
+func (x *ClientRenameRequest) Reset() {
+	*x = ClientRenameRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -840,13 +929,13 @@ func (x *TunnelAddRequest) Reset() {
 	}
 }
 
-func (x *TunnelAddRequest) String() string {
+func (x *ClientRenameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelAddRequest) ProtoMessage() {}
+func (*ClientRenameRequest) ProtoMessage() {}
 
-func (x *TunnelAddRequest) ProtoReflect() protoreflect.Message {
+func (x *ClientRenameRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -858,33 +947,33 @@ func (x *TunnelAddRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelAddRequest.ProtoReflect.Descriptor instead.
-func (*TunnelAddRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientRenameRequest.ProtoReflect.Descriptor instead.
+func (*ClientRenameRequest) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *TunnelAddRequest) GetClientId() string {
+func (x *ClientRenameRequest) GetClientId() string {
 	if x != nil {
 		return x.ClientId
 	}
 	return ""
 }
 
-func (x *TunnelAddRequest) GetTunnel() *Tunnel {
+func (x *ClientRenameRequest) GetAlias() string {
 	if x != nil {
-		return x.Tunnel
+		return x.Alias
 	}
-	return nil
+	return ""
 }
 
-type TunnelAddResponse struct {
+type ClientRenameResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *TunnelAddResponse) Reset() {
-	*x = TunnelAddResponse{}
+func (x *ClientRenameResponse) Reset() {
+	*x = ClientRenameResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -892,13 +981,13 @@ func (x *TunnelAddResponse) Reset() {
 	}
 }
 
-func (x *TunnelAddResponse) String() string {
+func (x *ClientRenameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelAddResponse) ProtoMessage() {}
+func (*ClientRenameResponse) ProtoMessage() {}
 
-func (x *TunnelAddResponse) ProtoReflect() protoreflect.Message {
+func (x *ClientRenameResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -910,22 +999,23 @@ func (x *TunnelAddResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelAddResponse.ProtoReflect.Descriptor instead.
-func (*TunnelAddResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientRenameResponse.ProtoReflect.Descriptor instead.
+func (*ClientRenameResponse) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{15}
 }
 
-type TunnelDeleteRequest struct {
+type ClientTagRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
+	ClientId string   `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Add      []string `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Remove   []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
 }
 
-func (x *TunnelDeleteRequest) Reset() {
-	*x = TunnelDeleteRequest{}
+func (x *ClientTagRequest) Reset() {
+	*x = ClientTagRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,13 +1023,13 @@ func (x *TunnelDeleteRequest) Reset() {
 	}
 }
 
-func (x *TunnelDeleteRequest) String() string {
+func (x *ClientTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelDeleteRequest) ProtoMessage() {}
+func (*ClientTagRequest) ProtoMessage() {}
 
-func (x *TunnelDeleteRequest) ProtoReflect() protoreflect.Message {
+func (x *ClientTagRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -951,33 +1041,42 @@ func (x *TunnelDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelDeleteRequest.ProtoReflect.Descriptor instead.
-func (*TunnelDeleteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientTagRequest.ProtoReflect.Descriptor instead.
+func (*ClientTagRequest) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *TunnelDeleteRequest) GetClientId() string {
+func (x *ClientTagRequest) GetClientId() string {
 	if x != nil {
 		return x.ClientId
 	}
 	return ""
 }
 
-func (x *TunnelDeleteRequest) GetTunnelId() string {
+func (x *ClientTagRequest) GetAdd() []string {
 	if x != nil {
-		return x.TunnelId
+		return x.Add
 	}
-	return ""
+	return nil
 }
 
-type TunnelDeleteResponse struct {
+func (x *ClientTagRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type ClientTagResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
 }
 
-func (x *TunnelDeleteResponse) Reset() {
-	*x = TunnelDeleteResponse{}
+func (x *ClientTagResponse) Reset() {
+	*x = ClientTagResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -985,13 +1084,13 @@ func (x *TunnelDeleteResponse) Reset() {
 	}
 }
 
-func (x *TunnelDeleteResponse) String() string {
+func (x *ClientTagResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelDeleteResponse) ProtoMessage() {}
+func (*ClientTagResponse) ProtoMessage() {}
 
-func (x *TunnelDeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *ClientTagResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1003,21 +1102,33 @@ func (x *TunnelDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelDeleteResponse.ProtoReflect.Descriptor instead.
-func (*TunnelDeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientTagResponse.ProtoReflect.Descriptor instead.
+func (*ClientTagResponse) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{17}
 }
 
-type TunnelListRequest struct {
+func (x *ClientTagResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ClientPingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Number of pings. 4 if 0
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Also measure the round-trip time over a byte stream that the
+	// gClient echoes
+	Echo bool `protobuf:"varint,3,opt,name=echo,proto3" json:"echo,omitempty"`
 }
 
-func (x *TunnelListRequest) Reset() {
-	*x = TunnelListRequest{}
+func (x *ClientPingRequest) Reset() {
+	*x = ClientPingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_admin_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1025,13 +1136,13 @@ func (x *TunnelListRequest) Reset() {
 	}
 }
 
-func (x *TunnelListRequest) String() string {
+func (x *ClientPingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TunnelListRequest) ProtoMessage() {}
+func (*ClientPingRequest) ProtoMessage() {}
 
-func (x *TunnelListRequest) ProtoReflect() protoreflect.Message {
+func (x *ClientPingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_admin_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1043,757 +1154,4248 @@ func (x *TunnelListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TunnelListRequest.ProtoReflect.Descriptor instead.
-func (*TunnelListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ClientPingRequest.ProtoReflect.Descriptor instead.
+func (*ClientPingRequest) Descriptor() ([]byte, []int) {
 	return file_admin_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *TunnelListRequest) GetClientId() string {
+func (x *ClientPingRequest) GetClientId() string {
 	if x != nil {
 		return x.ClientId
 	}
 	return ""
 }
 
-var File_admin_proto protoreflect.FileDescriptor
+func (x *ClientPingRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
 
-var file_admin_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x22, 0x20, 0x0a, 0x0a, 0x42, 0x79, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xb7, 0x01, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65,
-	0x22, 0xea, 0x01, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x19, 0x0a, 0x08,
-	0x62, 0x69, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x62, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x70,
-	0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x2e, 0x0a,
-	0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x36, 0x0a,
-	0x17, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x1a, 0x0a, 0x18, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x13, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9c, 0x01, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
-	0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50,
-	0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x51, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74,
-	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x11, 0x53, 0x6f, 0x63, 0x6b,
-	0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
-	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f,
-	0x63, 0x6b, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09,
-	0x73, 0x6f, 0x63, 0x6b, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x6f, 0x63,
-	0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x2f, 0x0a, 0x10, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64,
-	0x22, 0x13, 0x0a, 0x11, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x06, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
-	0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x6c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e,
-	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x56,
-	0x0a, 0x10, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x25, 0x0a, 0x06, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x06,
-	0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x13, 0x0a, 0x11, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
-	0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4f, 0x0a, 0x13, 0x54,
-	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x1b, 0x0a, 0x09, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14,
-	0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x30, 0x0a, 0x11, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x32, 0x87, 0x05, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x10, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1e, 0x2e, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x39, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e,
-	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x64, 0x6d,
-	0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x43, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12,
-	0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53,
-	0x74, 0x6f, 0x70, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b,
-	0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61,
-	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x75, 0x6e, 0x6e,
-	0x65, 0x6c, 0x41, 0x64, 0x64, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75,
-	0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
-	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x54, 0x75,
-	0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d,
-	0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54,
-	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x4c,
-	0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e,
-	0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e,
-	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x00, 0x30, 0x01,
-	0x42, 0x18, 0x0a, 0x07, 0x67, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x07, 0x47, 0x54, 0x75,
-	0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x01, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+func (x *ClientPingRequest) GetEcho() bool {
+	if x != nil {
+		return x.Echo
+	}
+	return false
 }
 
-var (
-	file_admin_proto_rawDescOnce sync.Once
-	file_admin_proto_rawDescData = file_admin_proto_rawDesc
-)
+// Round-trip times in microseconds
+type ClientPingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func file_admin_proto_rawDescGZIP() []byte {
-	file_admin_proto_rawDescOnce.Do(func() {
-		file_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_admin_proto_rawDescData)
-	})
-	return file_admin_proto_rawDescData
+	ControlRtts []int64 `protobuf:"varint,1,rep,packed,name=control_rtts,json=controlRtts,proto3" json:"control_rtts,omitempty"`
+	StreamRtts  []int64 `protobuf:"varint,2,rep,packed,name=stream_rtts,json=streamRtts,proto3" json:"stream_rtts,omitempty"`
 }
 
-var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
-var file_admin_proto_goTypes = []interface{}{
-	(*ByteStream)(nil),               // 0: admin.ByteStream
-	(*Client)(nil),                   // 1: admin.Client
-	(*ClientRegisterRequest)(nil),    // 2: admin.ClientRegisterRequest
-	(*ClientRegisterResponse)(nil),   // 3: admin.ClientRegisterResponse
-	(*ClientDisconnectRequest)(nil),  // 4: admin.ClientDisconnectRequest
-	(*ClientDisconnectResponse)(nil), // 5: admin.ClientDisconnectResponse
-	(*ClientListRequest)(nil),        // 6: admin.ClientListRequest
-	(*Connection)(nil),               // 7: admin.Connection
-	(*ConnectionListRequest)(nil),    // 8: admin.ConnectionListRequest
-	(*SocksStartRequest)(nil),        // 9: admin.SocksStartRequest
-	(*SocksStartResponse)(nil),       // 10: admin.SocksStartResponse
-	(*SocksStopRequest)(nil),         // 11: admin.SocksStopRequest
-	(*SocksStopResponse)(nil),        // 12: admin.SocksStopResponse
-	(*Tunnel)(nil),                   // 13: admin.Tunnel
-	(*TunnelAddRequest)(nil),         // 14: admin.TunnelAddRequest
-	(*TunnelAddResponse)(nil),        // 15: admin.TunnelAddResponse
-	(*TunnelDeleteRequest)(nil),      // 16: admin.TunnelDeleteRequest
-	(*TunnelDeleteResponse)(nil),     // 17: admin.TunnelDeleteResponse
-	(*TunnelListRequest)(nil),        // 18: admin.TunnelListRequest
-}
-var file_admin_proto_depIdxs = []int32{
-	13, // 0: admin.TunnelAddRequest.tunnel:type_name -> admin.Tunnel
-	2,  // 1: admin.AdminService.ClientRegister:input_type -> admin.ClientRegisterRequest
-	4,  // 2: admin.AdminService.ClientDisconnect:input_type -> admin.ClientDisconnectRequest
-	6,  // 3: admin.AdminService.ClientList:input_type -> admin.ClientListRequest
-	8,  // 4: admin.AdminService.ConnectionList:input_type -> admin.ConnectionListRequest
-	9,  // 5: admin.AdminService.SocksStart:input_type -> admin.SocksStartRequest
-	11, // 6: admin.AdminService.SocksStop:input_type -> admin.SocksStopRequest
-	14, // 7: admin.AdminService.TunnelAdd:input_type -> admin.TunnelAddRequest
-	16, // 8: admin.AdminService.TunnelDelete:input_type -> admin.TunnelDeleteRequest
-	18, // 9: admin.AdminService.TunnelList:input_type -> admin.TunnelListRequest
-	3,  // 10: admin.AdminService.ClientRegister:output_type -> admin.ClientRegisterResponse
-	5,  // 11: admin.AdminService.ClientDisconnect:output_type -> admin.ClientDisconnectResponse
-	1,  // 12: admin.AdminService.ClientList:output_type -> admin.Client
-	7,  // 13: admin.AdminService.ConnectionList:output_type -> admin.Connection
-	10, // 14: admin.AdminService.SocksStart:output_type -> admin.SocksStartResponse
-	12, // 15: admin.AdminService.SocksStop:output_type -> admin.SocksStopResponse
-	15, // 16: admin.AdminService.TunnelAdd:output_type -> admin.TunnelAddResponse
-	17, // 17: admin.AdminService.TunnelDelete:output_type -> admin.TunnelDeleteResponse
-	13, // 18: admin.AdminService.TunnelList:output_type -> admin.Tunnel
-	10, // [10:19] is the sub-list for method output_type
-	1,  // [1:10] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+func (x *ClientPingResponse) Reset() {
+	*x = ClientPingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func init() { file_admin_proto_init() }
-func file_admin_proto_init() {
-	if File_admin_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ByteStream); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Client); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+func (x *ClientPingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientPingResponse) ProtoMessage() {}
+
+func (x *ClientPingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientRegisterRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientPingResponse.ProtoReflect.Descriptor instead.
+func (*ClientPingResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ClientPingResponse) GetControlRtts() []int64 {
+	if x != nil {
+		return x.ControlRtts
+	}
+	return nil
+}
+
+func (x *ClientPingResponse) GetStreamRtts() []int64 {
+	if x != nil {
+		return x.StreamRtts
+	}
+	return nil
+}
+
+type ClientSpeedTestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Number of bytes sent in each direction. 10 MiB if 0
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+}
+
+func (x *ClientSpeedTestRequest) Reset() {
+	*x = ClientSpeedTestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientSpeedTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientSpeedTestRequest) ProtoMessage() {}
+
+func (x *ClientSpeedTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientRegisterResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientSpeedTestRequest.ProtoReflect.Descriptor instead.
+func (*ClientSpeedTestRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ClientSpeedTestRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ClientSpeedTestRequest) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// Times in microseconds that it took to send bytes to the gClient and
+// to receive as many from it
+type ClientSpeedTestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bytes          uint64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	UploadMicros   int64  `protobuf:"varint,2,opt,name=upload_micros,json=uploadMicros,proto3" json:"upload_micros,omitempty"`
+	DownloadMicros int64  `protobuf:"varint,3,opt,name=download_micros,json=downloadMicros,proto3" json:"download_micros,omitempty"`
+}
+
+func (x *ClientSpeedTestResponse) Reset() {
+	*x = ClientSpeedTestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientSpeedTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientSpeedTestResponse) ProtoMessage() {}
+
+func (x *ClientSpeedTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientDisconnectRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientSpeedTestResponse.ProtoReflect.Descriptor instead.
+func (*ClientSpeedTestResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClientSpeedTestResponse) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *ClientSpeedTestResponse) GetUploadMicros() int64 {
+	if x != nil {
+		return x.UploadMicros
+	}
+	return 0
+}
+
+func (x *ClientSpeedTestResponse) GetDownloadMicros() int64 {
+	if x != nil {
+		return x.DownloadMicros
+	}
+	return 0
+}
+
+type ClientScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	// Comma separated addresses and networks, such as 10.0.0.0/24
+	Hosts string `protobuf:"bytes,2,opt,name=hosts,proto3" json:"hosts,omitempty"`
+	// Comma separated ports and ranges, such as 22,80,8000-8100
+	Ports string `protobuf:"bytes,3,opt,name=ports,proto3" json:"ports,omitempty"`
+	// Milliseconds to wait for each port. 1000 if 0
+	Timeout uint32 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *ClientScanRequest) Reset() {
+	*x = ClientScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientScanRequest) ProtoMessage() {}
+
+func (x *ClientScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientDisconnectResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientScanRequest.ProtoReflect.Descriptor instead.
+func (*ClientScanRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ClientScanRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ClientScanRequest) GetHosts() string {
+	if x != nil {
+		return x.Hosts
+	}
+	return ""
+}
+
+func (x *ClientScanRequest) GetPorts() string {
+	if x != nil {
+		return x.Ports
+	}
+	return ""
+}
+
+func (x *ClientScanRequest) GetTimeout() uint32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type ScanResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ScanResult) Reset() {
+	*x = ScanResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScanResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResult) ProtoMessage() {}
+
+func (x *ScanResult) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientListRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Connection); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConnectionListRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SocksStartRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResult.ProtoReflect.Descriptor instead.
+func (*ScanResult) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ScanResult) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// A part of a file that is transferred. Size and mode are set on the
+// first chunk, the SHA-256 hash of the file on the last chunk of a
+// download.
+type FileChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Path     string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Size     uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Mode     uint32 `protobuf:"varint,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	Data     []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Sha256   []byte `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SocksStartResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FileChunk) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *FileChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChunk) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileChunk) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetSha256() []byte {
+	if x != nil {
+		return x.Sha256
+	}
+	return nil
+}
+
+type FileDownloadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Path     string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *FileDownloadRequest) Reset() {
+	*x = FileDownloadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileDownloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDownloadRequest) ProtoMessage() {}
+
+func (x *FileDownloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SocksStopRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDownloadRequest.ProtoReflect.Descriptor instead.
+func (*FileDownloadRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FileDownloadRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *FileDownloadRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type FileTransferResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size   uint64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256 []byte `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`
+}
+
+func (x *FileTransferResponse) Reset() {
+	*x = FileTransferResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileTransferResponse) ProtoMessage() {}
+
+func (x *FileTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SocksStopResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileTransferResponse.ProtoReflect.Descriptor instead.
+func (*FileTransferResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FileTransferResponse) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileTransferResponse) GetSha256() []byte {
+	if x != nil {
+		return x.Sha256
+	}
+	return nil
+}
+
+type ShellInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Data     []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ShellInput) Reset() {
+	*x = ShellInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellInput) ProtoMessage() {}
+
+func (x *ShellInput) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tunnel); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellInput.ProtoReflect.Descriptor instead.
+func (*ShellInput) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ShellInput) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ShellInput) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ShellOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ShellOutput) Reset() {
+	*x = ShellOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShellOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellOutput) ProtoMessage() {}
+
+func (x *ShellOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TunnelAddRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellOutput.ProtoReflect.Descriptor instead.
+func (*ShellOutput) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ShellOutput) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ClientListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tags []string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *ClientListRequest) Reset() {
+	*x = ClientListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientListRequest) ProtoMessage() {}
+
+func (x *ClientListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TunnelAddResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientListRequest.ProtoReflect.Descriptor instead.
+func (*ClientListRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ClientListRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Connection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceIp        uint32   `protobuf:"varint,1,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	SourcePort      uint32   `protobuf:"varint,2,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationIp   uint32   `protobuf:"varint,3,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
+	DestinationPort uint32   `protobuf:"varint,4,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Warnings        []string `protobuf:"bytes,5,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	BytesSent       uint64   `protobuf:"varint,6,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   uint64   `protobuf:"varint,7,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	Id              string   `protobuf:"bytes,8,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime       int64    `protobuf:"varint,9,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	OriginAddress   string   `protobuf:"bytes,10,opt,name=origin_address,json=originAddress,proto3" json:"origin_address,omitempty"`
+	// The destination that the connection was connected to, which is
+	// a failover destination if the tunnel failed over
+	DestinationAddress string `protobuf:"bytes,11,opt,name=destination_address,json=destinationAddress,proto3" json:"destination_address,omitempty"`
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TunnelDeleteRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Connection) GetSourceIp() uint32 {
+	if x != nil {
+		return x.SourceIp
+	}
+	return 0
+}
+
+func (x *Connection) GetSourcePort() uint32 {
+	if x != nil {
+		return x.SourcePort
+	}
+	return 0
+}
+
+func (x *Connection) GetDestinationIp() uint32 {
+	if x != nil {
+		return x.DestinationIp
+	}
+	return 0
+}
+
+func (x *Connection) GetDestinationPort() uint32 {
+	if x != nil {
+		return x.DestinationPort
+	}
+	return 0
+}
+
+func (x *Connection) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+func (x *Connection) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *Connection) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *Connection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Connection) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Connection) GetOriginAddress() string {
+	if x != nil {
+		return x.OriginAddress
+	}
+	return ""
+}
+
+func (x *Connection) GetDestinationAddress() string {
+	if x != nil {
+		return x.DestinationAddress
+	}
+	return ""
+}
+
+type ConnectionListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
+}
+
+func (x *ConnectionListRequest) Reset() {
+	*x = ConnectionListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionListRequest) ProtoMessage() {}
+
+func (x *ConnectionListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TunnelDeleteResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionListRequest.ProtoReflect.Descriptor instead.
+func (*ConnectionListRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ConnectionListRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *ConnectionListRequest) GetTunnelId() string {
+	if x != nil {
+		return x.TunnelId
+	}
+	return ""
+}
+
+type LogLevelSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// The client whose log level is set, gServer if empty
+	ClientId string `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+}
+
+func (x *LogLevelSetRequest) Reset() {
+	*x = LogLevelSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLevelSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevelSetRequest) ProtoMessage() {}
+
+func (x *LogLevelSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_admin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TunnelListRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevelSetRequest.ProtoReflect.Descriptor instead.
+func (*LogLevelSetRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LogLevelSetRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *LogLevelSetRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type LogLevelSetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PreviousLevel string `protobuf:"bytes,1,opt,name=previous_level,json=previousLevel,proto3" json:"previous_level,omitempty"`
+}
+
+func (x *LogLevelSetResponse) Reset() {
+	*x = LogLevelSetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogLevelSetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevelSetResponse) ProtoMessage() {}
+
+func (x *LogLevelSetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevelSetResponse.ProtoReflect.Descriptor instead.
+func (*LogLevelSetResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *LogLevelSetResponse) GetPreviousLevel() string {
+	if x != nil {
+		return x.PreviousLevel
+	}
+	return ""
+}
+
+type SocksStartRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId  string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	SocksPort uint32 `protobuf:"varint,2,opt,name=socks_port,json=socksPort,proto3" json:"socks_port,omitempty"`
+}
+
+func (x *SocksStartRequest) Reset() {
+	*x = SocksStartRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocksStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocksStartRequest) ProtoMessage() {}
+
+func (x *SocksStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocksStartRequest.ProtoReflect.Descriptor instead.
+func (*SocksStartRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SocksStartRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *SocksStartRequest) GetSocksPort() uint32 {
+	if x != nil {
+		return x.SocksPort
+	}
+	return 0
+}
+
+type SocksStartResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SocksStartResponse) Reset() {
+	*x = SocksStartResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocksStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocksStartResponse) ProtoMessage() {}
+
+func (x *SocksStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocksStartResponse.ProtoReflect.Descriptor instead.
+func (*SocksStartResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{35}
+}
+
+type SocksStopRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+}
+
+func (x *SocksStopRequest) Reset() {
+	*x = SocksStopRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocksStopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocksStopRequest) ProtoMessage() {}
+
+func (x *SocksStopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocksStopRequest.ProtoReflect.Descriptor instead.
+func (*SocksStopRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SocksStopRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type SocksStopResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SocksStopResponse) Reset() {
+	*x = SocksStopResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SocksStopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SocksStopResponse) ProtoMessage() {}
+
+func (x *SocksStopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SocksStopResponse.ProtoReflect.Descriptor instead.
+func (*SocksStopResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{37}
+}
+
+type Tunnel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Direction       uint32 `protobuf:"varint,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	ListenIp        uint32 `protobuf:"varint,3,opt,name=listen_ip,json=listenIp,proto3" json:"listen_ip,omitempty"`
+	ListenPort      uint32 `protobuf:"varint,4,opt,name=listen_port,json=listenPort,proto3" json:"listen_port,omitempty"`
+	DestinationIp   uint32 `protobuf:"varint,5,opt,name=destination_ip,json=destinationIp,proto3" json:"destination_ip,omitempty"`
+	DestinationPort uint32 `protobuf:"varint,6,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Command         string `protobuf:"bytes,7,opt,name=command,proto3" json:"command,omitempty"`
+	DetectDeception bool   `protobuf:"varint,8,opt,name=detect_deception,json=detectDeception,proto3" json:"detect_deception,omitempty"`
+	LoopbackAlias   bool   `protobuf:"varint,9,opt,name=loopback_alias,json=loopbackAlias,proto3" json:"loopback_alias,omitempty"`
+	Hostname        string `protobuf:"bytes,10,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	BytesSent       uint64 `protobuf:"varint,11,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	BytesReceived   uint64 `protobuf:"varint,12,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	StartTime       int64  `protobuf:"varint,13,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Connections     uint32 `protobuf:"varint,14,opt,name=connections,proto3" json:"connections,omitempty"`
+	Paused          bool   `protobuf:"varint,15,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Lifetime in seconds after which the tunnel is deleted. 0 if unlimited
+	Ttl int64 `protobuf:"varint,16,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Bytes the tunnel may carry before it is deleted. 0 if unlimited
+	ByteLimit uint64 `protobuf:"varint,17,opt,name=byte_limit,json=byteLimit,proto3" json:"byte_limit,omitempty"`
+	// Time window such as "Mon-Fri 09:00-17:00" outside of which the
+	// tunnel is closed. Empty if the tunnel is always open
+	Schedule string `protobuf:"bytes,18,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Timezone string `protobuf:"bytes,19,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Bytes read from a TCP connection per byte stream message. The MTU
+	// of the endpoint is used if 0
+	ChunkSize uint32 `protobuf:"varint,20,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// Socket options of the accepted and dialed TCP connections. Nagle
+	// clears TCP_NODELAY, tcp_keepalive is in seconds and disables TCP
+	// keepalives if negative, buffer sizes are in bytes. 0 keeps the
+	// system defaults
+	Nagle        bool   `protobuf:"varint,21,opt,name=nagle,proto3" json:"nagle,omitempty"`
+	TcpKeepalive int64  `protobuf:"varint,22,opt,name=tcp_keepalive,json=tcpKeepalive,proto3" json:"tcp_keepalive,omitempty"`
+	ReadBuffer   uint32 `protobuf:"varint,23,opt,name=read_buffer,json=readBuffer,proto3" json:"read_buffer,omitempty"`
+	WriteBuffer  uint32 `protobuf:"varint,24,opt,name=write_buffer,json=writeBuffer,proto3" json:"write_buffer,omitempty"`
+	// Bandwidth in bytes per second that the connections of the tunnel
+	// may use together. 0 means unlimited
+	RateLimit uint64 `protobuf:"varint,25,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Seconds a connection of the tunnel may relay no data before it is
+	// closed. 0 means never
+	IdleTimeout int64 `protobuf:"varint,26,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	// Number of connections the tunnel may carry at the same time. 0
+	// means unlimited
+	MaxConnections uint32 `protobuf:"varint,27,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	// Connections of the tunnel that could not be established, and why
+	// and when the last of them failed
+	FailedConnections uint64 `protobuf:"varint,28,opt,name=failed_connections,json=failedConnections,proto3" json:"failed_connections,omitempty"`
+	LastError         string `protobuf:"bytes,29,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LastErrorTime     int64  `protobuf:"varint,30,opt,name=last_error_time,json=lastErrorTime,proto3" json:"last_error_time,omitempty"`
+	// Compress the byte streams of the tunnel with gzip
+	Compress bool `protobuf:"varint,31,opt,name=compress,proto3" json:"compress,omitempty"`
+	// Algorithm with which gtunnel compresses the byte stream payloads of
+	// the tunnel, such as snappy. None if empty
+	StreamCompression string `protobuf:"bytes,32,opt,name=stream_compression,json=streamCompression,proto3" json:"stream_compression,omitempty"`
+	// Encrypt the byte streams of the tunnel with a key of its own
+	Encrypt bool `protobuf:"varint,33,opt,name=encrypt,proto3" json:"encrypt,omitempty"`
+	// Seconds after which the key of an encrypted tunnel is replaced.
+	// Never if 0
+	RekeyInterval int64 `protobuf:"varint,34,opt,name=rekey_interval,json=rekeyInterval,proto3" json:"rekey_interval,omitempty"`
+	// Bytes after which the key of an encrypted tunnel is replaced.
+	// Never if 0
+	RekeyBytes uint64 `protobuf:"varint,35,opt,name=rekey_bytes,json=rekeyBytes,proto3" json:"rekey_bytes,omitempty"`
+	// Size in bytes to a multiple of which data messages are padded.
+	// Not padded if 0
+	PadSize uint32 `protobuf:"varint,36,opt,name=pad_size,json=padSize,proto3" json:"pad_size,omitempty"`
+	// Milliseconds of random delay of up to which each data message is
+	// held back. No delay if 0
+	SendJitter int64 `protobuf:"varint,37,opt,name=send_jitter,json=sendJitter,proto3" json:"send_jitter,omitempty"`
+	// Comma separated endpoints that connections pass after the endpoint
+	// of the tunnel, such as "clientB@10.0.0.5:4444", each of which
+	// listens on the address for the previous hop. Direct if empty
+	Hops string `protobuf:"bytes,38,opt,name=hops,proto3" json:"hops,omitempty"`
+	// Mode in which the forward tunnels of endpoints with the same listen
+	// address spread new connections, roundrobin or leastconn. Not
+	// balanced if empty
+	Balance string `protobuf:"bytes,39,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Comma separated destinations, such as "10.0.0.6:80,10.0.0.7:80",
+	// that are dialed in order when the destination cannot be. None if
+	// empty
+	Failover string `protobuf:"bytes,40,opt,name=failover,proto3" json:"failover,omitempty"`
+	// Seconds after which a forward tunnel checks again that its
+	// destination can be connected to. Not checked if 0
+	HealthCheck int64 `protobuf:"varint,41,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	// Text, such as "SSH-", that the destination has to send first
+	// for a health check to pass. Connecting is enough if empty
+	HealthBanner string `protobuf:"bytes,42,opt,name=health_banner,json=healthBanner,proto3" json:"health_banner,omitempty"`
+	// Result of the last health check, "up" or why the destination is
+	// down. Empty if the tunnel was not checked
+	Health string `protobuf:"bytes,43,opt,name=health,proto3" json:"health,omitempty"`
+}
+
+func (x *Tunnel) Reset() {
+	*x = Tunnel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Tunnel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tunnel) ProtoMessage() {}
+
+func (x *Tunnel) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tunnel.ProtoReflect.Descriptor instead.
+func (*Tunnel) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Tunnel) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tunnel) GetDirection() uint32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *Tunnel) GetListenIp() uint32 {
+	if x != nil {
+		return x.ListenIp
+	}
+	return 0
+}
+
+func (x *Tunnel) GetListenPort() uint32 {
+	if x != nil {
+		return x.ListenPort
+	}
+	return 0
+}
+
+func (x *Tunnel) GetDestinationIp() uint32 {
+	if x != nil {
+		return x.DestinationIp
+	}
+	return 0
+}
+
+func (x *Tunnel) GetDestinationPort() uint32 {
+	if x != nil {
+		return x.DestinationPort
+	}
+	return 0
+}
+
+func (x *Tunnel) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *Tunnel) GetDetectDeception() bool {
+	if x != nil {
+		return x.DetectDeception
+	}
+	return false
+}
+
+func (x *Tunnel) GetLoopbackAlias() bool {
+	if x != nil {
+		return x.LoopbackAlias
+	}
+	return false
+}
+
+func (x *Tunnel) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *Tunnel) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *Tunnel) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *Tunnel) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Tunnel) GetConnections() uint32 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *Tunnel) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *Tunnel) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *Tunnel) GetByteLimit() uint64 {
+	if x != nil {
+		return x.ByteLimit
+	}
+	return 0
+}
+
+func (x *Tunnel) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Tunnel) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Tunnel) GetChunkSize() uint32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *Tunnel) GetNagle() bool {
+	if x != nil {
+		return x.Nagle
+	}
+	return false
+}
+
+func (x *Tunnel) GetTcpKeepalive() int64 {
+	if x != nil {
+		return x.TcpKeepalive
+	}
+	return 0
+}
+
+func (x *Tunnel) GetReadBuffer() uint32 {
+	if x != nil {
+		return x.ReadBuffer
+	}
+	return 0
+}
+
+func (x *Tunnel) GetWriteBuffer() uint32 {
+	if x != nil {
+		return x.WriteBuffer
+	}
+	return 0
+}
+
+func (x *Tunnel) GetRateLimit() uint64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *Tunnel) GetIdleTimeout() int64 {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return 0
+}
+
+func (x *Tunnel) GetMaxConnections() uint32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *Tunnel) GetFailedConnections() uint64 {
+	if x != nil {
+		return x.FailedConnections
+	}
+	return 0
+}
+
+func (x *Tunnel) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Tunnel) GetLastErrorTime() int64 {
+	if x != nil {
+		return x.LastErrorTime
+	}
+	return 0
+}
+
+func (x *Tunnel) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+func (x *Tunnel) GetStreamCompression() string {
+	if x != nil {
+		return x.StreamCompression
+	}
+	return ""
+}
+
+func (x *Tunnel) GetEncrypt() bool {
+	if x != nil {
+		return x.Encrypt
+	}
+	return false
+}
+
+func (x *Tunnel) GetRekeyInterval() int64 {
+	if x != nil {
+		return x.RekeyInterval
+	}
+	return 0
+}
+
+func (x *Tunnel) GetRekeyBytes() uint64 {
+	if x != nil {
+		return x.RekeyBytes
+	}
+	return 0
+}
+
+func (x *Tunnel) GetPadSize() uint32 {
+	if x != nil {
+		return x.PadSize
+	}
+	return 0
+}
+
+func (x *Tunnel) GetSendJitter() int64 {
+	if x != nil {
+		return x.SendJitter
+	}
+	return 0
+}
+
+func (x *Tunnel) GetHops() string {
+	if x != nil {
+		return x.Hops
+	}
+	return ""
+}
+
+func (x *Tunnel) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Tunnel) GetFailover() string {
+	if x != nil {
+		return x.Failover
+	}
+	return ""
+}
+
+func (x *Tunnel) GetHealthCheck() int64 {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return 0
+}
+
+func (x *Tunnel) GetHealthBanner() string {
+	if x != nil {
+		return x.HealthBanner
+	}
+	return ""
+}
+
+func (x *Tunnel) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+type TunnelAddRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string  `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	Tunnel   *Tunnel `protobuf:"bytes,2,opt,name=tunnel,proto3" json:"tunnel,omitempty"`
+}
+
+func (x *TunnelAddRequest) Reset() {
+	*x = TunnelAddRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelAddRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelAddRequest) ProtoMessage() {}
+
+func (x *TunnelAddRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelAddRequest.ProtoReflect.Descriptor instead.
+func (*TunnelAddRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TunnelAddRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TunnelAddRequest) GetTunnel() *Tunnel {
+	if x != nil {
+		return x.Tunnel
+	}
+	return nil
+}
+
+type TunnelAddResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TunnelAddResponse) Reset() {
+	*x = TunnelAddResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelAddResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelAddResponse) ProtoMessage() {}
+
+func (x *TunnelAddResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelAddResponse.ProtoReflect.Descriptor instead.
+func (*TunnelAddResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{40}
+}
+
+type TunnelImportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tunnels []*TunnelAddRequest `protobuf:"bytes,1,rep,name=tunnels,proto3" json:"tunnels,omitempty"`
+}
+
+func (x *TunnelImportRequest) Reset() {
+	*x = TunnelImportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelImportRequest) ProtoMessage() {}
+
+func (x *TunnelImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelImportRequest.ProtoReflect.Descriptor instead.
+func (*TunnelImportRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TunnelImportRequest) GetTunnels() []*TunnelAddRequest {
+	if x != nil {
+		return x.Tunnels
+	}
+	return nil
+}
+
+type TunnelImportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TunnelIds []string `protobuf:"bytes,1,rep,name=tunnel_ids,json=tunnelIds,proto3" json:"tunnel_ids,omitempty"`
+}
+
+func (x *TunnelImportResponse) Reset() {
+	*x = TunnelImportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelImportResponse) ProtoMessage() {}
+
+func (x *TunnelImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelImportResponse.ProtoReflect.Descriptor instead.
+func (*TunnelImportResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TunnelImportResponse) GetTunnelIds() []string {
+	if x != nil {
+		return x.TunnelIds
+	}
+	return nil
+}
+
+type TunnelDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
+}
+
+func (x *TunnelDeleteRequest) Reset() {
+	*x = TunnelDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelDeleteRequest) ProtoMessage() {}
+
+func (x *TunnelDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelDeleteRequest.ProtoReflect.Descriptor instead.
+func (*TunnelDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TunnelDeleteRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TunnelDeleteRequest) GetTunnelId() string {
+	if x != nil {
+		return x.TunnelId
+	}
+	return ""
+}
+
+type TunnelDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TunnelDeleteResponse) Reset() {
+	*x = TunnelDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelDeleteResponse) ProtoMessage() {}
+
+func (x *TunnelDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelDeleteResponse.ProtoReflect.Descriptor instead.
+func (*TunnelDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{44}
+}
+
+type TunnelPauseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
+	Freeze   bool   `protobuf:"varint,3,opt,name=freeze,proto3" json:"freeze,omitempty"`
+}
+
+func (x *TunnelPauseRequest) Reset() {
+	*x = TunnelPauseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelPauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelPauseRequest) ProtoMessage() {}
+
+func (x *TunnelPauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelPauseRequest.ProtoReflect.Descriptor instead.
+func (*TunnelPauseRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *TunnelPauseRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TunnelPauseRequest) GetTunnelId() string {
+	if x != nil {
+		return x.TunnelId
+	}
+	return ""
+}
+
+func (x *TunnelPauseRequest) GetFreeze() bool {
+	if x != nil {
+		return x.Freeze
+	}
+	return false
+}
+
+type TunnelPauseResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TunnelPauseResponse) Reset() {
+	*x = TunnelPauseResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelPauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelPauseResponse) ProtoMessage() {}
+
+func (x *TunnelPauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelPauseResponse.ProtoReflect.Descriptor instead.
+func (*TunnelPauseResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{46}
+}
+
+type TunnelResumeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	TunnelId string `protobuf:"bytes,2,opt,name=tunnel_id,json=tunnelId,proto3" json:"tunnel_id,omitempty"`
+}
+
+func (x *TunnelResumeRequest) Reset() {
+	*x = TunnelResumeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelResumeRequest) ProtoMessage() {}
+
+func (x *TunnelResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelResumeRequest.ProtoReflect.Descriptor instead.
+func (*TunnelResumeRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TunnelResumeRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TunnelResumeRequest) GetTunnelId() string {
+	if x != nil {
+		return x.TunnelId
+	}
+	return ""
+}
+
+type TunnelResumeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TunnelResumeResponse) Reset() {
+	*x = TunnelResumeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelResumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelResumeResponse) ProtoMessage() {}
+
+func (x *TunnelResumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelResumeResponse.ProtoReflect.Descriptor instead.
+func (*TunnelResumeResponse) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{48}
+}
+
+type TunnelListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClientId string `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+}
+
+func (x *TunnelListRequest) Reset() {
+	*x = TunnelListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TunnelListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TunnelListRequest) ProtoMessage() {}
+
+func (x *TunnelListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TunnelListRequest.ProtoReflect.Descriptor instead.
+func (*TunnelListRequest) Descriptor() ([]byte, []int) {
+	return file_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TunnelListRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+var File_admin_proto protoreflect.FileDescriptor
+
+var file_admin_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x22, 0x49, 0x0a, 0x05, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x5f, 0x69, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x49, 0x70, 0x22,
+	0x12, 0x0a, 0x10, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x20, 0x0a, 0x0a, 0x42, 0x79, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x96, 0x04, 0x0a, 0x06, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x73, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x73,
+	0x5f, 0x6f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x73, 0x4f, 0x72, 0x67, 0x61, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0xea,
+	0x01, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x69,
+	0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x69,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f,
+	0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x2e, 0x0a, 0x16, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x65, 0x0a, 0x17, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x61, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x62, 0x61, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x61, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x1a, 0x0a, 0x18, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb2,
+	0x01, 0x0a, 0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x74, 0x75, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x74, 0x75, 0x12, 0x2d, 0x0a, 0x12, 0x6b, 0x65, 0x65, 0x70,
+	0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x61, 0x6c, 0x5f,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x61,
+	0x6c, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x61, 0x6c, 0x5f, 0x64,
+	0x65, 0x6e, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x61, 0x6c, 0x44,
+	0x65, 0x6e, 0x79, 0x22, 0x19, 0x0a, 0x17, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x31,
+	0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x62, 0x61, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x10, 0x0a, 0x0e, 0x42, 0x61, 0x6e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x59, 0x0a, 0x03, 0x42, 0x61,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x61, 0x6e, 0x6e, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0x48, 0x0a, 0x13, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x22,
+	0x16, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x59, 0x0a, 0x10, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x61, 0x64, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x22, 0x27, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x5a, 0x0a, 0x11, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x63, 0x68, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x65, 0x63, 0x68, 0x6f, 0x22, 0x58, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x72, 0x74, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x52, 0x74, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x72, 0x74, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x74, 0x74,
+	0x73, 0x22, 0x4b, 0x0a, 0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x22, 0x7d,
+	0x0a, 0x17, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x54, 0x65, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x69,
+	0x63, 0x72, 0x6f, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x22, 0x76, 0x0a,
+	0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x68, 0x6f, 0x73, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x26, 0x0a, 0x0a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x90, 0x01,
+	0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x22, 0x46, 0x0a, 0x13, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x42, 0x0a, 0x14, 0x46, 0x69, 0x6c, 0x65,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x22, 0x3d, 0x0a, 0x0a,
+	0x53, 0x68, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x21, 0x0a, 0x0b, 0x53,
+	0x68, 0x65, 0x6c, 0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x27,
+	0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x22, 0x85, 0x03, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e,
+	0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x53, 0x65, 0x6e,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2f,
+	0x0a, 0x13, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x51, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x64, 0x22, 0x47, 0x0a, 0x12, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x13, 0x4c,
+	0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x4f, 0x0a, 0x11, 0x53, 0x6f, 0x63,
+	0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x6f, 0x63, 0x6b, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x73, 0x6f, 0x63, 0x6b, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x6f,
+	0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x2f, 0x0a, 0x10, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x22, 0x13, 0x0a, 0x11, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe8, 0x0a, 0x0a, 0x06, 0x54, 0x75, 0x6e, 0x6e, 0x65,
+	0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x70, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f,
+	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x44, 0x65, 0x63, 0x65, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x6f, 0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b,
+	0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6c, 0x6f,
+	0x6f, 0x70, 0x62, 0x61, 0x63, 0x6b, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
+	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74, 0x65,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x61, 0x67, 0x6c, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6e,
+	0x61, 0x67, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70,
+	0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x74, 0x63, 0x70,
+	0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x61,
+	0x64, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x72, 0x65, 0x61, 0x64, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x77, 0x72, 0x69, 0x74, 0x65, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x66, 0x61, 0x69, 0x6c,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x1c,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x22, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x6b,
+	0x65, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x6b, 0x65, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x23, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x72, 0x65, 0x6b, 0x65, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x70,
+	0x61, 0x64, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70,
+	0x61, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x6a,
+	0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x25, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x65, 0x6e,
+	0x64, 0x4a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x18,
+	0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x70, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65,
+	0x72, 0x18, 0x28, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65,
+	0x72, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x18, 0x29, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x62,
+	0x61, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x42, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x22, 0x56, 0x0a, 0x10, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x06, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65,
+	0x6c, 0x52, 0x06, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x13, 0x0a, 0x11, 0x54, 0x75, 0x6e,
+	0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x48,
+	0x0a, 0x13, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54,
+	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x07, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0x35, 0x0a, 0x14, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x73, 0x22,
+	0x4f, 0x0a, 0x13, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64,
+	0x22, 0x16, 0x0a, 0x14, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x66, 0x0a, 0x12, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x72, 0x65, 0x65,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x72, 0x65, 0x65, 0x7a, 0x65,
+	0x22, 0x15, 0x0a, 0x13, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4f, 0x0a, 0x13, 0x54, 0x75, 0x6e, 0x6e, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x30, 0x0a, 0x11, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x49, 0x64, 0x32, 0xd7, 0x0d, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x10, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1e, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0f, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x12, 0x1d,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x46, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x12, 0x19,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x62,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x07, 0x42, 0x61, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x15, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x42, 0x61, 0x6e, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x42, 0x61, 0x6e, 0x22, 0x00, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x0c, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x61,
+	0x67, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x54, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x61, 0x67, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x50, 0x69, 0x6e, 0x67, 0x12, 0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x50, 0x69, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0f, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65,
+	0x65, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x70, 0x65, 0x65,
+	0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3d, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x18, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3f,
+	0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x10, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1b,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12,
+	0x40, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x3a, 0x0a, 0x0b, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x68, 0x65, 0x6c, 0x6c,
+	0x12, 0x11, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c, 0x6c, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x1a, 0x12, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x68, 0x65, 0x6c,
+	0x6c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x36, 0x0a,
+	0x09, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0b, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x6f, 0x67,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
+	0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x18, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x43, 0x0a, 0x0a, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x18, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x61, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f,
+	0x70, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53,
+	0x74, 0x6f, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x09, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x41, 0x64, 0x64, 0x12, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x54, 0x75, 0x6e, 0x6e,
+	0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e,
+	0x6e, 0x65, 0x6c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0a, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x22, 0x00, 0x30, 0x01, 0x12, 0x46,
+	0x0a, 0x0b, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x12, 0x19, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54,
+	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x49, 0x0a, 0x0c, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x6d, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x18, 0x0a, 0x07,
+	0x67, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x07, 0x47, 0x54, 0x75, 0x6e, 0x6e, 0x65, 0x6c,
+	0x50, 0x01, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_admin_proto_rawDescOnce sync.Once
+	file_admin_proto_rawDescData = file_admin_proto_rawDesc
+)
+
+func file_admin_proto_rawDescGZIP() []byte {
+	file_admin_proto_rawDescOnce.Do(func() {
+		file_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_admin_proto_rawDescData)
+	})
+	return file_admin_proto_rawDescData
+}
+
+var file_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
+var file_admin_proto_goTypes = []interface{}{
+	(*Alias)(nil),                    // 0: admin.Alias
+	(*AliasListRequest)(nil),         // 1: admin.AliasListRequest
+	(*ByteStream)(nil),               // 2: admin.ByteStream
+	(*Client)(nil),                   // 3: admin.Client
+	(*ClientRegisterRequest)(nil),    // 4: admin.ClientRegisterRequest
+	(*ClientRegisterResponse)(nil),   // 5: admin.ClientRegisterResponse
+	(*ClientDisconnectRequest)(nil),  // 6: admin.ClientDisconnectRequest
+	(*ClientDisconnectResponse)(nil), // 7: admin.ClientDisconnectResponse
+	(*ClientConfigureRequest)(nil),   // 8: admin.ClientConfigureRequest
+	(*ClientConfigureResponse)(nil),  // 9: admin.ClientConfigureResponse
+	(*ClientUnbanRequest)(nil),       // 10: admin.ClientUnbanRequest
+	(*ClientUnbanResponse)(nil),      // 11: admin.ClientUnbanResponse
+	(*BanListRequest)(nil),           // 12: admin.BanListRequest
+	(*Ban)(nil),                      // 13: admin.Ban
+	(*ClientRenameRequest)(nil),      // 14: admin.ClientRenameRequest
+	(*ClientRenameResponse)(nil),     // 15: admin.ClientRenameResponse
+	(*ClientTagRequest)(nil),         // 16: admin.ClientTagRequest
+	(*ClientTagResponse)(nil),        // 17: admin.ClientTagResponse
+	(*ClientPingRequest)(nil),        // 18: admin.ClientPingRequest
+	(*ClientPingResponse)(nil),       // 19: admin.ClientPingResponse
+	(*ClientSpeedTestRequest)(nil),   // 20: admin.ClientSpeedTestRequest
+	(*ClientSpeedTestResponse)(nil),  // 21: admin.ClientSpeedTestResponse
+	(*ClientScanRequest)(nil),        // 22: admin.ClientScanRequest
+	(*ScanResult)(nil),               // 23: admin.ScanResult
+	(*FileChunk)(nil),                // 24: admin.FileChunk
+	(*FileDownloadRequest)(nil),      // 25: admin.FileDownloadRequest
+	(*FileTransferResponse)(nil),     // 26: admin.FileTransferResponse
+	(*ShellInput)(nil),               // 27: admin.ShellInput
+	(*ShellOutput)(nil),              // 28: admin.ShellOutput
+	(*ClientListRequest)(nil),        // 29: admin.ClientListRequest
+	(*Connection)(nil),               // 30: admin.Connection
+	(*ConnectionListRequest)(nil),    // 31: admin.ConnectionListRequest
+	(*LogLevelSetRequest)(nil),       // 32: admin.LogLevelSetRequest
+	(*LogLevelSetResponse)(nil),      // 33: admin.LogLevelSetResponse
+	(*SocksStartRequest)(nil),        // 34: admin.SocksStartRequest
+	(*SocksStartResponse)(nil),       // 35: admin.SocksStartResponse
+	(*SocksStopRequest)(nil),         // 36: admin.SocksStopRequest
+	(*SocksStopResponse)(nil),        // 37: admin.SocksStopResponse
+	(*Tunnel)(nil),                   // 38: admin.Tunnel
+	(*TunnelAddRequest)(nil),         // 39: admin.TunnelAddRequest
+	(*TunnelAddResponse)(nil),        // 40: admin.TunnelAddResponse
+	(*TunnelImportRequest)(nil),      // 41: admin.TunnelImportRequest
+	(*TunnelImportResponse)(nil),     // 42: admin.TunnelImportResponse
+	(*TunnelDeleteRequest)(nil),      // 43: admin.TunnelDeleteRequest
+	(*TunnelDeleteResponse)(nil),     // 44: admin.TunnelDeleteResponse
+	(*TunnelPauseRequest)(nil),       // 45: admin.TunnelPauseRequest
+	(*TunnelPauseResponse)(nil),      // 46: admin.TunnelPauseResponse
+	(*TunnelResumeRequest)(nil),      // 47: admin.TunnelResumeRequest
+	(*TunnelResumeResponse)(nil),     // 48: admin.TunnelResumeResponse
+	(*TunnelListRequest)(nil),        // 49: admin.TunnelListRequest
+}
+var file_admin_proto_depIdxs = []int32{
+	38, // 0: admin.TunnelAddRequest.tunnel:type_name -> admin.Tunnel
+	39, // 1: admin.TunnelImportRequest.tunnels:type_name -> admin.TunnelAddRequest
+	4,  // 2: admin.AdminService.ClientRegister:input_type -> admin.ClientRegisterRequest
+	6,  // 3: admin.AdminService.ClientDisconnect:input_type -> admin.ClientDisconnectRequest
+	8,  // 4: admin.AdminService.ClientConfigure:input_type -> admin.ClientConfigureRequest
+	10, // 5: admin.AdminService.ClientUnban:input_type -> admin.ClientUnbanRequest
+	12, // 6: admin.AdminService.BanList:input_type -> admin.BanListRequest
+	14, // 7: admin.AdminService.ClientRename:input_type -> admin.ClientRenameRequest
+	16, // 8: admin.AdminService.ClientTag:input_type -> admin.ClientTagRequest
+	18, // 9: admin.AdminService.ClientPing:input_type -> admin.ClientPingRequest
+	20, // 10: admin.AdminService.ClientSpeedTest:input_type -> admin.ClientSpeedTestRequest
+	22, // 11: admin.AdminService.ClientScan:input_type -> admin.ClientScanRequest
+	24, // 12: admin.AdminService.FileUpload:input_type -> admin.FileChunk
+	25, // 13: admin.AdminService.FileDownload:input_type -> admin.FileDownloadRequest
+	27, // 14: admin.AdminService.ClientShell:input_type -> admin.ShellInput
+	1,  // 15: admin.AdminService.AliasList:input_type -> admin.AliasListRequest
+	32, // 16: admin.AdminService.LogLevelSet:input_type -> admin.LogLevelSetRequest
+	29, // 17: admin.AdminService.ClientList:input_type -> admin.ClientListRequest
+	31, // 18: admin.AdminService.ConnectionList:input_type -> admin.ConnectionListRequest
+	34, // 19: admin.AdminService.SocksStart:input_type -> admin.SocksStartRequest
+	36, // 20: admin.AdminService.SocksStop:input_type -> admin.SocksStopRequest
+	39, // 21: admin.AdminService.TunnelAdd:input_type -> admin.TunnelAddRequest
+	43, // 22: admin.AdminService.TunnelDelete:input_type -> admin.TunnelDeleteRequest
+	49, // 23: admin.AdminService.TunnelList:input_type -> admin.TunnelListRequest
+	45, // 24: admin.AdminService.TunnelPause:input_type -> admin.TunnelPauseRequest
+	47, // 25: admin.AdminService.TunnelResume:input_type -> admin.TunnelResumeRequest
+	41, // 26: admin.AdminService.TunnelImport:input_type -> admin.TunnelImportRequest
+	5,  // 27: admin.AdminService.ClientRegister:output_type -> admin.ClientRegisterResponse
+	7,  // 28: admin.AdminService.ClientDisconnect:output_type -> admin.ClientDisconnectResponse
+	9,  // 29: admin.AdminService.ClientConfigure:output_type -> admin.ClientConfigureResponse
+	11, // 30: admin.AdminService.ClientUnban:output_type -> admin.ClientUnbanResponse
+	13, // 31: admin.AdminService.BanList:output_type -> admin.Ban
+	15, // 32: admin.AdminService.ClientRename:output_type -> admin.ClientRenameResponse
+	17, // 33: admin.AdminService.ClientTag:output_type -> admin.ClientTagResponse
+	19, // 34: admin.AdminService.ClientPing:output_type -> admin.ClientPingResponse
+	21, // 35: admin.AdminService.ClientSpeedTest:output_type -> admin.ClientSpeedTestResponse
+	23, // 36: admin.AdminService.ClientScan:output_type -> admin.ScanResult
+	26, // 37: admin.AdminService.FileUpload:output_type -> admin.FileTransferResponse
+	24, // 38: admin.AdminService.FileDownload:output_type -> admin.FileChunk
+	28, // 39: admin.AdminService.ClientShell:output_type -> admin.ShellOutput
+	0,  // 40: admin.AdminService.AliasList:output_type -> admin.Alias
+	33, // 41: admin.AdminService.LogLevelSet:output_type -> admin.LogLevelSetResponse
+	3,  // 42: admin.AdminService.ClientList:output_type -> admin.Client
+	30, // 43: admin.AdminService.ConnectionList:output_type -> admin.Connection
+	35, // 44: admin.AdminService.SocksStart:output_type -> admin.SocksStartResponse
+	37, // 45: admin.AdminService.SocksStop:output_type -> admin.SocksStopResponse
+	40, // 46: admin.AdminService.TunnelAdd:output_type -> admin.TunnelAddResponse
+	44, // 47: admin.AdminService.TunnelDelete:output_type -> admin.TunnelDeleteResponse
+	38, // 48: admin.AdminService.TunnelList:output_type -> admin.Tunnel
+	46, // 49: admin.AdminService.TunnelPause:output_type -> admin.TunnelPauseResponse
+	48, // 50: admin.AdminService.TunnelResume:output_type -> admin.TunnelResumeResponse
+	42, // 51: admin.AdminService.TunnelImport:output_type -> admin.TunnelImportResponse
+	27, // [27:52] is the sub-list for method output_type
+	2,  // [2:27] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_admin_proto_init() }
+func file_admin_proto_init() {
+	if File_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AliasListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ByteStream); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Client); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRegisterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRegisterResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientDisconnectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientDisconnectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientConfigureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientConfigureResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientUnbanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientUnbanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BanListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ban); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRenameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientRenameResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientTagRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientTagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientPingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientPingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientSpeedTestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientSpeedTestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileDownloadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileTransferResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShellInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShellOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Connection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLevelSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogLevelSetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocksStartRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocksStartResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocksStopRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SocksStopResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tunnel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelAddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelAddResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelImportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelImportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelPauseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelPauseResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelResumeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelResumeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TunnelListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_admin_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   50,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_proto_goTypes,
+		DependencyIndexes: file_admin_proto_depIdxs,
+		MessageInfos:      file_admin_proto_msgTypes,
+	}.Build()
+	File_admin_proto = out.File
+	file_admin_proto_rawDesc = nil
+	file_admin_proto_goTypes = nil
+	file_admin_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// Generates a configred gClient executable
+	ClientRegister(ctx context.Context, in *ClientRegisterRequest, opts ...grpc.CallOption) (*ClientRegisterResponse, error)
+	// Disconnects a client from the server
+	ClientDisconnect(ctx context.Context, in *ClientDisconnectRequest, opts ...grpc.CallOption) (*ClientDisconnectResponse, error)
+	// Sets the control message MTU and keepalive cadence of a gClient
+	ClientConfigure(ctx context.Context, in *ClientConfigureRequest, opts ...grpc.CallOption) (*ClientConfigureResponse, error)
+	// Allows a banned gClient to register again
+	ClientUnban(ctx context.Context, in *ClientUnbanRequest, opts ...grpc.CallOption) (*ClientUnbanResponse, error)
+	// Lists all banned gClients
+	BanList(ctx context.Context, in *BanListRequest, opts ...grpc.CallOption) (AdminService_BanListClient, error)
+	// Assigns a human-friendly name to a gClient
+	ClientRename(ctx context.Context, in *ClientRenameRequest, opts ...grpc.CallOption) (*ClientRenameResponse, error)
+	// Adds and removes tags of a gClient
+	ClientTag(ctx context.Context, in *ClientTagRequest, opts ...grpc.CallOption) (*ClientTagResponse, error)
+	// Measures the round-trip time to a gClient
+	ClientPing(ctx context.Context, in *ClientPingRequest, opts ...grpc.CallOption) (*ClientPingResponse, error)
+	// Measures the throughput to and from a gClient
+	ClientSpeedTest(ctx context.Context, in *ClientSpeedTestRequest, opts ...grpc.CallOption) (*ClientSpeedTestResponse, error)
+	// Has a gClient scan its network for open TCP ports
+	ClientScan(ctx context.Context, in *ClientScanRequest, opts ...grpc.CallOption) (AdminService_ClientScanClient, error)
+	// Writes a file to a gClient. The first chunk names the gClient and
+	// the file
+	FileUpload(ctx context.Context, opts ...grpc.CallOption) (AdminService_FileUploadClient, error)
+	// Reads a file from a gClient
+	FileDownload(ctx context.Context, in *FileDownloadRequest, opts ...grpc.CallOption) (AdminService_FileDownloadClient, error)
+	// Runs an interactive shell on a gClient that was built with shell
+	// support. The first input names the gClient
+	ClientShell(ctx context.Context, opts ...grpc.CallOption) (AdminService_ClientShellClient, error)
+	// Lists the loopback aliases assigned to tunnel destinations
+	AliasList(ctx context.Context, in *AliasListRequest, opts ...grpc.CallOption) (AdminService_AliasListClient, error)
+	// Sets the log level of gServer at runtime
+	LogLevelSet(ctx context.Context, in *LogLevelSetRequest, opts ...grpc.CallOption) (*LogLevelSetResponse, error)
+	// Lists all connected gClients
+	ClientList(ctx context.Context, in *ClientListRequest, opts ...grpc.CallOption) (AdminService_ClientListClient, error)
+	// List all connections for a tunnel
+	ConnectionList(ctx context.Context, in *ConnectionListRequest, opts ...grpc.CallOption) (AdminService_ConnectionListClient, error)
+	// Starts a SocksV5 server on a gClient
+	SocksStart(ctx context.Context, in *SocksStartRequest, opts ...grpc.CallOption) (*SocksStartResponse, error)
+	// Stops a SocksV5 server on a gClient
+	SocksStop(ctx context.Context, in *SocksStopRequest, opts ...grpc.CallOption) (*SocksStopResponse, error)
+	// Add a tunnel
+	TunnelAdd(ctx context.Context, in *TunnelAddRequest, opts ...grpc.CallOption) (*TunnelAddResponse, error)
+	// Delete a tunnel
+	TunnelDelete(ctx context.Context, in *TunnelDeleteRequest, opts ...grpc.CallOption) (*TunnelDeleteResponse, error)
+	// List all tunnels for an endppoint
+	TunnelList(ctx context.Context, in *TunnelListRequest, opts ...grpc.CallOption) (AdminService_TunnelListClient, error)
+	// Stops a tunnel from accepting new connections, optionally freezing
+	// its existing connections
+	TunnelPause(ctx context.Context, in *TunnelPauseRequest, opts ...grpc.CallOption) (*TunnelPauseResponse, error)
+	// Resumes a paused tunnel
+	TunnelResume(ctx context.Context, in *TunnelResumeRequest, opts ...grpc.CallOption) (*TunnelResumeResponse, error)
+	// Adds many tunnels at once. Either all of them are added or none
+	TunnelImport(ctx context.Context, in *TunnelImportRequest, opts ...grpc.CallOption) (*TunnelImportResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ClientRegister(ctx context.Context, in *ClientRegisterRequest, opts ...grpc.CallOption) (*ClientRegisterResponse, error) {
+	out := new(ClientRegisterResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientRegister", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientDisconnect(ctx context.Context, in *ClientDisconnectRequest, opts ...grpc.CallOption) (*ClientDisconnectResponse, error) {
+	out := new(ClientDisconnectResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientDisconnect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientConfigure(ctx context.Context, in *ClientConfigureRequest, opts ...grpc.CallOption) (*ClientConfigureResponse, error) {
+	out := new(ClientConfigureResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientConfigure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientUnban(ctx context.Context, in *ClientUnbanRequest, opts ...grpc.CallOption) (*ClientUnbanResponse, error) {
+	out := new(ClientUnbanResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientUnban", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) BanList(ctx context.Context, in *BanListRequest, opts ...grpc.CallOption) (AdminService_BanListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[0], "/admin.AdminService/BanList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceBanListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_BanListClient interface {
+	Recv() (*Ban, error)
+	grpc.ClientStream
+}
+
+type adminServiceBanListClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceBanListClient) Recv() (*Ban, error) {
+	m := new(Ban)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ClientRename(ctx context.Context, in *ClientRenameRequest, opts ...grpc.CallOption) (*ClientRenameResponse, error) {
+	out := new(ClientRenameResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientRename", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientTag(ctx context.Context, in *ClientTagRequest, opts ...grpc.CallOption) (*ClientTagResponse, error) {
+	out := new(ClientTagResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientTag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientPing(ctx context.Context, in *ClientPingRequest, opts ...grpc.CallOption) (*ClientPingResponse, error) {
+	out := new(ClientPingResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientPing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientSpeedTest(ctx context.Context, in *ClientSpeedTestRequest, opts ...grpc.CallOption) (*ClientSpeedTestResponse, error) {
+	out := new(ClientSpeedTestResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientSpeedTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientScan(ctx context.Context, in *ClientScanRequest, opts ...grpc.CallOption) (AdminService_ClientScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[1], "/admin.AdminService/ClientScan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceClientScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ClientScanClient interface {
+	Recv() (*ScanResult, error)
+	grpc.ClientStream
+}
+
+type adminServiceClientScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceClientScanClient) Recv() (*ScanResult, error) {
+	m := new(ScanResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) FileUpload(ctx context.Context, opts ...grpc.CallOption) (AdminService_FileUploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[2], "/admin.AdminService/FileUpload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceFileUploadClient{stream}
+	return x, nil
+}
+
+type AdminService_FileUploadClient interface {
+	Send(*FileChunk) error
+	CloseAndRecv() (*FileTransferResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceFileUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceFileUploadClient) Send(m *FileChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceFileUploadClient) CloseAndRecv() (*FileTransferResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(FileTransferResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) FileDownload(ctx context.Context, in *FileDownloadRequest, opts ...grpc.CallOption) (AdminService_FileDownloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[3], "/admin.AdminService/FileDownload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceFileDownloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_FileDownloadClient interface {
+	Recv() (*FileChunk, error)
+	grpc.ClientStream
+}
+
+type adminServiceFileDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceFileDownloadClient) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ClientShell(ctx context.Context, opts ...grpc.CallOption) (AdminService_ClientShellClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[4], "/admin.AdminService/ClientShell", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceClientShellClient{stream}
+	return x, nil
+}
+
+type AdminService_ClientShellClient interface {
+	Send(*ShellInput) error
+	Recv() (*ShellOutput, error)
+	grpc.ClientStream
+}
+
+type adminServiceClientShellClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceClientShellClient) Send(m *ShellInput) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceClientShellClient) Recv() (*ShellOutput, error) {
+	m := new(ShellOutput)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) AliasList(ctx context.Context, in *AliasListRequest, opts ...grpc.CallOption) (AdminService_AliasListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[5], "/admin.AdminService/AliasList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceAliasListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_AliasListClient interface {
+	Recv() (*Alias, error)
+	grpc.ClientStream
+}
+
+type adminServiceAliasListClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceAliasListClient) Recv() (*Alias, error) {
+	m := new(Alias)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) LogLevelSet(ctx context.Context, in *LogLevelSetRequest, opts ...grpc.CallOption) (*LogLevelSetResponse, error) {
+	out := new(LogLevelSetResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/LogLevelSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ClientList(ctx context.Context, in *ClientListRequest, opts ...grpc.CallOption) (AdminService_ClientListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[6], "/admin.AdminService/ClientList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceClientListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ClientListClient interface {
+	Recv() (*Client, error)
+	grpc.ClientStream
+}
+
+type adminServiceClientListClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceClientListClient) Recv() (*Client, error) {
+	m := new(Client)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ConnectionList(ctx context.Context, in *ConnectionListRequest, opts ...grpc.CallOption) (AdminService_ConnectionListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[7], "/admin.AdminService/ConnectionList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceConnectionListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ConnectionListClient interface {
+	Recv() (*Connection, error)
+	grpc.ClientStream
+}
+
+type adminServiceConnectionListClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceConnectionListClient) Recv() (*Connection, error) {
+	m := new(Connection)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) SocksStart(ctx context.Context, in *SocksStartRequest, opts ...grpc.CallOption) (*SocksStartResponse, error) {
+	out := new(SocksStartResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SocksStart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SocksStop(ctx context.Context, in *SocksStopRequest, opts ...grpc.CallOption) (*SocksStopResponse, error) {
+	out := new(SocksStopResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/SocksStop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TunnelAdd(ctx context.Context, in *TunnelAddRequest, opts ...grpc.CallOption) (*TunnelAddResponse, error) {
+	out := new(TunnelAddResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TunnelDelete(ctx context.Context, in *TunnelDeleteRequest, opts ...grpc.CallOption) (*TunnelDeleteResponse, error) {
+	out := new(TunnelDeleteResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TunnelList(ctx context.Context, in *TunnelListRequest, opts ...grpc.CallOption) (AdminService_TunnelListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[8], "/admin.AdminService/TunnelList", opts...)
+	if err != nil {
+		return nil, err
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_admin_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   19,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_admin_proto_goTypes,
-		DependencyIndexes: file_admin_proto_depIdxs,
-		MessageInfos:      file_admin_proto_msgTypes,
-	}.Build()
-	File_admin_proto = out.File
-	file_admin_proto_rawDesc = nil
-	file_admin_proto_goTypes = nil
-	file_admin_proto_depIdxs = nil
+	x := &adminServiceTunnelListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+type AdminService_TunnelListClient interface {
+	Recv() (*Tunnel, error)
+	grpc.ClientStream
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+type adminServiceTunnelListClient struct {
+	grpc.ClientStream
+}
 
-// AdminServiceClient is the client API for AdminService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type AdminServiceClient interface {
+func (x *adminServiceTunnelListClient) Recv() (*Tunnel, error) {
+	m := new(Tunnel)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) TunnelPause(ctx context.Context, in *TunnelPauseRequest, opts ...grpc.CallOption) (*TunnelPauseResponse, error) {
+	out := new(TunnelPauseResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelPause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TunnelResume(ctx context.Context, in *TunnelResumeRequest, opts ...grpc.CallOption) (*TunnelResumeResponse, error) {
+	out := new(TunnelResumeResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelResume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) TunnelImport(ctx context.Context, in *TunnelImportRequest, opts ...grpc.CallOption) (*TunnelImportResponse, error) {
+	out := new(TunnelImportResponse)
+	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelImport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
 	// Generates a configred gClient executable
-	ClientRegister(ctx context.Context, in *ClientRegisterRequest, opts ...grpc.CallOption) (*ClientRegisterResponse, error)
+	ClientRegister(context.Context, *ClientRegisterRequest) (*ClientRegisterResponse, error)
 	// Disconnects a client from the server
-	ClientDisconnect(ctx context.Context, in *ClientDisconnectRequest, opts ...grpc.CallOption) (*ClientDisconnectResponse, error)
+	ClientDisconnect(context.Context, *ClientDisconnectRequest) (*ClientDisconnectResponse, error)
+	// Sets the control message MTU and keepalive cadence of a gClient
+	ClientConfigure(context.Context, *ClientConfigureRequest) (*ClientConfigureResponse, error)
+	// Allows a banned gClient to register again
+	ClientUnban(context.Context, *ClientUnbanRequest) (*ClientUnbanResponse, error)
+	// Lists all banned gClients
+	BanList(*BanListRequest, AdminService_BanListServer) error
+	// Assigns a human-friendly name to a gClient
+	ClientRename(context.Context, *ClientRenameRequest) (*ClientRenameResponse, error)
+	// Adds and removes tags of a gClient
+	ClientTag(context.Context, *ClientTagRequest) (*ClientTagResponse, error)
+	// Measures the round-trip time to a gClient
+	ClientPing(context.Context, *ClientPingRequest) (*ClientPingResponse, error)
+	// Measures the throughput to and from a gClient
+	ClientSpeedTest(context.Context, *ClientSpeedTestRequest) (*ClientSpeedTestResponse, error)
+	// Has a gClient scan its network for open TCP ports
+	ClientScan(*ClientScanRequest, AdminService_ClientScanServer) error
+	// Writes a file to a gClient. The first chunk names the gClient and
+	// the file
+	FileUpload(AdminService_FileUploadServer) error
+	// Reads a file from a gClient
+	FileDownload(*FileDownloadRequest, AdminService_FileDownloadServer) error
+	// Runs an interactive shell on a gClient that was built with shell
+	// support. The first input names the gClient
+	ClientShell(AdminService_ClientShellServer) error
+	// Lists the loopback aliases assigned to tunnel destinations
+	AliasList(*AliasListRequest, AdminService_AliasListServer) error
+	// Sets the log level of gServer at runtime
+	LogLevelSet(context.Context, *LogLevelSetRequest) (*LogLevelSetResponse, error)
 	// Lists all connected gClients
-	ClientList(ctx context.Context, in *ClientListRequest, opts ...grpc.CallOption) (AdminService_ClientListClient, error)
+	ClientList(*ClientListRequest, AdminService_ClientListServer) error
 	// List all connections for a tunnel
-	ConnectionList(ctx context.Context, in *ConnectionListRequest, opts ...grpc.CallOption) (AdminService_ConnectionListClient, error)
+	ConnectionList(*ConnectionListRequest, AdminService_ConnectionListServer) error
 	// Starts a SocksV5 server on a gClient
-	SocksStart(ctx context.Context, in *SocksStartRequest, opts ...grpc.CallOption) (*SocksStartResponse, error)
+	SocksStart(context.Context, *SocksStartRequest) (*SocksStartResponse, error)
 	// Stops a SocksV5 server on a gClient
-	SocksStop(ctx context.Context, in *SocksStopRequest, opts ...grpc.CallOption) (*SocksStopResponse, error)
+	SocksStop(context.Context, *SocksStopRequest) (*SocksStopResponse, error)
 	// Add a tunnel
-	TunnelAdd(ctx context.Context, in *TunnelAddRequest, opts ...grpc.CallOption) (*TunnelAddResponse, error)
+	TunnelAdd(context.Context, *TunnelAddRequest) (*TunnelAddResponse, error)
 	// Delete a tunnel
-	TunnelDelete(ctx context.Context, in *TunnelDeleteRequest, opts ...grpc.CallOption) (*TunnelDeleteResponse, error)
+	TunnelDelete(context.Context, *TunnelDeleteRequest) (*TunnelDeleteResponse, error)
 	// List all tunnels for an endppoint
-	TunnelList(ctx context.Context, in *TunnelListRequest, opts ...grpc.CallOption) (AdminService_TunnelListClient, error)
+	TunnelList(*TunnelListRequest, AdminService_TunnelListServer) error
+	// Stops a tunnel from accepting new connections, optionally freezing
+	// its existing connections
+	TunnelPause(context.Context, *TunnelPauseRequest) (*TunnelPauseResponse, error)
+	// Resumes a paused tunnel
+	TunnelResume(context.Context, *TunnelResumeRequest) (*TunnelResumeResponse, error)
+	// Adds many tunnels at once. Either all of them are added or none
+	TunnelImport(context.Context, *TunnelImportRequest) (*TunnelImportResponse, error)
 }
 
-type adminServiceClient struct {
-	cc grpc.ClientConnInterface
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
 }
 
-func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
-	return &adminServiceClient{cc}
+func (*UnimplementedAdminServiceServer) ClientRegister(context.Context, *ClientRegisterRequest) (*ClientRegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientRegister not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientDisconnect(context.Context, *ClientDisconnectRequest) (*ClientDisconnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientDisconnect not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientConfigure(context.Context, *ClientConfigureRequest) (*ClientConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientConfigure not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientUnban(context.Context, *ClientUnbanRequest) (*ClientUnbanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientUnban not implemented")
+}
+func (*UnimplementedAdminServiceServer) BanList(*BanListRequest, AdminService_BanListServer) error {
+	return status.Errorf(codes.Unimplemented, "method BanList not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientRename(context.Context, *ClientRenameRequest) (*ClientRenameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientRename not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientTag(context.Context, *ClientTagRequest) (*ClientTagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientTag not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientPing(context.Context, *ClientPingRequest) (*ClientPingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientPing not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientSpeedTest(context.Context, *ClientSpeedTestRequest) (*ClientSpeedTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClientSpeedTest not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientScan(*ClientScanRequest, AdminService_ClientScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClientScan not implemented")
+}
+func (*UnimplementedAdminServiceServer) FileUpload(AdminService_FileUploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method FileUpload not implemented")
+}
+func (*UnimplementedAdminServiceServer) FileDownload(*FileDownloadRequest, AdminService_FileDownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method FileDownload not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientShell(AdminService_ClientShellServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClientShell not implemented")
+}
+func (*UnimplementedAdminServiceServer) AliasList(*AliasListRequest, AdminService_AliasListServer) error {
+	return status.Errorf(codes.Unimplemented, "method AliasList not implemented")
+}
+func (*UnimplementedAdminServiceServer) LogLevelSet(context.Context, *LogLevelSetRequest) (*LogLevelSetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogLevelSet not implemented")
+}
+func (*UnimplementedAdminServiceServer) ClientList(*ClientListRequest, AdminService_ClientListServer) error {
+	return status.Errorf(codes.Unimplemented, "method ClientList not implemented")
+}
+func (*UnimplementedAdminServiceServer) ConnectionList(*ConnectionListRequest, AdminService_ConnectionListServer) error {
+	return status.Errorf(codes.Unimplemented, "method ConnectionList not implemented")
+}
+func (*UnimplementedAdminServiceServer) SocksStart(context.Context, *SocksStartRequest) (*SocksStartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SocksStart not implemented")
+}
+func (*UnimplementedAdminServiceServer) SocksStop(context.Context, *SocksStopRequest) (*SocksStopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SocksStop not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelAdd(context.Context, *TunnelAddRequest) (*TunnelAddResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelAdd not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelDelete(context.Context, *TunnelDeleteRequest) (*TunnelDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelDelete not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelList(*TunnelListRequest, AdminService_TunnelListServer) error {
+	return status.Errorf(codes.Unimplemented, "method TunnelList not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelPause(context.Context, *TunnelPauseRequest) (*TunnelPauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelPause not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelResume(context.Context, *TunnelResumeRequest) (*TunnelResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelResume not implemented")
+}
+func (*UnimplementedAdminServiceServer) TunnelImport(context.Context, *TunnelImportRequest) (*TunnelImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TunnelImport not implemented")
 }
 
-func (c *adminServiceClient) ClientRegister(ctx context.Context, in *ClientRegisterRequest, opts ...grpc.CallOption) (*ClientRegisterResponse, error) {
-	out := new(ClientRegisterResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientRegister", in, out, opts...)
-	if err != nil {
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_ClientRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRegisterRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientRegister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientRegister",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientRegister(ctx, req.(*ClientRegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *adminServiceClient) ClientDisconnect(ctx context.Context, in *ClientDisconnectRequest, opts ...grpc.CallOption) (*ClientDisconnectResponse, error) {
-	out := new(ClientDisconnectResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/ClientDisconnect", in, out, opts...)
-	if err != nil {
+func _AdminService_ClientDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientDisconnectRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientDisconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientDisconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientDisconnect(ctx, req.(*ClientDisconnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *adminServiceClient) ClientList(ctx context.Context, in *ClientListRequest, opts ...grpc.CallOption) (AdminService_ClientListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[0], "/admin.AdminService/ClientList", opts...)
-	if err != nil {
+func _AdminService_ClientConfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientConfigureRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &adminServiceClientListClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientConfigure(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientConfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientConfigure(ctx, req.(*ClientConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClientUnban_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientUnbanRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return x, nil
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientUnban(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientUnban",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientUnban(ctx, req.(*ClientUnbanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type AdminService_ClientListClient interface {
-	Recv() (*Client, error)
-	grpc.ClientStream
+func _AdminService_BanList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BanListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).BanList(m, &adminServiceBanListServer{stream})
 }
 
-type adminServiceClientListClient struct {
-	grpc.ClientStream
+type AdminService_BanListServer interface {
+	Send(*Ban) error
+	grpc.ServerStream
 }
 
-func (x *adminServiceClientListClient) Recv() (*Client, error) {
-	m := new(Client)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+type adminServiceBanListServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceBanListServer) Send(m *Ban) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_ClientRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientRenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientRename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientRename(ctx, req.(*ClientRenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClientTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientTagRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientTag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientTag(ctx, req.(*ClientTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ClientPing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientPingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientPing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientPing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientPing(ctx, req.(*ClientPingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *adminServiceClient) ConnectionList(ctx context.Context, in *ConnectionListRequest, opts ...grpc.CallOption) (AdminService_ConnectionListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[1], "/admin.AdminService/ConnectionList", opts...)
-	if err != nil {
+func _AdminService_ClientSpeedTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClientSpeedTestRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &adminServiceConnectionListClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ClientSpeedTest(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/ClientSpeedTest",
 	}
-	return x, nil
-}
-
-type AdminService_ConnectionListClient interface {
-	Recv() (*Connection, error)
-	grpc.ClientStream
-}
-
-type adminServiceConnectionListClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ClientSpeedTest(ctx, req.(*ClientSpeedTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *adminServiceConnectionListClient) Recv() (*Connection, error) {
-	m := new(Connection)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func _AdminService_ClientScan_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ClientScanRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return m, nil
+	return srv.(AdminServiceServer).ClientScan(m, &adminServiceClientScanServer{stream})
 }
 
-func (c *adminServiceClient) SocksStart(ctx context.Context, in *SocksStartRequest, opts ...grpc.CallOption) (*SocksStartResponse, error) {
-	out := new(SocksStartResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/SocksStart", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type AdminService_ClientScanServer interface {
+	Send(*ScanResult) error
+	grpc.ServerStream
 }
 
-func (c *adminServiceClient) SocksStop(ctx context.Context, in *SocksStopRequest, opts ...grpc.CallOption) (*SocksStopResponse, error) {
-	out := new(SocksStopResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/SocksStop", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type adminServiceClientScanServer struct {
+	grpc.ServerStream
 }
 
-func (c *adminServiceClient) TunnelAdd(ctx context.Context, in *TunnelAddRequest, opts ...grpc.CallOption) (*TunnelAddResponse, error) {
-	out := new(TunnelAddResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelAdd", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *adminServiceClientScanServer) Send(m *ScanResult) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (c *adminServiceClient) TunnelDelete(ctx context.Context, in *TunnelDeleteRequest, opts ...grpc.CallOption) (*TunnelDeleteResponse, error) {
-	out := new(TunnelDeleteResponse)
-	err := c.cc.Invoke(ctx, "/admin.AdminService/TunnelDelete", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func _AdminService_FileUpload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).FileUpload(&adminServiceFileUploadServer{stream})
 }
 
-func (c *adminServiceClient) TunnelList(ctx context.Context, in *TunnelListRequest, opts ...grpc.CallOption) (AdminService_TunnelListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[2], "/admin.AdminService/TunnelList", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &adminServiceTunnelListClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+type AdminService_FileUploadServer interface {
+	SendAndClose(*FileTransferResponse) error
+	Recv() (*FileChunk, error)
+	grpc.ServerStream
 }
 
-type AdminService_TunnelListClient interface {
-	Recv() (*Tunnel, error)
-	grpc.ClientStream
+type adminServiceFileUploadServer struct {
+	grpc.ServerStream
 }
 
-type adminServiceTunnelListClient struct {
-	grpc.ClientStream
+func (x *adminServiceFileUploadServer) SendAndClose(m *FileTransferResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (x *adminServiceTunnelListClient) Recv() (*Tunnel, error) {
-	m := new(Tunnel)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func (x *adminServiceFileUploadServer) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-// AdminServiceServer is the server API for AdminService service.
-type AdminServiceServer interface {
-	// Generates a configred gClient executable
-	ClientRegister(context.Context, *ClientRegisterRequest) (*ClientRegisterResponse, error)
-	// Disconnects a client from the server
-	ClientDisconnect(context.Context, *ClientDisconnectRequest) (*ClientDisconnectResponse, error)
-	// Lists all connected gClients
-	ClientList(*ClientListRequest, AdminService_ClientListServer) error
-	// List all connections for a tunnel
-	ConnectionList(*ConnectionListRequest, AdminService_ConnectionListServer) error
-	// Starts a SocksV5 server on a gClient
-	SocksStart(context.Context, *SocksStartRequest) (*SocksStartResponse, error)
-	// Stops a SocksV5 server on a gClient
-	SocksStop(context.Context, *SocksStopRequest) (*SocksStopResponse, error)
-	// Add a tunnel
-	TunnelAdd(context.Context, *TunnelAddRequest) (*TunnelAddResponse, error)
-	// Delete a tunnel
-	TunnelDelete(context.Context, *TunnelDeleteRequest) (*TunnelDeleteResponse, error)
-	// List all tunnels for an endppoint
-	TunnelList(*TunnelListRequest, AdminService_TunnelListServer) error
+func _AdminService_FileDownload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FileDownloadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).FileDownload(m, &adminServiceFileDownloadServer{stream})
 }
 
-// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedAdminServiceServer struct {
+type AdminService_FileDownloadServer interface {
+	Send(*FileChunk) error
+	grpc.ServerStream
 }
 
-func (*UnimplementedAdminServiceServer) ClientRegister(context.Context, *ClientRegisterRequest) (*ClientRegisterResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ClientRegister not implemented")
+type adminServiceFileDownloadServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedAdminServiceServer) ClientDisconnect(context.Context, *ClientDisconnectRequest) (*ClientDisconnectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ClientDisconnect not implemented")
+
+func (x *adminServiceFileDownloadServer) Send(m *FileChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedAdminServiceServer) ClientList(*ClientListRequest, AdminService_ClientListServer) error {
-	return status.Errorf(codes.Unimplemented, "method ClientList not implemented")
+
+func _AdminService_ClientShell_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).ClientShell(&adminServiceClientShellServer{stream})
 }
-func (*UnimplementedAdminServiceServer) ConnectionList(*ConnectionListRequest, AdminService_ConnectionListServer) error {
-	return status.Errorf(codes.Unimplemented, "method ConnectionList not implemented")
+
+type AdminService_ClientShellServer interface {
+	Send(*ShellOutput) error
+	Recv() (*ShellInput, error)
+	grpc.ServerStream
 }
-func (*UnimplementedAdminServiceServer) SocksStart(context.Context, *SocksStartRequest) (*SocksStartResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SocksStart not implemented")
+
+type adminServiceClientShellServer struct {
+	grpc.ServerStream
 }
-func (*UnimplementedAdminServiceServer) SocksStop(context.Context, *SocksStopRequest) (*SocksStopResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SocksStop not implemented")
+
+func (x *adminServiceClientShellServer) Send(m *ShellOutput) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (*UnimplementedAdminServiceServer) TunnelAdd(context.Context, *TunnelAddRequest) (*TunnelAddResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TunnelAdd not implemented")
+
+func (x *adminServiceClientShellServer) Recv() (*ShellInput, error) {
+	m := new(ShellInput)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (*UnimplementedAdminServiceServer) TunnelDelete(context.Context, *TunnelDeleteRequest) (*TunnelDeleteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TunnelDelete not implemented")
+
+func _AdminService_AliasList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AliasListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).AliasList(m, &adminServiceAliasListServer{stream})
 }
-func (*UnimplementedAdminServiceServer) TunnelList(*TunnelListRequest, AdminService_TunnelListServer) error {
-	return status.Errorf(codes.Unimplemented, "method TunnelList not implemented")
+
+type AdminService_AliasListServer interface {
+	Send(*Alias) error
+	grpc.ServerStream
 }
 
-func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
-	s.RegisterService(&_AdminService_serviceDesc, srv)
+type adminServiceAliasListServer struct {
+	grpc.ServerStream
 }
 
-func _AdminService_ClientRegister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientRegisterRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(AdminServiceServer).ClientRegister(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/admin.AdminService/ClientRegister",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServiceServer).ClientRegister(ctx, req.(*ClientRegisterRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (x *adminServiceAliasListServer) Send(m *Alias) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _AdminService_ClientDisconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ClientDisconnectRequest)
+func _AdminService_LogLevelSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogLevelSetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AdminServiceServer).ClientDisconnect(ctx, in)
+		return srv.(AdminServiceServer).LogLevelSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/admin.AdminService/ClientDisconnect",
+		FullMethod: "/admin.AdminService/LogLevelSet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AdminServiceServer).ClientDisconnect(ctx, req.(*ClientDisconnectRequest))
+		return srv.(AdminServiceServer).LogLevelSet(ctx, req.(*LogLevelSetRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1933,6 +5535,60 @@ func (x *adminServiceTunnelListServer) Send(m *Tunnel) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _AdminService_TunnelPause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TunnelPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TunnelPause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/TunnelPause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TunnelPause(ctx, req.(*TunnelPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TunnelResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TunnelResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TunnelResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/TunnelResume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TunnelResume(ctx, req.(*TunnelResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_TunnelImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TunnelImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).TunnelImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.AdminService/TunnelImport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).TunnelImport(ctx, req.(*TunnelImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _AdminService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "admin.AdminService",
 	HandlerType: (*AdminServiceServer)(nil),
@@ -1945,6 +5601,34 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ClientDisconnect",
 			Handler:    _AdminService_ClientDisconnect_Handler,
 		},
+		{
+			MethodName: "ClientConfigure",
+			Handler:    _AdminService_ClientConfigure_Handler,
+		},
+		{
+			MethodName: "ClientUnban",
+			Handler:    _AdminService_ClientUnban_Handler,
+		},
+		{
+			MethodName: "ClientRename",
+			Handler:    _AdminService_ClientRename_Handler,
+		},
+		{
+			MethodName: "ClientTag",
+			Handler:    _AdminService_ClientTag_Handler,
+		},
+		{
+			MethodName: "ClientPing",
+			Handler:    _AdminService_ClientPing_Handler,
+		},
+		{
+			MethodName: "ClientSpeedTest",
+			Handler:    _AdminService_ClientSpeedTest_Handler,
+		},
+		{
+			MethodName: "LogLevelSet",
+			Handler:    _AdminService_LogLevelSet_Handler,
+		},
 		{
 			MethodName: "SocksStart",
 			Handler:    _AdminService_SocksStart_Handler,
@@ -1961,8 +5645,51 @@ var _AdminService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TunnelDelete",
 			Handler:    _AdminService_TunnelDelete_Handler,
 		},
+		{
+			MethodName: "TunnelPause",
+			Handler:    _AdminService_TunnelPause_Handler,
+		},
+		{
+			MethodName: "TunnelResume",
+			Handler:    _AdminService_TunnelResume_Handler,
+		},
+		{
+			MethodName: "TunnelImport",
+			Handler:    _AdminService_TunnelImport_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BanList",
+			Handler:       _AdminService_BanList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientScan",
+			Handler:       _AdminService_ClientScan_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FileUpload",
+			Handler:       _AdminService_FileUpload_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "FileDownload",
+			Handler:       _AdminService_FileDownload_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientShell",
+			Handler:       _AdminService_ClientShell_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "AliasList",
+			Handler:       _AdminService_AliasList_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ClientList",
 			Handler:       _AdminService_ClientList_Handler,
@@ -3,29 +3,127 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/kai5263499/gtunnel/common"
 	"github.com/kai5263499/gtunnel/gserver/gserverlib"
 )
 
 var (
-	tls        = flag.Bool("tls", true, "Connection uses TLS if true, else plain HTTP")
-	certFile   = flag.String("cert_file", "tls/cert", "The TLS cert file")
-	keyFile    = flag.String("key_file", "tls/key", "The TLS key file")
-	clientPort = flag.Int("clientPort", 443, "The server port")
-	adminPort  = flag.Int("adminPort", 1337, "The server port")
-	logfile    = flag.String("logFile", "", "The file where log output will be written")
+	tls           = flag.Bool("tls", true, "Connection uses TLS if true, else plain HTTP")
+	certFile      = flag.String("cert_file", "tls/cert", "The TLS cert file")
+	keyFile       = flag.String("key_file", "tls/key", "The TLS key file")
+	clientPort    = flag.Int("clientPort", 443, "The server port")
+	wsPort        = flag.Int("webSocketPort", 0, "The port on which clients may connect over the websocket transport. Disabled if 0")
+	longPollPort  = flag.Int("longPollPort", 0, "The port on which clients may connect over the long-poll transport. Disabled if 0")
+	quicPort      = flag.Int("quicPort", 0, "The UDP port on which clients may connect over the quic transport. Disabled if 0")
+	dnsTransport  = flag.String("dnsTransport", "", "The udp address, such as :53, on which clients may connect over the dns transport. Disabled if empty")
+	dnsDomain     = flag.String("dnsDomain", "", "The domain of the dns transport, for which this server has to be the authoritative name server")
+	grpcNames     = flag.String("grpcNames", "", "Semicolon separated gRPC names that clients were built with, such as google.pubsub.v1.Publisher,Heartbeat=Publish. The original names are always accepted")
+	icmpTransport = flag.String("icmpTransport", "", "The ipv4 address, such as 0.0.0.0, on which clients may connect over the icmp transport. Needs raw sockets. Disabled if empty")
+	adminPort     = flag.Int("adminPort", 1337, "The server port")
+	restPort      = flag.Int("restPort", 0, "The port for the admin rest api and web dashboard. Disabled if 0")
+	metricsPort   = flag.Int("metricsPort", 0, "The port for the prometheus metrics endpoint. Disabled if 0")
+	pprofPort     = flag.Int("pprofPort", 0, "The localhost port for the pprof debug endpoints. Disabled if 0")
+	otlpEndpoint  = flag.String("otlpEndpoint", "", "The OTLP/HTTP traces url of an OpenTelemetry collector, such as http://localhost:4318/v1/traces. Disabled if empty")
+	logfile       = flag.String("logFile", "", "The file where log output will be written")
+	logMaxSize    = flag.Int("logMaxSize", 0, "The size in megabytes at which the log file is rotated. Disabled if 0")
+	logMaxAge     = flag.Duration("logMaxAge", 0, "The age at which the log file is rotated. Disabled if 0")
+	logMaxBackups = flag.Int("logMaxBackups", 0, "The number of rotated log files to keep. All are kept if 0")
+	logRetain     = flag.Duration("logRetain", 0, "How long rotated log files are kept. Forever if 0")
+	logLevel      = flag.String("logLevel", "info", "The log level: debug, info, warn, error or none")
+	logShip       = flag.String("logShip", "", "A syslog destination that log output is also shipped to, such as udp://host:514, tls://host:6514 or unix:///dev/log")
+	geoIP         = flag.String("geoip", "", "A comma separated list of MaxMind databases used to look up the country and ASN of endpoints. Disabled if empty")
+	hostsFile     = flag.String("hostsFile", "", "A hosts file in which to publish tunnel hostnames. Disabled if empty")
+	dnsStub       = flag.String("dnsStub", "", "The udp address of a dns stub serving tunnel hostnames. Disabled if empty")
+	webhook       = flag.String("webhook", "", "A url that events are posted to as JSON. Disabled if empty")
+	webhookSecret = flag.String("webhookSecret", "", "A secret used to sign webhook requests with HMAC-SHA256")
+	webhookEvents = flag.String("webhookEvents", "", "A comma separated list of event types posted to the webhook. All if empty")
+	slackHook     = flag.String("slackWebhook", "", "A Slack incoming webhook url notified when endpoints check in or go silent. Disabled if empty")
+	discordHook   = flag.String("discordWebhook", "", "A Discord webhook url notified when endpoints check in or go silent. Disabled if empty")
+	heartbeat     = flag.Duration("heartbeat", gserverlib.DefaultHeartbeatInterval, "How often endpoints send a heartbeat. Disabled if 0")
+	orphanGrace   = flag.Duration("orphanGrace", gserverlib.DefaultOrphanGracePeriod,
+		"How long the tunnels of a lost endpoint are kept waiting for it to return")
+	grpcKeepalive = flag.Duration("grpcKeepalive", 0, "The idle time after which the server pings clients. The gRPC default of 2h if 0")
+	grpcTimeout   = flag.Duration("grpcKeepaliveTimeout", gserverlib.DefaultGRPCKeepaliveTimeout, "How long a keepalive ping may go unanswered before the connection is closed")
+	grpcMinPing   = flag.Duration("grpcMinPing", gserverlib.DefaultGRPCMinPing, "The shortest interval at which clients may send keepalive pings")
+	drainTimeout  = flag.Duration("drainTimeout", gserverlib.DefaultDrainTimeout, "How long a shutdown waits for active connections to finish")
+	grpcIdlePings = flag.Bool("grpcPermitWithoutStream", true, "Allow clients to send keepalive pings while they have no active streams")
+	grpcMaxRecv   = flag.Int("grpcMaxRecvMsgSize", 0, "The largest gRPC message in bytes that the server receives from clients. The gRPC default of 4MB if 0")
+	grpcMaxSend   = flag.Int("grpcMaxSendMsgSize", 0, "The largest gRPC message in bytes that the server sends to clients. Unlimited if 0")
+	adminTLS      = flag.Bool("adminTLS", false, "Serve the admin grpc and rest apis and the metrics endpoint over TLS with cert_file and key_file, for remote consoles")
+	adminTokens   = flag.String("adminTokens", "", "A file of name:token lines. Calls to the admin grpc and rest apis and the metrics endpoint must carry one of the tokens. Disabled if empty")
+	grpcWebPort   = flag.Int("grpcWebPort", 0, "The port on which the admin grpc api is served to browsers with grpc-web, over TLS with adminTLS. Disabled if 0")
+	grpcWebOrigin = flag.String("grpcWebOrigins", "", "A comma separated list of origins, such as https://dashboard:8443, whose pages may call the grpc-web api. Any origin if *, only same origin requests if empty")
+	rateLimit     = flag.Uint64("rateLimit", 0, "The bandwidth in bytes per second that all tunnels may use together. Unlimited if 0")
+	endpointLimit = flag.Uint64("endpointRateLimit", 0, "The bandwidth in bytes per second that the tunnels of each endpoint may use together. Unlimited if 0")
+	ctrlTimeout   = flag.Duration("controlTimeout", gserverlib.DefaultControlTimeout, "How long tunnel operations wait for an endpoint to respond before they fail")
+	clientAllow   = flag.String("clientAllow", "", "A comma separated list of IPs and CIDRs, such as 203.0.113.0/24, that may connect to the client port. Anything else is reset. Any address if empty")
+	regLimit      = flag.Int("registrationLimit", 0, "How many times per minute a source address may try to register an endpoint. Unlimited if 0")
+	listenPorts   = flag.String("listenPorts", "", "A comma separated list of ports and ranges, such as 1024-65535, on which forward tunnels may listen. Any port if empty")
+	serverKey     = flag.String("serverKey", "tls/server_key", "The file of the static key that authenticates the key exchange of encrypted tunnels, generated if missing. gClients are built with its public key, which is logged at startup")
+	configFile    = flag.String("config", "", "A JSON configuration file with settings named after these flags, clients and tunnels. Flags on the command line take precedence")
 )
 
 // What it do
 func main() {
 	flag.Parse()
 
+	cmdline := gserverlib.SetFlags(flag.CommandLine)
+	config := new(gserverlib.ServerConfig)
+	if *configFile != "" {
+		var err error
+		config, err = gserverlib.LoadServerConfig(*configFile)
+		if err == nil {
+			err = config.ApplyFlags(flag.CommandLine, cmdline)
+		}
+		if err != nil {
+			common.Log.Fatalf("Invalid config file %s: %s", *configFile, err)
+		}
+	}
+
+	level, err := common.ParseLogLevel(*logLevel)
+	if err != nil {
+		common.Log.Fatalf("%s", err)
+	}
+	common.SetLogLevel(level)
+
 	var filePath = ""
 	s := gserverlib.NewGServer()
+	s.ApplyConfig(config)
+
+	if *adminTokens != "" {
+		auth, err := gserverlib.LoadAdminAuth(*adminTokens)
+		if err != nil {
+			common.Log.Fatalf("Failed to load admin tokens: %s", err)
+		}
+		s.GetAdminServer().SetAuth(auth)
+	}
+
+	if *adminTLS {
+		s.GetAdminServer().SetTLS(*certFile, *keyFile)
+	}
+
+	key, err := common.LoadServerKey(*serverKey)
+	if err != nil {
+		common.Log.Fatalf("Failed to load server key: %s", err)
+	}
+	s.SetServerKey(key)
+	common.Log.Infof("Server key for building clients: %s", key)
+
+	if *grpcWebPort != 0 {
+		origins := []string{}
+		if *grpcWebOrigin != "" {
+			origins = strings.Split(*grpcWebOrigin, ",")
+		}
+		s.GetAdminServer().SetGRPCWeb(*grpcWebPort, origins)
+	}
 
 	if *logfile == "" {
 		time := strings.ReplaceAll(time.Now().UTC().String(), " ", "")
@@ -34,15 +132,189 @@ func main() {
 		filePath = *logfile
 	}
 
-	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	file, err := gserverlib.NewRotatingFile(filePath,
+		int64(*logMaxSize)*1024*1024, *logMaxAge, *logMaxBackups, *logRetain)
 
 	if err != nil {
-		log.Fatalf("[!] Failed to create log file.")
+		common.Log.Fatalf("Failed to create log file.")
 	}
 
-	log.Printf("Logging output to : %s\n", file.Name())
+	common.Log.Infof("Logging output to %s", file.Name())
 	log.SetOutput(file)
 
-	s.Start(*clientPort, *adminPort, *tls, *certFile, *keyFile)
+	if *logShip != "" {
+		shipper, err := gserverlib.NewLogShipper(*logShip)
+		if err != nil {
+			common.Log.Fatalf("Failed to connect to log destination: %s", err)
+		}
+		log.SetOutput(io.MultiWriter(file, shipper))
+	}
+
+	if *geoIP != "" {
+		resolver, err := gserverlib.NewGeoIPResolver(strings.Split(*geoIP, ","))
+		if err != nil {
+			common.Log.Fatalf("Failed to open GeoIP database: %s", err)
+		}
+		s.SetGeoIPResolver(resolver)
+	}
+
+	if *hostsFile != "" {
+		nameService, err := gserverlib.NewHostsFileNameService(*hostsFile)
+		if err != nil {
+			common.Log.Fatalf("Failed to open hosts file: %s", err)
+		}
+		s.AddNameService(nameService)
+	}
+
+	if *dnsStub != "" {
+		nameService, err := gserverlib.NewDNSStubNameService(*dnsStub)
+		if err != nil {
+			common.Log.Fatalf("Failed to start dns stub: %s", err)
+		}
+		s.AddNameService(nameService)
+	}
+
+	ports, err := common.ParsePortRanges(*listenPorts)
+	if err != nil {
+		common.Log.Fatalf("Invalid listen ports: %s", err)
+	}
+
+	allowlist, err := common.ParseNetworks(*clientAllow)
+	if err != nil {
+		common.Log.Fatalf("Invalid client allowlist: %s", err)
+	}
+
+	s.SetOrphanGracePeriod(*orphanGrace)
+	s.SetHeartbeatInterval(*heartbeat)
+	s.SetRateLimits(*rateLimit, *endpointLimit)
+	s.SetControlTimeout(*ctrlTimeout)
+	s.SetListenPorts(ports)
+	s.SetClientAllowlist(allowlist)
+	s.SetRegistrationLimit(*regLimit)
+	s.SetWebSocketPort(*wsPort)
+	s.SetLongPollPort(*longPollPort)
+	s.SetQUICPort(*quicPort)
+	if *dnsTransport != "" {
+		if *dnsDomain == "" {
+			common.Log.Fatalf("The dns transport requires a dnsDomain")
+		}
+		s.SetDNSTransport(*dnsTransport, *dnsDomain)
+	}
+	s.SetICMPTransport(*icmpTransport)
+
+	var names []*common.GRPCNames
+	for _, list := range strings.Split(*grpcNames, ";") {
+		if strings.TrimSpace(list) == "" {
+			continue
+		}
+		n, err := common.ParseGRPCNames(list)
+		if err != nil {
+			common.Log.Fatalf("Invalid grpc names: %s", err)
+		}
+		names = append(names, n)
+	}
+	s.SetGRPCNames(names)
+	s.SetGRPCKeepalive(gserverlib.GRPCKeepalive{
+		Time:                *grpcKeepalive,
+		Timeout:             *grpcTimeout,
+		MinPing:             *grpcMinPing,
+		PermitWithoutStream: *grpcIdlePings,
+	})
+	s.SetGRPCMsgSize(gserverlib.GRPCMsgSize{
+		MaxRecv: *grpcMaxRecv,
+		MaxSend: *grpcMaxSend,
+	})
+
+	if *webhook != "" {
+		var events []string
+		if *webhookEvents != "" {
+			events = strings.Split(*webhookEvents, ",")
+		}
+		notifier := gserverlib.NewWebhookNotifier(*webhook, *webhookSecret, events)
+		s.AddEventHandler(notifier.Handle)
+	}
+
+	if *slackHook != "" {
+		s.AddEventHandler(gserverlib.NewSlackNotifier(*slackHook).Handle)
+	}
+
+	if *discordHook != "" {
+		s.AddEventHandler(gserverlib.NewDiscordNotifier(*discordHook).Handle)
+	}
+
+	if *metricsPort != 0 {
+		go s.StartMetrics(*metricsPort)
+	}
+
+	if *pprofPort != 0 {
+		go gserverlib.StartPprof(*pprofPort)
+	}
+
+	if *otlpEndpoint != "" {
+		common.SetSpanExporter(gserverlib.NewOTLPExporter(*otlpEndpoint).Export)
+	}
+
+	// Drain connections instead of dropping them on termination
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	go func() {
+		<-signals
+		s.Shutdown(*drainTimeout)
+		os.Exit(0)
+	}()
+
+	if *configFile != "" {
+		reloads := make(chan os.Signal, 1)
+		signal.Notify(reloads, syscall.SIGHUP)
+		go func() {
+			for range reloads {
+				reloadConfig(s, cmdline)
+			}
+		}()
+	}
+
+	s.Start(*clientPort, *adminPort, *restPort, *tls, *certFile, *keyFile)
+
+}
+
+// reloadConfig reads the config file again and applies the settings
+// that can change without dropping endpoints. Other settings, such as
+// ports and TLS material, take effect on the next restart.
+func reloadConfig(s *gserverlib.GServer, cmdline map[string]bool) {
+	config, err := gserverlib.LoadServerConfig(*configFile)
+	if err == nil {
+		err = config.ApplyFlags(flag.CommandLine, cmdline)
+	}
+	if err != nil {
+		common.Log.Errorf("Failed to reload config file %s: %s", *configFile, err)
+		return
+	}
+
+	level, err := common.ParseLogLevel(*logLevel)
+	if err != nil {
+		common.Log.Errorf("Failed to reload config file %s: %s", *configFile, err)
+		return
+	}
+	ports, err := common.ParsePortRanges(*listenPorts)
+	if err != nil {
+		common.Log.Errorf("Failed to reload config file %s: %s", *configFile, err)
+		return
+	}
+
+	allowlist, err := common.ParseNetworks(*clientAllow)
+	if err != nil {
+		common.Log.Errorf("Failed to reload config file %s: %s", *configFile, err)
+		return
+	}
+
+	common.SetLogLevel(level)
+	s.SetOrphanGracePeriod(*orphanGrace)
+	s.SetRateLimits(*rateLimit, *endpointLimit)
+	s.SetControlTimeout(*ctrlTimeout)
+	s.SetListenPorts(ports)
+	s.SetClientAllowlist(allowlist)
+	s.SetRegistrationLimit(*regLimit)
+	s.ReloadConfig(config)
 
+	common.Log.Infof("Reloaded config file %s", *configFile)
 }
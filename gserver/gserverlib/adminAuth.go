@@ -0,0 +1,153 @@
+package gserverlib
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAuth authenticates the operators of the admin grpc api by their
+// bearer tokens.
+type AdminAuth struct {
+	operators map[string]string
+}
+
+// NewAdminAuth is a constructor for AdminAuth that accepts the provided
+// tokens, keyed by operator name.
+func NewAdminAuth(tokens map[string]string) *AdminAuth {
+	a := new(AdminAuth)
+	a.operators = make(map[string]string)
+	for name, token := range tokens {
+		a.operators[token] = name
+	}
+	return a
+}
+
+// LoadAdminAuth is a constructor for AdminAuth that reads a file with
+// one operator per line in the form name:token. Blank lines and lines
+// that start with # are skipped.
+func LoadAdminAuth(path string) (*AdminAuth, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tokens := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || len(parts[1]) < 16 {
+			return nil, fmt.Errorf("line %d: expected name:token with a token of at least 16 characters",
+				lineNumber)
+		}
+		tokens[parts[0]] = parts[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no tokens in %s", path)
+	}
+	return NewAdminAuth(tokens), nil
+}
+
+// errNoAdminToken and errInvalidAdminToken are why a call or request
+// is not authenticated.
+var (
+	errNoAdminToken      = errors.New("admin token is not provided")
+	errInvalidAdminToken = errors.New("invalid admin token")
+)
+
+// operator returns the name of the operator whose bearer token is in
+// the authorization header.
+func (a *AdminAuth) operator(authorization string) (string, error) {
+	if !strings.HasPrefix(authorization, common.BearerString) {
+		return "", errNoAdminToken
+	}
+	token := strings.TrimPrefix(authorization, common.BearerString)
+
+	for known, name := range a.operators {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			return name, nil
+		}
+	}
+	return "", errInvalidAdminToken
+}
+
+// authenticate returns the name of the operator whose token is in the
+// metadata of a call.
+func (a *AdminAuth) authenticate(ctx context.Context) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := md["authorization"]
+	if len(header) == 0 {
+		return "", status.Error(codes.Unauthenticated, errNoAdminToken.Error())
+	}
+	name, err := a.operator(header[0])
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+	return name, nil
+}
+
+// HTTPHandler rejects http requests without a valid admin token in
+// their Authorization header before they reach handler.
+func (a *AdminAuth) HTTPHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, err := a.operator(r.Header.Get("Authorization"))
+		if err != nil {
+			common.Log.Warnf("Rejected admin request to %s: %v", r.URL.Path, err)
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		common.Log.Debugf("Operator %s requested %s %s", name, r.Method, r.URL.Path)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// UnaryInterceptor rejects unary calls without a valid admin token.
+func (a *AdminAuth) UnaryInterceptor(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	name, err := a.authenticate(ctx)
+	if err != nil {
+		common.Log.Warnf("Rejected admin call to %s: %v", info.FullMethod, err)
+		return nil, err
+	}
+	common.Log.Debugf("Operator %s called %s", name, info.FullMethod)
+	return handler(ctx, req)
+}
+
+// StreamInterceptor rejects stream calls without a valid admin token.
+func (a *AdminAuth) StreamInterceptor(srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	name, err := a.authenticate(ss.Context())
+	if err != nil {
+		common.Log.Warnf("Rejected admin call to %s: %v", info.FullMethod, err)
+		return err
+	}
+	common.Log.Debugf("Operator %s called %s", name, info.FullMethod)
+	return handler(srv, ss)
+}
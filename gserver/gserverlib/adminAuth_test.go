@@ -0,0 +1,53 @@
+package gserverlib
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAdminAuth(t *testing.T) {
+	auth := NewAdminAuth(map[string]string{"alice": "0123456789abcdef"})
+
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{common.BearerString + "0123456789abcdef", "alice", true},
+		{common.BearerString + "0123456789abcdeg", "", false},
+		{"0123456789abcdef", "", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		ctx := context.Background()
+		if test.header != "" {
+			ctx = metadata.NewIncomingContext(ctx,
+				metadata.Pairs("authorization", test.header))
+		}
+		name, err := auth.authenticate(ctx)
+		if name != test.want || (err == nil) != test.ok {
+			t.Errorf("authenticate(%q): Got: %q %v Want: %q",
+				test.header, name, err, test.want)
+		}
+
+		// The rest api and metrics endpoint take the same tokens
+		handler := auth.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/clients", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if (w.Code == http.StatusNoContent) != test.ok {
+			t.Errorf("HTTPHandler(%q): answered %d", test.header, w.Code)
+		}
+	}
+}
@@ -3,13 +3,15 @@ package gserverlib
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/kai5263499/gtunnel/common"
 	as "github.com/kai5263499/gtunnel/grpc/admin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
@@ -18,7 +20,12 @@ import (
 // grpc functions for the AdminServiceServer
 type AdminServiceServer struct {
 	as.UnimplementedAdminServiceServer
-	gServer *GServer
+	gServer        *GServer
+	auth           *AdminAuth
+	certFile       string
+	keyFile        string
+	grpcWebPort    int
+	grpcWebOrigins []string
 }
 
 // NewAdminServiceServer is a constructor that returns an AdminServiceServer
@@ -32,7 +39,7 @@ func NewAdminServiceServer(gServer *GServer) *AdminServiceServer {
 // ClientRegister will create a gClient binary and send it back in a binary stream.
 func (s *AdminServiceServer) ClientRegister(ctx context.Context, req *as.ClientRegisterRequest) (
 	*as.ClientRegisterResponse, error) {
-	log.Printf("[*] ClientRegister called")
+	common.Log.Debugf("ClientRegister called")
 
 	ip := common.Int32ToIP(req.IpAddress)
 
@@ -52,33 +59,400 @@ func (s *AdminServiceServer) ClientRegister(ctx context.Context, req *as.ClientR
 	return resp, err
 }
 
-// ClientDisconnect will disconnect a gClient from gServer.
+// ClientDisconnect will disconnect a gClient from gServer and
+// optionally ban it from registering again.
 func (s *AdminServiceServer) ClientDisconnect(ctx context.Context, req *as.ClientDisconnectRequest) (
 	*as.ClientDisconnectResponse, error) {
-	log.Printf("[*] ClientDisconnect called")
+	common.Log.Debugf("ClientDisconnect called")
 
-	id := req.ClientId
+	id := s.gServer.ResolveEndpointID(req.ClientId)
 
-	s.gServer.DisconnectEndpoint(id)
+	if req.Ban || req.BanToken {
+		if err := s.gServer.BanEndpoint(id, req.BanToken); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	err := s.gServer.DisconnectEndpoint(id)
+
+	// Banning an endpoint that is not connected is fine
+	if err != nil && !req.Ban && !req.BanToken {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
 
 	resp := new(as.ClientDisconnectResponse)
 
 	return resp, nil
 }
 
+// ClientUnban allows a banned gClient to register again.
+func (s *AdminServiceServer) ClientUnban(ctx context.Context, req *as.ClientUnbanRequest) (
+	*as.ClientUnbanResponse, error) {
+	common.Log.Debugf("ClientUnban called")
+
+	if err := s.gServer.UnbanEndpoint(req.ClientId); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return new(as.ClientUnbanResponse), nil
+}
+
+// BanList lists all banned gClients.
+func (s *AdminServiceServer) BanList(req *as.BanListRequest,
+	stream as.AdminService_BanListServer) error {
+	common.Log.Debugf("BanList called")
+
+	bans := s.gServer.GetBans()
+
+	if len(bans) == 0 {
+		return status.Error(codes.OutOfRange, "no bans exist")
+	}
+
+	for _, ban := range bans {
+		resp := new(as.Ban)
+		resp.ClientId = ban.ClientID
+		resp.TokenBanned = ban.TokenBanned
+		resp.Date = ban.Date.Unix()
+		stream.Send(resp)
+	}
+
+	return nil
+}
+
+// AliasList will list the loopback aliases that have been assigned
+// to tunnel destinations.
+func (s *AdminServiceServer) AliasList(req *as.AliasListRequest,
+	stream as.AdminService_AliasListServer) error {
+	common.Log.Debugf("AliasList called")
+
+	aliases := s.gServer.GetLoopbackAliases()
+
+	if len(aliases) == 0 {
+		return status.Error(codes.OutOfRange, "no aliases exist")
+	}
+
+	for destination, alias := range aliases {
+		resp := new(as.Alias)
+		resp.DestinationIp = common.IpToInt32(net.ParseIP(destination))
+		resp.AliasIp = common.IpToInt32(alias)
+		stream.Send(resp)
+	}
+
+	return nil
+}
+
+// ClientConfigure will set the byte stream MTU and keepalive interval
+// of a connected gClient.
+func (s *AdminServiceServer) ClientConfigure(ctx context.Context, req *as.ClientConfigureRequest) (
+	*as.ClientConfigureResponse, error) {
+	common.Log.Debugf("ClientConfigure called")
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	keepalive := time.Duration(req.KeepaliveInterval) * time.Second
+	dialPolicy := req.DialAllow != "" || req.DialDeny != ""
+
+	// A request that only sets a dial policy leaves the rest of the
+	// configuration alone.
+	if !dialPolicy || req.Mtu != 0 || req.KeepaliveInterval != 0 {
+		err := s.gServer.ConfigureEndpoint(clientID, req.Mtu, keepalive)
+
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	if dialPolicy {
+		err := s.gServer.SetEndpointDialPolicy(clientID, req.DialAllow,
+			req.DialDeny)
+
+		if err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+
+	return new(as.ClientConfigureResponse), nil
+}
+
+// ClientRename assigns an alias to a connected gClient that can be used
+// in place of its ID. An empty alias removes it.
+func (s *AdminServiceServer) ClientRename(ctx context.Context, req *as.ClientRenameRequest) (
+	*as.ClientRenameResponse, error) {
+	common.Log.Debugf("ClientRename called")
+
+	err := s.gServer.RenameEndpoint(req.ClientId, req.Alias)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return new(as.ClientRenameResponse), nil
+}
+
+// ClientTag adds tags to and removes tags from a connected gClient.
+func (s *AdminServiceServer) ClientTag(ctx context.Context, req *as.ClientTagRequest) (
+	*as.ClientTagResponse, error) {
+	common.Log.Debugf("ClientTag called")
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	tags, err := s.gServer.TagEndpoint(clientID, req.Add, req.Remove)
+
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	resp := new(as.ClientTagResponse)
+	resp.Tags = tags
+	return resp, nil
+}
+
+// ClientPing measures the round-trip time to a connected gClient.
+func (s *AdminServiceServer) ClientPing(ctx context.Context, req *as.ClientPingRequest) (
+	*as.ClientPingResponse, error) {
+	common.Log.Debugf("ClientPing called")
+
+	count := int(req.Count)
+	if count == 0 {
+		count = defaultPingCount
+	} else if count > maxPingCount {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"count must be at most %d", maxPingCount)
+	}
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	result, err := s.gServer.PingEndpoint(clientID, count, req.Echo)
+
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	resp := new(as.ClientPingResponse)
+	for _, rtt := range result.Control {
+		resp.ControlRtts = append(resp.ControlRtts, rtt.Microseconds())
+	}
+	for _, rtt := range result.Stream {
+		resp.StreamRtts = append(resp.StreamRtts, rtt.Microseconds())
+	}
+	return resp, nil
+}
+
+// ClientSpeedTest measures the throughput to and from a connected
+// gClient.
+func (s *AdminServiceServer) ClientSpeedTest(ctx context.Context, req *as.ClientSpeedTestRequest) (
+	*as.ClientSpeedTestResponse, error) {
+	common.Log.Debugf("ClientSpeedTest called")
+
+	size := req.Bytes
+	if size == 0 {
+		size = defaultSpeedTestBytes
+	} else if size > maxSpeedTestBytes {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"bytes must be at most %d", maxSpeedTestBytes)
+	}
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	result, err := s.gServer.SpeedTestEndpoint(clientID, size)
+
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	resp := new(as.ClientSpeedTestResponse)
+	resp.Bytes = result.Bytes
+	resp.UploadMicros = result.Upload.Microseconds()
+	resp.DownloadMicros = result.Download.Microseconds()
+	return resp, nil
+}
+
+// ClientScan has a connected gClient scan its network for open TCP
+// ports and streams back each one it finds.
+func (s *AdminServiceServer) ClientScan(req *as.ClientScanRequest,
+	stream as.AdminService_ClientScanServer) error {
+	common.Log.Debugf("ClientScan called")
+
+	timeout := time.Duration(req.Timeout) * time.Millisecond
+	if _, err := common.NewPortScan(req.Hosts, req.Ports, timeout); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	err := s.gServer.ScanEndpoint(stream.Context(), clientID, req.Hosts,
+		req.Ports, timeout, func(address string) error {
+			resp := new(as.ScanResult)
+			resp.Address = address
+			return stream.Send(resp)
+		})
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return nil
+}
+
+// FileUpload writes a file that arrives in chunks to a connected
+// gClient and returns its SHA-256 hash once the gClient agrees on it.
+func (s *AdminServiceServer) FileUpload(stream as.AdminService_FileUploadServer) error {
+	common.Log.Debugf("FileUpload called")
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.Path == "" {
+		return status.Errorf(codes.InvalidArgument, "path is required")
+	}
+
+	pending := first.Data
+	read := func() ([]byte, error) {
+		if len(pending) > 0 {
+			data := pending
+			pending = nil
+			return data, nil
+		}
+		chunk, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return chunk.Data, nil
+	}
+
+	clientID := s.gServer.ResolveEndpointID(first.ClientId)
+	sum, err := s.gServer.PutFile(stream.Context(), clientID, first.Path,
+		first.Size, first.Mode, read)
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	common.Log.WithEndpoint(clientID).Infof("Put %d bytes to %s", first.Size,
+		first.Path)
+	resp := new(as.FileTransferResponse)
+	resp.Size = first.Size
+	resp.Sha256 = sum
+	return stream.SendAndClose(resp)
+}
+
+// FileDownload reads a file from a connected gClient and streams it
+// back in chunks, followed by its SHA-256 hash.
+func (s *AdminServiceServer) FileDownload(req *as.FileDownloadRequest,
+	stream as.AdminService_FileDownloadServer) error {
+	common.Log.Debugf("FileDownload called")
+
+	if req.Path == "" {
+		return status.Errorf(codes.InvalidArgument, "path is required")
+	}
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	start := func(size uint64) error {
+		chunk := new(as.FileChunk)
+		chunk.Size = size
+		return stream.Send(chunk)
+	}
+	write := func(data []byte) error {
+		chunk := new(as.FileChunk)
+		chunk.Data = data
+		return stream.Send(chunk)
+	}
+	sum, err := s.gServer.GetFile(stream.Context(), clientID, req.Path,
+		start, write)
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	common.Log.WithEndpoint(clientID).Infof("Got %s", req.Path)
+	chunk := new(as.FileChunk)
+	chunk.Sha256 = sum
+	return stream.Send(chunk)
+}
+
+// ClientShell runs an interactive shell on a connected gClient and
+// relays its input and output.
+func (s *AdminServiceServer) ClientShell(stream as.AdminService_ClientShellServer) error {
+	common.Log.Debugf("ClientShell called")
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	pending := first.Data
+	input := func() ([]byte, error) {
+		if len(pending) > 0 {
+			data := pending
+			pending = nil
+			return data, nil
+		}
+		in, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return in.Data, nil
+	}
+	output := func(data []byte) error {
+		out := new(as.ShellOutput)
+		out.Data = data
+		return stream.Send(out)
+	}
+
+	clientID := s.gServer.ResolveEndpointID(first.ClientId)
+	err = s.gServer.ShellEndpoint(stream.Context(), clientID, input, output)
+
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return nil
+}
+
+// LogLevelSet changes the log level of gServer, or of a client if the
+// request names one, at runtime. The previous level is only known for
+// gServer.
+func (s *AdminServiceServer) LogLevelSet(ctx context.Context, req *as.LogLevelSetRequest) (
+	*as.LogLevelSetResponse, error) {
+	common.Log.Debugf("LogLevelSet called")
+
+	level, err := common.ParseLogLevel(req.Level)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.ClientId != "" {
+		clientID := s.gServer.ResolveEndpointID(req.ClientId)
+		if err := s.gServer.SetEndpointLogLevel(clientID, req.Level); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return new(as.LogLevelSetResponse), nil
+	}
+
+	resp := new(as.LogLevelSetResponse)
+	resp.PreviousLevel = common.GetLogLevel().String()
+
+	common.SetLogLevel(level)
+
+	return resp, nil
+}
+
 // ClientList will list all configured clients for the gServer and their
 // connection status as well as the configured ip, port, and bearer token
 func (s *AdminServiceServer) ClientList(req *as.ClientListRequest,
 	stream as.AdminService_ClientListServer) error {
-	log.Printf("[*] ClientList called")
+	common.Log.Debugf("ClientList called")
 
-	clients := s.gServer.connectedClients
+	clients := s.gServer.getClients()
 
 	if len(clients) == 0 {
 		return status.Error(codes.OutOfRange, "no clients exist")
 	}
 
+	sent := 0
 	for _, client := range clients {
+		if !s.gServer.EndpointHasTags(client.uniqueID, req.Tags) {
+			continue
+		}
+		sent++
+
 		resp := new(as.Client)
 		resp.Name = client.configuredClient.Name
 		resp.ClientId = client.uniqueID
@@ -86,9 +460,26 @@ func (s *AdminServiceServer) ClientList(req *as.ClientListRequest,
 		resp.RemoteAddress = client.remoteAddr
 		resp.Hostname = client.hostname
 		resp.ConnectDate = client.connectDate.String()
+		resp.ProtocolVersion = client.protocolVersion
+		resp.Capabilities = client.capabilities
+		resp.Country = client.geo.Country
+		resp.Asn = client.geo.ASN
+		resp.AsOrganization = client.geo.Organization
+		resp.Os = client.os
+		resp.Arch = client.arch
+		resp.Username = client.username
+		resp.Addresses = client.addresses
+		resp.LastSeen = client.getLastSeen().Unix()
+		resp.State = s.gServer.endpointState(client)
+		resp.Alias = s.gServer.EndpointAlias(client.uniqueID)
+		resp.Tags = s.gServer.EndpointTags(client.uniqueID)
 		stream.Send(resp)
 	}
 
+	if sent == 0 {
+		return status.Error(codes.OutOfRange, "no clients have the provided tags")
+	}
+
 	return nil
 }
 
@@ -96,40 +487,47 @@ func (s *AdminServiceServer) ClientList(req *as.ClientListRequest,
 // tunnel ID.
 func (s *AdminServiceServer) ConnectionList(req *as.ConnectionListRequest,
 	stream as.AdminService_ConnectionListServer) error {
-	log.Printf("[*] ConnectionList called")
+	common.Log.Debugf("ConnectionList called")
 
-	clientID := req.ClientId
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
 	tunnelID := req.TunnelId
 
 	endpoint, ok := s.gServer.GetEndpoint(clientID)
 
 	if !ok {
 		return status.Errorf(codes.NotFound,
-			fmt.Sprintf("client %s does not exist", clientID))
+			"client %s does not exist", clientID)
 	}
 
 	tunnel, ok := endpoint.GetTunnel(tunnelID)
 
 	if !ok {
 		return status.Errorf(codes.NotFound,
-			fmt.Sprintf("tunnel %s does not exist", tunnelID))
+			"tunnel %s does not exist", tunnelID)
 	}
 
 	connections := tunnel.GetConnections()
 
 	if len(connections) == 0 {
 		return status.Errorf(codes.OutOfRange,
-			fmt.Sprint("no connections exist for tunnel %s", tunnelID))
+			"no connections exist for tunnel %s", tunnelID)
 	}
 
 	for _, connection := range connections {
 		newCon := new(as.Connection)
-		sourceIP := connection.TCPConn.LocalAddr().(*net.TCPAddr).IP
-		destIP := connection.TCPConn.RemoteAddr().(*net.TCPAddr).IP
+		sourceIP, sourcePort := common.AddrToIPPort(connection.TCPConn.LocalAddr())
+		destIP, destPort := common.AddrToIPPort(connection.TCPConn.RemoteAddr())
 		newCon.SourceIp = common.IpToInt32(sourceIP)
-		newCon.SourcePort = uint32(connection.TCPConn.LocalAddr().(*net.TCPAddr).Port)
+		newCon.SourcePort = sourcePort
 		newCon.DestinationIp = common.IpToInt32(destIP)
-		newCon.DestinationPort = uint32(connection.TCPConn.RemoteAddr().(*net.TCPAddr).Port)
+		newCon.DestinationPort = destPort
+		newCon.Warnings = connection.GetWarnings()
+		newCon.BytesSent = connection.GetBytesSent()
+		newCon.BytesReceived = connection.GetBytesReceived()
+		newCon.Id = connection.ID
+		newCon.StartTime = connection.GetStartTime().Unix()
+		newCon.OriginAddress = connection.GetOriginAddress()
+		newCon.DestinationAddress = connection.GetDestinationAddress()
 		stream.Send(newCon)
 	}
 	return nil
@@ -139,15 +537,15 @@ func (s *AdminServiceServer) ConnectionList(req *as.ConnectionListRequest,
 func (s *AdminServiceServer) SocksStart(ctx context.Context,
 	req *as.SocksStartRequest) (
 	*as.SocksStartResponse, error) {
-	log.Printf("[*] SocksStart called")
+	common.Log.Debugf("SocksStart called")
 
-	clientID := req.ClientId
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
 	socksPort := req.SocksPort
 
 	err := s.gServer.StartProxy(clientID, socksPort)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return new(as.SocksStartResponse), nil
@@ -157,80 +555,258 @@ func (s *AdminServiceServer) SocksStart(ctx context.Context,
 func (s *AdminServiceServer) SocksStop(ctx context.Context,
 	req *as.SocksStopRequest) (
 	*as.SocksStopResponse, error) {
-	log.Printf("[*] SocksStart called")
+	common.Log.Debugf("SocksStart called")
 
-	clientID := req.ClientId
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
 
 	err := s.gServer.StopProxy(clientID)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return new(as.SocksStopResponse), nil
 }
 
+// SetAuth requires every call to the admin api to carry the token of
+// an operator. The rest api and the metrics endpoint require it as well.
+func (s *AdminServiceServer) SetAuth(auth *AdminAuth) {
+	s.auth = auth
+}
+
+// SetTLS serves the admin api over TLS with the provided certificate,
+// and so the rest api and the metrics endpoint.
+func (s *AdminServiceServer) SetTLS(certFile string, keyFile string) {
+	s.certFile = certFile
+	s.keyFile = keyFile
+}
+
+// authenticated returns handler behind the admin tokens, if there are
+// any.
+func (s *AdminServiceServer) authenticated(handler http.Handler) http.Handler {
+	if s.auth == nil {
+		return handler
+	}
+	return s.auth.HTTPHandler(handler)
+}
+
+// serveHTTP serves handler on port, over TLS if the admin api is. It
+// blocks until the server exits.
+func (s *AdminServiceServer) serveHTTP(port int, handler http.Handler) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		return err
+	}
+	if s.certFile != "" {
+		return http.ServeTLS(lis, handler, s.certFile, s.keyFile)
+	}
+	return http.Serve(lis, handler)
+}
+
 // Start will start the grpc server
 func (s *AdminServiceServer) Start(port int) {
-	log.Printf("[*] Starting admin grpc server on port: %d\n", port)
-	grpcServer := grpc.NewServer()
+	common.Log.Infof("Starting admin grpc server on port: %d", port)
+
+	var opts []grpc.ServerOption
+	if s.certFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.certFile, s.keyFile)
+		if err != nil {
+			common.Log.Fatalf("Failed to generate credentials %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if s.auth != nil {
+		opts = append(opts, grpc.UnaryInterceptor(s.auth.UnaryInterceptor),
+			grpc.StreamInterceptor(s.auth.StreamInterceptor))
+	}
+	grpcServer := grpc.NewServer(opts...)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		common.Log.Fatalf("Failed to listen: %v", err)
 	}
 
 	as.RegisterAdminServiceServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
+	if s.grpcWebPort != 0 {
+		go s.startGRPCWeb(grpcServer)
+	}
+
 	grpcServer.Serve(lis)
 }
 
 // TunnelAdd adds a tunnel to an endpoint specified in the request.
 func (s *AdminServiceServer) TunnelAdd(ctx context.Context, req *as.TunnelAddRequest) (
 	*as.TunnelAddResponse, error) {
-	log.Printf("[*] TunnelAdd called")
+	common.Log.Debugf("TunnelAdd called")
 
 	if req.Tunnel.Id == "" {
 		req.Tunnel.Id = common.GenerateString(8)
 	}
 
+	if req.Tunnel.Command != "" && req.Tunnel.Direction != common.TunnelDirectionForward {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"commands are only supported for forward tunnels")
+	}
+
+	listenIP := common.Int32ToIP(req.Tunnel.ListenIp)
+	if req.Tunnel.LoopbackAlias {
+		if req.Tunnel.Direction != common.TunnelDirectionForward {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"loopback aliases are only supported for forward tunnels")
+		}
+
+		var err error
+		listenIP, err = s.gServer.AllocateLoopbackAlias(
+			common.Int32ToIP(req.Tunnel.DestinationIp))
+		if err != nil {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+	}
+
 	err := s.gServer.AddTunnel(
-		req.ClientId,
+		s.gServer.ResolveEndpointID(req.ClientId),
 		req.Tunnel.Id,
 		req.Tunnel.Direction,
-		common.Int32ToIP(req.Tunnel.ListenIp),
+		listenIP,
 		req.Tunnel.ListenPort,
 		common.Int32ToIP(req.Tunnel.DestinationIp),
-		req.Tunnel.DestinationPort)
+		req.Tunnel.DestinationPort,
+		common.TunnelOptions{
+			Command:           req.Tunnel.Command,
+			DetectDeception:   req.Tunnel.DetectDeception,
+			Hostname:          req.Tunnel.Hostname,
+			TTL:               time.Duration(req.Tunnel.Ttl) * time.Second,
+			ByteLimit:         req.Tunnel.ByteLimit,
+			Schedule:          req.Tunnel.Schedule,
+			Timezone:          req.Tunnel.Timezone,
+			ChunkSize:         req.Tunnel.ChunkSize,
+			Nagle:             req.Tunnel.Nagle,
+			TCPKeepalive:      time.Duration(req.Tunnel.TcpKeepalive) * time.Second,
+			ReadBuffer:        int(req.Tunnel.ReadBuffer),
+			WriteBuffer:       int(req.Tunnel.WriteBuffer),
+			RateLimit:         req.Tunnel.RateLimit,
+			IdleTimeout:       time.Duration(req.Tunnel.IdleTimeout) * time.Second,
+			MaxConnections:    req.Tunnel.MaxConnections,
+			Compress:          req.Tunnel.Compress,
+			StreamCompression: req.Tunnel.StreamCompression,
+			Encrypt:           req.Tunnel.Encrypt,
+			RekeyInterval:     time.Duration(req.Tunnel.RekeyInterval) * time.Second,
+			RekeyBytes:        req.Tunnel.RekeyBytes,
+			PadSize:           req.Tunnel.PadSize,
+			SendJitter:        time.Duration(req.Tunnel.SendJitter) * time.Millisecond,
+			Hops:              req.Tunnel.Hops,
+			Balance:           req.Tunnel.Balance,
+			Failover:          req.Tunnel.Failover,
+			HealthCheck:       time.Duration(req.Tunnel.HealthCheck) * time.Second,
+			HealthBanner:      req.Tunnel.HealthBanner,
+		})
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return new(as.TunnelAddResponse), nil
 }
 
+// TunnelImport adds many tunnels at once. If one of them cannot be
+// added, the tunnels that were added before it are deleted again.
+func (s *AdminServiceServer) TunnelImport(ctx context.Context,
+	req *as.TunnelImportRequest) (*as.TunnelImportResponse, error) {
+	common.Log.Debugf("TunnelImport called")
+
+	// Existing IDs would be replaced by random ones, which could not
+	// be rolled back.
+	seen := make(map[string]bool)
+	for i, addReq := range req.Tunnels {
+		if addReq.Tunnel == nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"tunnel %d: no tunnel provided", i)
+		}
+		endpoint, ok := s.gServer.GetEndpoint(
+			s.gServer.ResolveEndpointID(addReq.ClientId))
+		if !ok {
+			return nil, status.Errorf(codes.NotFound,
+				"tunnel %d: client %s does not exist", i, addReq.ClientId)
+		}
+		key := s.gServer.ResolveEndpointID(addReq.ClientId) + "/" + addReq.Tunnel.Id
+		if _, ok := endpoint.GetTunnel(addReq.Tunnel.Id); ok || seen[key] {
+			return nil, status.Errorf(codes.AlreadyExists,
+				"tunnel %d: tunnel %s already exists", i, addReq.Tunnel.Id)
+		}
+		if addReq.Tunnel.Id != "" {
+			seen[key] = true
+		}
+	}
+
+	resp := new(as.TunnelImportResponse)
+	for i, addReq := range req.Tunnels {
+		if _, err := s.TunnelAdd(ctx, addReq); err != nil {
+			for j, tunnelID := range resp.TunnelIds {
+				s.gServer.DeleteTunnel(
+					s.gServer.ResolveEndpointID(req.Tunnels[j].ClientId), tunnelID)
+			}
+			return nil, status.Errorf(status.Code(err), "tunnel %d: %s", i,
+				status.Convert(err).Message())
+		}
+		resp.TunnelIds = append(resp.TunnelIds, addReq.Tunnel.Id)
+	}
+
+	return resp, nil
+}
+
 // TunnelDelete deletes a tunnel with the provided tunnel ID
 func (s *AdminServiceServer) TunnelDelete(ctx context.Context, req *as.TunnelDeleteRequest) (
 	*as.TunnelDeleteResponse, error) {
-	log.Printf("[*] TunnelDelete called")
+	common.Log.Debugf("TunnelDelete called")
 
-	err := s.gServer.DeleteTunnel(req.ClientId, req.TunnelId)
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	err := s.gServer.DeleteTunnel(clientID, req.TunnelId)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return new(as.TunnelDeleteResponse), nil
 }
 
+// TunnelPause stops a tunnel from accepting new connections.
+func (s *AdminServiceServer) TunnelPause(ctx context.Context, req *as.TunnelPauseRequest) (
+	*as.TunnelPauseResponse, error) {
+	common.Log.Debugf("TunnelPause called")
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	err := s.gServer.PauseTunnel(clientID, req.TunnelId, req.Freeze)
+
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return new(as.TunnelPauseResponse), nil
+}
+
+// TunnelResume resumes a paused tunnel.
+func (s *AdminServiceServer) TunnelResume(ctx context.Context, req *as.TunnelResumeRequest) (
+	*as.TunnelResumeResponse, error) {
+	common.Log.Debugf("TunnelResume called")
+
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
+	err := s.gServer.ResumeTunnel(clientID, req.TunnelId)
+
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return new(as.TunnelResumeResponse), nil
+}
+
 // TunnelList lists all tunnels associated with the provided client ID.
 func (s *AdminServiceServer) TunnelList(req *as.TunnelListRequest,
 	stream as.AdminService_TunnelListServer) error {
-	log.Printf("[*] TunnelList called")
+	common.Log.Debugf("TunnelList called")
 
-	clientID := req.ClientId
+	clientID := s.gServer.ResolveEndpointID(req.ClientId)
 
 	endpoint, ok := s.gServer.GetEndpoint(clientID)
 	if !ok {
@@ -253,6 +829,45 @@ func (s *AdminServiceServer) TunnelList(req *as.TunnelListRequest,
 		newTun.ListenPort = tunnel.GetListenPort()
 		newTun.DestinationIp = common.IpToInt32(tunnel.GetDestinationIP())
 		newTun.DestinationPort = tunnel.GetDestinationPort()
+		newTun.Command = tunnel.GetOptions().Command
+		newTun.DetectDeception = tunnel.GetOptions().DetectDeception
+		newTun.Hostname = tunnel.GetOptions().Hostname
+		newTun.BytesSent = tunnel.GetBytesSent()
+		newTun.BytesReceived = tunnel.GetBytesReceived()
+		newTun.StartTime = tunnel.GetStartTime().Unix()
+		newTun.Connections = uint32(len(tunnel.GetConnections()))
+		newTun.Paused = tunnel.IsPaused()
+		newTun.Ttl = int64(tunnel.GetOptions().TTL / time.Second)
+		newTun.ByteLimit = tunnel.GetOptions().ByteLimit
+		newTun.Schedule = tunnel.GetOptions().Schedule
+		newTun.Timezone = tunnel.GetOptions().Timezone
+		newTun.ChunkSize = tunnel.GetOptions().ChunkSize
+		newTun.Nagle = tunnel.GetOptions().Nagle
+		newTun.TcpKeepalive = int64(tunnel.GetOptions().TCPKeepalive / time.Second)
+		newTun.ReadBuffer = uint32(tunnel.GetOptions().ReadBuffer)
+		newTun.WriteBuffer = uint32(tunnel.GetOptions().WriteBuffer)
+		newTun.RateLimit = tunnel.GetOptions().RateLimit
+		newTun.IdleTimeout = int64(tunnel.GetOptions().IdleTimeout / time.Second)
+		newTun.MaxConnections = tunnel.GetOptions().MaxConnections
+		newTun.Compress = tunnel.GetOptions().Compress
+		newTun.StreamCompression = tunnel.GetOptions().StreamCompression
+		newTun.Encrypt = tunnel.GetOptions().Encrypt
+		newTun.RekeyInterval = int64(tunnel.GetOptions().RekeyInterval / time.Second)
+		newTun.RekeyBytes = tunnel.GetOptions().RekeyBytes
+		newTun.PadSize = tunnel.GetOptions().PadSize
+		newTun.SendJitter = int64(tunnel.GetOptions().SendJitter / time.Millisecond)
+		newTun.Hops = tunnel.GetOptions().Hops
+		newTun.Balance = tunnel.GetOptions().Balance
+		newTun.Failover = tunnel.GetOptions().Failover
+		newTun.HealthCheck = int64(tunnel.GetOptions().HealthCheck / time.Second)
+		newTun.HealthBanner = tunnel.GetOptions().HealthBanner
+		failures, lastFailure := tunnel.GetFailedConnections()
+		newTun.FailedConnections = failures
+		newTun.Health = tunnel.GetHealth().String()
+		if failures > 0 {
+			newTun.LastError = lastFailure.String()
+			newTun.LastErrorTime = lastFailure.Time.Unix()
+		}
 
 		stream.Send(newTun)
 	}
@@ -0,0 +1,77 @@
+package gserverlib
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+)
+
+const (
+	// firstLoopbackAlias is the first address handed out as a
+	// loopback alias (127.0.1.1).
+	firstLoopbackAlias = 0x7f000101
+
+	// lastLoopbackAlias is the last address handed out as a
+	// loopback alias (127.0.255.254).
+	lastLoopbackAlias = 0x7f00fffe
+)
+
+// LoopbackAliases hands out a unique loopback address per tunnel
+// destination so that the same port of many remote hosts can be
+// exposed on the gServer at the same time. On linux the whole
+// 127.0.0.0/8 network is routed to the loopback interface, other
+// platforms need the aliases added to the loopback interface.
+type LoopbackAliases struct {
+	aliases map[string]net.IP
+	next    uint32
+	mutex   sync.Mutex
+}
+
+// NewLoopbackAliases is a constructor for LoopbackAliases.
+func NewLoopbackAliases() *LoopbackAliases {
+	l := new(LoopbackAliases)
+	l.aliases = make(map[string]net.IP)
+	l.next = firstLoopbackAlias
+	return l
+}
+
+// Allocate returns the loopback alias of the provided destination,
+// allocating a new one if the destination does not have one yet.
+func (l *LoopbackAliases) Allocate(destination net.IP) (net.IP, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	key := destination.String()
+	if alias, ok := l.aliases[key]; ok {
+		return alias, nil
+	}
+
+	// Skip network and broadcast style addresses of each /24
+	for l.next&0xff == 0 || l.next&0xff == 0xff {
+		l.next++
+	}
+
+	if l.next > lastLoopbackAlias {
+		return nil, fmt.Errorf("no loopback aliases left")
+	}
+
+	alias := make(net.IP, 4)
+	binary.BigEndian.PutUint32(alias, l.next)
+	l.next++
+
+	l.aliases[key] = alias
+	return alias, nil
+}
+
+// List returns the mapping of destination addresses to their aliases.
+func (l *LoopbackAliases) List() map[string]net.IP {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	aliases := make(map[string]net.IP)
+	for destination, alias := range l.aliases {
+		aliases[destination] = alias
+	}
+	return aliases
+}
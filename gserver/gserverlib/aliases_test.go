@@ -0,0 +1,47 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+)
+
+func TestLoopbackAliasesAllocate(t *testing.T) {
+	aliases := NewLoopbackAliases()
+
+	first, err := aliases.Allocate(net.ParseIP("10.0.0.1"))
+	if err != nil {
+		t.Fatalf("Allocate failed: %s", err)
+	}
+	if first.String() != "127.0.1.1" {
+		t.Errorf("Allocate: Got: %s Want: 127.0.1.1", first)
+	}
+
+	second, _ := aliases.Allocate(net.ParseIP("10.0.0.2"))
+	if second.String() != "127.0.1.2" {
+		t.Errorf("Allocate: Got: %s Want: 127.0.1.2", second)
+	}
+
+	again, _ := aliases.Allocate(net.ParseIP("10.0.0.1"))
+	if !again.Equal(first) {
+		t.Errorf("Allocate: Got: %s Want: %s", again, first)
+	}
+
+	if len(aliases.List()) != 2 {
+		t.Errorf("List: Got: %d entries Want: 2", len(aliases.List()))
+	}
+}
+
+func TestLoopbackAliasesSkipsBroadcast(t *testing.T) {
+	aliases := NewLoopbackAliases()
+	aliases.next = 0x7f0001fe
+
+	alias, _ := aliases.Allocate(net.ParseIP("10.0.0.1"))
+	if alias.String() != "127.0.1.254" {
+		t.Errorf("Allocate: Got: %s Want: 127.0.1.254", alias)
+	}
+
+	alias, _ = aliases.Allocate(net.ParseIP("10.0.0.2"))
+	if alias.String() != "127.0.2.1" {
+		t.Errorf("Allocate: Got: %s Want: 127.0.2.1", alias)
+	}
+}
@@ -29,8 +29,9 @@ func GetClientInfoFromCtx(ctx context.Context) (string, string, error) {
 		return "", "", status.Errorf(codes.InvalidArgument, "Invalid authorization header")
 	}
 
-	auth := strings.Split(bearerToken, common.BearerString)[1]
-	token := strings.Split(auth, "-")[0]
-	uuid := strings.Split(auth, "-")[1]
-	return token, uuid, nil
+	auth := strings.SplitN(strings.TrimPrefix(bearerToken, common.BearerString), "-", 2)
+	if len(auth) != 2 {
+		return "", "", status.Error(codes.Unauthenticated, "Authorization token has no endpoint ID")
+	}
+	return auth[0], auth[1], nil
 }
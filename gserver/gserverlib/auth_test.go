@@ -0,0 +1,105 @@
+package gserverlib
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func handler(ctx context.Context, req interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestAuthInterceptorValid(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.configStore = NewConfigStore()
+
+	token, err := common.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	s.configStore.LoadConfiguredClient(&ConfiguredClient{Name: "unittestid", Token: token})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/client.ClientService/CreateTunnelControlStream"}
+	call := func(header string) error {
+		md := metadata.New(map[string]string{"authorization": header})
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		_, err := s.UnaryAuthInterceptor(ctx, nil, info, handler)
+		return err
+	}
+
+	t.Run("ValidCreds", func(t *testing.T) {
+		if err := call(common.BearerString + token + "-endpoint"); err != nil {
+			t.Errorf("AuthInterceptor error: %s", err)
+		}
+	})
+	t.Run("InvalidCreds", func(t *testing.T) {
+		for _, header := range []string{
+			common.BearerString + "BADTOKEN-endpoint",
+			common.BearerString + "BADTOKEN",
+		} {
+			if err := call(header); status.Code(err) != codes.Unauthenticated {
+				t.Errorf("AuthInterceptor(%q): Got: %v Want: Unauthenticated",
+					header, err)
+			}
+		}
+	})
+}
+
+func TestConfigurationFile(t *testing.T) {
+	// This test will load a configuration file, ensure its clients
+	// are looked up by their token, add a new client to the file and
+	// confirm that the client is known after a reload
+	dir, err := ioutil.TempDir("", "gtunnel")
+	if err != nil {
+		t.Fatalf("TempDir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "gserver.json")
+	write := func(config string) {
+		if err := ioutil.WriteFile(configFile, []byte(config), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	write(`{"clients": [{"name": "test", "token": "QbcW7ChjefhyB$X[v@Q<F@hWzMnZGuW(X8CBmcF"}]}`)
+
+	s, _ := newTestServer(time.Hour)
+	s.configStore = NewConfigStore()
+	config, err := LoadServerConfig(configFile)
+	if err != nil {
+		t.Fatalf("[!] Failed to load test configuration: %v", err)
+	}
+	s.ApplyConfig(config)
+
+	config1 := s.configStore.GetConfiguredClient("QbcW7ChjefhyB$X[v@Q<F@hWzMnZGuW(X8CBmcF")
+	if config1 == nil {
+		t.Fatalf("Failed to lookup bearer token")
+	}
+	if got, want := config1.Name, "test"; got != want {
+		t.Errorf("GetConfiguredClient: Got: %s Want: %s", got, want)
+	}
+
+	newToken, _ := common.GenerateToken()
+	write(`{"clients": [{"name": "test", "token": "QbcW7ChjefhyB$X[v@Q<F@hWzMnZGuW(X8CBmcF"},
+		{"name": "newClient", "token": "` + newToken + `"}]}`)
+	config, err = LoadServerConfig(configFile)
+	if err != nil {
+		t.Fatalf("Failed to reload test configuration: %v", err)
+	}
+	s.ReloadConfig(config)
+
+	newConfig := s.configStore.GetConfiguredClient(newToken)
+	if newConfig == nil || newConfig.Name != "newClient" {
+		t.Errorf("ClientID lookup failed: Got: %v Want: newClient", newConfig)
+	}
+}
@@ -0,0 +1,81 @@
+package gserverlib
+
+import (
+	"fmt"
+	"time"
+)
+
+// EndpointBan records an endpoint that may no longer register.
+type EndpointBan struct {
+	ClientID    string
+	TokenBanned bool
+	Date        time.Time
+	token       string
+}
+
+// BanEndpoint prevents an endpoint ID from registering again. If
+// banToken is true, the bearer token of the connected endpoint is
+// banned as well so that no gClient built with it can register.
+func (s *GServer) BanEndpoint(clientID string, banToken bool) error {
+	ban := new(EndpointBan)
+	ban.ClientID = clientID
+	ban.Date = time.Now()
+
+	if banToken {
+		client, ok := s.getClient(clientID)
+		if !ok {
+			return fmt.Errorf("client %s is not connected, its token is unknown",
+				clientID)
+		}
+		ban.TokenBanned = true
+		ban.token = client.configuredClient.Token
+	}
+
+	s.banMutex.Lock()
+	s.bans[clientID] = ban
+	s.banMutex.Unlock()
+
+	s.emitEvent(EventEndpointBanned, clientID, "", "endpoint banned")
+	return nil
+}
+
+// UnbanEndpoint lifts the ban of an endpoint ID and its token.
+func (s *GServer) UnbanEndpoint(clientID string) error {
+	s.banMutex.Lock()
+	defer s.banMutex.Unlock()
+
+	if _, ok := s.bans[clientID]; !ok {
+		return fmt.Errorf("client %s is not banned", clientID)
+	}
+	delete(s.bans, clientID)
+	return nil
+}
+
+// GetBans returns all endpoint bans.
+func (s *GServer) GetBans() []EndpointBan {
+	s.banMutex.Lock()
+	defer s.banMutex.Unlock()
+
+	bans := make([]EndpointBan, 0, len(s.bans))
+	for _, ban := range s.bans {
+		bans = append(bans, *ban)
+	}
+	return bans
+}
+
+// isBanned returns true if the endpoint ID or its bearer token is
+// banned.
+func (s *GServer) isBanned(clientID string, token string) bool {
+	s.banMutex.Lock()
+	defer s.banMutex.Unlock()
+
+	if _, ok := s.bans[clientID]; ok {
+		return true
+	}
+	for _, ban := range s.bans {
+		if ban.TokenBanned && ban.token == token {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBanEndpoint(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.bans = make(map[string]*EndpointBan)
+	s.connectedClients["endpoint"].configuredClient = &ConfiguredClient{Token: "token"}
+
+	if err := s.BanEndpoint("endpoint", true); err != nil {
+		t.Fatalf("BanEndpoint: %v", err)
+	}
+	if !s.isBanned("endpoint", "other") {
+		t.Errorf("isBanned: banned ID was not rejected")
+	}
+	if !s.isBanned("new-endpoint", "token") {
+		t.Errorf("isBanned: banned token was not rejected")
+	}
+	if s.isBanned("new-endpoint", "other") {
+		t.Errorf("isBanned: unrelated endpoint was rejected")
+	}
+
+	if err := s.UnbanEndpoint("endpoint"); err != nil {
+		t.Fatalf("UnbanEndpoint: %v", err)
+	}
+	if s.isBanned("endpoint", "token") {
+		t.Errorf("isBanned: endpoint still banned after UnbanEndpoint")
+	}
+
+	if err := s.BanEndpoint("missing", true); err == nil {
+		t.Errorf("BanEndpoint: expected error banning the token of an unknown client")
+	}
+}
@@ -0,0 +1,54 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// beaconQueueSize is the number of control messages that are queued
+// for a beaconing endpoint between two polls.
+const beaconQueueSize = 64
+
+// PollControlMessages is a gRPC function that a beaconing client calls
+// instead of keeping an endpoint control stream open. It returns all
+// control messages queued since the last poll.
+func (s *ClientServiceServer) PollControlMessages(ctx context.Context,
+	req *cs.PollControlMessagesRequest) (*cs.PollControlMessagesResponse, error) {
+
+	_, uuid, err := GetClientInfoFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := s.gServer.getClient(uuid)
+	if !ok {
+		common.Log.WithEndpoint(uuid).Errorf("Poll from unknown endpoint")
+		return nil, fmt.Errorf("uuid does not exist")
+	}
+
+	client.touch()
+
+	resp := new(cs.PollControlMessagesResponse)
+	for {
+		select {
+		case controlMessage := <-client.endpointInput:
+			resp.Messages = append(resp.Messages, controlMessage)
+			continue
+		default:
+		}
+		break
+	}
+
+	// The disconnect message has been picked up, the endpoint is gone
+	s.gServer.orphanMutex.Lock()
+	if client.disconnecting {
+		s.gServer.removeEndpoint(uuid, client)
+	}
+	s.gServer.orphanMutex.Unlock()
+
+	return resp, nil
+}
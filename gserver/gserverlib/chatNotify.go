@@ -0,0 +1,55 @@
+package gserverlib
+
+import (
+	"fmt"
+)
+
+// chatEvents are the events posted to chat channels: an endpoint
+// checking in, going silent, returning and being removed.
+var chatEvents = []string{
+	EventEndpointConnected,
+	EventEndpointLost,
+	EventEndpointReturned,
+	EventEndpointRemoved,
+}
+
+// NewSlackNotifier returns a WebhookNotifier that posts endpoint
+// events to a Slack incoming webhook url.
+func NewSlackNotifier(url string) *WebhookNotifier {
+	w := NewWebhookNotifier(url, "", chatEvents)
+	w.payload = func(event Event) interface{} {
+		return map[string]string{"text": chatMessage(event)}
+	}
+	return w
+}
+
+// NewDiscordNotifier returns a WebhookNotifier that posts endpoint
+// events to a Discord webhook url.
+func NewDiscordNotifier(url string) *WebhookNotifier {
+	w := NewWebhookNotifier(url, "", chatEvents)
+	w.payload = func(event Event) interface{} {
+		return map[string]string{"content": chatMessage(event)}
+	}
+	return w
+}
+
+// chatMessage formats an event as a single line of chat text.
+func chatMessage(event Event) string {
+	var prefix string
+	switch event.Type {
+	case EventEndpointConnected:
+		prefix = "New endpoint checked in"
+	case EventEndpointLost:
+		prefix = "Endpoint went silent"
+	case EventEndpointReturned:
+		prefix = "Endpoint returned"
+	case EventEndpointRemoved:
+		prefix = "Endpoint removed"
+	default:
+		prefix = event.Type
+	}
+
+	return fmt.Sprintf("[gtunnel] %s: %s (%s) at %s", prefix,
+		event.EndpointID, event.Message,
+		event.Time.UTC().Format("2006-01-02 15:04:05 MST"))
+}
@@ -0,0 +1,64 @@
+package gserverlib
+
+import (
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetClientAllowlist restricts the source addresses that may connect
+// to the client port to networks. Connections from anywhere else are
+// reset before they reach gRPC, so they cannot register endpoints or
+// probe the service. Any address is allowed if networks is empty.
+func (s *GServer) SetClientAllowlist(networks []*net.IPNet) {
+	s.allowMutex.Lock()
+	defer s.allowMutex.Unlock()
+
+	s.clientAllowlist = networks
+}
+
+// isClientAllowed returns true if ip may connect to the client port.
+func (s *GServer) isClientAllowed(ip net.IP) bool {
+	s.allowMutex.Lock()
+	defer s.allowMutex.Unlock()
+
+	if len(s.clientAllowlist) == 0 {
+		return true
+	}
+	for _, network := range s.clientAllowlist {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowlistListener is a listener that resets connections from source
+// addresses that are not allowed by the server.
+type allowlistListener struct {
+	net.Listener
+	gServer *GServer
+}
+
+// Accept returns the next connection from an allowed address.
+func (l *allowlistListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		ip, _ := common.AddrToIPPort(conn.RemoteAddr())
+		if l.gServer.isClientAllowed(ip) {
+			return conn, nil
+		}
+
+		// Scanners would flood the log above debug level
+		common.Log.Debugf("Reset connection from %s, which is not allowlisted",
+			conn.RemoteAddr())
+		if tcpConn, ok := conn.(*net.TCPConn); ok {
+			tcpConn.SetLinger(0)
+		}
+		conn.Close()
+	}
+}
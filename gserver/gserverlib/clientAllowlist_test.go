@@ -0,0 +1,62 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestClientAllowlist(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	networks, _ := common.ParseNetworks("10.0.0.0/8")
+	s.SetClientAllowlist(networks)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	lis := &allowlistListener{Listener: ln, gServer: s}
+	defer lis.Close()
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	// Loopback is not allowlisted. The reset can already fail the dial.
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err == nil {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if _, err := conn.Read(make([]byte, 1)); err == nil {
+			t.Errorf("Read: connection was not reset")
+		}
+		conn.Close()
+	}
+	select {
+	case <-accepted:
+		t.Errorf("Accept: returned a connection that is not allowlisted")
+	default:
+	}
+
+	networks, _ = common.ParseNetworks("10.0.0.0/8,127.0.0.1")
+	s.SetClientAllowlist(networks)
+	conn, err = net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+	select {
+	case c := <-accepted:
+		c.Close()
+	case <-time.After(5 * time.Second):
+		t.Errorf("Accept: allowlisted connection was not accepted")
+	}
+}
@@ -3,13 +3,18 @@ package gserverlib
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
 	"time"
 
 	cs "github.com/kai5263499/gtunnel/grpc/client"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	// Byte streams of compressed tunnels are gzip encoded. Replies
+	// use the encoding of the stream.
+	_ "google.golang.org/grpc/encoding/gzip"
 
 	"github.com/kai5263499/gtunnel/common"
 	"google.golang.org/grpc/peer"
@@ -17,7 +22,8 @@ import (
 
 type ClientServiceServer struct {
 	cs.UnimplementedClientServiceServer
-	gServer *GServer
+	gServer    *GServer
+	grpcServer *grpc.Server
 }
 
 func NewClientServiceServer(gserver *GServer) *ClientServiceServer {
@@ -39,31 +45,88 @@ func (s *ClientServiceServer) GetConfigurationMessage(ctx context.Context, req *
 	clientConfig := s.gServer.configStore.GetConfiguredClient(token)
 
 	if clientConfig == nil {
-		log.Printf("[!] Failed to lookup configured client with key: %s\n", token)
+		common.Log.Errorf("Failed to lookup configured client with key: %s", token)
 		return nil, fmt.Errorf("token does not exist in client configuration")
 	}
 
+	if req.ProtocolVersion < common.MinProtocolVersion {
+		common.Log.WithEndpoint(uuid).Errorf("Unsupported protocol version %d",
+			req.ProtocolVersion)
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"protocol version %d is not supported", req.ProtocolVersion)
+	}
+
 	peerInfo, ok := peer.FromContext(ctx)
 
 	if !ok {
-		log.Printf("[!] Failed to get peer info.")
+		common.Log.Errorf("Failed to get peer info.")
 		return nil, fmt.Errorf("getting info from peer context failed")
 	}
 
-	log.Printf("[*] New client connected: %s\n%s\n%s\n", clientConfig.Name, uuid, peerInfo.Addr.String())
+	if s.gServer.isShuttingDown() {
+		return nil, status.Errorf(codes.Unavailable, "%v", errShuttingDown)
+	}
+
+	configMsg := new(cs.GetConfigurationMessageResponse)
+	configMsg.ProtocolVersion = common.ProtocolVersion
+	configMsg.Capabilities = common.SupportedCapabilities()
+	configMsg.HeartbeatInterval = int64(s.gServer.heartbeatInterval / time.Second)
+
+	// An endpoint that lost its control stream keeps its tunnels if it
+	// returns within the grace period.
+	if client, ok := s.gServer.reclaimEndpoint(uuid); ok {
+		client.remoteAddr = peerInfo.Addr.String()
+		client.geo = s.gServer.resolveGeo(peerInfo.Addr)
+		client.protocolVersion = req.ProtocolVersion
+		client.capabilities = common.NegotiateCapabilities(req.Capabilities)
+		client.heartbeat = s.gServer.clientHeartbeat(client)
+		client.touch()
+		return configMsg, nil
+	}
+
+	common.Log.WithEndpoint(uuid).Infof("New client connected: %s %s", clientConfig.Name, peerInfo.Addr.String())
 
 	connectedclient := new(ConnectedClient)
 	connectedclient.uniqueID = uuid
 	connectedclient.remoteAddr = peerInfo.Addr.String()
+	connectedclient.geo = s.gServer.resolveGeo(peerInfo.Addr)
 	connectedclient.hostname = req.Hostname
+	connectedclient.os = req.Os
+	connectedclient.arch = req.Arch
+	connectedclient.username = req.Username
+	connectedclient.addresses = req.Addresses
 	connectedclient.configuredClient = clientConfig
 	connectedclient.connectDate = time.Now()
+	connectedclient.protocolVersion = req.ProtocolVersion
+	connectedclient.capabilities = common.NegotiateCapabilities(req.Capabilities)
+	connectedclient.heartbeat = s.gServer.clientHeartbeat(connectedclient)
+	connectedclient.touch()
 	connectedclient.endpoint = common.NewEndpoint()
+	connectedclient.rateLimiter = s.gServer.newEndpointRateLimiter()
 	connectedclient.endpointInput = make(chan *cs.EndpointControlMessage)
 
-	s.gServer.AddConnectedClient(uuid, connectedclient)
+	// Beaconing endpoints pick up their control messages when they poll,
+	// which the jitter of the interval may delay
+	if common.HasCapability(connectedclient.capabilities, common.CapabilityBeacon) &&
+		req.BeaconInterval > 0 {
+		beacon := time.Duration(req.BeaconInterval) * time.Second
+		latest := latestPoll(beacon, req.BeaconJitter)
+		connectedclient.endpointInput = make(chan *cs.EndpointControlMessage,
+			beaconQueueSize)
+		connectedclient.heartbeat = 2 * latest
+		connectedclient.beacon = latest
+		common.Log.WithEndpoint(uuid).Infof("Endpoint is beaconing every %s with %d%% jitter",
+			beacon, req.BeaconJitter)
+	}
 
-	configMsg := new(cs.GetConfigurationMessageResponse)
+	if s.gServer.AddConnectedClient(uuid, connectedclient) {
+		message := fmt.Sprintf("%s connected from %s", clientConfig.Name,
+			connectedclient.remoteAddr)
+		if geo := connectedclient.geo.String(); geo != "" {
+			message += fmt.Sprintf(" (%s)", geo)
+		}
+		s.gServer.emitEvent(EventEndpointConnected, uuid, "", message)
+	}
 
 	return configMsg, nil
 
@@ -85,32 +148,66 @@ func (s *ClientServiceServer) CreateEndpointControlStream(
 		return err
 	}
 
-	client, ok := s.gServer.connectedClients[uuid]
+	client, ok := s.gServer.getClient(uuid)
 
 	if !ok {
-		log.Printf("[!] UUID does not exist to create control stream")
+		common.Log.Errorf("UUID does not exist to create control stream")
 		return fmt.Errorf("uuid does not exist")
 	}
 
+	s.gServer.orphanMutex.Lock()
+	client.closeControl = cancel
+	s.gServer.orphanMutex.Unlock()
+
+	// A returning endpoint lost its side of the tunnels
+	if s.gServer.takeResume(client) {
+		go s.gServer.resumeTunnels(ctx, uuid, client)
+	} else {
+		go s.gServer.restoreEndpoint(uuid)
+	}
+
+	// The keepalive ticker is disabled until the endpoint is
+	// configured with a keepalive interval.
+	keepalive := time.NewTicker(time.Hour)
+	keepalive.Stop()
+	defer keepalive.Stop()
+
 	for {
 		select {
 
 		case controlMessage, ok := <-client.endpointInput:
 			if !ok {
-				log.Printf(
+				common.Log.WithEndpoint(uuid).Debugf(
 					"Failed to read from EndpointCtrlStream channel. Exiting")
 				break
 			}
-			stream.Send(controlMessage)
-		case <-ctx.Done():
-			log.Printf("Endpoint disconnected: %s", uuid)
-			client, ok := s.gServer.connectedClients[uuid]
-			if !ok {
-				log.Printf("Endpoint already removed: %s",
-					uuid)
+			if controlMessage.Operation == common.EndpointCtrlConfigure {
+				keepalive.Stop()
+				if interval := client.endpoint.GetKeepalive(); interval > 0 {
+					keepalive = time.NewTicker(interval)
+				}
 			}
-			client.endpoint.Stop()
-			delete(s.gServer.connectedClients, uuid)
+			span := common.StartSpan(nil, "endpoint.control")
+			span.SetAttribute("endpoint.id", uuid)
+			span.SetAttribute("tunnel.id", controlMessage.TunnelId)
+			span.SetAttribute("operation", fmt.Sprint(controlMessage.Operation))
+			err := stream.Send(controlMessage)
+			span.SetError(err)
+			span.Finish()
+			if err != nil {
+				s.gServer.endpointLost(uuid)
+				return err
+			}
+		case <-keepalive.C:
+			controlMessage := new(cs.EndpointControlMessage)
+			controlMessage.Operation = common.EndpointCtrlKeepalive
+			if err := stream.Send(controlMessage); err != nil {
+				s.gServer.endpointLost(uuid)
+				return err
+			}
+		case <-ctx.Done():
+			common.Log.WithEndpoint(uuid).Infof("Endpoint disconnected")
+			s.gServer.endpointLost(uuid)
 			return nil
 		}
 	}
@@ -128,28 +225,44 @@ func (s *ClientServiceServer) CreateTunnelControlStream(
 		return err
 	}
 
-	client, ok := s.gServer.connectedClients[uuid]
+	client, ok := s.gServer.getClient(uuid)
 
 	if !ok {
-		log.Printf("[!] CreateTunnelControl: uuid doesn't exist: %s\n", uuid)
+		common.Log.WithEndpoint(uuid).Errorf("CreateTunnelControl: uuid doesn't exist")
 		return fmt.Errorf("uuid does not exist")
 	}
 
 	tunMessage, err := stream.Recv()
 	if err != nil {
-		log.Printf("Failed to receive initial tun stream message: %v", err)
+		common.Log.WithEndpoint(uuid).Errorf("Failed to receive initial tun stream message: %v", err)
 	}
 
 	tun, ok := client.endpoint.GetTunnel(tunMessage.TunnelId)
 
 	if !ok {
-		log.Printf("[!] Received tunnel messsage for ID that doesn't exist")
+		common.Log.WithEndpoint(uuid).WithTunnel(tunMessage.TunnelId).Errorf("Received tunnel messsage for ID that doesn't exist")
 		return fmt.Errorf("failed to establish tunnel")
 	}
 
-	tun.SetControlStream(stream)
-	tun.Start()
-	<-tun.Kill
+	if err := tun.SetPeerKey(tunMessage.PublicKey, s.gServer.serverKey); err != nil {
+		common.Log.WithEndpoint(uuid).WithTunnel(tunMessage.TunnelId).Errorf(
+			"Failed to establish tunnel key: %v", err)
+		return fmt.Errorf("failed to establish tunnel key")
+	}
+
+	// A tunnel that is recreated by a returning endpoint only needs
+	// its new control stream.
+	if tun.GetControlStream() != nil {
+		tun.ReplaceControlStream(stream)
+	} else {
+		tun.SetControlStream(stream)
+		tun.Start()
+	}
+
+	select {
+	case <-tun.Context().Done():
+	case <-stream.Context().Done():
+	}
 	return nil
 }
 
@@ -165,28 +278,91 @@ func (s *ClientServiceServer) CreateConnectionStream(
 		return err
 	}
 
-	client, ok := s.gServer.connectedClients[uuid]
+	client, ok := s.gServer.getClient(uuid)
 
 	if !ok {
-		log.Printf("[!] CreateTunnelControl: uuid doesn't exist: %s\n", uuid)
+		common.Log.WithEndpoint(uuid).Errorf("CreateTunnelControl: uuid doesn't exist")
 		return fmt.Errorf("uuid does not exist")
 	}
 
-	bytesMessage, _ := stream.Recv()
+	bytesMessage, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	// Test streams belong to no tunnel
+	if bytesMessage.TunnelId == "" {
+		return s.gServer.serveTestStream(uuid, bytesMessage.ConnectionId, stream)
+	}
+
 	tunnel, ok := client.endpoint.GetTunnel(bytesMessage.TunnelId)
 
 	if !ok {
-		log.Printf("[!] Got a ByteMessage for a non-existent tunnel: %s\n",
-			bytesMessage.TunnelId)
+		common.Log.WithEndpoint(uuid).WithTunnel(bytesMessage.TunnelId).Errorf(
+			"Got a ByteMessage for a non-existent tunnel")
 		return fmt.Errorf("invalid tunnel id")
 	}
 
 	conn := tunnel.GetConnection(bytesMessage.ConnectionId)
+	logger := common.Log.WithEndpoint(uuid).WithTunnel(bytesMessage.TunnelId).WithConnection(
+		bytesMessage.ConnectionId)
+
+	if conn == nil {
+		logger.Errorf("Got a ByteMessage for a non-existent connection")
+		return status.Errorf(codes.NotFound, "invalid connection id")
+	}
+
+	// A resumable connection continues on a new byte stream after its
+	// stream broke. The old stream is released once it is replaced.
+	if bytesMessage.Resume {
+		replaced, err := conn.ResumeStream(stream, bytesMessage)
+		if err != nil {
+			logger.Warnf("Rejected byte stream resumption: %v", err)
+			return status.Errorf(codes.PermissionDenied, "%v", err)
+		}
+		return s.awaitConnection(tunnel, conn, replaced)
+	}
+
+	// A connection only ever gets a single byte stream. Clients that
+	// support it also have to present the token that was issued for
+	// the connection over the tunnel control stream.
+	if common.HasCapability(client.capabilities, common.CapabilityStreamAuth) &&
+		!conn.ConsumeStreamToken(bytesMessage.StreamToken) {
+		logger.Warnf("Rejected a byte stream with an invalid stream token")
+		return status.Errorf(codes.PermissionDenied, "invalid stream token")
+	}
+
+	if !conn.AttachStream(stream) {
+		logger.Warnf("Rejected a second byte stream for the connection")
+		return status.Errorf(codes.PermissionDenied, "byte stream already attached")
+	}
+
+	if common.HasCapability(client.capabilities, common.CapabilityStreamResume) {
+		conn.EnableResume(bytesMessage.TunnelId, bytesMessage.StreamToken, nil)
+	}
+
+	if common.HasCapability(client.capabilities, common.CapabilityFlowControl) {
+		conn.EnableFlowControl()
+	}
+
+	if common.HasCapability(client.capabilities, common.CapabilityHalfClose) {
+		conn.EnableHalfClose()
+	}
 
-	conn.SetStream(stream)
 	close(conn.Connected)
-	<-conn.Kill
-	tunnel.RemoveConnection(conn.ID)
+	return s.awaitConnection(tunnel, conn, conn.StreamReplaced())
+}
+
+// awaitConnection keeps a byte stream open until its connection is
+// closed or the stream is replaced.
+func (s *ClientServiceServer) awaitConnection(tunnel *common.Tunnel,
+	conn *common.Connection, replaced <-chan struct{}) error {
+
+	select {
+	case <-conn.Kill:
+		tunnel.RemoveConnection(conn.ID)
+	case <-replaced:
+	}
 	return nil
 }
 
@@ -197,35 +373,58 @@ func (s *ClientServiceServer) Start(
 	certFile string,
 	keyFile string) {
 
-	log.Printf("[*] Starting client grpc server on port: %d\n", port)
+	common.Log.Infof("Starting client grpc server on port: %d", port)
 	var opts []grpc.ServerOption
 	opts = append(opts,
 		grpc.UnaryInterceptor(s.gServer.UnaryAuthInterceptor),
 		grpc.StreamInterceptor(s.gServer.StreamAuthInterceptor),
+		grpc.UnknownServiceHandler(s.serveRenamed),
 	)
+	opts = append(opts, s.gServer.grpcKeepalive.serverOptions()...)
+	opts = append(opts, s.gServer.grpcMsgSize.serverOptions()...)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		common.Log.Fatalf("Failed to listen: %v", err)
 	}
+	lis = &allowlistListener{Listener: lis, gServer: s.gServer}
 
 	if tls == true {
 		creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
 
 		if err != nil {
-			log.Fatalf("Failed to load TLS certificates.")
+			common.Log.Fatalf("Failed to load TLS certificates.")
 		}
 
-		log.Printf("Successfully loaded key/certificate pair")
+		common.Log.Infof("Successfully loaded key/certificate pair")
 		opts = append(opts, grpc.Creds(creds))
 	} else {
-		log.Printf("[!] Starting gServer without TLS!")
+		common.Log.Warnf("Starting gServer without TLS!")
 	}
 
 	grpcServer := grpc.NewServer(opts...)
+	s.grpcServer = grpcServer
 
 	cs.RegisterClientServiceServer(grpcServer, s)
 
+	if s.gServer.webSocketPort != 0 {
+		go s.startWebSocket(grpcServer, s.gServer.webSocketPort, tls,
+			certFile, keyFile)
+	}
+	if s.gServer.longPollPort != 0 {
+		go s.startLongPoll(grpcServer, s.gServer.longPollPort, tls,
+			certFile, keyFile)
+	}
+	if s.gServer.dnsAddress != "" {
+		go s.startDNS(grpcServer, s.gServer.dnsAddress, s.gServer.dnsDomain)
+	}
+	if s.gServer.icmpAddress != "" {
+		go s.startICMP(grpcServer, s.gServer.icmpAddress)
+	}
+	if s.gServer.quicPort != 0 {
+		go s.startQUIC(grpcServer, s.gServer.quicPort, certFile, keyFile)
+	}
+
 	grpcServer.Serve(lis)
 
 }
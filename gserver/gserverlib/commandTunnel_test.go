@@ -0,0 +1,54 @@
+package gserverlib
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/kai5263499/gtunnel/common"
+	as "github.com/kai5263499/gtunnel/grpc/admin"
+)
+
+func TestReverseCommandTunnel(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.connectedClients["endpoint"].capabilities = []string{common.CapabilityCommandTunnel}
+
+	// gServer dials for reverse tunnels and would run the command itself
+	err := s.AddTunnel("endpoint", "command", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("127.0.0.1"), 0,
+		common.TunnelOptions{Command: "/bin/sh"})
+	if err == nil {
+		t.Errorf("AddTunnel: added a reverse tunnel that runs a command")
+	}
+	if _, ok := s.connectedClients["endpoint"].endpoint.GetTunnel("command"); ok {
+		t.Errorf("AddTunnel: the rejected tunnel was added")
+	}
+
+	admin := NewAdminServiceServer(s)
+	_, err = admin.TunnelAdd(context.Background(), &as.TunnelAddRequest{
+		ClientId: "endpoint",
+		Tunnel: &as.Tunnel{
+			Direction: common.TunnelDirectionReverse,
+			Command:   "/bin/sh",
+		},
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("TunnelAdd: Got: %v Want: InvalidArgument", err)
+	}
+
+	body := `{"direction": "reverse", "destination_ip": "127.0.0.1", "command": "/bin/sh"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/clients/endpoint/tunnels",
+		strings.NewReader(body))
+	w := httptest.NewRecorder()
+	NewRestServiceServer(s).handleClient(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("tunnelAdd: answered %d", w.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/encoding"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestCompression(t *testing.T) {
+	// Compressed byte streams can only be decoded with gzip registered
+	if encoding.GetCompressor("gzip") == nil {
+		t.Errorf("gzip compressor is not registered")
+	}
+
+	// The test endpoint negotiated no capabilities
+	s, _ := newTestServer(time.Hour)
+	err := s.AddTunnel("endpoint", "compressed", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{Compress: true})
+	if err == nil {
+		t.Errorf("AddTunnel: compressed tunnel added to an endpoint without support")
+	}
+}
@@ -3,12 +3,41 @@ package gserverlib
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"strings"
 	"sync"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/kai5263499/gtunnel/common"
 )
 
+// Stored tunnels and endpoints are kept under these key prefixes, apart
+// from the configured clients, which are keyed by their token.
+const (
+	tunnelKeyPrefix   = "tunnel:"
+	endpointKeyPrefix = "endpoint:"
+)
+
+// StoredTunnel is the definition of a tunnel that is restored when its
+// endpoint connects to a restarted gServer.
+type StoredTunnel struct {
+	EndpointID      string
+	ID              string
+	Direction       uint32
+	ListenIP        string
+	ListenPort      uint32
+	DestinationIP   string
+	DestinationPort uint32
+	Options         common.TunnelOptions
+}
+
+// StoredEndpoint is the metadata of an endpoint that is kept across
+// restarts of gServer.
+type StoredEndpoint struct {
+	ID    string
+	Alias string
+	Tags  []string
+}
+
 // ConfigStore is a structure that represents all of the configurations of
 // the gServer and will keep state to a json file
 type ConfigStore struct {
@@ -16,11 +45,15 @@ type ConfigStore struct {
 	// uses their bearer token as a key for easy auth lookup
 	configuredClients map[string]*ConfiguredClient
 
+	// Tunnels are kept by endpoint ID and tunnel ID
+	storedTunnels   map[string]map[string]*StoredTunnel
+	storedEndpoints map[string]*StoredEndpoint
+
 	// The filename where the configuration will save changes and load
 	// on start
 	redisClient *redis.Client
 	context     context.Context
-	mutex       sync.Mutex
+	mutex       sync.RWMutex
 }
 
 func NewConfigStore() *ConfigStore {
@@ -33,6 +66,8 @@ func NewConfigStore() *ConfigStore {
 	})
 
 	configStore.configuredClients = make(map[string]*ConfiguredClient)
+	configStore.storedTunnels = make(map[string]map[string]*StoredTunnel)
+	configStore.storedEndpoints = make(map[string]*StoredEndpoint)
 
 	return configStore
 }
@@ -44,7 +79,7 @@ func (c *ConfigStore) AddConfiguredClient(client *ConfiguredClient) error {
 	clientJSON, err := json.Marshal(client)
 
 	if err != nil {
-		log.Printf("[!] Failed to convert configured client into json")
+		common.Log.Errorf("Failed to convert configured client into json")
 		return err
 	}
 
@@ -54,7 +89,7 @@ func (c *ConfigStore) AddConfiguredClient(client *ConfiguredClient) error {
 
 	err = c.redisClient.Set(c.context, client.Token, clientJSON, 0).Err()
 	if err != nil {
-		log.Printf("[!] Failed to insert configured client into redis database")
+		common.Log.Errorf("Failed to insert configured client into redis database")
 		return err
 	}
 
@@ -63,7 +98,13 @@ func (c *ConfigStore) AddConfiguredClient(client *ConfiguredClient) error {
 	return nil
 }
 
+// GetConfiguredClient returns the configured client with the bearer
+// token key, or nil if there is none. It is called for every request
+// while the configuration may be reloaded.
 func (c *ConfigStore) GetConfiguredClient(key string) *ConfiguredClient {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	client, ok := c.configuredClients[key]
 	if !ok {
 		return nil
@@ -71,13 +112,22 @@ func (c *ConfigStore) GetConfiguredClient(key string) *ConfiguredClient {
 	return client
 }
 
+// LoadConfiguredClient adds a configured client without writing it to
+// the redis datastore. It is used for the clients of the configuration
+// file, which remains their source of truth.
+func (c *ConfigStore) LoadConfiguredClient(client *ConfiguredClient) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.configuredClients[client.Token] = client
+}
+
 func (c *ConfigStore) DeleteConfiguredClient(key string) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
 	err := c.redisClient.Del(c.context, key).Err()
 	if err != nil {
-		log.Printf("[!] Failed to delete configured client")
+		common.Log.Errorf("Failed to delete configured client")
 		return err
 	}
 
@@ -93,18 +143,42 @@ func (c *ConfigStore) Initialize() error {
 	keys, err := c.redisClient.Keys(c.context, "*").Result()
 
 	if err != nil {
-		log.Printf("[!] Failed to initialize configuration store")
+		common.Log.Errorf("Failed to initialize configuration store")
 		return err
 	}
 
 	for _, key := range keys {
-		clientConfig := new(ConfiguredClient)
-
 		value, err := c.redisClient.Get(c.context, key).Result()
+		if err != nil {
+			common.Log.Errorf("Failed to read %s from configuration store", key)
+			continue
+		}
+
+		if strings.HasPrefix(key, tunnelKeyPrefix) {
+			tunnel := new(StoredTunnel)
+			if err := json.Unmarshal([]byte(value), tunnel); err != nil {
+				common.Log.Errorf("Failed to load stored tunnel %s", key)
+				continue
+			}
+			c.addStoredTunnel(tunnel)
+			continue
+		}
+
+		if strings.HasPrefix(key, endpointKeyPrefix) {
+			endpoint := new(StoredEndpoint)
+			if err := json.Unmarshal([]byte(value), endpoint); err != nil {
+				common.Log.Errorf("Failed to load stored endpoint %s", key)
+				continue
+			}
+			c.storedEndpoints[endpoint.ID] = endpoint
+			continue
+		}
+
+		clientConfig := new(ConfiguredClient)
 
 		err = json.Unmarshal([]byte(value), clientConfig)
 		if err != nil {
-			log.Printf("[!] Failed to load configured client")
+			common.Log.Errorf("Failed to load configured client")
 			continue
 		}
 
@@ -113,3 +187,108 @@ func (c *ConfigStore) Initialize() error {
 
 	return nil
 }
+
+// SaveTunnel stores the definition of a tunnel, replacing any earlier
+// definition with the same endpoint and tunnel ID.
+func (c *ConfigStore) SaveTunnel(tunnel *StoredTunnel) error {
+	tunnelJSON, err := json.Marshal(tunnel)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := tunnelKeyPrefix + tunnel.EndpointID + ":" + tunnel.ID
+	if err := c.redisClient.Set(c.context, key, tunnelJSON, 0).Err(); err != nil {
+		return err
+	}
+
+	c.addStoredTunnel(tunnel)
+	return nil
+}
+
+// addStoredTunnel adds a tunnel to the in memory copy of the store. It
+// must be called with the mutex held.
+func (c *ConfigStore) addStoredTunnel(tunnel *StoredTunnel) {
+	tunnels, ok := c.storedTunnels[tunnel.EndpointID]
+	if !ok {
+		tunnels = make(map[string]*StoredTunnel)
+		c.storedTunnels[tunnel.EndpointID] = tunnels
+	}
+	tunnels[tunnel.ID] = tunnel
+}
+
+// DeleteTunnel removes the definition of a tunnel.
+func (c *ConfigStore) DeleteTunnel(endpointID string, tunnelID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := tunnelKeyPrefix + endpointID + ":" + tunnelID
+	if err := c.redisClient.Del(c.context, key).Err(); err != nil {
+		return err
+	}
+
+	delete(c.storedTunnels[endpointID], tunnelID)
+	if len(c.storedTunnels[endpointID]) == 0 {
+		delete(c.storedTunnels, endpointID)
+	}
+	return nil
+}
+
+// GetTunnels returns the stored tunnels of an endpoint.
+func (c *ConfigStore) GetTunnels(endpointID string) []*StoredTunnel {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	tunnels := make([]*StoredTunnel, 0, len(c.storedTunnels[endpointID]))
+	for _, tunnel := range c.storedTunnels[endpointID] {
+		tunnels = append(tunnels, tunnel)
+	}
+	return tunnels
+}
+
+// SaveEndpoint stores the metadata of an endpoint.
+func (c *ConfigStore) SaveEndpoint(endpoint *StoredEndpoint) error {
+	endpointJSON, err := json.Marshal(endpoint)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := endpointKeyPrefix + endpoint.ID
+	if err := c.redisClient.Set(c.context, key, endpointJSON, 0).Err(); err != nil {
+		return err
+	}
+
+	c.storedEndpoints[endpoint.ID] = endpoint
+	return nil
+}
+
+// GetEndpoint returns the stored metadata of an endpoint or nil if
+// there is none.
+func (c *ConfigStore) GetEndpoint(endpointID string) *StoredEndpoint {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.storedEndpoints[endpointID]
+}
+
+// DeleteEndpoint removes the metadata and all tunnels of an endpoint.
+func (c *ConfigStore) DeleteEndpoint(endpointID string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	keys := []string{endpointKeyPrefix + endpointID}
+	for tunnelID := range c.storedTunnels[endpointID] {
+		keys = append(keys, tunnelKeyPrefix+endpointID+":"+tunnelID)
+	}
+	if err := c.redisClient.Del(c.context, keys...).Err(); err != nil {
+		return err
+	}
+
+	delete(c.storedEndpoints, endpointID)
+	delete(c.storedTunnels, endpointID)
+	return nil
+}
@@ -0,0 +1,35 @@
+package gserverlib
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConfiguredClientReload(t *testing.T) {
+	store := NewConfigStore()
+
+	// Requests look up clients while the configuration is reloaded
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			store.LoadConfiguredClient(&ConfiguredClient{Token: fmt.Sprintf("token%d", i)})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			store.GetConfiguredClient(fmt.Sprintf("token%d", i))
+		}
+	}()
+	wg.Wait()
+
+	if client := store.GetConfiguredClient("token999"); client == nil {
+		t.Errorf("GetConfiguredClient: Got: nil Want: the loaded client")
+	}
+	if client := store.GetConfiguredClient("missing"); client != nil {
+		t.Errorf("GetConfiguredClient: Got: %v Want: nil", client)
+	}
+}
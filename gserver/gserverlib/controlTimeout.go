@@ -0,0 +1,89 @@
+package gserverlib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// DefaultControlTimeout is how long control operations wait for an
+// endpoint by default.
+const DefaultControlTimeout = 10 * time.Second
+
+// SetControlTimeout sets how long control operations, such as adding
+// and deleting tunnels, wait for an endpoint to take a control message
+// and to acknowledge a new tunnel before they fail.
+func (s *GServer) SetControlTimeout(timeout time.Duration) {
+	s.controlMutex.Lock()
+	defer s.controlMutex.Unlock()
+
+	s.controlTimeout = timeout
+}
+
+// getControlTimeout returns the control timeout, or the default if
+// none is set.
+func (s *GServer) getControlTimeout() time.Duration {
+	s.controlMutex.Lock()
+	defer s.controlMutex.Unlock()
+
+	if s.controlTimeout <= 0 {
+		return DefaultControlTimeout
+	}
+	return s.controlTimeout
+}
+
+// sendControlMessage hands a control message to the control stream of
+// an endpoint. It fails if the endpoint does not take it within the
+// control timeout, instead of blocking the caller.
+func (s *GServer) sendControlMessage(clientID string, client *ConnectedClient,
+	message *cs.EndpointControlMessage) error {
+
+	timeout := s.getControlTimeout()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case client.endpointInput <- message:
+		return nil
+	case <-timer.C:
+		common.Log.WithEndpoint(clientID).WithTunnel(message.TunnelId).Errorf(
+			"Endpoint did not take control message %d within %s",
+			message.Operation, timeout)
+		return fmt.Errorf("endpoint did not respond within %s", timeout)
+	}
+}
+
+// awaitTunnel deletes a new tunnel if its endpoint does not open the
+// control stream of the tunnel within the control timeout. Beaconing
+// endpoints get their longest beacon interval, with its jitter, on
+// top, since they only pick up the tunnel when they poll.
+func (s *GServer) awaitTunnel(clientID string, tunnelID string,
+	client *ConnectedClient, tunnel *common.Tunnel) {
+
+	timeout := s.getControlTimeout() + client.beacon
+	if tunnel.WaitControlStream(timeout) || tunnel.Context().Err() != nil {
+		return
+	}
+
+	reason := fmt.Sprintf("endpoint did not acknowledge the tunnel within %s",
+		timeout)
+	common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Errorf(
+		"Tunnel failed: %s", reason)
+	s.emitEvent(EventTunnelFailed, clientID, tunnelID, reason)
+
+	if err := s.DeleteTunnel(clientID, tunnelID); err != nil {
+		common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf(
+			"Failed to delete failed tunnel: %v", err)
+	}
+}
+
+// latestPoll returns the longest time between the polls of an endpoint
+// that beacons at interval, varied by up to jitter percent.
+func latestPoll(interval time.Duration, jitter uint32) time.Duration {
+	if jitter > 100 {
+		jitter = 100
+	}
+	return interval + interval*time.Duration(jitter)/100
+}
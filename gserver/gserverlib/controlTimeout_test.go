@@ -0,0 +1,75 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestControlTimeout(t *testing.T) {
+	// Events are emitted from the goroutine that waits for the tunnel
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	s.SetControlTimeout(50 * time.Millisecond)
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	s.connectedClients["endpoint"] = client
+
+	// Nobody takes the message off the control stream
+	client.endpointInput = make(chan *cs.EndpointControlMessage)
+	err := s.AddTunnel("endpoint", "stuck", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{})
+	if err == nil {
+		t.Errorf("AddTunnel: succeeded without the endpoint")
+	}
+	if _, ok := client.endpoint.GetTunnel("stuck"); ok {
+		t.Errorf("AddTunnel: the failed tunnel was kept")
+	}
+
+	// The endpoint takes the message but never opens the tunnel
+	failed := make(chan bool, 1)
+	s.AddEventHandler(func(event Event) {
+		if event.Type == EventTunnelFailed {
+			failed <- true
+		}
+	})
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+	err = s.AddTunnel("endpoint", "unacknowledged", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{})
+	if err != nil {
+		t.Fatalf("AddTunnel: %v", err)
+	}
+	select {
+	case <-failed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("events: no %s event", EventTunnelFailed)
+	}
+	if _, ok := client.endpoint.GetTunnel("unacknowledged"); ok {
+		t.Errorf("the unacknowledged tunnel was kept")
+	}
+}
+
+func TestLatestPoll(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		jitter   uint32
+		want     time.Duration
+	}{
+		{10 * time.Second, 0, 10 * time.Second},
+		{10 * time.Second, 50, 15 * time.Second},
+		{10 * time.Second, 100, 20 * time.Second},
+		{10 * time.Second, 250, 20 * time.Second},
+	}
+	for _, test := range tests {
+		if got := latestPoll(test.interval, test.jitter); got != test.want {
+			t.Errorf("latestPoll(%s, %d) = %s, want %s", test.interval,
+				test.jitter, got, test.want)
+		}
+	}
+}
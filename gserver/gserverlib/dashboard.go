@@ -0,0 +1,187 @@
+package gserverlib
+
+import (
+	"net/http"
+)
+
+// handleDashboard serves the web dashboard. The dashboard is a single
+// page that drives the rest api from the browser with the admin token
+// the operator enters, which is kept for the browser session.
+func (s *RestServiceServer) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(dashboardHTML))
+}
+
+// dashboardHTML is the markup and script for the web dashboard.
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>gTunnel</title>
+<style>
+body { font-family: monospace; background: #1e1e1e; color: #d4d4d4; margin: 2em; }
+h1, h2 { color: #9cdcfe; }
+table { border-collapse: collapse; margin-bottom: 1em; width: 100%; }
+th, td { border: 1px solid #444; padding: 4px 8px; text-align: left; }
+th { background: #2d2d2d; }
+button { background: #3c3c3c; color: #d4d4d4; border: 1px solid #666; cursor: pointer; }
+input, select { background: #2d2d2d; color: #d4d4d4; border: 1px solid #666; }
+#error { color: #f48771; }
+</style>
+</head>
+<body>
+<h1>gTunnel</h1>
+<form id="login">
+<input id="token" type="password" placeholder="admin token" size="40">
+<button type="submit">Use token</button>
+</form>
+<div id="error"></div>
+
+<h2>Endpoints</h2>
+<table>
+<thead><tr><th>Name</th><th>Client ID</th><th>Remote Address</th><th>Hostname</th><th>Connected</th><th></th></tr></thead>
+<tbody id="clients"></tbody>
+</table>
+
+<h2>Tunnels</h2>
+<table>
+<thead><tr><th>Client ID</th><th>Tunnel ID</th><th>Direction</th><th>Listen</th><th>Destination</th><th>Connections</th><th></th></tr></thead>
+<tbody id="tunnels"></tbody>
+</table>
+
+<h2>Add Tunnel</h2>
+<form id="add">
+<select id="add-client"></select>
+<select id="add-direction"><option>forward</option><option>reverse</option></select>
+<input id="add-id" placeholder="tunnel id (optional)">
+<input id="add-listenip" placeholder="listen ip" value="0.0.0.0">
+<input id="add-listenport" placeholder="listen port" size="6">
+<input id="add-destip" placeholder="destination ip">
+<input id="add-destport" placeholder="destination port" size="6">
+<button type="submit">Add</button>
+</form>
+
+<script>
+function api(method, path, body) {
+  var opts = {method: method, headers: {}};
+  // The rest api only accepts changes with an Authorization header,
+  // which is empty without admin tokens, and a JSON body
+  var token = sessionStorage.getItem("gtunnel-token") || "";
+  opts.headers["Authorization"] = "Bearer " + token;
+  if (body !== undefined) {
+    opts.headers["Content-Type"] = "application/json";
+    opts.body = JSON.stringify(body);
+  }
+  return fetch("/api/v1" + path, opts).then(function (resp) {
+    if (!resp.ok) {
+      return resp.json().then(function (e) { throw new Error(e.error); });
+    }
+    if (resp.status === 204) {
+      return null;
+    }
+    return resp.text().then(function (t) { return t ? JSON.parse(t) : null; });
+  });
+}
+
+function cell(row, text) {
+  var td = document.createElement("td");
+  td.textContent = text;
+  row.appendChild(td);
+  return td;
+}
+
+function button(row, label, action) {
+  var td = cell(row, "");
+  var b = document.createElement("button");
+  b.textContent = label;
+  b.onclick = function () { action().then(refresh).catch(showError); };
+  td.appendChild(b);
+}
+
+function showError(err) {
+  document.getElementById("error").textContent = err.message;
+}
+
+function refresh() {
+  api("GET", "/clients").then(function (clients) {
+    var clientBody = document.getElementById("clients");
+    var tunnelBody = document.getElementById("tunnels");
+    var select = document.getElementById("add-client");
+    var selected = select.value;
+    clientBody.innerHTML = "";
+    select.innerHTML = "";
+
+    var pending = clients.map(function (c) {
+      var row = clientBody.insertRow();
+      cell(row, c.name);
+      cell(row, c.client_id);
+      cell(row, c.remote_address);
+      cell(row, c.hostname);
+      cell(row, c.connect_date);
+      button(row, "disconnect", function () {
+        return api("DELETE", "/clients/" + c.client_id);
+      });
+      var opt = document.createElement("option");
+      opt.textContent = c.client_id;
+      select.appendChild(opt);
+
+      return api("GET", "/clients/" + c.client_id + "/tunnels").then(function (tunnels) {
+        return tunnels.map(function (t) { t.client_id = c.client_id; return t; });
+      });
+    });
+    if (selected) {
+      select.value = selected;
+    }
+
+    return Promise.all(pending).then(function (lists) {
+      tunnelBody.innerHTML = "";
+      lists.forEach(function (tunnels) {
+        tunnels.forEach(function (t) {
+          var row = tunnelBody.insertRow();
+          cell(row, t.client_id);
+          cell(row, t.id);
+          cell(row, t.direction);
+          cell(row, t.listen_ip + ":" + t.listen_port);
+          cell(row, t.destination_ip + ":" + t.destination_port);
+          cell(row, t.connections);
+          button(row, "delete", function () {
+            return api("DELETE", "/clients/" + t.client_id + "/tunnels/" + t.id);
+          });
+        });
+      });
+      document.getElementById("error").textContent = "";
+    });
+  }).catch(showError);
+}
+
+document.getElementById("login").onsubmit = function (ev) {
+  ev.preventDefault();
+  sessionStorage.setItem("gtunnel-token", document.getElementById("token").value);
+  document.getElementById("token").value = "";
+  refresh();
+};
+
+document.getElementById("add").onsubmit = function (ev) {
+  ev.preventDefault();
+  var client = document.getElementById("add-client").value;
+  api("POST", "/clients/" + client + "/tunnels", {
+    id: document.getElementById("add-id").value,
+    direction: document.getElementById("add-direction").value,
+    listen_ip: document.getElementById("add-listenip").value,
+    listen_port: parseInt(document.getElementById("add-listenport").value, 10) || 0,
+    destination_ip: document.getElementById("add-destip").value,
+    destination_port: parseInt(document.getElementById("add-destport").value, 10) || 0
+  }).then(refresh).catch(showError);
+};
+
+refresh();
+setInterval(refresh, 2000);
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,37 @@
+package gserverlib
+
+import (
+	"fmt"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetEndpointDialPolicy sends a dial policy to an endpoint, restricting
+// the destinations it may dial. The endpoint applies it on top of the
+// policy embedded in it, so it can only tighten that one. A new policy
+// replaces the previously sent one.
+func (s *GServer) SetEndpointDialPolicy(clientID string, allow string,
+	deny string) error {
+
+	if _, err := common.ParseDialPolicy(allow, deny); err != nil {
+		return err
+	}
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	if !common.HasCapability(client.capabilities, common.CapabilityDialPolicy) {
+		return fmt.Errorf("client %s does not support dial policies", clientID)
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlDialPolicy
+	controlMessage.DialAllow = allow
+	controlMessage.DialDeny = deny
+
+	return s.sendControlMessage(clientID, client, controlMessage)
+}
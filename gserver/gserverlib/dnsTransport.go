@@ -0,0 +1,158 @@
+package gserverlib
+
+import (
+	"encoding/hex"
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+	"golang.org/x/net/dns/dnsmessage"
+	"google.golang.org/grpc"
+)
+
+// SetDNSTransport makes the client service accept gClients over the DNS
+// transport. gServer answers TXT queries for names within domain on
+// the udp address, which has to be the authoritative name server of
+// domain. Disabled if address is empty. It has to be called before
+// Start.
+func (s *GServer) SetDNSTransport(address string, domain string) {
+	s.dnsAddress = address
+	s.dnsDomain = domain
+}
+
+// dnsTransportServer answers the queries of DNS transport sessions.
+type dnsTransportServer struct {
+	conn     net.PacketConn
+	domain   string
+	sessions *pollSessions
+}
+
+// txt returns the TXT record that answers the query for name from
+// addr, or false if there is none.
+func (d *dnsTransportServer) txt(name string, addr net.Addr) (string, bool) {
+	id, seq, data, err := common.ParseDNSQueryName(name, d.domain)
+	if err != nil {
+		common.Log.Debugf("DNS transport dropped query from %s: %v", addr, err)
+		return "", false
+	}
+	// Opening queries carry a nonce
+	if id == common.DNSOpenLabel {
+		return d.sessions.open(hex.EncodeToString(data), addr)
+	}
+
+	s, ok := d.sessions.get(id)
+	if !ok {
+		return "", false
+	}
+	answer, more, _, ok := s.exchange(seq, data, common.DNSDownstreamBytes)
+	return common.EncodeDNSAnswer(answer, more), ok
+}
+
+// answer builds the response to a single query.
+func (d *dnsTransportServer) answer(query []byte, addr net.Addr) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := parser.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	respHeader := dnsmessage.Header{
+		ID:               header.ID,
+		Response:         true,
+		OpCode:           header.OpCode,
+		Authoritative:    true,
+		RecursionDesired: header.RecursionDesired,
+	}
+
+	var txt string
+	ok := false
+	if question.Type == dnsmessage.TypeTXT && question.Class == dnsmessage.ClassINET {
+		txt, ok = d.txt(question.Name.String(), addr)
+	}
+	if !ok {
+		// The resolver retries, possibly once gRPC caught up
+		respHeader.RCode = dnsmessage.RCodeServerFailure
+	}
+
+	builder := dnsmessage.NewBuilder(make([]byte, 0, 512), respHeader)
+	builder.EnableCompression()
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+	if !ok {
+		return builder.Finish()
+	}
+
+	if err := builder.StartAnswers(); err != nil {
+		return nil, err
+	}
+	// Answers must not be cached, every query is a new exchange
+	err = builder.TXTResource(dnsmessage.ResourceHeader{
+		Name:  question.Name,
+		Class: dnsmessage.ClassINET,
+		TTL:   0,
+	}, dnsmessage.TXTResource{TXT: splitTXT(txt)})
+	if err != nil {
+		return nil, err
+	}
+	return builder.Finish()
+}
+
+// splitTXT splits s into the strings of up to 255 bytes of a TXT
+// record.
+func splitTXT(s string) []string {
+	var strs []string
+	for len(s) > 255 {
+		strs = append(strs, s[:255])
+		s = s[255:]
+	}
+	return append(strs, s)
+}
+
+// serve reads queries until the socket is closed.
+func (d *dnsTransportServer) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := d.conn.ReadFrom(buf)
+		if err != nil {
+			common.Log.Errorf("DNS transport stopped: %v", err)
+			return
+		}
+
+		resp, err := d.answer(buf[:n], addr)
+		if err != nil {
+			common.Log.Debugf("DNS transport dropped query from %s: %v", addr, err)
+			continue
+		}
+		d.conn.WriteTo(resp, addr)
+	}
+}
+
+// startDNS serves grpcServer to DNS transports.
+func (s *ClientServiceServer) startDNS(grpcServer *grpc.Server,
+	address string,
+	domain string) {
+
+	common.Log.Infof("Starting client dns server on %s for %s", address, domain)
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		common.Log.Errorf("Failed to listen for dns: %v", err)
+		return
+	}
+
+	listener := newTransportListener(conn.LocalAddr())
+	d := new(dnsTransportServer)
+	d.conn = conn
+	d.domain = domain
+	d.sessions = newPollSessions(listener)
+
+	go grpcServer.Serve(listener)
+	d.serve()
+}
@@ -0,0 +1,75 @@
+package gserverlib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestDNSTransport(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket: %v", err)
+	}
+	defer conn.Close()
+
+	listener := newTransportListener(conn.LocalAddr())
+	defer listener.Close()
+	d := new(dnsTransportServer)
+	d.conn = conn
+	d.domain = "t.example.com"
+	d.sessions = newPollSessions(listener)
+	go d.serve()
+
+	// Echo what the client sends
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		io.Copy(conn, conn)
+	}()
+
+	transport := &common.DNSTransport{
+		Domain:   d.domain,
+		Resolver: conn.LocalAddr().String(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := transport.Dial(ctx, "")
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	// Several queries and answers in each direction
+	data := bytes.Repeat([]byte("0123456789"), 50)
+	client.Write(data)
+	reply := make([]byte, len(data))
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(client, reply)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil || !bytes.Equal(reply, data) {
+			t.Errorf("Read: Got: %q, %v Want: the data", reply, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Read: no reply")
+	}
+
+	// Resolvers may change the case of names
+	name := common.DNSQueryName("session", 7, []byte("data"), d.domain)
+	session, seq, payload, err := common.ParseDNSQueryName(strings.ToUpper(name), d.domain)
+	if err != nil || session != "session" || seq != 7 || string(payload) != "data" {
+		t.Errorf("ParseDNSQueryName: Got: %s %d %q, %v", session, seq, payload, err)
+	}
+}
@@ -0,0 +1,79 @@
+package gserverlib
+
+import (
+	"fmt"
+)
+
+// RenameEndpoint assigns an alias to a connected endpoint, replacing
+// any alias it had before. The alias can be used in place of the
+// endpoint ID in all commands. An empty alias removes the alias.
+func (s *GServer) RenameEndpoint(clientID string, alias string) error {
+	clientID = s.ResolveEndpointID(clientID)
+
+	if _, ok := s.getClient(clientID); !ok {
+		return fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	if _, ok := s.getClient(alias); ok && alias != clientID {
+		return fmt.Errorf("alias %s is the id of another client", alias)
+	}
+
+	// Deferred first so that it runs after the mutex is released
+	defer s.storeEndpoint(clientID)
+
+	s.aliasMutex.Lock()
+	defer s.aliasMutex.Unlock()
+
+	if id, ok := s.endpointAliases[alias]; ok && id != clientID {
+		return fmt.Errorf("alias %s is already used by client %s", alias, id)
+	}
+
+	for existing, id := range s.endpointAliases {
+		if id == clientID {
+			delete(s.endpointAliases, existing)
+		}
+	}
+
+	if alias != "" && alias != clientID {
+		s.endpointAliases[alias] = clientID
+	}
+	return nil
+}
+
+// ResolveEndpointID returns the endpoint ID for an alias. Anything
+// that is not an alias is returned unchanged.
+func (s *GServer) ResolveEndpointID(idOrAlias string) string {
+	s.aliasMutex.Lock()
+	defer s.aliasMutex.Unlock()
+
+	if id, ok := s.endpointAliases[idOrAlias]; ok {
+		return id
+	}
+	return idOrAlias
+}
+
+// EndpointAlias returns the alias of an endpoint or an empty string
+// if it has none.
+func (s *GServer) EndpointAlias(clientID string) string {
+	s.aliasMutex.Lock()
+	defer s.aliasMutex.Unlock()
+
+	for alias, id := range s.endpointAliases {
+		if id == clientID {
+			return alias
+		}
+	}
+	return ""
+}
+
+// removeEndpointAlias forgets the alias of an endpoint.
+func (s *GServer) removeEndpointAlias(clientID string) {
+	s.aliasMutex.Lock()
+	defer s.aliasMutex.Unlock()
+
+	for alias, id := range s.endpointAliases {
+		if id == clientID {
+			delete(s.endpointAliases, alias)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenameEndpoint(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.endpointAliases = make(map[string]string)
+
+	if err := s.RenameEndpoint("endpoint", "dc01-pivot"); err != nil {
+		t.Fatalf("RenameEndpoint: %v", err)
+	}
+	if id := s.ResolveEndpointID("dc01-pivot"); id != "endpoint" {
+		t.Errorf("ResolveEndpointID: Got: %s Want: endpoint", id)
+	}
+
+	// Renaming by alias replaces the old alias
+	if err := s.RenameEndpoint("dc01-pivot", "dc02-pivot"); err != nil {
+		t.Fatalf("RenameEndpoint: %v", err)
+	}
+	if id := s.ResolveEndpointID("dc01-pivot"); id != "dc01-pivot" {
+		t.Errorf("ResolveEndpointID: old alias still resolves to %s", id)
+	}
+	if alias := s.EndpointAlias("endpoint"); alias != "dc02-pivot" {
+		t.Errorf("EndpointAlias: Got: %s Want: dc02-pivot", alias)
+	}
+
+	if err := s.RenameEndpoint("missing", "other"); err == nil {
+		t.Errorf("RenameEndpoint: expected error for unknown client")
+	}
+}
@@ -0,0 +1,78 @@
+package gserverlib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagEndpoint adds tags to and removes tags from a connected endpoint
+// and returns its resulting tags.
+func (s *GServer) TagEndpoint(clientID string, add []string,
+	remove []string) ([]string, error) {
+
+	if _, ok := s.getClient(clientID); !ok {
+		return nil, fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	// Deferred first so that it runs after the mutex is released
+	defer s.storeEndpoint(clientID)
+
+	s.tagMutex.Lock()
+	defer s.tagMutex.Unlock()
+
+	tags, ok := s.endpointTags[clientID]
+	if !ok {
+		tags = make(map[string]bool)
+		s.endpointTags[clientID] = tags
+	}
+
+	for _, tag := range add {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags[tag] = true
+		}
+	}
+	for _, tag := range remove {
+		delete(tags, strings.TrimSpace(tag))
+	}
+
+	return sortedTags(tags), nil
+}
+
+// EndpointTags returns the sorted tags of an endpoint.
+func (s *GServer) EndpointTags(clientID string) []string {
+	s.tagMutex.Lock()
+	defer s.tagMutex.Unlock()
+	return sortedTags(s.endpointTags[clientID])
+}
+
+// EndpointHasTags returns true if an endpoint has all of the provided
+// tags.
+func (s *GServer) EndpointHasTags(clientID string, tags []string) bool {
+	s.tagMutex.Lock()
+	defer s.tagMutex.Unlock()
+
+	for _, tag := range tags {
+		if !s.endpointTags[clientID][tag] {
+			return false
+		}
+	}
+	return true
+}
+
+// removeEndpointTags forgets the tags of an endpoint.
+func (s *GServer) removeEndpointTags(clientID string) {
+	s.tagMutex.Lock()
+	defer s.tagMutex.Unlock()
+	delete(s.endpointTags, clientID)
+}
+
+// sortedTags returns the tags of a tag set in sorted order.
+func sortedTags(tags map[string]bool) []string {
+	sorted := make([]string, 0, len(tags))
+	for tag := range tags {
+		sorted = append(sorted, tag)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,35 @@
+package gserverlib
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTagEndpoint(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.endpointTags = make(map[string]map[string]bool)
+
+	tags, err := s.TagEndpoint("endpoint", []string{"win", "dmz", "prod"}, nil)
+	if err != nil {
+		t.Fatalf("TagEndpoint: %v", err)
+	}
+	if want := []string{"dmz", "prod", "win"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("TagEndpoint: Got: %v Want: %v", tags, want)
+	}
+
+	tags, _ = s.TagEndpoint("endpoint", nil, []string{"prod"})
+	if want := []string{"dmz", "win"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("TagEndpoint: Got: %v Want: %v", tags, want)
+	}
+
+	if !s.EndpointHasTags("endpoint", []string{"dmz", "win"}) {
+		t.Errorf("EndpointHasTags: Got: false Want: true")
+	}
+	if s.EndpointHasTags("endpoint", []string{"dmz", "prod"}) {
+		t.Errorf("EndpointHasTags: Got: true Want: false")
+	}
+	if !s.EndpointHasTags("endpoint", nil) {
+		t.Errorf("EndpointHasTags: no tags should match every endpoint")
+	}
+}
@@ -0,0 +1,77 @@
+package gserverlib
+
+import (
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// Event types emitted by gServer.
+const (
+	EventEndpointConnected = "endpoint.connected"
+	EventEndpointLost      = "endpoint.lost"
+	EventEndpointReturned  = "endpoint.returned"
+	EventEndpointRemoved   = "endpoint.removed"
+	EventTunnelAdded       = "tunnel.added"
+	EventTunnelDeleted     = "tunnel.deleted"
+	EventTunnelOrphaned    = "tunnel.orphaned"
+	EventTunnelPaused      = "tunnel.paused"
+	EventTunnelResumed     = "tunnel.resumed"
+	EventTunnelExpired     = "tunnel.expired"
+	EventTunnelFailed      = "tunnel.failed"
+	EventConnectionFailed  = "connection.failed"
+	EventDestinationDown   = "destination.down"
+	EventDestinationUp     = "destination.up"
+	EventEndpointBanned    = "endpoint.banned"
+)
+
+// Event describes a change in the state of the endpoints and tunnels
+// of a gServer.
+type Event struct {
+	Type       string    `json:"type"`
+	Time       time.Time `json:"time"`
+	EndpointID string    `json:"endpoint_id,omitempty"`
+	TunnelID   string    `json:"tunnel_id,omitempty"`
+	Message    string    `json:"message,omitempty"`
+}
+
+// EventHandler is called for every event emitted by gServer. Handlers
+// are called synchronously and must not block.
+type EventHandler func(event Event)
+
+// AddEventHandler registers a handler that is called for every event.
+func (s *GServer) AddEventHandler(handler EventHandler) {
+	s.eventMutex.Lock()
+	defer s.eventMutex.Unlock()
+	s.eventHandlers = append(s.eventHandlers, handler)
+}
+
+// emitEvent logs an event and passes it to all registered handlers.
+func (s *GServer) emitEvent(eventType string, endpointID string,
+	tunnelID string, message string) {
+
+	event := Event{
+		Type:       eventType,
+		Time:       time.Now(),
+		EndpointID: endpointID,
+		TunnelID:   tunnelID,
+		Message:    message,
+	}
+
+	logger := common.Log.WithField("event", eventType)
+	if endpointID != "" {
+		logger = logger.WithEndpoint(endpointID)
+	}
+	if tunnelID != "" {
+		logger = logger.WithTunnel(tunnelID)
+	}
+	logger.Infof("%s", message)
+
+	s.eventMutex.Lock()
+	handlers := s.eventHandlers
+	s.eventMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,185 @@
+package gserverlib
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// fileTransferClient returns a connected endpoint that supports file
+// transfers.
+func (s *GServer) fileTransferClient(clientID string) (*ConnectedClient, error) {
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return nil, fmt.Errorf("client does not exist")
+	}
+	if s.isLost(client) {
+		return nil, fmt.Errorf("client lost its control stream")
+	}
+	if !common.HasCapability(client.capabilities, common.CapabilityFileTransfer) {
+		return nil, fmt.Errorf("client does not support file transfers")
+	}
+	return client, nil
+}
+
+// PutFile writes a file of size bytes to path on an endpoint. Read is
+// called for the content of the file until it returns io.EOF. It
+// returns the SHA-256 hash of the file once the endpoint wrote it and
+// agrees on the hash. The transfer is stopped when ctx is cancelled.
+func (s *GServer) PutFile(ctx context.Context, clientID string, path string,
+	size uint64, mode uint32, read func() ([]byte, error)) ([]byte, error) {
+
+	client, err := s.fileTransferClient(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("put failed - %v", err)
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlPutFile
+	controlMessage.FilePath = path
+	controlMessage.FileSize = size
+	controlMessage.FileMode = mode
+	stream, p, err := s.openProbeStream(clientID, client, controlMessage, "file")
+	if err != nil {
+		return nil, fmt.Errorf("put failed - %v", err)
+	}
+	defer s.forgetProbe(p)
+	p.closeOnCancel(ctx)
+
+	watched := s.watchStream(stream, p, client)
+	defer watched.timer.Stop()
+
+	// The endpoint answers once it has the whole file or failed to
+	// write it
+	answer := make(chan *cs.BytesMessage, 1)
+	go func() {
+		message, err := watched.Recv()
+		if err != nil {
+			message = new(cs.BytesMessage)
+			message.Error = err.Error()
+		}
+		answer <- message
+	}()
+
+	hash := sha256.New()
+	var sent uint64
+	for {
+		data, err := read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("put failed - %v", err)
+		}
+		if sent += uint64(len(data)); sent > size {
+			return nil, fmt.Errorf("put failed - got more than %d bytes", size)
+		}
+		hash.Write(data)
+
+		for len(data) > 0 {
+			n := len(data)
+			if n > common.FileChunkSize {
+				n = common.FileChunkSize
+			}
+			message := new(cs.BytesMessage)
+			message.Content = data[:n]
+			if err := watched.Send(message); err != nil {
+				return nil, fmt.Errorf("put failed - %v", err)
+			}
+			data = data[n:]
+		}
+
+		select {
+		case message := <-answer:
+			return nil, fmt.Errorf("put failed - %s", message.Error)
+		default:
+		}
+	}
+	if sent != size {
+		return nil, fmt.Errorf("put failed - got %d of %d bytes", sent, size)
+	}
+
+	message := <-answer
+	if message.Error != "" {
+		return nil, fmt.Errorf("put failed - %s", message.Error)
+	}
+	sum := hash.Sum(nil)
+	if !bytes.Equal(message.FileHash, sum) {
+		return nil, fmt.Errorf("put failed - the endpoint got other content")
+	}
+	return sum, nil
+}
+
+// GetFile reads the file at path on an endpoint. Start is called with
+// the size of the file, then write with each part of its content. It
+// returns the SHA-256 hash of the file once all of it arrived and the
+// endpoint agrees on the hash. The transfer is stopped when ctx is
+// cancelled or a callback returns an error.
+func (s *GServer) GetFile(ctx context.Context, clientID string, path string,
+	start func(size uint64) error, write func(data []byte) error) ([]byte, error) {
+
+	client, err := s.fileTransferClient(clientID)
+	if err != nil {
+		return nil, fmt.Errorf("get failed - %v", err)
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlGetFile
+	controlMessage.FilePath = path
+	stream, p, err := s.openProbeStream(clientID, client, controlMessage, "file")
+	if err != nil {
+		return nil, fmt.Errorf("get failed - %v", err)
+	}
+	defer s.forgetProbe(p)
+	p.closeOnCancel(ctx)
+
+	watched := s.watchStream(stream, p, client)
+	defer watched.timer.Stop()
+
+	recv := func() (*cs.BytesMessage, error) {
+		message, err := watched.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("get failed - %v", err)
+		}
+		if message.Error != "" {
+			return nil, fmt.Errorf("get failed - %s", message.Error)
+		}
+		return message, nil
+	}
+
+	header, err := recv()
+	if err != nil {
+		return nil, err
+	}
+	if err := start(header.FileSize); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	var received uint64
+	for received < header.FileSize {
+		message, err := recv()
+		if err != nil {
+			return nil, err
+		}
+		received += uint64(len(message.Content))
+		hash.Write(message.Content)
+		if err := write(message.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	done, err := recv()
+	if err != nil {
+		return nil, err
+	}
+	sum := hash.Sum(nil)
+	if received != header.FileSize || !bytes.Equal(done.FileHash, sum) {
+		return nil, fmt.Errorf("get failed - the endpoint sent other content")
+	}
+	return sum, nil
+}
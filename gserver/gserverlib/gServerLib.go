@@ -3,8 +3,10 @@ package gserverlib
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kai5263499/gtunnel/common"
@@ -37,8 +39,24 @@ type ConnectedClient struct {
 	remoteAddr       string
 	uniqueID         string
 	connectDate      time.Time
+	protocolVersion  uint32
+	capabilities     []string
 	endpoint         *common.Endpoint
 	endpointInput    chan *cs.EndpointControlMessage
+	disconnecting    bool
+	lost             bool
+	resume           bool
+	orphanTimer      *time.Timer
+	geo              GeoInfo
+	os               string
+	arch             string
+	username         string
+	addresses        []string
+	lastSeen         atomic.Value
+	heartbeat        time.Duration
+	closeControl     context.CancelFunc
+	beacon           time.Duration
+	rateLimiter      *common.RateLimiter
 }
 
 type GServer struct {
@@ -47,7 +65,54 @@ type GServer struct {
 	configStore      *ConfigStore
 	clientServer     *ClientServiceServer
 	adminServer      *AdminServiceServer
+	restServer       *RestServiceServer
 	connectedClients map[string]*ConnectedClient
+	clientMutex       sync.RWMutex
+	aliases           *LoopbackAliases
+	nameServices      []NameService
+	eventHandlers     []EventHandler
+	eventMutex        sync.Mutex
+	orphanGracePeriod time.Duration
+	orphanMutex       sync.Mutex
+	geoIP             *GeoIPResolver
+	heartbeatInterval time.Duration
+	endpointAliases   map[string]string
+	aliasMutex        sync.Mutex
+	endpointTags      map[string]map[string]bool
+	tagMutex          sync.Mutex
+	bans              map[string]*EndpointBan
+	banMutex          sync.Mutex
+	grpcKeepalive     GRPCKeepalive
+	grpcMsgSize       GRPCMsgSize
+	shuttingDown      int32
+	configTunnels     []*ConfigTunnel
+	configMutex       sync.Mutex
+	rateLimiter       *common.RateLimiter
+	endpointRateLimit uint64
+	rateMutex         sync.Mutex
+	controlTimeout    time.Duration
+	controlMutex      sync.Mutex
+	listenPorts       []common.PortRange
+	portMutex         sync.Mutex
+	clientAllowlist   []*net.IPNet
+	allowMutex        sync.Mutex
+	registrationLimit int
+	registrations     map[string]*registrationAttempts
+	registrationMutex sync.Mutex
+	webSocketPort     int
+	longPollPort      int
+	dnsAddress        string
+	dnsDomain         string
+	icmpAddress       string
+	quicPort          int
+	grpcNames         []*common.GRPCNames
+	relays            map[string]map[string]*cs.EndpointControlMessage
+	relayMutex        sync.Mutex
+	balancers         map[string]*common.Balancer
+	balanceMutex      sync.Mutex
+	probes            map[string]*probe
+	probeMutex        sync.Mutex
+	serverKey         *common.ServerKey
 }
 
 // ServerConnectionHandler TODO
@@ -70,7 +135,17 @@ func NewGServer() *GServer {
 
 	newServer.clientServer = NewClientServiceServer(newServer)
 	newServer.adminServer = NewAdminServiceServer(newServer)
+	newServer.restServer = NewRestServiceServer(newServer)
 	newServer.connectedClients = make(map[string]*ConnectedClient)
+	newServer.aliases = NewLoopbackAliases()
+	newServer.orphanGracePeriod = DefaultOrphanGracePeriod
+	newServer.grpcKeepalive.Timeout = DefaultGRPCKeepaliveTimeout
+	newServer.grpcKeepalive.MinPing = DefaultGRPCMinPing
+	newServer.heartbeatInterval = DefaultHeartbeatInterval
+	newServer.controlTimeout = DefaultControlTimeout
+	newServer.endpointAliases = make(map[string]string)
+	newServer.endpointTags = make(map[string]map[string]bool)
+	newServer.bans = make(map[string]*EndpointBan)
 
 	return newServer
 }
@@ -88,10 +163,13 @@ func NewConfiguredClient(clientData map[string]interface{}) *ConfiguredClient {
 // AddConnectedClient will take in a unique ID and a ConnectedClient structure
 // and insert them into the connectedClients map with the unique ID as the key.
 func (s *GServer) AddConnectedClient(uuid string, client *ConnectedClient) bool {
+	s.clientMutex.Lock()
+	defer s.clientMutex.Unlock()
+
 	_, ok := s.connectedClients[uuid]
 
 	if ok {
-		log.Printf("[!] Attempting to add client that already exists")
+		common.Log.Errorf("Attempting to add client that already exists")
 		return false
 	}
 	s.connectedClients[uuid] = client
@@ -99,6 +177,28 @@ func (s *GServer) AddConnectedClient(uuid string, client *ConnectedClient) bool
 	return true
 }
 
+// getClient returns the connected client with the given unique ID.
+func (s *GServer) getClient(uuid string) (*ConnectedClient, bool) {
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+
+	client, ok := s.connectedClients[uuid]
+	return client, ok
+}
+
+// getClients returns a copy of the connected clients map that can be
+// iterated while clients connect and disconnect.
+func (s *GServer) getClients() map[string]*ConnectedClient {
+	s.clientMutex.RLock()
+	defer s.clientMutex.RUnlock()
+
+	clients := make(map[string]*ConnectedClient, len(s.connectedClients))
+	for uuid, client := range s.connectedClients {
+		clients[uuid] = client
+	}
+	return clients
+}
+
 // StreamAuthInterceptor will check for proper authorization for all
 // stream based gRPC calls.
 func (s *GServer) StreamAuthInterceptor(srv interface{},
@@ -106,6 +206,11 @@ func (s *GServer) StreamAuthInterceptor(srv interface{},
 	info *grpc.StreamServerInfo,
 	handler grpc.StreamHandler) error {
 
+	// Renamed calls are authorized once they are dispatched
+	if _, ok := s.renamedMethod(info.FullMethod); ok {
+		return handler(srv, ss)
+	}
+
 	ctx := ss.Context()
 
 	token, uuid, err := GetClientInfoFromCtx(ctx)
@@ -117,15 +222,20 @@ func (s *GServer) StreamAuthInterceptor(srv interface{},
 	client := s.configStore.GetConfiguredClient(token)
 
 	if client == nil {
-		log.Printf("[!] Invalid bearer token\n")
-		return status.Errorf(codes.Unauthenticated, err.Error())
+		common.Log.Errorf("Invalid bearer token")
+		return status.Error(codes.Unauthenticated, "invalid bearer token")
 	}
 
-	_, ok := s.connectedClients[uuid]
+	if s.isBanned(uuid, token) {
+		common.Log.WithEndpoint(uuid).Warnf("Rejected banned endpoint")
+		return status.Errorf(codes.PermissionDenied, "endpoint is banned")
+	}
+
+	_, ok := s.getClient(uuid)
 
 	if !ok {
-		log.Printf("[!] UUID not connected\n")
-		return status.Errorf(codes.Unauthenticated, err.Error())
+		common.Log.WithEndpoint(uuid).Errorf("UUID not connected")
+		return status.Error(codes.Unauthenticated, "endpoint is not connected")
 	}
 
 	ctx = context.WithValue(ctx, contextKey("uuid"), uuid)
@@ -140,6 +250,12 @@ func (s *GServer) UnaryAuthInterceptor(ctx context.Context,
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (interface{}, error) {
 
+	registration := strings.HasSuffix(info.FullMethod, "/GetConfigurationMessage")
+	if registration && !s.allowRegistration(ctx) {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"too many registration attempts")
+	}
+
 	token, uuid, err := GetClientInfoFromCtx(ctx)
 
 	if err != nil {
@@ -149,14 +265,19 @@ func (s *GServer) UnaryAuthInterceptor(ctx context.Context,
 	client := s.configStore.GetConfiguredClient(token)
 
 	if client == nil {
-		log.Printf("[!] Invalid bearer token\n")
-		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+		common.Log.Errorf("Invalid bearer token")
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
 	}
 
-	_, ok := s.connectedClients[uuid]
+	if s.isBanned(uuid, token) {
+		common.Log.WithEndpoint(uuid).Warnf("Rejected banned endpoint")
+		return nil, status.Errorf(codes.PermissionDenied, "endpoint is banned")
+	}
 
-	if ok {
-		log.Printf("[!] gClient with uuid: %s already connected\n", uuid)
+	_, ok := s.getClient(uuid)
+
+	if ok && registration {
+		common.Log.WithEndpoint(uuid).Errorf("gClient already connected")
 	}
 
 	ctx = context.WithValue(ctx, contextKey("uuid"), uuid)
@@ -173,47 +294,190 @@ func (s *GServer) AddTunnel(
 	listenIP net.IP,
 	listenPort uint32,
 	destinationIP net.IP,
-	destinationPort uint32) error {
+	destinationPort uint32,
+	options common.TunnelOptions) error {
+
+	span := common.StartSpan(nil, "tunnel.create")
+	span.SetAttribute("endpoint.id", clientID)
+	span.SetAttribute("tunnel.id", tunnelID)
+	span.SetAttribute("direction", fmt.Sprint(direction))
+
+	err := s.addTunnel(clientID, tunnelID, direction, listenIP, listenPort,
+		destinationIP, destinationPort, options)
+
+	span.SetError(err)
+	span.Finish()
+	return err
+}
+
+// addTunnel implements AddTunnel.
+func (s *GServer) addTunnel(
+	clientID string,
+	tunnelID string,
+	direction uint32,
+	listenIP net.IP,
+	listenPort uint32,
+	destinationIP net.IP,
+	destinationPort uint32,
+	options common.TunnelOptions) error {
 
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n")
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return fmt.Errorf("addtunnel failed - client does not exist")
 	}
 
-	controlMessage := new(cs.EndpointControlMessage)
-	controlMessage.Operation = common.EndpointCtrlAddTunnel
-	controlMessage.TunnelId = tunnelID
-	newTunnel := common.NewTunnel(tunnelID,
-		direction,
-		listenIP,
-		uint32(listenPort),
-		destinationIP,
-		uint32(destinationPort))
+	if s.isLost(client) {
+		return fmt.Errorf("addtunnel failed - client lost its control stream")
+	}
+
+	if s.isShuttingDown() {
+		return errShuttingDown
+	}
+
+	// The side that dials spawns the command, which is gServer itself
+	// for reverse tunnels
+	if options.Command != "" && direction != common.TunnelDirectionForward {
+		return fmt.Errorf("addtunnel failed - only forward tunnels can run a command")
+	}
 
 	if direction == common.TunnelDirectionForward {
+		if options.Command != "" &&
+			!common.HasCapability(client.capabilities, common.CapabilityCommandTunnel) {
+			return fmt.Errorf("addtunnel failed - client does not support command tunnels")
+		}
+		if options.DetectDeception &&
+			!common.HasCapability(client.capabilities, common.CapabilityDeception) {
+			return fmt.Errorf("addtunnel failed - client does not support deception detection")
+		}
+	}
 
-		controlMessage.DestinationIp = common.IpToInt32(destinationIP)
-		controlMessage.DestinationPort = uint32(destinationPort)
-		// The client doesn't need to know what port and IP we are
-		// listening on
-		controlMessage.ListenIp = 0
-		controlMessage.ListenPort = 0
-	} else if direction == common.TunnelDirectionReverse {
-		// In the case of a reverse tunnel, the client
-		// doesn't need to know to where we are forwarding
-		// the connection
-		controlMessage.DestinationIp = 0
-		controlMessage.DestinationPort = 0
-		controlMessage.ListenIp = common.IpToInt32(listenIP)
-		controlMessage.ListenPort = uint32(listenPort)
-	} else {
+	if options.Compress &&
+		!common.HasCapability(client.capabilities, common.CapabilityCompression) {
+		return fmt.Errorf("addtunnel failed - client does not support compression")
+	}
+
+	if err := common.CheckStreamCompression(options.StreamCompression); err != nil {
+		return fmt.Errorf("addtunnel failed - %v", err)
+	}
+	if options.StreamCompression != "" &&
+		!common.HasCapability(client.capabilities, common.CapabilitySnappy) {
+		return fmt.Errorf("addtunnel failed - client does not support stream compression")
+	}
+
+	if options.Encrypt &&
+		!common.HasCapability(client.capabilities, common.CapabilityEncryption) {
+		return fmt.Errorf("addtunnel failed - client does not support encryption")
+	}
+	if options.Encrypt && s.serverKey == nil {
+		return fmt.Errorf("addtunnel failed - no server key is configured")
+	}
+
+	if options.RekeyInterval > 0 || options.RekeyBytes > 0 {
+		if !options.Encrypt {
+			return fmt.Errorf("addtunnel failed - rekeying requires encryption")
+		}
+		if !common.HasCapability(client.capabilities, common.CapabilityRekey) {
+			return fmt.Errorf("addtunnel failed - client does not support rekeying")
+		}
+	}
+
+	if direction != common.TunnelDirectionForward &&
+		direction != common.TunnelDirectionReverse {
 		return fmt.Errorf("invalid tunnel direction")
 	}
 
+	var hops []common.TunnelHop
+	if options.Hops != "" {
+		if direction != common.TunnelDirectionForward || options.Command != "" {
+			return fmt.Errorf("addtunnel failed - only forward tunnels to a destination can have hops")
+		}
+		var err error
+		if hops, err = s.parseHops(options.Hops); err != nil {
+			return fmt.Errorf("addtunnel failed - %v", err)
+		}
+	}
+
+	if options.Failover != "" {
+		if _, err := common.ParseDestinations(options.Failover); err != nil {
+			return fmt.Errorf("addtunnel failed - %v", err)
+		}
+		if options.Command != "" || options.Hops != "" {
+			return fmt.Errorf("addtunnel failed - command and multi-hop tunnels cannot fail over")
+		}
+		if direction == common.TunnelDirectionForward &&
+			!common.HasCapability(client.capabilities, common.CapabilityFailover) {
+			return fmt.Errorf("addtunnel failed - client does not support failover")
+		}
+	}
+
+	if options.HealthCheck != 0 || options.HealthBanner != "" {
+		if direction != common.TunnelDirectionForward {
+			return fmt.Errorf("addtunnel failed - only forward tunnels can be health checked")
+		}
+		if options.HealthCheck <= 0 {
+			return fmt.Errorf("addtunnel failed - a health check needs a positive interval")
+		}
+	}
+
+	if options.Balance != "" {
+		if direction != common.TunnelDirectionForward {
+			return fmt.Errorf("addtunnel failed - only forward tunnels can be balanced")
+		}
+		if err := common.CheckBalanceMode(options.Balance); err != nil {
+			return fmt.Errorf("addtunnel failed - %v", err)
+		}
+	}
+
+	if options.ChunkSize > common.MaxChunkSize {
+		return fmt.Errorf("addtunnel failed - chunk size is larger than %d",
+			common.MaxChunkSize)
+	}
+	if !s.grpcMsgSize.fits(options.ChunkSize) {
+		return fmt.Errorf("addtunnel failed - chunk size does not fit in the gRPC message size limit")
+	}
+
+	// Only forward tunnels listen on the server
+	if direction == common.TunnelDirectionForward {
+		if err := s.checkListenPort(listenPort); err != nil {
+			return fmt.Errorf("addtunnel failed - %v", err)
+		}
+	}
+
+	var schedule *common.TimeWindow
+	if options.Schedule != "" {
+		var err error
+		schedule, err = common.ParseTimeWindow(options.Schedule,
+			options.Timezone)
+		if err != nil {
+			return fmt.Errorf("addtunnel failed - invalid schedule: %v", err)
+		}
+	}
+
+	var balancer *common.Balancer
+	if options.Balance != "" {
+		var err error
+		balancer, err = s.balancer(listenIP, listenPort, options.Balance)
+		if err != nil {
+			return fmt.Errorf("addtunnel failed - %v", err)
+		}
+	}
+
+	newTunnel := common.NewTunnelWithContext(client.endpoint.Context(),
+		tunnelID,
+		direction,
+		listenIP,
+		uint32(listenPort),
+		destinationIP,
+		uint32(destinationPort))
+	newTunnel.SetOptions(options)
+	newTunnel.AddRateLimiter(s.serverRateLimiter())
+	newTunnel.AddRateLimiter(client.rateLimiter)
+	controlMessage := addTunnelMessage(tunnelID, newTunnel)
+
 	if _, ok := client.endpoint.GetTunnel(tunnelID); ok {
-		log.Printf("Tunnel ID already exists for this endpoint. Generating ID instead")
+		common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf("Tunnel ID already exists for this endpoint. Generating ID instead")
 		tunnelID = common.GenerateString(common.TunnelIDSize)
 	}
 
@@ -223,66 +487,290 @@ func (s *GServer) AddTunnel(
 	f.tunnelID = tunnelID
 
 	newTunnel.ConnectionHandler = f
+	newTunnel.SetConnectionFailedHandler(func(failure common.ConnectionFailure) {
+		s.emitEvent(EventConnectionFailed, clientID, tunnelID,
+			fmt.Sprintf("connection %s to %s:%d failed: %s", failure.ConnectionID,
+				destinationIP, destinationPort, failure))
+	})
 
 	if direction == common.TunnelDirectionForward {
 
 		if !newTunnel.AddListener(clientID) {
-			log.Printf("Failed to start listener. Returning")
+			common.Log.WithEndpoint(clientID).Errorf("Failed to start listener. Returning")
+			newTunnel.Stop()
 			return fmt.Errorf("failed to listen on port: %d", listenPort)
 		}
 	}
 
+	// Only tunnels that listen take connections of their group
+	if balancer != nil {
+		balancer.Add(newTunnel)
+	}
+
 	client.endpoint.AddTunnel(tunnelID, newTunnel)
 
-	client.endpointInput <- controlMessage
+	// The hops relay before the endpoint connects to the first one
+	if err := s.startHops(clientID, tunnelID, hops, destinationIP, destinationPort); err != nil {
+		client.endpoint.StopAndDeleteTunnel(tunnelID)
+		return fmt.Errorf("addtunnel failed - %v", err)
+	}
+
+	if err := s.sendControlMessage(clientID, client, controlMessage); err != nil {
+		client.endpoint.StopAndDeleteTunnel(tunnelID)
+		s.stopHops(clientID, tunnelID, hops)
+		return fmt.Errorf("addtunnel failed - %v", err)
+	}
+	go s.awaitTunnel(clientID, tunnelID, client, newTunnel)
+
+	if direction == common.TunnelDirectionForward && options.Hostname != "" {
+		s.publishName(options.Hostname, listenIP)
+	}
+
+	s.emitEvent(EventTunnelAdded, clientID, tunnelID,
+		fmt.Sprintf("tunnel %s:%d -> %s:%d added",
+			listenIP, listenPort, destinationIP, destinationPort))
+
+	if options.TTL > 0 || options.ByteLimit > 0 {
+		go s.enforceTunnelLimits(clientID, tunnelID, newTunnel)
+	}
+
+	if options.HealthCheck > 0 {
+		go s.checkTunnelHealth(clientID, tunnelID, newTunnel)
+	}
+
+	if schedule != nil {
+		go s.scheduleTunnel(clientID, tunnelID, newTunnel, schedule)
+	}
+
+	s.storeTunnel(clientID, tunnelID, newTunnel)
 
 	return nil
 }
 
+// addTunnelMessage returns the control message that tells the gclient
+// to create its side of a tunnel.
+func addTunnelMessage(tunnelID string,
+	tunnel *common.Tunnel) *cs.EndpointControlMessage {
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlAddTunnel
+	controlMessage.TunnelId = tunnelID
+
+	if tunnel.GetDirection() == common.TunnelDirectionForward {
+		options := tunnel.GetOptions()
+		controlMessage.DestinationIp = common.IpToInt32(tunnel.GetDestinationIP())
+		controlMessage.DestinationPort = tunnel.GetDestinationPort()
+		// The endpoint of a multi-hop tunnel dials the first hop
+		if hops, err := common.ParseTunnelHops(options.Hops); err == nil && len(hops) > 0 {
+			controlMessage.DestinationIp = common.IpToInt32(hops[0].IP)
+			controlMessage.DestinationPort = hops[0].Port
+		}
+		controlMessage.Command = options.Command
+		controlMessage.DetectDeception = options.DetectDeception
+		// The client doesn't need to know what port and IP we are
+		// listening on
+		controlMessage.ListenIp = 0
+		controlMessage.ListenPort = 0
+	} else {
+		// In the case of a reverse tunnel, the client
+		// doesn't need to know to where we are forwarding
+		// the connection
+		controlMessage.DestinationIp = 0
+		controlMessage.DestinationPort = 0
+		controlMessage.ListenIp = common.IpToInt32(tunnel.GetListenIP())
+		controlMessage.ListenPort = tunnel.GetListenPort()
+	}
+	// Both sides read from TCP connections in chunks of this size
+	options := tunnel.GetOptions()
+	controlMessage.Mtu = options.ChunkSize
+	controlMessage.Nagle = options.Nagle
+	controlMessage.TcpKeepalive = int64(options.TCPKeepalive / time.Second)
+	controlMessage.ReadBuffer = uint32(options.ReadBuffer)
+	controlMessage.WriteBuffer = uint32(options.WriteBuffer)
+	controlMessage.RateLimit = options.RateLimit
+	controlMessage.IdleTimeout = int64(options.IdleTimeout / time.Second)
+	controlMessage.MaxConnections = options.MaxConnections
+	controlMessage.Compress = options.Compress
+	controlMessage.StreamCompression = options.StreamCompression
+	controlMessage.Encrypt = options.Encrypt
+	controlMessage.PadSize = options.PadSize
+	controlMessage.SendJitter = int64(options.SendJitter / time.Millisecond)
+	controlMessage.Failover = options.Failover
+	controlMessage.PublicKey = tunnel.PublicKey()
+	return controlMessage
+}
+
 // DeleteTunnel will kill all TCP connections under the tunnel
 // and remove them from the list of managed tunnels.
 func (s *GServer) DeleteTunnel(
 	clientID string,
 	tunnelID string) error {
 
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n", clientID)
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return fmt.Errorf("deletetunnel failed - client does not exist")
 	}
 
+	var hops []common.TunnelHop
+	if tunnel, ok := client.endpoint.GetTunnel(tunnelID); ok {
+		s.unpublishTunnelName(tunnel)
+		hops, _ = s.parseHops(tunnel.GetOptions().Hops)
+	}
+
 	if !client.endpoint.StopAndDeleteTunnel(tunnelID) {
 		return fmt.Errorf("failed to delete tunnel")
 	}
+	s.forgetTunnel(clientID, tunnelID)
+	s.stopHops(clientID, tunnelID, hops)
+
+	// A lost endpoint has no control stream to receive the message
+	if !s.isLost(client) {
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlDeleteTunnel
+		controlMessage.TunnelId = tunnelID
+
+		err := s.sendControlMessage(clientID, client, controlMessage)
+		if err != nil {
+			s.emitEvent(EventTunnelDeleted, clientID, tunnelID,
+				"tunnel deleted on the server only")
+			return fmt.Errorf("deletetunnel failed - %v. The endpoint may still "+
+				"have its side of the tunnel", err)
+		}
+	}
 
-	controlMessage := new(cs.EndpointControlMessage)
-	controlMessage.Operation = common.EndpointCtrlDeleteTunnel
-	controlMessage.TunnelId = tunnelID
-
-	client.endpointInput <- controlMessage
+	s.emitEvent(EventTunnelDeleted, clientID, tunnelID, "tunnel deleted")
 
 	return nil
 }
 
+// AddNameService registers a name service that publishes the hostnames
+// of forward tunnels.
+func (s *GServer) AddNameService(nameService NameService) {
+	s.nameServices = append(s.nameServices, nameService)
+}
+
+// publishName publishes hostname with all registered name services.
+func (s *GServer) publishName(hostname string, ip net.IP) {
+	for _, nameService := range s.nameServices {
+		if err := nameService.Publish(hostname, ip); err != nil {
+			common.Log.Errorf("Failed to publish %s: %v", hostname, err)
+		}
+	}
+}
+
+// unpublishTunnelName removes the hostname of a tunnel from all
+// registered name services.
+func (s *GServer) unpublishTunnelName(tunnel *common.Tunnel) {
+	hostname := tunnel.GetOptions().Hostname
+	if hostname == "" {
+		return
+	}
+
+	for _, nameService := range s.nameServices {
+		if err := nameService.Unpublish(hostname); err != nil {
+			common.Log.Errorf("Failed to unpublish %s: %v", hostname, err)
+		}
+	}
+}
+
+// AllocateLoopbackAlias returns the loopback address that forward
+// tunnels to the provided destination should listen on.
+func (s *GServer) AllocateLoopbackAlias(destinationIP net.IP) (net.IP, error) {
+	return s.aliases.Allocate(destinationIP)
+}
+
+// ConfigureEndpoint will set the byte stream MTU and the keepalive
+// interval of an endpoint and message the gclient to do the same
+// on its side.
+func (s *GServer) ConfigureEndpoint(
+	clientID string,
+	mtu uint32,
+	keepalive time.Duration) error {
+
+	client, ok := s.getClient(clientID)
+
+	if !ok {
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
+		return fmt.Errorf("configureendpoint failed - client does not exist")
+	}
+	if mtu > common.MaxChunkSize {
+		return fmt.Errorf("configureendpoint failed - mtu is larger than %d",
+			common.MaxChunkSize)
+	}
+	if !s.grpcMsgSize.fits(mtu) {
+		return fmt.Errorf("configureendpoint failed - mtu does not fit in the gRPC message size limit")
+	}
+
+	client.endpoint.SetMTU(mtu)
+	client.endpoint.SetKeepalive(keepalive)
+
+	if !common.HasCapability(client.capabilities, common.CapabilityKeepalive) {
+		common.Log.WithEndpoint(clientID).Warnf(
+			"Client does not support configuration, only configuring server side")
+		return nil
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlConfigure
+	controlMessage.Mtu = client.endpoint.GetMTU()
+	controlMessage.KeepaliveInterval = uint32(keepalive / time.Second)
+
+	return s.sendControlMessage(clientID, client, controlMessage)
+}
+
+// disconnectTimeout is how long DisconnectEndpoint waits for the
+// control stream to take the disconnect message.
+const disconnectTimeout = 5 * time.Second
+
 // DisconnectEndpoint will send a control message to the
-// current endpoint to disconnect and end execution.
+// current endpoint to disconnect and end execution. The control
+// stream is closed and the tunnels are torn down even if the
+// endpoint does not cooperate.
 func (s *GServer) DisconnectEndpoint(
 	clientID string) error {
 
-	log.Printf("Disconnecting %s", clientID)
+	common.Log.WithEndpoint(clientID).Infof("Disconnecting")
 
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n", clientID)
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return fmt.Errorf("disconnectendpoint failed - client does not exist")
 	}
 
+	// The endpoint is going away on purpose, so its tunnels are torn
+	// down as soon as the control stream closes.
+	s.forgetEndpoint(clientID)
+
+	s.orphanMutex.Lock()
+	client.disconnecting = true
+	if client.lost {
+		// Nobody is reading the control stream of a lost endpoint
+		client.orphanTimer.Stop()
+		s.removeEndpoint(clientID, client)
+		s.orphanMutex.Unlock()
+		return nil
+	}
+	closeControl := client.closeControl
+	s.orphanMutex.Unlock()
+
 	controlMessage := new(cs.EndpointControlMessage)
 	controlMessage.Operation = common.EndpointCtrlDisconnect
 
-	client.endpointInput <- controlMessage
+	select {
+	case client.endpointInput <- controlMessage:
+	case <-time.After(disconnectTimeout):
+		common.Log.WithEndpoint(clientID).Warnf(
+			"Endpoint did not take the disconnect message, closing its control stream")
+	}
+
+	// Close the control stream even if the endpoint does not cooperate,
+	// which removes the endpoint and tears down its tunnels.
+	if closeControl != nil {
+		closeControl()
+	}
 	return nil
 }
 
@@ -293,7 +781,7 @@ func (s *GServer) RegisterClient(req *ConfiguredClient) error {
 	err := s.configStore.AddConfiguredClient(req)
 
 	if err != nil {
-		log.Printf("[!] Failed to generate client: %s", err)
+		common.Log.Errorf("Failed to generate client: %s", err)
 		s.configStore.DeleteConfiguredClient(req.Token)
 		return err
 	}
@@ -305,27 +793,43 @@ func (s *GServer) GetClientServer() *ClientServiceServer {
 	return s.clientServer
 }
 
+// GetAdminServer gets the grpc admin server
+func (s *GServer) GetAdminServer() *AdminServiceServer {
+	return s.adminServer
+}
+
+// GetLoopbackAliases returns the mapping of tunnel destinations to
+// their loopback aliases.
+func (s *GServer) GetLoopbackAliases() map[string]net.IP {
+	return s.aliases.List()
+}
+
 // GetEndpoint will retreive an endpoint struct with the provided endpoint ID.
 func (s *GServer) GetEndpoint(clientID string) (*common.Endpoint, bool) {
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n", clientID)
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return nil, ok
 	}
 
 	return client.endpoint, ok
 }
 
-// Start will start the client and admin gprc servers.
+// Start will start the client and admin gprc servers. If restPort
+// is non-zero, the admin rest api is started as well.
 func (s *GServer) Start(
 	clientPort int,
 	adminPort int,
+	restPort int,
 	tls bool,
 	certFile string,
 	keyFile string) {
 
 	go s.clientServer.Start(clientPort, tls, certFile, keyFile)
+	if restPort != 0 {
+		go s.restServer.Start(restPort)
+	}
 	s.adminServer.Start(adminPort)
 }
 
@@ -334,40 +838,36 @@ func (s *GServer) StartProxy(
 	clientID string,
 	socksPort uint32) error {
 
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n", clientID)
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return fmt.Errorf("startproxy failed - client does not exist")
 	}
 
-	log.Printf("Starting socks proxy on : %d", socksPort)
+	common.Log.WithEndpoint(clientID).Infof("Starting socks proxy on : %d", socksPort)
 	controlMessage := new(cs.EndpointControlMessage)
 	controlMessage.Operation = common.EndpointCtrlSocksProxy
 	controlMessage.ListenPort = uint32(socksPort)
 
-	client.endpointInput <- controlMessage
-
-	return nil
+	return s.sendControlMessage(clientID, client, controlMessage)
 }
 
 // StopProxy stops a proxy on the provided endpointID
 func (s *GServer) StopProxy(
 	clientID string) error {
 
-	client, ok := s.connectedClients[clientID]
+	client, ok := s.getClient(clientID)
 
 	if !ok {
-		log.Printf("[!] client with uuuid: %s does not exist\n", clientID)
+		common.Log.WithEndpoint(clientID).Errorf("client does not exist")
 		return fmt.Errorf("stopproxy failed - client does not exist")
 	}
 
 	controlMessage := new(cs.EndpointControlMessage)
 	controlMessage.Operation = common.EndpointCtrlSocksKill
 
-	client.endpointInput <- controlMessage
-
-	return nil
+	return s.sendControlMessage(clientID, client, controlMessage)
 }
 
 // Acknowledge is called  when the remote client acknowledges that a tcp connection can
@@ -377,7 +877,9 @@ func (s *ServerConnectionHandler) Acknowledge(tunnel *common.Tunnel,
 
 	conn := tunnel.GetConnection(ctrlMessage.ConnectionId)
 
-	<-conn.Connected
+	if !conn.WaitConnected(common.ConnectAckTimeout) {
+		return nil
+	}
 	return conn.GetStream()
 }
 
@@ -394,16 +896,19 @@ func (s *ServerConnectionHandler) CloseStream(tunnel *common.Tunnel, connID stri
 func (s *ServerConnectionHandler) GetByteStream(tunnel *common.Tunnel,
 	ctrlMessage *cs.TunnelControlMessage) common.ByteStream {
 
-	stream := tunnel.GetControlStream()
 	conn := tunnel.GetConnection(ctrlMessage.ConnectionId)
 
 	message := new(cs.TunnelControlMessage)
 	message.Operation = common.TunnelCtrlAck
 	message.TunnelId = s.tunnelID
 	message.ConnectionId = ctrlMessage.ConnectionId
+	message.StreamToken = conn.GetStreamToken()
+	message.DestinationAddress = ctrlMessage.DestinationAddress
 	// Since gRPC is always client to server, we need
 	// to get the client to make the byte stream connection.
-	stream.Send(message)
-	<-conn.Connected
+	tunnel.SendCtrlMessage(message)
+	if !conn.WaitConnected(common.ConnectAckTimeout) {
+		return nil
+	}
 	return conn.GetStream()
 }
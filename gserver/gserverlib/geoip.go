@@ -0,0 +1,365 @@
+package gserverlib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// mmdbMetadataMarker precedes the metadata at the end of a MaxMind
+// database file.
+var mmdbMetadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// mmdbDataSeparator is the size of the zeroed gap between the search
+// tree and the data section.
+const mmdbDataSeparator = 16
+
+// GeoInfo describes where an IP address is located.
+type GeoInfo struct {
+	Country      string
+	ASN          uint32
+	Organization string
+}
+
+// String returns a short description such as "US AS15169 Google LLC".
+func (g GeoInfo) String() string {
+	var out string
+	if g.Country != "" {
+		out = g.Country
+	}
+	if g.ASN != 0 {
+		if out != "" {
+			out += " "
+		}
+		out += fmt.Sprintf("AS%d", g.ASN)
+	}
+	if g.Organization != "" {
+		if out != "" {
+			out += " "
+		}
+		out += g.Organization
+	}
+	return out
+}
+
+// GeoIPDatabase is a MaxMind database, such as GeoLite2-Country or
+// GeoLite2-ASN, loaded into memory.
+type GeoIPDatabase struct {
+	tree       []byte
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+// OpenGeoIPDatabase is a constructor for GeoIPDatabase that reads a
+// MaxMind database file.
+func OpenGeoIPDatabase(path string) (*GeoIPDatabase, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGeoIPDatabase(buf)
+}
+
+// NewGeoIPDatabase is a constructor for GeoIPDatabase that parses the
+// contents of a MaxMind database file.
+func NewGeoIPDatabase(buf []byte) (*GeoIPDatabase, error) {
+	markerIndex := bytes.LastIndex(buf, mmdbMetadataMarker)
+	if markerIndex < 0 {
+		return nil, errors.New("not a maxmind database")
+	}
+
+	metadataStart := markerIndex + len(mmdbMetadataMarker)
+	value, _, err := mmdbDecode(buf[metadataStart:], 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid metadata")
+	}
+
+	db := new(GeoIPDatabase)
+	db.nodeCount = mmdbUint(metadata["node_count"])
+	db.recordSize = mmdbUint(metadata["record_size"])
+	db.ipVersion = mmdbUint(metadata["ip_version"])
+
+	if db.recordSize != 24 && db.recordSize != 28 && db.recordSize != 32 {
+		return nil, fmt.Errorf("unsupported record size %d", db.recordSize)
+	}
+
+	treeSize := db.nodeCount * db.recordSize / 4
+	if treeSize+mmdbDataSeparator > uint(markerIndex) {
+		return nil, errors.New("search tree exceeds file")
+	}
+	db.tree = buf[:treeSize]
+	db.data = buf[treeSize+mmdbDataSeparator : markerIndex]
+
+	// IPv4 addresses are stored as ::a.b.c.d in IPv6 databases
+	if db.ipVersion == 6 {
+		for i := 0; i < 96 && db.ipv4Start < db.nodeCount; i++ {
+			db.ipv4Start = db.readRecord(db.ipv4Start, 0)
+		}
+	}
+
+	return db, nil
+}
+
+// Lookup returns the record of an IP address, or nil if the database
+// has no record for it.
+func (db *GeoIPDatabase) Lookup(ip net.IP) (map[string]interface{}, error) {
+	node := uint(0)
+	bits := ip.To4()
+	if bits != nil {
+		node = db.ipv4Start
+	} else if db.ipVersion == 6 {
+		bits = ip.To16()
+	}
+	if bits == nil {
+		return nil, fmt.Errorf("cannot look up %s", ip)
+	}
+
+	for i := 0; i < len(bits)*8 && node < db.nodeCount; i++ {
+		bit := uint(bits[i/8]>>(7-uint(i%8))) & 1
+		node = db.readRecord(node, bit)
+	}
+
+	if node == db.nodeCount {
+		return nil, nil
+	}
+	if node < db.nodeCount {
+		return nil, errors.New("invalid search tree")
+	}
+
+	offset := node - db.nodeCount - mmdbDataSeparator
+	value, _, err := mmdbDecode(db.data, offset)
+	if err != nil {
+		return nil, err
+	}
+	record, _ := value.(map[string]interface{})
+	return record, nil
+}
+
+// readRecord returns the left (bit 0) or right (bit 1) record of a
+// node of the search tree.
+func (db *GeoIPDatabase) readRecord(node uint, bit uint) uint {
+	switch db.recordSize {
+	case 24:
+		b := db.tree[node*6+bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		b := db.tree[node*7:]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(db.tree[node*8+bit*4:]))
+	}
+}
+
+// mmdbDecode decodes the value at offset of a data section and returns
+// it along with the offset of the following value.
+func mmdbDecode(data []byte, offset uint) (interface{}, uint, error) {
+	if offset >= uint(len(data)) {
+		return nil, 0, errors.New("offset out of range")
+	}
+
+	ctrl := data[offset]
+	offset++
+	dataType := uint(ctrl >> 5)
+
+	// Pointers refer to a value elsewhere in the data section
+	if dataType == 1 {
+		size := uint(ctrl>>3) & 0x3
+		if offset+size+1 > uint(len(data)) {
+			return nil, 0, errors.New("pointer out of range")
+		}
+		pointer := uint(ctrl & 0x7)
+		switch size {
+		case 0:
+			pointer = pointer<<8 | uint(data[offset])
+		case 1:
+			pointer = (pointer<<16 | uint(data[offset])<<8 | uint(data[offset+1])) + 2048
+		case 2:
+			pointer = (pointer<<24 | uint(data[offset])<<16 |
+				uint(data[offset+1])<<8 | uint(data[offset+2])) + 526336
+		case 3:
+			pointer = uint(binary.BigEndian.Uint32(data[offset:]))
+		}
+		value, _, err := mmdbDecode(data, pointer)
+		return value, offset + size + 1, err
+	}
+
+	if dataType == 0 {
+		if offset >= uint(len(data)) {
+			return nil, 0, errors.New("extended type out of range")
+		}
+		dataType = 7 + uint(data[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		extra := size - 28
+		if offset+extra > uint(len(data)) {
+			return nil, 0, errors.New("size out of range")
+		}
+		n := uint(0)
+		for i := uint(0); i < extra; i++ {
+			n = n<<8 | uint(data[offset+i])
+		}
+		offset += extra
+		switch extra {
+		case 1:
+			size = 29 + n
+		case 2:
+			size = 285 + n
+		default:
+			size = 65821 + n
+		}
+	}
+
+	switch dataType {
+	case 7:
+		m := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			key, next, err := mmdbDecode(data, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			value, next, err := mmdbDecode(data, next)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[fmt.Sprint(key)] = value
+			offset = next
+		}
+		return m, offset, nil
+	case 11:
+		a := make([]interface{}, 0, size)
+		for i := uint(0); i < size; i++ {
+			value, next, err := mmdbDecode(data, offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			a = append(a, value)
+			offset = next
+		}
+		return a, offset, nil
+	case 14:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(data)) {
+		return nil, 0, errors.New("value out of range")
+	}
+	b := data[offset : offset+size]
+	offset += size
+
+	switch dataType {
+	case 2:
+		return string(b), offset, nil
+	case 3:
+		if size != 8 {
+			return nil, 0, errors.New("invalid double")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), offset, nil
+	case 4:
+		return b, offset, nil
+	case 5, 6, 9, 10:
+		n := uint64(0)
+		for _, c := range b {
+			n = n<<8 | uint64(c)
+		}
+		return n, offset, nil
+	case 8:
+		n := int32(0)
+		for _, c := range b {
+			n = n<<8 | int32(c)
+		}
+		return n, offset, nil
+	case 15:
+		if size != 4 {
+			return nil, 0, errors.New("invalid float")
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), offset, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported data type %d", dataType)
+}
+
+// mmdbUint returns a decoded unsigned integer or 0.
+func mmdbUint(value interface{}) uint {
+	n, _ := value.(uint64)
+	return uint(n)
+}
+
+// GeoIPResolver looks up IP addresses in one or more MaxMind
+// databases and merges the results.
+type GeoIPResolver struct {
+	databases []*GeoIPDatabase
+}
+
+// NewGeoIPResolver is a constructor for GeoIPResolver that opens the
+// provided MaxMind database files.
+func NewGeoIPResolver(paths []string) (*GeoIPResolver, error) {
+	r := new(GeoIPResolver)
+	for _, path := range paths {
+		db, err := OpenGeoIPDatabase(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		r.databases = append(r.databases, db)
+	}
+	return r, nil
+}
+
+// Resolve returns the country and autonomous system of an IP address
+// as far as they are known.
+func (r *GeoIPResolver) Resolve(ip net.IP) GeoInfo {
+	var info GeoInfo
+	for _, db := range r.databases {
+		record, err := db.Lookup(ip)
+		if err != nil || record == nil {
+			continue
+		}
+
+		for _, key := range []string{"country", "registered_country"} {
+			country, _ := record[key].(map[string]interface{})
+			if code, ok := country["iso_code"].(string); ok && info.Country == "" {
+				info.Country = code
+			}
+		}
+		if asn := mmdbUint(record["autonomous_system_number"]); asn != 0 {
+			info.ASN = uint32(asn)
+		}
+		if org, ok := record["autonomous_system_organization"].(string); ok {
+			info.Organization = org
+		}
+	}
+	return info
+}
+
+// SetGeoIPResolver sets the resolver used to look up the location of
+// connecting endpoints.
+func (s *GServer) SetGeoIPResolver(resolver *GeoIPResolver) {
+	s.geoIP = resolver
+}
+
+// resolveGeo returns the location of a remote address if a GeoIP
+// resolver is set.
+func (s *GServer) resolveGeo(addr net.Addr) GeoInfo {
+	if s.geoIP == nil {
+		return GeoInfo{}
+	}
+	ip, _ := common.AddrToIPPort(addr)
+	return s.geoIP.Resolve(ip)
+}
@@ -0,0 +1,75 @@
+package gserverlib
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+// mmdbString encodes a utf8 string of up to 284 bytes.
+func mmdbString(s string) []byte {
+	if len(s) < 29 {
+		return append([]byte{2<<5 | byte(len(s))}, s...)
+	}
+	return append([]byte{2<<5 | 29, byte(len(s) - 29)}, s...)
+}
+
+// mmdbUint16 encodes a uint16.
+func mmdbUint16(n uint16) []byte {
+	return []byte{5<<5 | 2, byte(n >> 8), byte(n)}
+}
+
+// mmdbUint32 encodes a uint32.
+func mmdbUint32(n uint32) []byte {
+	return []byte{6<<5 | 4, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}
+}
+
+// mmdbMap encodes a map from alternating keys and encoded values.
+func mmdbMap(pairs ...[]byte) []byte {
+	out := []byte{7<<5 | byte(len(pairs)/2)}
+	for _, p := range pairs {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func TestGeoIPResolver(t *testing.T) {
+	// A single node IPv4 tree: 0.0.0.0/1 points at the first record
+	// of the data section, 128.0.0.0/1 is not found.
+	const nodeCount = 1
+	tree := []byte{0, 0, nodeCount + 16, 0, 0, nodeCount}
+
+	data := mmdbMap(
+		mmdbString("country"), mmdbMap(mmdbString("iso_code"), mmdbString("NL")),
+		mmdbString("autonomous_system_number"), mmdbUint32(1136),
+		mmdbString("autonomous_system_organization"), mmdbString("KPN B.V."))
+
+	metadata := mmdbMap(
+		mmdbString("node_count"), mmdbUint32(nodeCount),
+		mmdbString("record_size"), mmdbUint16(24),
+		mmdbString("ip_version"), mmdbUint16(4))
+
+	var buf bytes.Buffer
+	buf.Write(tree)
+	buf.Write(make([]byte, mmdbDataSeparator))
+	buf.Write(data)
+	buf.Write(mmdbMetadataMarker)
+	buf.Write(metadata)
+
+	db, err := NewGeoIPDatabase(buf.Bytes())
+	if err != nil {
+		t.Fatalf("NewGeoIPDatabase: %v", err)
+	}
+
+	resolver := &GeoIPResolver{databases: []*GeoIPDatabase{db}}
+
+	info := resolver.Resolve(net.ParseIP("10.1.2.3"))
+	if info.String() != "NL AS1136 KPN B.V." {
+		t.Fatalf("unexpected info %+v", info)
+	}
+
+	info = resolver.Resolve(net.ParseIP("192.168.1.1"))
+	if info.String() != "" {
+		t.Fatalf("expected no info, got %+v", info)
+	}
+}
@@ -0,0 +1,57 @@
+package gserverlib
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// DefaultGRPCKeepaliveTimeout is how long the server waits for the
+	// answer to a keepalive ping before it closes the connection.
+	DefaultGRPCKeepaliveTimeout = 20 * time.Second
+
+	// DefaultGRPCMinPing is the shortest interval at which clients may
+	// send keepalive pings without being disconnected.
+	DefaultGRPCMinPing = 10 * time.Second
+)
+
+// GRPCKeepalive configures the HTTP/2 keepalive pings of the client
+// gRPC server, which keep NAT and firewall state of idle connections
+// alive.
+type GRPCKeepalive struct {
+	// Time is the idle time after which the server pings a client.
+	// The gRPC default of two hours is used if it is 0.
+	Time time.Duration
+
+	// Timeout is how long the server waits for a ping to be answered.
+	Timeout time.Duration
+
+	// MinPing is the shortest interval at which clients may ping.
+	MinPing time.Duration
+
+	// PermitWithoutStream allows clients to ping while they have no
+	// active streams.
+	PermitWithoutStream bool
+}
+
+// SetGRPCKeepalive sets the keepalive configuration of the client gRPC
+// server. It has to be called before the server is started.
+func (s *GServer) SetGRPCKeepalive(k GRPCKeepalive) {
+	s.grpcKeepalive = k
+}
+
+// serverOptions returns the gRPC server options for the configuration.
+func (k GRPCKeepalive) serverOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    k.Time,
+			Timeout: k.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             k.MinPing,
+			PermitWithoutStream: k.PermitWithoutStream,
+		}),
+	}
+}
@@ -0,0 +1,48 @@
+package gserverlib
+
+import (
+	"google.golang.org/grpc"
+)
+
+// grpcMessageOverhead is the room that the fields of a byte stream
+// message take up next to its content.
+const grpcMessageOverhead = 1024
+
+// GRPCMsgSize configures the size limits of the messages of the client
+// gRPC server. The gRPC defaults are 4MB for received messages and no
+// limit for sent ones.
+type GRPCMsgSize struct {
+	// MaxRecv is the largest message the server receives. The gRPC
+	// default is used if it is 0.
+	MaxRecv int
+
+	// MaxSend is the largest message the server sends. The gRPC
+	// default is used if it is 0.
+	MaxSend int
+}
+
+// SetGRPCMsgSize sets the message size limits of the client gRPC
+// server. It has to be called before the server is started.
+func (s *GServer) SetGRPCMsgSize(m GRPCMsgSize) {
+	s.grpcMsgSize = m
+}
+
+// serverOptions returns the gRPC server options for the limits.
+func (m GRPCMsgSize) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if m.MaxRecv > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(m.MaxRecv))
+	}
+	if m.MaxSend > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(m.MaxSend))
+	}
+	return opts
+}
+
+// fits returns true if byte stream messages with chunkSize bytes of
+// content stay within the limits.
+func (m GRPCMsgSize) fits(chunkSize uint32) bool {
+	size := int(chunkSize) + grpcMessageOverhead
+	return (m.MaxRecv == 0 || size <= m.MaxRecv) &&
+		(m.MaxSend == 0 || size <= m.MaxSend)
+}
@@ -0,0 +1,37 @@
+package gserverlib
+
+import (
+	"testing"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestGRPCMsgSize(t *testing.T) {
+	unlimited := GRPCMsgSize{}
+	if !unlimited.fits(common.MaxChunkSize) || len(unlimited.serverOptions()) != 0 {
+		t.Errorf("the gRPC defaults are not kept without limits")
+	}
+
+	limited := GRPCMsgSize{MaxRecv: 64 * 1024}
+	if !limited.fits(32 * 1024) {
+		t.Errorf("fits: a chunk well within the limit does not fit")
+	}
+	if limited.fits(64 * 1024) {
+		t.Errorf("fits: a chunk as large as the limit fits")
+	}
+
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	s.SetGRPCMsgSize(limited)
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	s.connectedClients["endpoint"] = client
+	if err := s.ConfigureEndpoint("endpoint", 128*1024, 0); err == nil {
+		t.Errorf("ConfigureEndpoint: accepted an mtu beyond the limit")
+	}
+
+	s.SetGRPCMsgSize(unlimited)
+	if err := s.ConfigureEndpoint("endpoint", common.MaxChunkSize+1, 0); err == nil {
+		t.Errorf("ConfigureEndpoint: accepted an mtu beyond the largest chunk size")
+	}
+}
@@ -0,0 +1,159 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetGRPCNames makes the client service answer gClients that call it
+// by any of names, besides its own. It has to be called before Start.
+func (s *GServer) SetGRPCNames(names []*common.GRPCNames) {
+	s.grpcNames = names
+}
+
+// renamedMethod returns the method of the client service that a call
+// to path renames, or false if path is not a renamed call.
+func (s *GServer) renamedMethod(path string) (string, bool) {
+	for _, names := range s.grpcNames {
+		if method, ok := names.Original(path); ok {
+			return method, true
+		}
+	}
+	return "", false
+}
+
+// serveRenamed handles the calls that gRPC finds no service for. It
+// dispatches the calls of gClients by other names to the methods of
+// the client service, authorizing them as the methods would be.
+func (s *ClientServiceServer) serveRenamed(srv interface{}, stream grpc.ServerStream) error {
+	path, _ := grpc.MethodFromServerStream(stream)
+	method, ok := s.gServer.renamedMethod(path)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", path)
+	}
+	fullMethod := fmt.Sprintf("/%s/%s", common.ClientServiceName, method)
+
+	switch method {
+	case "GetConfigurationMessage":
+		return s.serveRenamedUnary(stream, fullMethod, new(cs.GetConfigurationMessageRequest),
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetConfigurationMessage(ctx, req.(*cs.GetConfigurationMessageRequest))
+			})
+	case "Heartbeat":
+		return s.serveRenamedUnary(stream, fullMethod, new(cs.HeartbeatRequest),
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.Heartbeat(ctx, req.(*cs.HeartbeatRequest))
+			})
+	case "PollControlMessages":
+		return s.serveRenamedUnary(stream, fullMethod, new(cs.PollControlMessagesRequest),
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.PollControlMessages(ctx, req.(*cs.PollControlMessagesRequest))
+			})
+	case "CreateEndpointControlStream":
+		return s.serveRenamedStream(stream, fullMethod, func(stream grpc.ServerStream) error {
+			req := new(cs.EndpointControlMessage)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			return s.CreateEndpointControlStream(req, &renamedEndpointControlStream{stream})
+		})
+	case "CreateTunnelControlStream":
+		return s.serveRenamedStream(stream, fullMethod, func(stream grpc.ServerStream) error {
+			return s.CreateTunnelControlStream(&renamedTunnelControlStream{stream})
+		})
+	case "CreateConnectionStream":
+		return s.serveRenamedStream(stream, fullMethod, func(stream grpc.ServerStream) error {
+			return s.CreateConnectionStream(&renamedConnectionStream{stream})
+		})
+	}
+	return status.Errorf(codes.Unimplemented, "method %s not implemented", method)
+}
+
+// serveRenamedUnary reads req from stream and answers it with call.
+func (s *ClientServiceServer) serveRenamedUnary(stream grpc.ServerStream,
+	fullMethod string,
+	req interface{},
+	call grpc.UnaryHandler) error {
+
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	info := &grpc.UnaryServerInfo{Server: s, FullMethod: fullMethod}
+	resp, err := s.gServer.UnaryAuthInterceptor(stream.Context(), req, info, call)
+	if err != nil {
+		return err
+	}
+	return stream.SendMsg(resp)
+}
+
+// serveRenamedStream serves stream with call.
+func (s *ClientServiceServer) serveRenamedStream(stream grpc.ServerStream,
+	fullMethod string,
+	call func(stream grpc.ServerStream) error) error {
+
+	info := &grpc.StreamServerInfo{
+		FullMethod:     fullMethod,
+		IsClientStream: true,
+		IsServerStream: true,
+	}
+	return s.gServer.StreamAuthInterceptor(s, stream, info,
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return call(stream)
+		})
+}
+
+// renamedEndpointControlStream is a renamed CreateEndpointControlStream
+// call.
+type renamedEndpointControlStream struct {
+	grpc.ServerStream
+}
+
+// Send sends m to the gClient.
+func (x *renamedEndpointControlStream) Send(m *cs.EndpointControlMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// renamedTunnelControlStream is a renamed CreateTunnelControlStream
+// call.
+type renamedTunnelControlStream struct {
+	grpc.ServerStream
+}
+
+// Send sends m to the gClient.
+func (x *renamedTunnelControlStream) Send(m *cs.TunnelControlMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Recv receives a message from the gClient.
+func (x *renamedTunnelControlStream) Recv() (*cs.TunnelControlMessage, error) {
+	m := new(cs.TunnelControlMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// renamedConnectionStream is a renamed CreateConnectionStream call.
+type renamedConnectionStream struct {
+	grpc.ServerStream
+}
+
+// Send sends m to the gClient.
+func (x *renamedConnectionStream) Send(m *cs.BytesMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Recv receives a message from the gClient.
+func (x *renamedConnectionStream) Recv() (*cs.BytesMessage, error) {
+	m := new(cs.BytesMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
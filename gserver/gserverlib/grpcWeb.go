@@ -0,0 +1,218 @@
+package gserverlib
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc"
+)
+
+// Content types of grpc-web requests. The text variant carries its
+// frames base64 encoded.
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+)
+
+// grpcWebTrailerFlag marks the frame of a grpc-web response that
+// carries the trailers.
+const grpcWebTrailerFlag = 0x80
+
+// trailerPrefix marks headers that the gRPC server sets as trailers
+// after the response headers were written.
+const trailerPrefix = "Trailer:"
+
+// SetGRPCWeb serves the admin api to browsers with grpc-web on port as
+// well, from pages of the origins, such as https://dashboard:8443, or
+// any origin if one is "*". Client and bidirectional streaming calls
+// are not supported by grpc-web. Disabled if port is 0. It has to be
+// called before Start.
+func (s *AdminServiceServer) SetGRPCWeb(port int, origins []string) {
+	s.grpcWebPort = port
+	s.grpcWebOrigins = origins
+}
+
+// grpcWebHandler translates grpc-web requests into gRPC requests to a
+// gRPC server and their responses back.
+type grpcWebHandler struct {
+	grpcServer *grpc.Server
+	origins    []string
+}
+
+// newGRPCWebHandler returns a handler that serves grpcServer to
+// grpc-web requests from pages of origins.
+func newGRPCWebHandler(grpcServer *grpc.Server, origins []string) *grpcWebHandler {
+	h := new(grpcWebHandler)
+	h.grpcServer = grpcServer
+	h.origins = origins
+	return h
+}
+
+// allowOrigin returns true if pages of origin may call the api.
+func (h *grpcWebHandler) allowOrigin(origin string) bool {
+	for _, o := range h.origins {
+		if o == "*" || strings.EqualFold(o, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP serves a grpc-web request or its CORS preflight.
+func (h *grpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !h.allowOrigin(origin) {
+			http.Error(w, "origin not allowed", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "grpc-status, grpc-message")
+		w.Header().Add("Vary", "Origin")
+	}
+
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", "POST")
+		w.Header().Set("Access-Control-Allow-Headers",
+			"authorization, content-type, grpc-timeout, x-grpc-web, x-user-agent")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if r.Method != http.MethodPost || !strings.HasPrefix(contentType, grpcWebContentType) {
+		http.Error(w, "not a grpc-web request", http.StatusUnsupportedMediaType)
+		return
+	}
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+
+	// The gRPC server only serves HTTP/2 requests with a gRPC content
+	// type, which the request becomes
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2"
+	req.Header.Set("Content-Type", "application/grpc+proto")
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	if text {
+		req.Body = ioutil.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+
+	resp := newGRPCWebResponse(w, text)
+	h.grpcServer.ServeHTTP(resp, req)
+	resp.finish()
+}
+
+// grpcWebResponse is the response writer handed to the gRPC server. It
+// writes the frames of the server as they are and its trailers as a
+// trailer frame.
+type grpcWebResponse struct {
+	w             http.ResponseWriter
+	header        http.Header
+	text          bool
+	encoder       io.WriteCloser
+	wroteHeader   bool
+	headerWritten map[string]bool
+}
+
+func newGRPCWebResponse(w http.ResponseWriter, text bool) *grpcWebResponse {
+	resp := new(grpcWebResponse)
+	resp.w = w
+	resp.header = make(http.Header)
+	resp.text = text
+	return resp
+}
+
+// Header returns the headers of the gRPC response, which include its
+// trailers once the headers are written.
+func (resp *grpcWebResponse) Header() http.Header {
+	return resp.header
+}
+
+// WriteHeader writes the headers of the response.
+func (resp *grpcWebResponse) WriteHeader(code int) {
+	if resp.wroteHeader {
+		return
+	}
+	resp.wroteHeader = true
+
+	resp.headerWritten = make(map[string]bool)
+	for key, values := range resp.header {
+		resp.headerWritten[key] = true
+		if key == "Trailer" || key == "Content-Type" {
+			continue
+		}
+		resp.w.Header()[key] = values
+	}
+	if resp.text {
+		resp.w.Header().Set("Content-Type", grpcWebTextContentType+"+proto")
+	} else {
+		resp.w.Header().Set("Content-Type", grpcWebContentType+"+proto")
+	}
+	resp.w.WriteHeader(code)
+}
+
+// Write writes frames of the response.
+func (resp *grpcWebResponse) Write(b []byte) (int, error) {
+	resp.WriteHeader(http.StatusOK)
+	if !resp.text {
+		return resp.w.Write(b)
+	}
+	if resp.encoder == nil {
+		resp.encoder = base64.NewEncoder(base64.StdEncoding, resp.w)
+	}
+	return resp.encoder.Write(b)
+}
+
+// Flush sends what was written so far, which lets server streaming
+// calls reach the browser as they go. The base64 of the text variant
+// is padded where it is flushed, which grpc-web clients expect.
+func (resp *grpcWebResponse) Flush() {
+	resp.WriteHeader(http.StatusOK)
+	if resp.encoder != nil {
+		resp.encoder.Close()
+		resp.encoder = nil
+	}
+	if f, ok := resp.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the trailers of the gRPC server as a trailer frame.
+func (resp *grpcWebResponse) finish() {
+	resp.WriteHeader(http.StatusOK)
+
+	var trailers bytes.Buffer
+	for key, values := range resp.header {
+		name := strings.TrimPrefix(key, trailerPrefix)
+		if name == key && resp.headerWritten[key] {
+			continue
+		}
+		for _, value := range values {
+			fmt.Fprintf(&trailers, "%s: %s\r\n", strings.ToLower(name), value)
+		}
+	}
+
+	frame := make([]byte, 5, 5+trailers.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(trailers.Len()))
+	frame = append(frame, trailers.Bytes()...)
+	resp.Write(frame)
+	resp.Flush()
+}
+
+// startGRPCWeb serves grpcServer to grpc-web requests on the grpc-web
+// port, over TLS if the admin api is.
+func (s *AdminServiceServer) startGRPCWeb(grpcServer *grpc.Server) {
+	common.Log.Infof("Starting admin grpc-web server on port: %d", s.grpcWebPort)
+
+	// The admin tokens are checked by the gRPC server
+	handler := newGRPCWebHandler(grpcServer, s.grpcWebOrigins)
+	err := s.serveHTTP(s.grpcWebPort, handler)
+	common.Log.Errorf("grpc-web server stopped: %v", err)
+}
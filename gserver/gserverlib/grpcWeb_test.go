@@ -0,0 +1,73 @@
+package gserverlib
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestGRPCWeb(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+	handler := newGRPCWebHandler(grpcServer, []string{"https://dashboard"})
+
+	// An empty HealthCheckRequest in a single data frame
+	body := base64.StdEncoding.EncodeToString([]byte{0, 0, 0, 0, 0})
+	req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check",
+		strings.NewReader(body))
+	req.Header.Set("Content-Type", grpcWebTextContentType)
+	req.Header.Set("Origin", "https://dashboard")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://dashboard" {
+		t.Errorf("ServeHTTP: the origin is not allowed")
+	}
+	if w.Header().Get("Content-Type") != grpcWebTextContentType+"+proto" {
+		t.Errorf("ServeHTTP: answered with content type %q", w.Header().Get("Content-Type"))
+	}
+	// The base64 is padded wherever the response was flushed
+	text := w.Body.String()
+	frames := make([]byte, 0)
+	for i := 0; i+4 <= len(text); i += 4 {
+		quantum, err := base64.StdEncoding.DecodeString(text[i : i+4])
+		if err != nil {
+			t.Fatalf("ServeHTTP: the response %q is not base64: %v", text, err)
+		}
+		frames = append(frames, quantum...)
+	}
+	// The SERVING status in a data frame, followed by the trailer frame
+	if !bytes.HasPrefix(frames, []byte{0, 0, 0, 0, 2, 8, 1, grpcWebTrailerFlag}) {
+		t.Fatalf("ServeHTTP: unexpected frames %q", frames)
+	}
+	if !bytes.Contains(frames[12:], []byte("grpc-status: 0\r\n")) {
+		t.Errorf("ServeHTTP: the trailers %q carry no status", frames[12:])
+	}
+
+	// Preflight
+	req = httptest.NewRequest(http.MethodOptions, "/grpc.health.v1.Health/Check", nil)
+	req.Header.Set("Origin", "https://dashboard")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent || w.Header().Get("Access-Control-Allow-Methods") == "" {
+		t.Errorf("ServeHTTP: preflight answered %d", w.Code)
+	}
+
+	// Other origins
+	req = httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check",
+		strings.NewReader(body))
+	req.Header.Set("Content-Type", grpcWebTextContentType)
+	req.Header.Set("Origin", "https://elsewhere")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("ServeHTTP: answered %d to another origin", w.Code)
+	}
+}
@@ -0,0 +1,93 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// DefaultHeartbeatInterval is how often endpoints send a heartbeat
+// unless configured otherwise.
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// staleHeartbeats is the number of heartbeats an endpoint may miss
+// before it is considered stale.
+const staleHeartbeats = 3
+
+// Endpoint states shown in listings.
+const (
+	EndpointStateOnline = "online"
+	EndpointStateStale  = "stale"
+	EndpointStateLost   = "offline"
+)
+
+// SetHeartbeatInterval sets how often endpoints that connect from now
+// on send a heartbeat. An interval of 0 disables heartbeats.
+func (s *GServer) SetHeartbeatInterval(interval time.Duration) {
+	s.heartbeatInterval = interval
+}
+
+// clientHeartbeat returns the heartbeat interval of an endpoint, or 0
+// if the endpoint does not send heartbeats.
+func (s *GServer) clientHeartbeat(client *ConnectedClient) time.Duration {
+	if !common.HasCapability(client.capabilities, common.CapabilityHeartbeat) {
+		return 0
+	}
+	return s.heartbeatInterval
+}
+
+// touch records that the endpoint was seen just now.
+func (c *ConnectedClient) touch() {
+	c.lastSeen.Store(time.Now())
+}
+
+// getLastSeen returns when the endpoint was last seen.
+func (c *ConnectedClient) getLastSeen() time.Time {
+	lastSeen, _ := c.lastSeen.Load().(time.Time)
+	return lastSeen
+}
+
+// endpointState returns whether an endpoint is online, has missed
+// its recent heartbeats or lost its control stream.
+func (s *GServer) endpointState(client *ConnectedClient) string {
+	if s.isLost(client) {
+		return EndpointStateLost
+	}
+
+	if client.heartbeat > 0 &&
+		time.Since(client.getLastSeen()) > staleHeartbeats*client.heartbeat {
+		return EndpointStateStale
+	}
+	return EndpointStateOnline
+}
+
+// Heartbeat is a gRPC function that the client calls periodically so
+// that the server can track when the endpoint was last seen.
+func (s *ClientServiceServer) Heartbeat(ctx context.Context,
+	req *cs.HeartbeatRequest) (*cs.HeartbeatResponse, error) {
+
+	_, uuid, err := GetClientInfoFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := s.gServer.getClient(uuid)
+	if !ok {
+		common.Log.WithEndpoint(uuid).Errorf("Heartbeat from unknown endpoint")
+		return nil, fmt.Errorf("uuid does not exist")
+	}
+
+	client.touch()
+	if req.ProbeId != "" {
+		s.gServer.answerPing(uuid, req.ProbeId)
+	}
+
+	// Cover traffic is answered with as much padding as it asks for
+	resp := new(cs.HeartbeatResponse)
+	resp.Padding = common.CoverPadding(int(req.CoverBytes))
+	return resp, nil
+}
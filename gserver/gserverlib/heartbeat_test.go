@@ -0,0 +1,33 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEndpointState(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.heartbeat = time.Minute
+
+	client.touch()
+	if state := s.endpointState(client); state != EndpointStateOnline {
+		t.Errorf("endpointState: Got: %s Want: %s", state, EndpointStateOnline)
+	}
+
+	client.lastSeen.Store(time.Now().Add(-staleHeartbeats*time.Minute - time.Second))
+	if state := s.endpointState(client); state != EndpointStateStale {
+		t.Errorf("endpointState: Got: %s Want: %s", state, EndpointStateStale)
+	}
+
+	// Endpoints that do not send heartbeats are never stale
+	client.heartbeat = 0
+	if state := s.endpointState(client); state != EndpointStateOnline {
+		t.Errorf("endpointState: Got: %s Want: %s", state, EndpointStateOnline)
+	}
+
+	s.endpointLost("endpoint")
+	if state := s.endpointState(client); state != EndpointStateLost {
+		t.Errorf("endpointState: Got: %s Want: %s", state, EndpointStateLost)
+	}
+}
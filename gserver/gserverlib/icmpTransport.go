@@ -0,0 +1,127 @@
+package gserverlib
+
+import (
+	"encoding/hex"
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"google.golang.org/grpc"
+)
+
+// SetICMPTransport makes the client service accept gClients over the
+// ICMP transport. gServer answers their echo requests on the ipv4
+// address, such as 0.0.0.0, which needs the privilege to open raw
+// sockets. Disabled if address is empty. It has to be called before
+// Start.
+func (s *GServer) SetICMPTransport(address string) {
+	s.icmpAddress = address
+}
+
+// icmpTransportServer answers the echo requests of ICMP transport
+// sessions. The operating system keeps answering them as well, which
+// gClients ignore, unless it is told not to with
+// net.ipv4.icmp_echo_ignore_all.
+type icmpTransportServer struct {
+	conn     *icmp.PacketConn
+	sessions *pollSessions
+}
+
+// reply returns the reply to the request r from addr, or false if
+// there is none.
+func (i *icmpTransportServer) reply(r *common.ICMPRequest,
+	addr net.Addr) (*common.ICMPReply, bool) {
+
+	reply := new(common.ICMPReply)
+	reply.Seq = r.Seq
+
+	// Opening requests carry a nonce
+	if r.Session == [8]byte{} {
+		id, ok := i.sessions.open(hex.EncodeToString(r.Data), addr)
+		if !ok {
+			return nil, false
+		}
+		session, err := hex.DecodeString(id)
+		if err != nil {
+			return nil, false
+		}
+		copy(reply.Session[:], session)
+		return reply, true
+	}
+
+	s, ok := i.sessions.get(hex.EncodeToString(r.Session[:]))
+	if !ok {
+		return nil, false
+	}
+	answer, more, accepted, ok := s.exchange(r.Seq, r.Data, int(r.MaxReply))
+	reply.Session = r.Session
+	reply.Data = answer
+	reply.More = more
+	reply.Accepted = uint16(accepted)
+	return reply, ok
+}
+
+// serve reads echo requests until the socket is closed.
+func (i *icmpTransportServer) serve() {
+	buf := make([]byte, 65536)
+	for {
+		n, addr, err := i.conn.ReadFrom(buf)
+		if err != nil {
+			common.Log.Errorf("ICMP transport stopped: %v", err)
+			return
+		}
+
+		// The protocol number of ICMP for IPv4
+		msg, err := icmp.ParseMessage(1, buf[:n])
+		if err != nil || msg.Type != ipv4.ICMPTypeEcho {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok {
+			continue
+		}
+		// Ordinary pings are left to the operating system
+		r, err := common.ParseICMPRequest(echo.Data)
+		if err != nil {
+			continue
+		}
+
+		reply, ok := i.reply(r, addr)
+		if !ok {
+			// The gClient tries again, possibly once gRPC caught up
+			continue
+		}
+		resp := icmp.Message{
+			Type: ipv4.ICMPTypeEchoReply,
+			Body: &icmp.Echo{
+				ID:   echo.ID,
+				Seq:  echo.Seq,
+				Data: reply.Marshal(),
+			},
+		}
+		b, err := resp.Marshal(nil)
+		if err != nil {
+			continue
+		}
+		i.conn.WriteTo(b, addr)
+	}
+}
+
+// startICMP serves grpcServer to ICMP transports.
+func (s *ClientServiceServer) startICMP(grpcServer *grpc.Server, address string) {
+	common.Log.Infof("Starting client icmp server on %s", address)
+	conn, err := icmp.ListenPacket("ip4:icmp", address)
+	if err != nil {
+		common.Log.Errorf("Failed to listen for icmp: %v", err)
+		return
+	}
+
+	listener := newTransportListener(conn.LocalAddr())
+	i := new(icmpTransportServer)
+	i.conn = conn
+	i.sessions = newPollSessions(listener)
+
+	go grpcServer.Serve(listener)
+	i.serve()
+}
@@ -0,0 +1,92 @@
+package gserverlib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	"golang.org/x/net/icmp"
+)
+
+func TestICMPTransport(t *testing.T) {
+	conn, err := icmp.ListenPacket("ip4:icmp", "127.0.0.1")
+	if err != nil {
+		t.Skipf("raw icmp sockets are not permitted: %v", err)
+	}
+	defer conn.Close()
+
+	listener := newTransportListener(conn.LocalAddr())
+	defer listener.Close()
+	i := new(icmpTransportServer)
+	i.conn = conn
+	i.sessions = newPollSessions(listener)
+	go i.serve()
+
+	// Echo what the client sends
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		io.Copy(conn, conn)
+	}()
+
+	// Loopback does not lose echoes, the MTU stays as it is
+	transport := &common.ICMPTransport{MTU: 600}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := transport.Dial(ctx, "127.0.0.1:443")
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer client.Close()
+
+	// Several echoes in each direction
+	data := bytes.Repeat([]byte("0123456789"), 300)
+	client.Write(data)
+	reply := make([]byte, len(data))
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(client, reply)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil || !bytes.Equal(reply, data) {
+			t.Errorf("Read: Got: %q, %v Want: the data", reply, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Read: no reply")
+	}
+}
+
+func TestPollSessionRepeat(t *testing.T) {
+	listener := newTransportListener(nil)
+	defer listener.Close()
+	sessions := newPollSessions(listener)
+	id, _ := sessions.open("nonce", nil)
+	s, _ := sessions.get(id)
+	defer s.Close()
+
+	s.Write([]byte("0123456789"))
+	answer, more, accepted, ok := s.exchange(0, []byte("abc"), 8)
+	if !ok || string(answer) != "01234567" || !more || accepted != 3 {
+		t.Fatalf("exchange: Got: %q %v %d %v", answer, more, accepted, ok)
+	}
+
+	// A lost answer is asked for again with less room
+	answer, more, accepted, ok = s.exchange(0, []byte("abc"), 4)
+	if !ok || string(answer) != "0123" || !more || accepted != 3 {
+		t.Errorf("repeated exchange: Got: %q %v %d %v", answer, more, accepted, ok)
+	}
+	answer, more, _, ok = s.exchange(1, nil, 8)
+	if !ok || string(answer) != "456789" || more {
+		t.Errorf("next exchange: Got: %q %v %v", answer, more, ok)
+	}
+	if _, _, _, ok := s.exchange(5, nil, 8); ok {
+		t.Errorf("out of order exchange: Got: ok")
+	}
+}
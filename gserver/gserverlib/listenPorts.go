@@ -0,0 +1,39 @@
+package gserverlib
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetListenPorts restricts the ports on which the server opens
+// listeners for forward tunnels to ranges. Any port is allowed if
+// ranges is empty. Existing tunnels are not affected.
+func (s *GServer) SetListenPorts(ranges []common.PortRange) {
+	s.portMutex.Lock()
+	defer s.portMutex.Unlock()
+
+	s.listenPorts = ranges
+}
+
+// checkListenPort returns an error if the server may not listen on
+// port.
+func (s *GServer) checkListenPort(port uint32) error {
+	s.portMutex.Lock()
+	defer s.portMutex.Unlock()
+
+	if len(s.listenPorts) == 0 {
+		return nil
+	}
+
+	allowed := make([]string, 0, len(s.listenPorts))
+	for _, r := range s.listenPorts {
+		if r.Contains(port) {
+			return nil
+		}
+		allowed = append(allowed, r.String())
+	}
+	return fmt.Errorf("listen port %d is not allowed, use one of %s",
+		port, strings.Join(allowed, ","))
+}
@@ -0,0 +1,36 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestListenPorts(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	ranges, _ := common.ParsePortRanges("10000-20000")
+	s.SetListenPorts(ranges)
+
+	err := s.AddTunnel("endpoint", "privileged", common.TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 80, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{})
+	if err == nil {
+		t.Errorf("AddTunnel: listened on a port outside of the policy")
+	}
+	if _, ok := s.connectedClients["endpoint"].endpoint.GetTunnel("privileged"); ok {
+		t.Errorf("AddTunnel: the rejected tunnel was added")
+	}
+
+	if err := s.checkListenPort(15000); err != nil {
+		t.Errorf("checkListenPort: %v", err)
+	}
+	if err := s.checkListenPort(80); err == nil {
+		t.Errorf("checkListenPort: allowed port 80")
+	}
+	s.SetListenPorts(nil)
+	if err := s.checkListenPort(80); err != nil {
+		t.Errorf("checkListenPort without a policy: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package gserverlib
+
+import (
+	"fmt"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetEndpointLogLevel changes the log level of an endpoint at runtime,
+// such as to debug a tunnel without rebuilding the endpoint.
+func (s *GServer) SetEndpointLogLevel(clientID string, level string) error {
+	if _, err := common.ParseLogLevel(level); err != nil {
+		return err
+	}
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	if !common.HasCapability(client.capabilities, common.CapabilityLogLevel) {
+		return fmt.Errorf("client %s does not support changing its log level", clientID)
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlLogLevel
+	controlMessage.LogLevel = level
+
+	return s.sendControlMessage(clientID, client, controlMessage)
+}
@@ -0,0 +1,32 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestSetEndpointLogLevel(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 1)
+
+	if err := s.SetEndpointLogLevel("endpoint", "debug"); err == nil {
+		t.Errorf("SetEndpointLogLevel: Got no error for an old client")
+	}
+
+	client.capabilities = []string{common.CapabilityLogLevel}
+	if err := s.SetEndpointLogLevel("endpoint", "verbose"); err == nil {
+		t.Errorf("SetEndpointLogLevel: Got no error for an invalid level")
+	}
+	if err := s.SetEndpointLogLevel("endpoint", "debug"); err != nil {
+		t.Fatalf("SetEndpointLogLevel: %v", err)
+	}
+	message := <-client.endpointInput
+	if message.Operation != common.EndpointCtrlLogLevel || message.LogLevel != "debug" {
+		t.Errorf("SetEndpointLogLevel: Got: %d %s", message.Operation, message.LogLevel)
+	}
+}
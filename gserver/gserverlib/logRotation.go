@@ -0,0 +1,157 @@
+package gserverlib
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeFormat is appended to the name of rotated log files.
+const rotatedTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.Writer that writes to a log file and rotates
+// it once it grows past a maximum size or age. Rotated files are kept
+// next to the log file and pruned according to the retention settings.
+type RotatingFile struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	retainAge  time.Duration
+	file       *os.File
+	size       int64
+	openedAt   time.Time
+	mutex      sync.Mutex
+}
+
+// NewRotatingFile is a constructor for RotatingFile. The file is
+// rotated once it is larger than maxSize bytes or older than maxAge,
+// a value of 0 disables that trigger. Only the newest maxBackups
+// rotated files that are younger than retainAge are kept, a value
+// of 0 disables that limit.
+func NewRotatingFile(path string, maxSize int64, maxAge time.Duration,
+	maxBackups int, retainAge time.Duration) (*RotatingFile, error) {
+
+	r := new(RotatingFile)
+	r.path = path
+	r.maxSize = maxSize
+	r.maxAge = maxAge
+	r.maxBackups = maxBackups
+	r.retainAge = retainAge
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Name returns the path of the current log file.
+func (r *RotatingFile) Name() string {
+	return r.path
+}
+
+// open opens the log file for appending.
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+// Write writes p to the log file, rotating it first if needed.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file.
+func (r *RotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.file.Close()
+}
+
+// shouldRotate returns true if writing length more bytes would exceed
+// the maximum size or if the file is older than the maximum age.
+func (r *RotatingFile) shouldRotate(length int64) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.maxSize > 0 && r.size+length > r.maxSize {
+		return true
+	}
+	return r.maxAge > 0 && time.Since(r.openedAt) > r.maxAge
+}
+
+// rotate renames the current log file, opens a new one and prunes
+// the rotated files.
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	rotated := r.path + "." + time.Now().UTC().Format(rotatedTimeFormat)
+	if err := os.Rename(r.path, rotated); err != nil {
+		return err
+	}
+
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	r.prune()
+	return nil
+}
+
+// prune removes the rotated files that are past the retention limits.
+func (r *RotatingFile) prune() {
+	matches, err := filepath.Glob(r.path + ".*")
+	if err != nil {
+		return
+	}
+
+	backups := make([]string, 0, len(matches))
+	rotatedAt := make(map[string]time.Time)
+	for _, match := range matches {
+		suffix := strings.TrimPrefix(match, r.path+".")
+		if t, err := time.Parse(rotatedTimeFormat, suffix); err == nil {
+			backups = append(backups, match)
+			rotatedAt[match] = t
+		}
+	}
+
+	// The timestamp format sorts chronologically, newest last
+	sort.Strings(backups)
+
+	for i, backup := range backups {
+		tooMany := r.maxBackups > 0 && i < len(backups)-r.maxBackups
+		tooOld := r.retainAge > 0 && time.Since(rotatedAt[backup]) > r.retainAge
+		if tooMany || tooOld {
+			os.Remove(backup)
+		}
+	}
+}
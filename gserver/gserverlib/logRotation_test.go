@@ -0,0 +1,40 @@
+package gserverlib
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "gtunnel.log")
+	r, err := NewRotatingFile(path, 10, 0, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for i := 0; i < 4; i++ {
+		r.Write([]byte("0123456789"))
+		// Rotated files are named by time with millisecond precision
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	backups, _ := filepath.Glob(path + ".*")
+	if len(backups) != 2 {
+		t.Errorf("backups: Got: %d Want: 2", len(backups))
+	}
+
+	contents, _ := ioutil.ReadFile(path)
+	if string(contents) != "0123456789" {
+		t.Errorf("log file: Got: %q Want: %q", contents, "0123456789")
+	}
+}
@@ -0,0 +1,169 @@
+package gserverlib
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// syslogFacility is the syslog facility of shipped messages (local0).
+	syslogFacility = 16
+
+	// syslogAppName is the APP-NAME of shipped messages.
+	syslogAppName = "gtunnel"
+
+	// logShipperDialTimeout bounds how long a write may block while
+	// reconnecting to the collector.
+	logShipperDialTimeout = 5 * time.Second
+)
+
+// syslogSeverities maps the level field written by common.Logger to
+// a syslog severity.
+var syslogSeverities = []struct {
+	field    []byte
+	severity int
+}{
+	{[]byte("level=debug "), 7},
+	{[]byte("level=info "), 6},
+	{[]byte("level=warn "), 4},
+	{[]byte("level=error "), 3},
+}
+
+// LogShipper is an io.Writer that ships log lines as RFC 5424 syslog
+// messages to a local syslog daemon or a remote collector such as a
+// SIEM. It is meant to be used with log.SetOutput.
+type LogShipper struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	hostname  string
+	conn      net.Conn
+	mutex     sync.Mutex
+}
+
+// NewLogShipper is a constructor for LogShipper. The destination is a
+// url with one of the schemes udp, tcp, tls or unix, for example
+// tls://siem.example.com:6514 or unix:///dev/log.
+func NewLogShipper(destination string) (*LogShipper, error) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	l := new(LogShipper)
+	l.hostname, _ = os.Hostname()
+
+	switch u.Scheme {
+	case "udp", "tcp":
+		l.network = u.Scheme
+		l.address = u.Host
+	case "tls":
+		l.network = "tcp"
+		l.address = u.Host
+		l.tlsConfig = &tls.Config{ServerName: u.Hostname()}
+	case "unix":
+		l.network = "unixgram"
+		l.address = u.Path
+	default:
+		return nil, fmt.Errorf("unsupported log destination scheme: %s", u.Scheme)
+	}
+
+	if err := l.connect(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// connect (re)establishes the connection to the collector.
+func (l *LogShipper) connect() error {
+	if l.conn != nil {
+		l.conn.Close()
+		l.conn = nil
+	}
+
+	dialer := &net.Dialer{Timeout: logShipperDialTimeout}
+
+	var conn net.Conn
+	var err error
+	if l.tlsConfig != nil {
+		conn, err = tls.DialWithDialer(dialer, l.network, l.address, l.tlsConfig)
+	} else {
+		conn, err = dialer.Dial(l.network, l.address)
+	}
+	if err != nil {
+		return err
+	}
+
+	l.conn = conn
+	return nil
+}
+
+// Write ships a single log line. If the connection to the collector
+// was lost, one reconnect is attempted before the line is dropped.
+func (l *LogShipper) Write(p []byte) (int, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	message := l.format(p)
+
+	if l.conn == nil {
+		if err := l.connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := l.conn.Write(message); err != nil {
+		if err := l.connect(); err != nil {
+			return 0, err
+		}
+		if _, err := l.conn.Write(message); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to the collector.
+func (l *LogShipper) Close() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	err := l.conn.Close()
+	l.conn = nil
+	return err
+}
+
+// format wraps a log line in a syslog header. Stream transports use
+// octet counting framing so that messages may contain newlines.
+func (l *LogShipper) format(p []byte) []byte {
+	severity := 6
+	for _, s := range syslogSeverities {
+		if bytes.Contains(p, s.field) {
+			severity = s.severity
+			break
+		}
+	}
+
+	message := fmt.Sprintf("<%d>1 %s %s %s %d - - %s",
+		syslogFacility*8+severity,
+		time.Now().UTC().Format(time.RFC3339Nano),
+		l.hostname,
+		syslogAppName,
+		os.Getpid(),
+		bytes.TrimRight(p, "\n"))
+
+	if l.network == "tcp" {
+		return []byte(fmt.Sprintf("%d %s", len(message), message))
+	}
+	return []byte(message)
+}
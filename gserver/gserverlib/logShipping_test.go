@@ -0,0 +1,27 @@
+package gserverlib
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLogShipperFormat(t *testing.T) {
+	l := new(LogShipper)
+	l.network = "tcp"
+	l.hostname = "gserver"
+
+	message := string(l.format([]byte("level=error msg=failed\n")))
+
+	frame := strings.SplitN(message, " ", 2)
+	if length, _ := strconv.Atoi(frame[0]); length != len(frame[1]) {
+		t.Errorf("format: Got: %q Want: octet count %d", message, len(frame[1]))
+	}
+	if !strings.HasPrefix(frame[1], "<131>1 ") {
+		t.Errorf("format: Got: %q Want: local0.err priority", message)
+	}
+	if !strings.Contains(frame[1], " gserver gtunnel ") ||
+		!strings.HasSuffix(frame[1], " - - level=error msg=failed") {
+		t.Errorf("format: Got: %q", message)
+	}
+}
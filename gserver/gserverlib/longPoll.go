@@ -0,0 +1,254 @@
+package gserverlib
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc"
+)
+
+// longPollTimeout is how long a long-poll session lives without
+// requests of its gClient.
+const longPollTimeout = 3 * common.LongPollWait
+
+// maxPollBytes is the most data returned by a single poll.
+const maxPollBytes = 1 << 20
+
+// SetLongPollPort makes the client service accept gClients over the
+// long-poll transport on port as well. Disabled if 0. It has to be
+// called before Start.
+func (s *GServer) SetLongPollPort(port int) {
+	s.longPollPort = port
+}
+
+// longPollSession is the gServer side of the connection of a long-poll
+// transport. Posted data is read by gRPC, what gRPC writes is returned
+// by the polls.
+type longPollSession struct {
+	id         string
+	remoteAddr net.Addr
+	localAddr  net.Addr
+	reader     *io.PipeReader
+	writer     *io.PipeWriter
+	postMutex  sync.Mutex
+	out        bytes.Buffer
+	outMutex   sync.Mutex
+	ready      chan struct{}
+	closed     chan struct{}
+	closeOnce  sync.Once
+	expiry     *time.Timer
+	onClose    func()
+}
+
+// Read reads data posted by the gClient.
+func (s *longPollSession) Read(b []byte) (int, error) {
+	return s.reader.Read(b)
+}
+
+// Write queues b for the next poll.
+func (s *longPollSession) Write(b []byte) (int, error) {
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+
+	select {
+	case <-s.closed:
+		return 0, errors.New("long-poll session closed")
+	default:
+	}
+	s.out.Write(b)
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+	return len(b), nil
+}
+
+// take returns the queued data, up to maxPollBytes.
+func (s *longPollSession) take() []byte {
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+
+	n := s.out.Len()
+	if n > maxPollBytes {
+		n = maxPollBytes
+	}
+	data := make([]byte, n)
+	s.out.Read(data)
+
+	// The rest is left for the next poll
+	if s.out.Len() > 0 {
+		select {
+		case s.ready <- struct{}{}:
+		default:
+		}
+	}
+	return data
+}
+
+// Close ends the session.
+func (s *longPollSession) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.expiry.Stop()
+		// gRPC reads EOF once posted data was read
+		s.writer.Close()
+		s.onClose()
+	})
+	return nil
+}
+
+// LocalAddr returns the address of the long-poll port.
+func (s *longPollSession) LocalAddr() net.Addr {
+	return s.localAddr
+}
+
+// RemoteAddr returns the address of the gClient that opened the
+// session.
+func (s *longPollSession) RemoteAddr() net.Addr {
+	return s.remoteAddr
+}
+
+// SetDeadline is not supported. gRPC does not depend on deadlines.
+func (s *longPollSession) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline is not supported.
+func (s *longPollSession) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is not supported.
+func (s *longPollSession) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// longPollHandler serves the requests of long-poll sessions. A POST
+// without a session opens one, POSTs with one carry data to gRPC,
+// GETs poll for data from gRPC and a DELETE closes the session.
+type longPollHandler struct {
+	listener *transportListener
+	sessions map[string]*longPollSession
+	mutex    sync.Mutex
+}
+
+// newLongPollHandler returns the handler that passes new long-poll
+// sessions to l.
+func newLongPollHandler(l *transportListener) http.Handler {
+	h := new(longPollHandler)
+	h.listener = l
+	h.sessions = make(map[string]*longPollSession)
+	return h
+}
+
+// open starts a new session for the client of r.
+func (h *longPollHandler) open(w http.ResponseWriter, r *http.Request) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		http.Error(w, "", http.StatusInternalServerError)
+		return
+	}
+
+	s := new(longPollSession)
+	s.id = hex.EncodeToString(id)
+	s.remoteAddr, _ = net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	s.localAddr = h.listener.Addr()
+	s.reader, s.writer = io.Pipe()
+	s.ready = make(chan struct{}, 1)
+	s.closed = make(chan struct{})
+	s.expiry = time.AfterFunc(longPollTimeout, func() { s.Close() })
+	s.onClose = func() {
+		h.mutex.Lock()
+		delete(h.sessions, s.id)
+		h.mutex.Unlock()
+	}
+
+	h.mutex.Lock()
+	h.sessions[s.id] = s
+	h.mutex.Unlock()
+
+	if !h.listener.put(s) {
+		s.Close()
+		http.Error(w, "", http.StatusServiceUnavailable)
+		return
+	}
+	w.Write([]byte(s.id))
+}
+
+// ServeHTTP serves a request of a long-poll session.
+func (h *longPollHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("session")
+	if id == "" && r.Method == http.MethodPost {
+		h.open(w, r)
+		return
+	}
+
+	h.mutex.Lock()
+	s, ok := h.sessions[id]
+	h.mutex.Unlock()
+	if !ok {
+		http.Error(w, "", http.StatusGone)
+		return
+	}
+	s.expiry.Reset(longPollTimeout)
+
+	switch r.Method {
+	case http.MethodPost:
+		// Posts are passed on in the order they arrive
+		s.postMutex.Lock()
+		_, err := io.Copy(s.writer, r.Body)
+		s.postMutex.Unlock()
+		if err != nil {
+			http.Error(w, "", http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		timer := time.NewTimer(common.LongPollWait)
+		defer timer.Stop()
+
+		select {
+		case <-s.ready:
+		case <-s.closed:
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+		data := s.take()
+		if len(data) == 0 {
+			select {
+			case <-s.closed:
+				http.Error(w, "", http.StatusGone)
+			default:
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		s.Close()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "", http.StatusMethodNotAllowed)
+	}
+}
+
+// startLongPoll serves grpcServer to long-poll transports on port.
+func (s *ClientServiceServer) startLongPoll(grpcServer *grpc.Server,
+	port int,
+	tls bool,
+	certFile string,
+	keyFile string) {
+
+	s.serveTransport(grpcServer, "long-poll", port, tls, certFile, keyFile,
+		common.LongPollPath, newLongPollHandler)
+}
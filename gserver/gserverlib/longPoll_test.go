@@ -0,0 +1,77 @@
+package gserverlib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestLongPollTransport(t *testing.T) {
+	ln := newTransportListener(nil)
+	defer ln.Close()
+
+	// The requests are fronted
+	handler := newLongPollHandler(ln)
+	var unfronted int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "gserver.example.com" || r.TLS.ServerName != "front.example.com" {
+			atomic.AddInt32(&unfronted, 1)
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	// Echo what the client sends until it closes the session
+	done := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		_, err = io.Copy(conn, conn)
+		conn.Close()
+		done <- err
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	transport := &common.LongPollTransport{
+		ServerName:  "front.example.com",
+		Host:        "gserver.example.com",
+		Fingerprint: common.TLSFingerprintChrome,
+	}
+	conn, err := transport.Dial(ctx, strings.TrimPrefix(server.URL, "https://"))
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+
+	// More than a single poll returns
+	data := bytes.Repeat([]byte("0123456789abcdef"), maxPollBytes/8)
+	go conn.Write(data)
+	reply := make([]byte, len(data))
+	if _, err := io.ReadFull(conn, reply); err != nil || !bytes.Equal(reply, data) {
+		t.Errorf("Read: Got: %d bytes, %v Want: the data", len(reply), err)
+	}
+
+	conn.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Read: Got: %v Want: EOF once the session is closed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Close: the session was not closed on the server")
+	}
+	if atomic.LoadInt32(&unfronted) != 0 {
+		t.Errorf("Dial: Want: the server name and host of the front")
+	}
+}
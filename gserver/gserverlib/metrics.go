@@ -0,0 +1,104 @@
+package gserverlib
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// metricsLabelEscaper escapes label values for the prometheus text
+// exposition format.
+var metricsLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// StartMetrics serves prometheus metrics at /metrics on the provided
+// port, with the admin tokens and TLS of the admin grpc api, if it has
+// them. It blocks until the server exits.
+func (s *GServer) StartMetrics(port int) {
+	common.Log.Infof("Starting metrics server on port: %d", port)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.adminServer.authenticated(http.HandlerFunc(s.handleMetrics)))
+
+	if err := s.adminServer.serveHTTP(port, mux); err != nil {
+		common.Log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// handleMetrics writes the current metrics in the prometheus text
+// exposition format.
+func (s *GServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var out bytes.Buffer
+
+	endpoints := 0
+	tunnels := 0
+	connections := make([]string, 0)
+
+	for clientID, client := range s.getClients() {
+		if !s.isLost(client) {
+			endpoints++
+		}
+		for tunnelID, tunnel := range client.endpoint.GetTunnels() {
+			tunnels++
+			connections = append(connections, fmt.Sprintf(
+				"gtunnel_tunnel_connections{endpoint=\"%s\",tunnel=\"%s\"} %d\n",
+				metricsLabelEscaper.Replace(clientID),
+				metricsLabelEscaper.Replace(tunnelID),
+				len(tunnel.GetConnections())))
+		}
+	}
+	sort.Strings(connections)
+
+	counters := common.GetMetricCounters()
+
+	writeMetric(&out, "gtunnel_endpoints", "gauge",
+		"Number of connected endpoints.", endpoints)
+	writeMetric(&out, "gtunnel_tunnels", "gauge",
+		"Number of tunnels.", tunnels)
+
+	writeMetricHeader(&out, "gtunnel_tunnel_connections", "gauge",
+		"Number of active connections of a tunnel.")
+	for _, line := range connections {
+		out.WriteString(line)
+	}
+
+	writeMetric(&out, "gtunnel_bytes_received_total", "counter",
+		"Bytes read from local sockets and sent over byte streams.",
+		counters.BytesReceived)
+	writeMetric(&out, "gtunnel_bytes_sent_total", "counter",
+		"Bytes received over byte streams and written to local sockets.",
+		counters.BytesSent)
+	writeMetric(&out, "gtunnel_dial_failures_total", "counter",
+		"Tunnel destinations that could not be connected to.",
+		counters.DialFailures)
+	writeMetric(&out, "gtunnel_stream_errors_total", "counter",
+		"Byte streams that failed.", counters.StreamErrors)
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	writeMetric(&out, "gtunnel_goroutines", "gauge",
+		"Number of goroutines of the gServer.", runtime.NumGoroutine())
+	writeMetric(&out, "gtunnel_heap_bytes", "gauge",
+		"Bytes of allocated heap objects of the gServer.", memStats.HeapAlloc)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(out.Bytes())
+}
+
+// writeMetricHeader writes the HELP and TYPE lines of a metric.
+func writeMetricHeader(out *bytes.Buffer, name string, metricType string,
+	help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+// writeMetric writes a metric without labels.
+func writeMetric(out *bytes.Buffer, name string, metricType string,
+	help string, value interface{}) {
+	writeMetricHeader(out, name, metricType, help)
+	fmt.Fprintf(out, "%s %v\n", name, value)
+}
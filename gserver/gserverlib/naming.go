@@ -0,0 +1,280 @@
+package gserverlib
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kai5263499/gtunnel/common"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// hostsSectionBegin and hostsSectionEnd delimit the part of the
+	// hosts file that is managed by gServer.
+	hostsSectionBegin = "# BEGIN gtunnel"
+	hostsSectionEnd   = "# END gtunnel"
+
+	// dnsStubTTL is the ttl in seconds of the records served by the
+	// dns stub. It is kept short since tunnels come and go.
+	dnsStubTTL = 5
+)
+
+// NameService publishes hostnames for the loopback aliases of active
+// tunnels so that tools can reach pivoted services by their real
+// internal hostnames.
+type NameService interface {
+	// Publish maps hostname to ip, replacing any previous mapping.
+	Publish(hostname string, ip net.IP) error
+	// Unpublish removes the mapping of hostname.
+	Unpublish(hostname string) error
+}
+
+// nameRecords is a concurrency safe set of hostname to ip mappings.
+type nameRecords struct {
+	records map[string]net.IP
+	mutex   sync.RWMutex
+}
+
+func newNameRecords() *nameRecords {
+	n := new(nameRecords)
+	n.records = make(map[string]net.IP)
+	return n
+}
+
+func (n *nameRecords) set(hostname string, ip net.IP) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.records[canonicalHostname(hostname)] = ip
+}
+
+func (n *nameRecords) remove(hostname string) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	delete(n.records, canonicalHostname(hostname))
+}
+
+func (n *nameRecords) lookup(hostname string) (net.IP, bool) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	ip, ok := n.records[canonicalHostname(hostname)]
+	return ip, ok
+}
+
+// sorted returns the hostnames in a stable order.
+func (n *nameRecords) sorted() []string {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+
+	hostnames := make([]string, 0, len(n.records))
+	for hostname := range n.records {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	return hostnames
+}
+
+// canonicalHostname lower cases a hostname and strips the trailing dot.
+func canonicalHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(hostname), ".")
+}
+
+// HostsFileNameService publishes hostnames by maintaining a section
+// of a hosts file, such as /etc/hosts. Entries outside of the section
+// are left untouched.
+type HostsFileNameService struct {
+	path    string
+	records *nameRecords
+	mutex   sync.Mutex
+}
+
+// NewHostsFileNameService is a constructor for HostsFileNameService.
+// Any section left behind by a previous run is cleared.
+func NewHostsFileNameService(path string) (*HostsFileNameService, error) {
+	h := new(HostsFileNameService)
+	h.path = path
+	h.records = newNameRecords()
+
+	if err := h.write(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Publish adds hostname to the managed section of the hosts file.
+func (h *HostsFileNameService) Publish(hostname string, ip net.IP) error {
+	h.records.set(hostname, ip)
+	return h.write()
+}
+
+// Unpublish removes hostname from the managed section of the hosts file.
+func (h *HostsFileNameService) Unpublish(hostname string) error {
+	h.records.remove(hostname)
+	return h.write()
+}
+
+// write replaces the managed section of the hosts file with the
+// current records.
+func (h *HostsFileNameService) write() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	contents, err := ioutil.ReadFile(h.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var out bytes.Buffer
+	inSection := false
+	for _, line := range strings.SplitAfter(string(contents), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == hostsSectionBegin {
+			inSection = true
+			continue
+		}
+		if trimmed == hostsSectionEnd {
+			inSection = false
+			continue
+		}
+		if !inSection {
+			out.WriteString(line)
+		}
+	}
+
+	hostnames := h.records.sorted()
+	if len(hostnames) > 0 {
+		if out.Len() > 0 && !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+			out.WriteString("\n")
+		}
+		out.WriteString(hostsSectionBegin + "\n")
+		for _, hostname := range hostnames {
+			ip, _ := h.records.lookup(hostname)
+			fmt.Fprintf(&out, "%s\t%s\n", ip, hostname)
+		}
+		out.WriteString(hostsSectionEnd + "\n")
+	}
+
+	return ioutil.WriteFile(h.path, out.Bytes(), 0644)
+}
+
+// DNSStubNameService publishes hostnames by answering A queries on a
+// local udp port. Queries for unknown names are answered with
+// NXDOMAIN so that resolvers can fall through to the next server.
+type DNSStubNameService struct {
+	conn    net.PacketConn
+	records *nameRecords
+}
+
+// NewDNSStubNameService is a constructor for DNSStubNameService. The
+// stub starts serving on the provided address immediately.
+func NewDNSStubNameService(address string) (*DNSStubNameService, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	d := new(DNSStubNameService)
+	d.conn = conn
+	d.records = newNameRecords()
+
+	go d.serve()
+	return d, nil
+}
+
+// Publish adds an A record for hostname.
+func (d *DNSStubNameService) Publish(hostname string, ip net.IP) error {
+	if ip.To4() == nil {
+		return fmt.Errorf("dns stub only supports ipv4 addresses")
+	}
+	d.records.set(hostname, ip)
+	return nil
+}
+
+// Unpublish removes the A record of hostname.
+func (d *DNSStubNameService) Unpublish(hostname string) error {
+	d.records.remove(hostname)
+	return nil
+}
+
+// serve reads queries until the socket is closed.
+func (d *DNSStubNameService) serve() {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := d.conn.ReadFrom(buf)
+		if err != nil {
+			common.Log.Errorf("DNS stub stopped: %v", err)
+			return
+		}
+
+		resp, err := d.answer(buf[:n])
+		if err != nil {
+			common.Log.Debugf("DNS stub dropped query from %s: %v", addr, err)
+			continue
+		}
+		d.conn.WriteTo(resp, addr)
+	}
+}
+
+// answer builds the response to a single dns query.
+func (d *DNSStubNameService) answer(query []byte) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := parser.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	respHeader := dnsmessage.Header{
+		ID:                 header.ID,
+		Response:           true,
+		OpCode:             header.OpCode,
+		Authoritative:      true,
+		RecursionDesired:   header.RecursionDesired,
+		RecursionAvailable: false,
+	}
+
+	ip, ok := d.records.lookup(question.Name.String())
+	if !ok {
+		respHeader.RCode = dnsmessage.RCodeNameError
+	}
+
+	builder := dnsmessage.NewBuilder(make([]byte, 0, 512), respHeader)
+	builder.EnableCompression()
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+
+	// Known names without an A record are answered with an empty
+	// answer section rather than NXDOMAIN.
+	if ok && question.Type == dnsmessage.TypeA && question.Class == dnsmessage.ClassINET {
+		if err := builder.StartAnswers(); err != nil {
+			return nil, err
+		}
+
+		var a dnsmessage.AResource
+		copy(a.A[:], ip.To4())
+
+		err := builder.AResource(dnsmessage.ResourceHeader{
+			Name:  question.Name,
+			Class: dnsmessage.ClassINET,
+			TTL:   dnsStubTTL,
+		}, a)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Finish()
+}
@@ -0,0 +1,79 @@
+package gserverlib
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func TestHostsFileNameService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtunnel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "hosts")
+	original := "127.0.0.1\tlocalhost\n"
+	ioutil.WriteFile(path, []byte(original), 0644)
+
+	h, err := NewHostsFileNameService(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h.Publish("dc01.corp.local", net.ParseIP("127.0.1.1"))
+	contents, _ := ioutil.ReadFile(path)
+	want := original + hostsSectionBegin + "\n127.0.1.1\tdc01.corp.local\n" + hostsSectionEnd + "\n"
+	if string(contents) != want {
+		t.Errorf("Publish: Got: %q Want: %q", contents, want)
+	}
+
+	h.Unpublish("dc01.corp.local")
+	contents, _ = ioutil.ReadFile(path)
+	if string(contents) != original {
+		t.Errorf("Unpublish: Got: %q Want: %q", contents, original)
+	}
+}
+
+func TestDNSStubAnswer(t *testing.T) {
+	d := new(DNSStubNameService)
+	d.records = newNameRecords()
+	d.Publish("dc01.corp.local", net.ParseIP("127.0.1.1"))
+
+	query := func(name string) dnsmessage.Message {
+		msg := dnsmessage.Message{
+			Header: dnsmessage.Header{ID: 1},
+			Questions: []dnsmessage.Question{{
+				Name:  dnsmessage.MustNewName(name),
+				Type:  dnsmessage.TypeA,
+				Class: dnsmessage.ClassINET,
+			}},
+		}
+		packed, _ := msg.Pack()
+		resp, err := d.answer(packed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed dnsmessage.Message
+		if err := parsed.Unpack(resp); err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	resp := query("DC01.corp.local.")
+	if len(resp.Answers) != 1 ||
+		resp.Answers[0].Body.(*dnsmessage.AResource).A != [4]byte{127, 0, 1, 1} {
+		t.Errorf("answer: Got: %v Want: 127.0.1.1", resp.Answers)
+	}
+
+	resp = query("unknown.corp.local.")
+	if resp.RCode != dnsmessage.RCodeNameError {
+		t.Errorf("answer: Got: %v Want: NXDOMAIN", resp.RCode)
+	}
+}
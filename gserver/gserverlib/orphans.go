@@ -0,0 +1,160 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// DefaultOrphanGracePeriod is how long the tunnels of an endpoint whose
+// control stream died are kept before they are torn down.
+const DefaultOrphanGracePeriod = 30 * time.Second
+
+// SetOrphanGracePeriod sets how long the tunnels of an endpoint whose
+// control stream died are kept waiting for it to return. A grace
+// period of 0 tears them down immediately.
+func (s *GServer) SetOrphanGracePeriod(gracePeriod time.Duration) {
+	s.orphanMutex.Lock()
+	defer s.orphanMutex.Unlock()
+	s.orphanGracePeriod = gracePeriod
+}
+
+// isLost returns true if the endpoint lost its control stream and
+// has not returned yet.
+func (s *GServer) isLost(client *ConnectedClient) bool {
+	s.orphanMutex.Lock()
+	defer s.orphanMutex.Unlock()
+	return client.lost
+}
+
+// endpointLost is called when the control stream of an endpoint dies.
+// Unless the endpoint was disconnected on purpose, its tunnels are
+// kept for the grace period in case it returns.
+func (s *GServer) endpointLost(clientID string) {
+	s.orphanMutex.Lock()
+	defer s.orphanMutex.Unlock()
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		common.Log.WithEndpoint(clientID).Warnf("Endpoint already removed")
+		return
+	}
+
+	if client.disconnecting || s.orphanGracePeriod == 0 {
+		s.removeEndpoint(clientID, client)
+		return
+	}
+
+	client.lost = true
+	s.emitEvent(EventEndpointLost, clientID, "",
+		fmt.Sprintf("control stream lost, removing in %s unless it returns",
+			s.orphanGracePeriod))
+
+	client.orphanTimer = time.AfterFunc(s.orphanGracePeriod, func() {
+		s.orphanMutex.Lock()
+		defer s.orphanMutex.Unlock()
+
+		// The endpoint may have returned and been lost again while
+		// the timer was firing.
+		if current, ok := s.getClient(clientID); ok &&
+			current == client && client.lost {
+			s.removeEndpoint(clientID, client)
+		}
+	})
+}
+
+// reclaimEndpoint returns the lost endpoint with the provided ID if it
+// is still within its grace period, cancelling its removal.
+func (s *GServer) reclaimEndpoint(clientID string) (*ConnectedClient, bool) {
+	s.orphanMutex.Lock()
+	defer s.orphanMutex.Unlock()
+
+	client, ok := s.getClient(clientID)
+	if !ok || !client.lost {
+		return nil, false
+	}
+
+	client.orphanTimer.Stop()
+	client.lost = false
+	client.resume = true
+	s.emitEvent(EventEndpointReturned, clientID, "", "control stream returned")
+	return client, true
+}
+
+// removeEndpoint tears down the listeners of all tunnels of an endpoint,
+// flags the tunnels as orphaned and forgets the endpoint. It must be
+// called with orphanMutex held.
+func (s *GServer) removeEndpoint(clientID string, client *ConnectedClient) {
+	for tunnelID, tunnel := range client.endpoint.GetTunnels() {
+		s.unpublishTunnelName(tunnel)
+		// stopHops takes orphanMutex
+		if hops, _ := s.parseHops(tunnel.GetOptions().Hops); len(hops) > 0 {
+			go s.stopHops(clientID, tunnelID, hops)
+		}
+		s.emitEvent(EventTunnelOrphaned, clientID, tunnelID,
+			fmt.Sprintf("tunnel %s:%d -> %s:%d torn down",
+				tunnel.GetListenIP(), tunnel.GetListenPort(),
+				tunnel.GetDestinationIP(), tunnel.GetDestinationPort()))
+	}
+
+	client.endpoint.Stop()
+	s.clientMutex.Lock()
+	delete(s.connectedClients, clientID)
+	s.clientMutex.Unlock()
+	s.removeEndpointAlias(clientID)
+	s.removeEndpointTags(clientID)
+	s.forgetRelays(clientID)
+	s.emitEvent(EventEndpointRemoved, clientID, "", "endpoint removed")
+}
+
+// takeResume returns true once after an endpoint was reclaimed, when
+// its tunnels have to be recreated on the gclient side.
+func (s *GServer) takeResume(client *ConnectedClient) bool {
+	s.orphanMutex.Lock()
+	defer s.orphanMutex.Unlock()
+
+	resume := client.resume
+	client.resume = false
+	return resume
+}
+
+// resumeTunnels tells a returned endpoint to recreate its configuration
+// and the tunnels that were kept during its grace period. The messages
+// are sent until ctx, the context of the new control stream, is done.
+func (s *GServer) resumeTunnels(ctx context.Context, clientID string,
+	client *ConnectedClient) {
+
+	var messages []*cs.EndpointControlMessage
+
+	keepalive := client.endpoint.GetKeepalive()
+	if (keepalive > 0 || client.endpoint.GetMTU() != common.DefaultMTU) &&
+		common.HasCapability(client.capabilities, common.CapabilityKeepalive) {
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlConfigure
+		controlMessage.Mtu = client.endpoint.GetMTU()
+		controlMessage.KeepaliveInterval = uint32(keepalive / time.Second)
+		messages = append(messages, controlMessage)
+	}
+
+	// Relays first, the tunnels of other endpoints may pass them
+	messages = append(messages, s.getRelays(clientID)...)
+
+	tunnels := client.endpoint.GetTunnels()
+	for tunnelID, tunnel := range tunnels {
+		messages = append(messages, addTunnelMessage(tunnelID, tunnel))
+	}
+
+	common.Log.WithEndpoint(clientID).Infof("Resuming %d tunnels", len(tunnels))
+
+	for _, controlMessage := range messages {
+		select {
+		case client.endpointInput <- controlMessage:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gserverlib
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func newTestServer(gracePeriod time.Duration) (*GServer, *[]string) {
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	s.orphanGracePeriod = gracePeriod
+
+	events := new([]string)
+	s.AddEventHandler(func(event Event) {
+		*events = append(*events, event.Type)
+	})
+
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	s.connectedClients["endpoint"] = client
+
+	return s, events
+}
+
+func TestEndpointLostReclaim(t *testing.T) {
+	s, events := newTestServer(time.Hour)
+
+	s.endpointLost("endpoint")
+	if _, ok := s.connectedClients["endpoint"]; !ok {
+		t.Fatalf("endpointLost: endpoint removed within grace period")
+	}
+
+	if _, ok := s.reclaimEndpoint("endpoint"); !ok {
+		t.Errorf("reclaimEndpoint: Got: false Want: true")
+	}
+
+	want := []string{EventEndpointLost, EventEndpointReturned}
+	if len(*events) != len(want) || (*events)[0] != want[0] || (*events)[1] != want[1] {
+		t.Errorf("events: Got: %v Want: %v", *events, want)
+	}
+}
+
+func TestEndpointLostRemove(t *testing.T) {
+	s, events := newTestServer(0)
+
+	s.endpointLost("endpoint")
+	if _, ok := s.connectedClients["endpoint"]; ok {
+		t.Errorf("endpointLost: endpoint not removed")
+	}
+
+	if len(*events) != 1 || (*events)[0] != EventEndpointRemoved {
+		t.Errorf("events: Got: %v Want: [%s]", *events, EventEndpointRemoved)
+	}
+}
+
+func TestEndpointResumeTunnels(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+	client.endpoint.AddTunnel("tunnel", common.NewTunnel("tunnel",
+		common.TunnelDirectionReverse, net.ParseIP("127.0.0.1"), 8080,
+		net.ParseIP("10.0.0.1"), 80))
+
+	s.endpointLost("endpoint")
+	if _, ok := s.reclaimEndpoint("endpoint"); !ok {
+		t.Fatalf("reclaimEndpoint: Got: false Want: true")
+	}
+
+	if !s.takeResume(client) {
+		t.Fatalf("takeResume: Got: false Want: true")
+	}
+	if s.takeResume(client) {
+		t.Errorf("takeResume: resumed twice")
+	}
+
+	s.resumeTunnels(context.Background(), "endpoint", client)
+	if len(client.endpointInput) != 1 {
+		t.Fatalf("resumeTunnels: Got: %d messages Want: 1", len(client.endpointInput))
+	}
+
+	message := <-client.endpointInput
+	if message.Operation != common.EndpointCtrlAddTunnel ||
+		message.TunnelId != "tunnel" || message.ListenPort != 8080 ||
+		message.DestinationPort != 0 {
+		t.Errorf("resumeTunnels: unexpected message %+v", message)
+	}
+}
@@ -0,0 +1,125 @@
+package gserverlib
+
+import (
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// storeTunnel saves the definition of a tunnel so that it is restored
+// when its endpoint connects to a restarted gServer. Tunnels defined in
+// the configuration file are not stored, they are added from the file
+// again.
+func (s *GServer) storeTunnel(clientID string, tunnelID string,
+	tunnel *common.Tunnel) {
+
+	if s.configStore == nil || s.isConfigTunnel(clientID, tunnelID) {
+		return
+	}
+
+	stored := new(StoredTunnel)
+	stored.EndpointID = clientID
+	stored.ID = tunnelID
+	stored.Direction = tunnel.GetDirection()
+	stored.ListenIP = tunnel.GetListenIP().String()
+	stored.ListenPort = tunnel.GetListenPort()
+	stored.DestinationIP = tunnel.GetDestinationIP().String()
+	stored.DestinationPort = tunnel.GetDestinationPort()
+	stored.Options = tunnel.GetOptions()
+
+	if err := s.configStore.SaveTunnel(stored); err != nil {
+		common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf(
+			"Failed to store tunnel: %v", err)
+	}
+}
+
+// forgetTunnel removes the stored definition of a deleted tunnel.
+func (s *GServer) forgetTunnel(clientID string, tunnelID string) {
+	if s.configStore == nil {
+		return
+	}
+
+	if err := s.configStore.DeleteTunnel(clientID, tunnelID); err != nil {
+		common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf(
+			"Failed to remove stored tunnel: %v", err)
+	}
+}
+
+// storeEndpoint saves the alias and tags of an endpoint.
+func (s *GServer) storeEndpoint(clientID string) {
+	if s.configStore == nil {
+		return
+	}
+
+	stored := new(StoredEndpoint)
+	stored.ID = clientID
+	stored.Alias = s.EndpointAlias(clientID)
+	stored.Tags = s.EndpointTags(clientID)
+
+	if err := s.configStore.SaveEndpoint(stored); err != nil {
+		common.Log.WithEndpoint(clientID).Warnf("Failed to store endpoint: %v", err)
+	}
+}
+
+// forgetEndpoint removes everything stored about an endpoint that was
+// disconnected on purpose.
+func (s *GServer) forgetEndpoint(clientID string) {
+	if s.configStore == nil {
+		return
+	}
+
+	if err := s.configStore.DeleteEndpoint(clientID); err != nil {
+		common.Log.WithEndpoint(clientID).Warnf(
+			"Failed to remove stored endpoint: %v", err)
+	}
+}
+
+// restoreEndpoint restores the stored alias, tags and tunnels of an
+// endpoint that connected and adds the tunnels of the configuration
+// file. Tunnels the endpoint already has are left alone.
+func (s *GServer) restoreEndpoint(clientID string) {
+	if s.configStore == nil {
+		return
+	}
+
+	logger := common.Log.WithEndpoint(clientID)
+
+	if stored := s.configStore.GetEndpoint(clientID); stored != nil {
+		if stored.Alias != "" {
+			if err := s.RenameEndpoint(clientID, stored.Alias); err != nil {
+				logger.Warnf("Failed to restore alias: %v", err)
+			}
+		}
+		if len(stored.Tags) > 0 {
+			s.TagEndpoint(clientID, stored.Tags, nil)
+		}
+	}
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return
+	}
+
+	restored := 0
+	for _, tunnel := range s.configStore.GetTunnels(clientID) {
+		if _, ok := client.endpoint.GetTunnel(tunnel.ID); ok {
+			continue
+		}
+
+		err := s.AddTunnel(clientID, tunnel.ID, tunnel.Direction,
+			net.ParseIP(tunnel.ListenIP), tunnel.ListenPort,
+			net.ParseIP(tunnel.DestinationIP), tunnel.DestinationPort,
+			tunnel.Options)
+		if err != nil {
+			logger.WithTunnel(tunnel.ID).Warnf("Failed to restore tunnel: %v", err)
+			continue
+		}
+		restored++
+	}
+
+	if restored > 0 {
+		logger.Infof("Restored %d stored tunnels", restored)
+	}
+
+	s.addConfigTunnels(clientID, client)
+}
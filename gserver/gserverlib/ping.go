@@ -0,0 +1,267 @@
+package gserverlib
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// probeIDSize is the length of the IDs of pings and test streams.
+const probeIDSize = 16
+
+// defaultPingCount is the number of pings if none is given.
+const defaultPingCount = 4
+
+// maxPingCount limits the number of pings per request.
+const maxPingCount = 100
+
+// PingResult holds the round-trip times to an endpoint that PingEndpoint
+// measured.
+type PingResult struct {
+	// Control are the round-trip times over the control stream.
+	Control []time.Duration
+
+	// Stream are the round-trip times over a byte stream that the
+	// endpoint echoes, if it was asked to.
+	Stream []time.Duration
+}
+
+// probe is a ping or test stream that gServer waits for an endpoint to
+// answer.
+type probe struct {
+	id        string
+	clientID  string
+	reply     chan struct{}
+	stream    chan cs.ClientService_CreateConnectionStreamServer
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// close releases the test stream of the probe, if any.
+func (p *probe) close() {
+	p.closeOnce.Do(func() { close(p.done) })
+}
+
+// closeOnCancel releases the test stream of the probe once ctx is
+// cancelled.
+func (p *probe) closeOnCancel(ctx context.Context) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.close()
+		case <-p.done:
+		}
+	}()
+}
+
+// PingEndpoint measures the round-trip time to an endpoint over its
+// control stream count times. If echo is true, it also measures the
+// round-trip time over a byte stream that the endpoint echoes.
+func (s *GServer) PingEndpoint(clientID string, count int,
+	echo bool) (*PingResult, error) {
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return nil, fmt.Errorf("ping failed - client does not exist")
+	}
+	if s.isLost(client) {
+		return nil, fmt.Errorf("ping failed - client lost its control stream")
+	}
+	if !common.HasCapability(client.capabilities, common.CapabilityPing) {
+		return nil, fmt.Errorf("ping failed - client does not support pings")
+	}
+
+	result := new(PingResult)
+	for i := 0; i < count; i++ {
+		rtt, err := s.pingControl(clientID, client)
+		if err != nil {
+			return result, fmt.Errorf("ping failed - %v", err)
+		}
+		result.Control = append(result.Control, rtt)
+	}
+	if !echo {
+		return result, nil
+	}
+
+	stream, p, err := s.openTestStream(clientID, client, common.StreamTestEcho, 0)
+	if err != nil {
+		return result, fmt.Errorf("ping failed - %v", err)
+	}
+	defer s.forgetProbe(p)
+
+	payload := []byte(common.GenerateString(probeIDSize))
+	for i := 0; i < count; i++ {
+		// A stuck stream is released so that Recv returns
+		timer := time.AfterFunc(s.probeTimeout(client), p.close)
+		start := time.Now()
+		err := stream.Send(&cs.BytesMessage{Content: payload})
+		var reply *cs.BytesMessage
+		if err == nil {
+			reply, err = stream.Recv()
+		}
+		timer.Stop()
+		if err != nil {
+			return result, fmt.Errorf("ping failed - echo stream: %v", err)
+		}
+		if !bytes.Equal(reply.Content, payload) {
+			return result, fmt.Errorf("ping failed - echo stream returned other data")
+		}
+		result.Stream = append(result.Stream, time.Since(start))
+	}
+	return result, nil
+}
+
+// pingControl measures the time in which an endpoint answers a ping
+// control message.
+func (s *GServer) pingControl(clientID string,
+	client *ConnectedClient) (time.Duration, error) {
+
+	p := s.newProbe(clientID)
+	defer s.forgetProbe(p)
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlPing
+	controlMessage.ProbeId = p.id
+	start := time.Now()
+	if err := s.sendControlMessage(clientID, client, controlMessage); err != nil {
+		return 0, err
+	}
+
+	timeout := s.probeTimeout(client)
+	select {
+	case <-p.reply:
+		return time.Since(start), nil
+	case <-time.After(timeout):
+		return 0, fmt.Errorf("no answer within %s", timeout)
+	}
+}
+
+// openTestStream asks an endpoint to open a test stream that it handles
+// as test says and returns the stream. Sink and source streams receive
+// or send size bytes. The caller must forget the probe to close the
+// stream.
+func (s *GServer) openTestStream(clientID string, client *ConnectedClient,
+	test string, size uint64) (cs.ClientService_CreateConnectionStreamServer, *probe, error) {
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlTestStream
+	controlMessage.StreamTest = test
+	controlMessage.StreamBytes = size
+	return s.openProbeStream(clientID, client, controlMessage, test)
+}
+
+// openProbeStream sends a control message that asks an endpoint to open
+// a byte stream for a new probe and returns the stream. The caller must
+// forget the probe to close the stream.
+func (s *GServer) openProbeStream(clientID string, client *ConnectedClient,
+	controlMessage *cs.EndpointControlMessage,
+	name string) (cs.ClientService_CreateConnectionStreamServer, *probe, error) {
+
+	p := s.newProbe(clientID)
+	controlMessage.ProbeId = p.id
+	if err := s.sendControlMessage(clientID, client, controlMessage); err != nil {
+		s.forgetProbe(p)
+		return nil, nil, err
+	}
+
+	timeout := s.probeTimeout(client)
+	select {
+	case stream := <-p.stream:
+		return stream, p, nil
+	case <-time.After(timeout):
+		s.forgetProbe(p)
+		return nil, nil, fmt.Errorf("no %s stream within %s", name, timeout)
+	}
+}
+
+// probeTimeout returns how long gServer waits for an endpoint to answer
+// a probe. Beaconing endpoints get their longest beacon interval, with
+// its jitter, on top.
+func (s *GServer) probeTimeout(client *ConnectedClient) time.Duration {
+	return s.getControlTimeout() + client.beacon
+}
+
+// newProbe registers a probe that an endpoint is asked to answer.
+func (s *GServer) newProbe(clientID string) *probe {
+	p := new(probe)
+	p.clientID = clientID
+	p.reply = make(chan struct{}, 1)
+	p.stream = make(chan cs.ClientService_CreateConnectionStreamServer, 1)
+	p.done = make(chan struct{})
+
+	s.probeMutex.Lock()
+	defer s.probeMutex.Unlock()
+
+	if s.probes == nil {
+		s.probes = make(map[string]*probe)
+	}
+	for {
+		id := common.GenerateString(probeIDSize)
+		if _, ok := s.probes[id]; !ok {
+			p.id = id
+			s.probes[id] = p
+			return p
+		}
+	}
+}
+
+// forgetProbe unregisters a probe and closes its test stream.
+func (s *GServer) forgetProbe(p *probe) {
+	s.probeMutex.Lock()
+	delete(s.probes, p.id)
+	s.probeMutex.Unlock()
+
+	p.close()
+}
+
+// getProbe returns the probe with an ID if it belongs to clientID.
+func (s *GServer) getProbe(clientID string, id string) (*probe, bool) {
+	s.probeMutex.Lock()
+	defer s.probeMutex.Unlock()
+
+	p, ok := s.probes[id]
+	if !ok || p.clientID != clientID {
+		return nil, false
+	}
+	return p, true
+}
+
+// answerPing records the answer of an endpoint to a ping.
+func (s *GServer) answerPing(clientID string, id string) {
+	if p, ok := s.getProbe(clientID, id); ok {
+		select {
+		case p.reply <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// serveTestStream hands a test stream that an endpoint opened to the
+// probe that asked for it and keeps it open until the probe is done.
+func (s *GServer) serveTestStream(clientID string, id string,
+	stream cs.ClientService_CreateConnectionStreamServer) error {
+
+	p, ok := s.getProbe(clientID, id)
+	if !ok {
+		common.Log.WithEndpoint(clientID).Warnf("Got a test stream that was not asked for")
+		return status.Errorf(codes.NotFound, "invalid test stream")
+	}
+	select {
+	case p.stream <- stream:
+	default:
+		return status.Errorf(codes.AlreadyExists, "test stream already attached")
+	}
+
+	select {
+	case <-p.done:
+	case <-stream.Context().Done():
+	}
+	return nil
+}
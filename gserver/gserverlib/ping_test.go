@@ -0,0 +1,59 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestPingEndpoint(t *testing.T) {
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	s.SetControlTimeout(time.Second)
+
+	old := new(ConnectedClient)
+	old.endpoint = common.NewEndpoint()
+	old.endpointInput = make(chan *cs.EndpointControlMessage, 1)
+	s.connectedClients["old"] = old
+	if _, err := s.PingEndpoint("old", 1, false); err == nil {
+		t.Errorf("PingEndpoint: pinged an endpoint without ping support")
+	}
+
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	client.endpointInput = make(chan *cs.EndpointControlMessage)
+	client.capabilities = []string{common.CapabilityPing}
+	s.connectedClients["endpoint"] = client
+
+	// The endpoint answers every ping, the first one of another endpoint
+	// is ignored
+	go func() {
+		for message := range client.endpointInput {
+			if message.Operation != common.EndpointCtrlPing {
+				continue
+			}
+			s.answerPing("old", message.ProbeId)
+			s.answerPing("endpoint", message.ProbeId)
+		}
+	}()
+	defer close(client.endpointInput)
+
+	result, err := s.PingEndpoint("endpoint", 3, false)
+	if err != nil {
+		t.Fatalf("PingEndpoint: %v", err)
+	}
+	if len(result.Control) != 3 || len(result.Stream) != 0 {
+		t.Errorf("PingEndpoint: got %d control and %d stream round trips, want 3 and 0",
+			len(result.Control), len(result.Stream))
+	}
+	if len(s.probes) != 0 {
+		t.Errorf("PingEndpoint: %d probes were not forgotten", len(s.probes))
+	}
+
+	if err := s.serveTestStream("endpoint", "unknown", nil); err == nil {
+		t.Errorf("serveTestStream: accepted a stream that was not asked for")
+	}
+}
@@ -0,0 +1,222 @@
+package gserverlib
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// pollSessionTimeout is how long a polled session lives without
+// exchanges.
+const pollSessionTimeout = time.Minute
+
+// errPollClosed is returned by a closed polled session.
+var errPollClosed = errors.New("session closed")
+
+// pollSession is the gServer side of the connection of a transport
+// that polls in numbered exchanges, such as DNS queries. Exchanges are
+// answered in order and a repeated exchange, which is sent when an
+// answer is lost, gets the previous answer again.
+type pollSession struct {
+	id         string
+	nonce      string
+	localAddr  net.Addr
+	remoteAddr net.Addr
+	in         chan []byte
+	pending    []byte
+	out        bytes.Buffer
+	outMutex   sync.Mutex
+	seq        uint32
+	answer     []byte
+	more       bool
+	accepted   int
+	closed     chan struct{}
+	closeOnce  sync.Once
+	expiry     *time.Timer
+	onClose    func()
+}
+
+// exchange hands the data of exchange number seq to gRPC and returns
+// up to max bytes of data from gRPC, whether more is waiting and how
+// many bytes of data were taken. It returns false if the exchange is
+// out of order or gRPC does not keep up.
+func (s *pollSession) exchange(seq uint32, data []byte,
+	max int) ([]byte, bool, int, bool) {
+
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+
+	s.expiry.Reset(pollSessionTimeout)
+	if seq+1 == s.seq {
+		// The repeat may ask for less if the answer got lost for its size
+		if len(s.answer) > max {
+			rest := append(s.answer[max:len(s.answer):len(s.answer)], s.out.Bytes()...)
+			s.out.Reset()
+			s.out.Write(rest)
+			s.answer = s.answer[:max]
+			s.more = true
+		}
+		return s.answer, s.more, s.accepted, true
+	}
+	if seq != s.seq {
+		return nil, false, 0, false
+	}
+
+	if len(data) > 0 {
+		select {
+		case s.in <- data:
+		default:
+			// The exchange is tried again
+			return nil, false, 0, false
+		}
+	}
+
+	n := s.out.Len()
+	if n > max {
+		n = max
+	}
+	s.answer = make([]byte, n)
+	s.out.Read(s.answer)
+	s.more = s.out.Len() > 0
+	s.accepted = len(data)
+	s.seq++
+	return s.answer, s.more, s.accepted, true
+}
+
+// Read reads data sent by the gClient.
+func (s *pollSession) Read(b []byte) (int, error) {
+	if len(s.pending) == 0 {
+		select {
+		case s.pending = <-s.in:
+		case <-s.closed:
+			return 0, errPollClosed
+		}
+	}
+	n := copy(b, s.pending)
+	s.pending = s.pending[n:]
+	return n, nil
+}
+
+// Write queues b for the next answers.
+func (s *pollSession) Write(b []byte) (int, error) {
+	s.outMutex.Lock()
+	defer s.outMutex.Unlock()
+
+	select {
+	case <-s.closed:
+		return 0, errPollClosed
+	default:
+	}
+	return s.out.Write(b)
+}
+
+// Close ends the session.
+func (s *pollSession) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.expiry.Stop()
+		s.onClose()
+	})
+	return nil
+}
+
+// LocalAddr returns the address of the transport.
+func (s *pollSession) LocalAddr() net.Addr {
+	return s.localAddr
+}
+
+// RemoteAddr returns the address from which the session was opened,
+// which may be a relay such as a resolver rather than the gClient.
+func (s *pollSession) RemoteAddr() net.Addr {
+	return s.remoteAddr
+}
+
+// SetDeadline is not supported. gRPC does not depend on deadlines.
+func (s *pollSession) SetDeadline(t time.Time) error {
+	return nil
+}
+
+// SetReadDeadline is not supported.
+func (s *pollSession) SetReadDeadline(t time.Time) error {
+	return nil
+}
+
+// SetWriteDeadline is not supported.
+func (s *pollSession) SetWriteDeadline(t time.Time) error {
+	return nil
+}
+
+// pollSessions are the open sessions of a polled transport.
+type pollSessions struct {
+	listener *transportListener
+	sessions map[string]*pollSession
+	opened   map[string]*pollSession
+	mutex    sync.Mutex
+}
+
+// newPollSessions is a constructor for pollSessions. New sessions are
+// passed to l.
+func newPollSessions(l *transportListener) *pollSessions {
+	p := new(pollSessions)
+	p.listener = l
+	p.sessions = make(map[string]*pollSession)
+	p.opened = make(map[string]*pollSession)
+	return p
+}
+
+// open starts a new session for an exchange from addr and returns its
+// ID. nonce identifies repeated exchanges, which get the same session.
+func (p *pollSessions) open(nonce string, addr net.Addr) (string, bool) {
+	p.mutex.Lock()
+	s, ok := p.opened[nonce]
+	p.mutex.Unlock()
+	if ok {
+		return s.id, true
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", false
+	}
+
+	s = new(pollSession)
+	s.id = hex.EncodeToString(id)
+	s.nonce = nonce
+	s.localAddr = p.listener.Addr()
+	s.remoteAddr = addr
+	s.in = make(chan []byte, 64)
+	s.closed = make(chan struct{})
+	s.expiry = time.AfterFunc(pollSessionTimeout, func() { s.Close() })
+	s.onClose = func() {
+		p.mutex.Lock()
+		delete(p.sessions, s.id)
+		delete(p.opened, s.nonce)
+		p.mutex.Unlock()
+	}
+
+	p.mutex.Lock()
+	p.sessions[s.id] = s
+	p.opened[nonce] = s
+	p.mutex.Unlock()
+
+	// gRPC accepts in a loop of its own, the exchanges wait briefly
+	go func() {
+		if !p.listener.put(s) {
+			s.Close()
+		}
+	}()
+	return s.id, true
+}
+
+// get returns the session with id.
+func (p *pollSessions) get(id string) (*pollSession, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	s, ok := p.sessions[id]
+	return s, ok
+}
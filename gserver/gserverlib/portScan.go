@@ -0,0 +1,60 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ScanEndpoint has an endpoint connect to ports on hosts of its network
+// and calls open with the address of each port that accepted. Hosts and
+// ports are in the format of common.NewPortScan. The scan is stopped
+// when ctx is cancelled or open returns an error.
+func (s *GServer) ScanEndpoint(ctx context.Context, clientID string,
+	hosts string, ports string, timeout time.Duration,
+	open func(address string) error) error {
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return fmt.Errorf("scan failed - client does not exist")
+	}
+	if s.isLost(client) {
+		return fmt.Errorf("scan failed - client lost its control stream")
+	}
+	if !common.HasCapability(client.capabilities, common.CapabilityScan) {
+		return fmt.Errorf("scan failed - client does not support scans")
+	}
+	if _, err := common.NewPortScan(hosts, ports, timeout); err != nil {
+		return fmt.Errorf("scan failed - %v", err)
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlScan
+	controlMessage.ScanHosts = hosts
+	controlMessage.ScanPorts = ports
+	controlMessage.ScanTimeout = uint32(timeout / time.Millisecond)
+	stream, p, err := s.openProbeStream(clientID, client, controlMessage, "scan")
+	if err != nil {
+		return fmt.Errorf("scan failed - %v", err)
+	}
+	defer s.forgetProbe(p)
+
+	// Closing the stream stops the scan on the endpoint
+	p.closeOnCancel(ctx)
+
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("scan failed - %v", err)
+		}
+		if err := open(string(message.Content)); err != nil {
+			return err
+		}
+	}
+}
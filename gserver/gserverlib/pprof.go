@@ -0,0 +1,30 @@
+package gserverlib
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// StartPprof serves the net/http/pprof debug endpoints at /debug/pprof/
+// on the provided port of localhost. It blocks until the server exits.
+func StartPprof(port int) {
+	common.Log.Infof("Starting pprof server on port: %d", port)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		common.Log.Fatalf("Failed to listen: %v", err)
+	}
+
+	http.Serve(lis, mux)
+}
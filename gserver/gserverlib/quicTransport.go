@@ -0,0 +1,77 @@
+package gserverlib
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/kai5263499/gtunnel/common"
+	"github.com/quic-go/quic-go"
+	"google.golang.org/grpc"
+)
+
+// SetQUICPort makes the client service accept gClients over the QUIC
+// transport on UDP port as well. Disabled if 0. It has to be called
+// before Start.
+func (s *GServer) SetQUICPort(port int) {
+	s.quicPort = port
+}
+
+// startQUIC serves grpcServer to QUIC transports on port. QUIC always
+// needs TLS, so the certificate is loaded even if the gRPC server runs
+// without TLS.
+func (s *ClientServiceServer) startQUIC(grpcServer *grpc.Server, port int,
+	certFile string, keyFile string) {
+
+	common.Log.Infof("Starting client quic server on port: %d", port)
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		common.Log.Errorf("Failed to load TLS certificates for quic: %v", err)
+		return
+	}
+	ln, err := quic.ListenAddr(fmt.Sprintf("0.0.0.0:%d", port), &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{common.QUICProtocol},
+	}, common.QUICConfig())
+	if err != nil {
+		common.Log.Errorf("Failed to listen for quic: %v", err)
+		return
+	}
+
+	tl := newTransportListener(ln.Addr())
+	go grpcServer.Serve(tl)
+	s.serveQUIC(ln, tl)
+}
+
+// serveQUIC passes every stream of the QUIC connections that ln
+// accepts from allowed addresses to l as a connection of its own.
+func (s *ClientServiceServer) serveQUIC(ln *quic.Listener, l *transportListener) {
+	for {
+		conn, err := ln.Accept(context.Background())
+		if err != nil {
+			common.Log.Errorf("The quic server stopped: %v", err)
+			return
+		}
+
+		ip, _ := common.AddrToIPPort(conn.RemoteAddr())
+		if !s.gServer.isClientAllowed(ip) {
+			common.Log.Debugf("Closed quic connection from %s, which is not allowlisted",
+				conn.RemoteAddr())
+			conn.CloseWithError(0, "")
+			continue
+		}
+
+		go func() {
+			for {
+				stream, err := conn.AcceptStream(context.Background())
+				if err != nil {
+					return
+				}
+				if !l.put(common.NewQUICConn(conn, stream)) {
+					conn.CloseWithError(0, "")
+					return
+				}
+			}
+		}()
+	}
+}
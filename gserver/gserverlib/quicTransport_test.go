@@ -0,0 +1,79 @@
+package gserverlib
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	"github.com/quic-go/quic-go"
+)
+
+func TestQUICTransport(t *testing.T) {
+	cert, err := tls.LoadX509KeyPair("../../testdata/cert", "../../testdata/key")
+	if err != nil {
+		t.Fatalf("LoadX509KeyPair: %v", err)
+	}
+	quicLn, err := quic.ListenAddr("127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{common.QUICProtocol},
+	}, common.QUICConfig())
+	if err != nil {
+		t.Fatalf("ListenAddr: %v", err)
+	}
+	defer quicLn.Close()
+
+	s := new(ClientServiceServer)
+	s.gServer = new(GServer)
+	ln := newTransportListener(quicLn.Addr())
+	defer ln.Close()
+	go s.serveQUIC(quicLn, ln)
+
+	// Echo what is sent on every stream
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	transport := &common.QUICTransport{ServerName: "gserver.example.com"}
+	var conns []net.Conn
+	for i := 0; i < 2; i++ {
+		conn, err := transport.Dial(ctx, quicLn.Addr().String())
+		if err != nil {
+			t.Fatalf("Dial: %v", err)
+		}
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+
+	// The streams are independent of each other
+	for i, conn := range conns {
+		data := []byte{'a' + byte(i)}
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, err := conn.Write(data); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+		reply := make([]byte, 1)
+		if _, err := io.ReadFull(conn, reply); err != nil || reply[0] != data[0] {
+			t.Errorf("Read: Got: %q, %v Want: %q", reply, err, data)
+		}
+	}
+
+	if conns[0].LocalAddr().String() != conns[1].LocalAddr().String() {
+		t.Errorf("LocalAddr: Got: %s and %s Want: a single quic connection",
+			conns[0].LocalAddr(), conns[1].LocalAddr())
+	}
+}
@@ -0,0 +1,44 @@
+package gserverlib
+
+import (
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetRateLimits sets the bandwidth in bytes per second that all the
+// tunnels of the server, and the tunnels of each endpoint, may use
+// together. A limit of 0 lifts it. The new limits apply to existing
+// tunnels as well.
+func (s *GServer) SetRateLimits(server uint64, endpoint uint64) {
+	s.serverRateLimiter().SetRate(server)
+
+	s.rateMutex.Lock()
+	s.endpointRateLimit = endpoint
+	s.rateMutex.Unlock()
+
+	for _, client := range s.getClients() {
+		if client.rateLimiter != nil {
+			client.rateLimiter.SetRate(endpoint)
+		}
+	}
+}
+
+// serverRateLimiter returns the rate limiter shared by all the tunnels
+// of the server.
+func (s *GServer) serverRateLimiter() *common.RateLimiter {
+	s.rateMutex.Lock()
+	defer s.rateMutex.Unlock()
+
+	if s.rateLimiter == nil {
+		s.rateLimiter = common.NewRateLimiter(0)
+	}
+	return s.rateLimiter
+}
+
+// newEndpointRateLimiter returns a rate limiter for the tunnels of a
+// new endpoint.
+func (s *GServer) newEndpointRateLimiter() *common.RateLimiter {
+	s.rateMutex.Lock()
+	defer s.rateMutex.Unlock()
+
+	return common.NewRateLimiter(s.endpointRateLimit)
+}
@@ -0,0 +1,28 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetRateLimits(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.rateLimiter = s.newEndpointRateLimiter()
+
+	s.SetRateLimits(1000, 500)
+	if rate := s.serverRateLimiter().GetRate(); rate != 1000 {
+		t.Errorf("server rate: Got: %d Want: 1000", rate)
+	}
+	if rate := client.rateLimiter.GetRate(); rate != 500 {
+		t.Errorf("endpoint rate: Got: %d Want: 500", rate)
+	}
+	if rate := s.newEndpointRateLimiter().GetRate(); rate != 500 {
+		t.Errorf("new endpoint rate: Got: %d Want: 500", rate)
+	}
+
+	s.SetRateLimits(0, 0)
+	if rate := client.rateLimiter.GetRate(); rate != 0 {
+		t.Errorf("endpoint rate: Got: %d Want: 0", rate)
+	}
+}
@@ -0,0 +1,85 @@
+package gserverlib
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/peer"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// registrationWindow is how long the registration attempts of a source
+// address are counted.
+const registrationWindow = time.Minute
+
+// registrationAttempts counts the registration attempts of a source
+// address within the current window.
+type registrationAttempts struct {
+	start     time.Time
+	count     int
+	throttled bool
+}
+
+// SetRegistrationLimit sets how many times per minute a source address
+// may try to register an endpoint. Further attempts are rejected until
+// the minute is over. A limit of 0 lifts it.
+func (s *GServer) SetRegistrationLimit(limit int) {
+	s.registrationMutex.Lock()
+	defer s.registrationMutex.Unlock()
+
+	s.registrationLimit = limit
+}
+
+// allowRegistration counts a registration attempt from the peer of ctx
+// and returns false if the peer made too many of them.
+func (s *GServer) allowRegistration(ctx context.Context) bool {
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		return true
+	}
+	ip, _ := common.AddrToIPPort(peerInfo.Addr)
+	return s.countRegistration(ip, time.Now())
+}
+
+// countRegistration implements allowRegistration for an attempt from
+// ip at now.
+func (s *GServer) countRegistration(ip net.IP, now time.Time) bool {
+	s.registrationMutex.Lock()
+	defer s.registrationMutex.Unlock()
+
+	if s.registrationLimit <= 0 {
+		return true
+	}
+
+	if s.registrations == nil {
+		s.registrations = make(map[string]*registrationAttempts)
+	}
+
+	// Forget addresses that stopped trying
+	for address, attempts := range s.registrations {
+		if now.Sub(attempts.start) >= registrationWindow {
+			delete(s.registrations, address)
+		}
+	}
+
+	attempts, ok := s.registrations[ip.String()]
+	if !ok {
+		attempts = &registrationAttempts{start: now}
+		s.registrations[ip.String()] = attempts
+	}
+
+	attempts.count++
+	if attempts.count <= s.registrationLimit {
+		return true
+	}
+
+	// Log once per window, scanners would flood the log otherwise
+	if !attempts.throttled {
+		attempts.throttled = true
+		common.Log.Warnf("Throttling registrations from %s after %d attempts",
+			ip, s.registrationLimit)
+	}
+	return false
+}
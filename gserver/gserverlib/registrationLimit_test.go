@@ -0,0 +1,36 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestRegistrationLimit(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	scanner := net.ParseIP("198.51.100.7")
+	now := time.Now()
+
+	if !s.countRegistration(scanner, now) {
+		t.Fatalf("countRegistration: throttled without a limit")
+	}
+
+	s.SetRegistrationLimit(3)
+	for i := 0; i < 3; i++ {
+		if !s.countRegistration(scanner, now) {
+			t.Fatalf("countRegistration: attempt %d throttled", i+1)
+		}
+	}
+	if s.countRegistration(scanner, now) {
+		t.Errorf("countRegistration: attempt over the limit allowed")
+	}
+
+	// Other addresses have their own count
+	if !s.countRegistration(net.ParseIP("198.51.100.8"), now) {
+		t.Errorf("countRegistration: throttled another address")
+	}
+
+	if !s.countRegistration(scanner, now.Add(registrationWindow)) {
+		t.Errorf("countRegistration: throttled in the next window")
+	}
+}
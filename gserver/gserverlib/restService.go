@@ -0,0 +1,727 @@
+package gserverlib
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// RestServiceServer exposes the administrative operations of the
+// gServer as a JSON over HTTP api so that scripts and external tooling
+// can manage gTunnel without speaking gRPC.
+type RestServiceServer struct {
+	gServer *GServer
+}
+
+// RestClient is the JSON representation of a connected gClient.
+type RestClient struct {
+	Name          string   `json:"name"`
+	ClientID      string   `json:"client_id"`
+	Status        uint32   `json:"status"`
+	RemoteAddress string   `json:"remote_address"`
+	Hostname      string   `json:"hostname"`
+	ConnectDate   string   `json:"connect_date"`
+	Country       string   `json:"country,omitempty"`
+	ASN           uint32   `json:"asn,omitempty"`
+	Organization  string   `json:"as_organization,omitempty"`
+	OS            string   `json:"os,omitempty"`
+	Arch          string   `json:"arch,omitempty"`
+	Username      string   `json:"username,omitempty"`
+	Addresses     []string `json:"addresses,omitempty"`
+	LastSeen      int64    `json:"last_seen"`
+	State         string   `json:"state"`
+	Alias         string   `json:"alias,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// RestClientRegisterRequest is the JSON body used to register a new
+// client configuration.
+type RestClientRegisterRequest struct {
+	ClientID    string `json:"client_id"`
+	Token       string `json:"token"`
+	IPAddress   string `json:"ip_address"`
+	Port        uint32 `json:"port"`
+	Platform    string `json:"platform"`
+	BinType     string `json:"bin_type"`
+	Arch        string `json:"arch"`
+	ProxyServer string `json:"proxy_server"`
+}
+
+// RestTunnel is the JSON representation of a tunnel.
+type RestTunnel struct {
+	ID                string `json:"id"`
+	Direction         string `json:"direction"`
+	ListenIP          string `json:"listen_ip"`
+	ListenPort        uint32 `json:"listen_port"`
+	DestinationIP     string `json:"destination_ip"`
+	DestinationPort   uint32 `json:"destination_port"`
+	Command           string `json:"command,omitempty"`
+	DetectDeception   bool   `json:"detect_deception,omitempty"`
+	LoopbackAlias     bool   `json:"loopback_alias,omitempty"`
+	Hostname          string `json:"hostname,omitempty"`
+	Connections       int    `json:"connections"`
+	BytesSent         uint64 `json:"bytes_sent"`
+	BytesReceived     uint64 `json:"bytes_received"`
+	Paused            bool   `json:"paused"`
+	FailedConns       uint64 `json:"failed_connections,omitempty"`
+	LastError         string `json:"last_error,omitempty"`
+	Health            string `json:"health,omitempty"`
+	TTL               int64  `json:"ttl,omitempty"`
+	ByteLimit         uint64 `json:"byte_limit,omitempty"`
+	Schedule          string `json:"schedule,omitempty"`
+	Timezone          string `json:"timezone,omitempty"`
+	ChunkSize         uint32 `json:"chunk_size,omitempty"`
+	Nagle             bool   `json:"nagle,omitempty"`
+	TCPKeepalive      int64  `json:"tcp_keepalive,omitempty"`
+	ReadBuffer        uint32 `json:"read_buffer,omitempty"`
+	WriteBuffer       uint32 `json:"write_buffer,omitempty"`
+	RateLimit         uint64 `json:"rate_limit,omitempty"`
+	IdleTimeout       int64  `json:"idle_timeout,omitempty"`
+	MaxConnections    uint32 `json:"max_connections,omitempty"`
+	Compress          bool   `json:"compress,omitempty"`
+	StreamCompression string `json:"stream_compression,omitempty"`
+	Encrypt           bool   `json:"encrypt,omitempty"`
+	RekeyInterval     int64  `json:"rekey_interval,omitempty"`
+	RekeyBytes        uint64 `json:"rekey_bytes,omitempty"`
+	PadSize           uint32 `json:"pad_size,omitempty"`
+	SendJitter        int64  `json:"send_jitter,omitempty"`
+	Hops              string `json:"hops,omitempty"`
+	Balance           string `json:"balance,omitempty"`
+	Failover          string `json:"failover,omitempty"`
+	HealthCheck       int64  `json:"health_check,omitempty"`
+	HealthBanner      string `json:"health_banner,omitempty"`
+}
+
+// options returns the options of a tunnel that is to be created.
+func (t *RestTunnel) options() common.TunnelOptions {
+	return common.TunnelOptions{
+		Command:           t.Command,
+		DetectDeception:   t.DetectDeception,
+		Hostname:          t.Hostname,
+		TTL:               time.Duration(t.TTL) * time.Second,
+		ByteLimit:         t.ByteLimit,
+		Schedule:          t.Schedule,
+		Timezone:          t.Timezone,
+		ChunkSize:         t.ChunkSize,
+		Nagle:             t.Nagle,
+		TCPKeepalive:      time.Duration(t.TCPKeepalive) * time.Second,
+		ReadBuffer:        int(t.ReadBuffer),
+		WriteBuffer:       int(t.WriteBuffer),
+		RateLimit:         t.RateLimit,
+		IdleTimeout:       time.Duration(t.IdleTimeout) * time.Second,
+		MaxConnections:    t.MaxConnections,
+		Compress:          t.Compress,
+		StreamCompression: t.StreamCompression,
+		Encrypt:           t.Encrypt,
+		RekeyInterval:     time.Duration(t.RekeyInterval) * time.Second,
+		RekeyBytes:        t.RekeyBytes,
+		PadSize:           t.PadSize,
+		SendJitter:        time.Duration(t.SendJitter) * time.Millisecond,
+		Hops:              t.Hops,
+		Balance:           t.Balance,
+		Failover:          t.Failover,
+		HealthCheck:       time.Duration(t.HealthCheck) * time.Second,
+		HealthBanner:      t.HealthBanner,
+	}
+}
+
+// RestConnection is the JSON representation of a tunneled TCP connection.
+type RestConnection struct {
+	SourceIP           string   `json:"source_ip"`
+	SourcePort         uint32   `json:"source_port"`
+	DestinationIP      string   `json:"destination_ip"`
+	DestinationPort    uint32   `json:"destination_port"`
+	Warnings           []string `json:"warnings,omitempty"`
+	ID                 string   `json:"id"`
+	BytesSent          uint64   `json:"bytes_sent"`
+	BytesReceived      uint64   `json:"bytes_received"`
+	StartTime          int64    `json:"start_time"`
+	OriginAddress      string   `json:"origin_address,omitempty"`
+	DestinationAddress string   `json:"destination_address,omitempty"`
+}
+
+// RestSocksRequest is the JSON body used to start a socks proxy.
+type RestSocksRequest struct {
+	Port uint32 `json:"port"`
+}
+
+// RestTagRequest is the JSON body used to change the tags of a client.
+type RestTagRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// RestRenameRequest is the JSON body used to set the alias of a client.
+type RestRenameRequest struct {
+	Alias string `json:"alias"`
+}
+
+// restError is the JSON body returned with any non 2xx response.
+type restError struct {
+	Error string `json:"error"`
+}
+
+// NewRestServiceServer is a constructor that returns a RestServiceServer.
+func NewRestServiceServer(gServer *GServer) *RestServiceServer {
+	restServer := new(RestServiceServer)
+	restServer.gServer = gServer
+	return restServer
+}
+
+// Start will start the http server, which serves both the rest api and
+// the web dashboard. The api requires the admin tokens and TLS of the
+// admin grpc api, if it does. The dashboard page itself holds no data
+// and asks for a token to call the api with. It blocks until the server
+// exits.
+func (s *RestServiceServer) Start(port int) {
+	common.Log.Infof("Starting admin rest server on port: %d", port)
+
+	admin := s.gServer.GetAdminServer()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleDashboard)
+	mux.Handle("/api/v1/clients",
+		admin.authenticated(checkWrites(http.HandlerFunc(s.handleClients))))
+	mux.Handle("/api/v1/clients/",
+		admin.authenticated(checkWrites(http.HandlerFunc(s.handleClient))))
+
+	if err := admin.serveHTTP(port, mux); err != nil {
+		common.Log.Fatalf("Failed to serve: %v", err)
+	}
+}
+
+// checkWrites rejects requests that change state unless they come from
+// the dashboard or a client other than a browser, so that other web
+// pages the operator visits cannot forge them. Browsers only send a
+// JSON body with an Authorization header to another origin after a
+// CORS preflight, which the rest api never allows.
+func checkWrites(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if origin := r.Header.Get("Origin"); origin != "" {
+			scheme := "http"
+			if r.TLS != nil {
+				scheme = "https"
+			}
+			if !strings.EqualFold(origin, scheme+"://"+r.Host) {
+				writeError(w, http.StatusForbidden, "origin not allowed")
+				return
+			}
+		}
+		if r.Header.Get("Authorization") == "" {
+			writeError(w, http.StatusUnauthorized, "authorization header required")
+			return
+		}
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if contentType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType,
+					"content type must be application/json")
+				return
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// handleClients serves the client collection.
+//
+//	GET  /api/v1/clients[?tag=dmz,win]
+//	POST /api/v1/clients
+func (s *RestServiceServer) handleClients(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.clientList(w, r)
+	case http.MethodPost:
+		s.clientRegister(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// handleClient routes every request below a single client.
+//
+//	DELETE /api/v1/clients/{clientID}[?ban=id|token]
+//	GET    /api/v1/clients/{clientID}/tunnels
+//	POST   /api/v1/clients/{clientID}/tunnels
+//	DELETE /api/v1/clients/{clientID}/tunnels/{tunnelID}
+//	GET    /api/v1/clients/{clientID}/tunnels/{tunnelID}/connections
+//	POST   /api/v1/clients/{clientID}/tunnels/{tunnelID}/pause[?freeze=true]
+//	POST   /api/v1/clients/{clientID}/tunnels/{tunnelID}/resume
+//	POST   /api/v1/clients/{clientID}/socks
+//	DELETE /api/v1/clients/{clientID}/socks
+//	PUT    /api/v1/clients/{clientID}/alias
+//	POST   /api/v1/clients/{clientID}/tags
+func (s *RestServiceServer) handleClient(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/clients/"), "/")
+	parts := strings.Split(path, "/")
+
+	if parts[0] == "" {
+		writeError(w, http.StatusNotFound, "client id required")
+		return
+	}
+	clientID := s.gServer.ResolveEndpointID(parts[0])
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodDelete:
+		s.clientDisconnect(w, r, clientID)
+	case len(parts) == 2 && parts[1] == "tunnels" && r.Method == http.MethodGet:
+		s.tunnelList(w, clientID)
+	case len(parts) == 2 && parts[1] == "tunnels" && r.Method == http.MethodPost:
+		s.tunnelAdd(w, r, clientID)
+	case len(parts) == 3 && parts[1] == "tunnels" && r.Method == http.MethodDelete:
+		s.tunnelDelete(w, clientID, parts[2])
+	case len(parts) == 4 && parts[1] == "tunnels" && parts[3] == "connections" &&
+		r.Method == http.MethodGet:
+		s.connectionList(w, clientID, parts[2])
+	case len(parts) == 4 && parts[1] == "tunnels" && parts[3] == "pause" &&
+		r.Method == http.MethodPost:
+		s.tunnelPause(w, r, clientID, parts[2])
+	case len(parts) == 4 && parts[1] == "tunnels" && parts[3] == "resume" &&
+		r.Method == http.MethodPost:
+		s.tunnelResume(w, clientID, parts[2])
+	case len(parts) == 2 && parts[1] == "socks" && r.Method == http.MethodPost:
+		s.socksStart(w, r, clientID)
+	case len(parts) == 2 && parts[1] == "socks" && r.Method == http.MethodDelete:
+		s.socksStop(w, clientID)
+	case len(parts) == 2 && parts[1] == "alias" && r.Method == http.MethodPut:
+		s.clientRename(w, r, clientID)
+	case len(parts) == 2 && parts[1] == "tags" && r.Method == http.MethodPost:
+		s.clientTag(w, r, clientID)
+	default:
+		writeError(w, http.StatusNotFound, "no such resource")
+	}
+}
+
+// clientList writes out all connected clients.
+func (s *RestServiceServer) clientList(w http.ResponseWriter, r *http.Request) {
+	common.Log.Debugf("REST ClientList called")
+
+	var tags []string
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		tags = strings.Split(tag, ",")
+	}
+
+	clients := make([]RestClient, 0)
+	for _, client := range s.gServer.getClients() {
+		if !s.gServer.EndpointHasTags(client.uniqueID, tags) {
+			continue
+		}
+		restClient := RestClient{
+			Name:          client.configuredClient.Name,
+			ClientID:      client.uniqueID,
+			Status:        1,
+			RemoteAddress: client.remoteAddr,
+			Hostname:      client.hostname,
+			ConnectDate:   client.connectDate.String(),
+			Country:       client.geo.Country,
+			ASN:           client.geo.ASN,
+			Organization:  client.geo.Organization,
+			OS:            client.os,
+			Arch:          client.arch,
+			Username:      client.username,
+			Addresses:     client.addresses,
+			LastSeen:      client.getLastSeen().Unix(),
+			State:         s.gServer.endpointState(client),
+			Alias:         s.gServer.EndpointAlias(client.uniqueID),
+			Tags:          s.gServer.EndpointTags(client.uniqueID),
+		}
+		clients = append(clients, restClient)
+	}
+
+	writeJSON(w, http.StatusOK, clients)
+}
+
+// clientRegister stores a new configured client.
+func (s *RestServiceServer) clientRegister(w http.ResponseWriter, r *http.Request) {
+	common.Log.Debugf("REST ClientRegister called")
+
+	req := new(RestClientRegisterRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.ClientID == "" || req.Token == "" {
+		writeError(w, http.StatusBadRequest, "client_id and token are required")
+		return
+	}
+
+	ip := net.ParseIP(req.IPAddress)
+	if ip == nil {
+		writeError(w, http.StatusBadRequest, "invalid ip_address")
+		return
+	}
+
+	configuredClient := new(ConfiguredClient)
+	configuredClient.Arch = req.Arch
+	configuredClient.Name = req.ClientID
+	configuredClient.Port = req.Port
+	configuredClient.Server = ip.String()
+	configuredClient.Token = req.Token
+	configuredClient.BinType = req.BinType
+	configuredClient.Platform = req.Platform
+	configuredClient.Proxy = req.ProxyServer
+
+	if err := s.gServer.RegisterClient(configuredClient); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+// clientDisconnect disconnects a gClient from the gServer and bans its
+// ID or token if requested.
+func (s *RestServiceServer) clientDisconnect(w http.ResponseWriter, r *http.Request,
+	clientID string) {
+	common.Log.Debugf("REST ClientDisconnect called")
+
+	ban := r.URL.Query().Get("ban")
+	banned := ban == "id" || ban == "token"
+	if banned {
+		if err := s.gServer.BanEndpoint(clientID, ban == "token"); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	err := s.gServer.DisconnectEndpoint(clientID)
+	if err != nil && !banned {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tunnelList writes out all tunnels for a client.
+func (s *RestServiceServer) tunnelList(w http.ResponseWriter, clientID string) {
+	common.Log.Debugf("REST TunnelList called")
+
+	endpoint, ok := s.gServer.GetEndpoint(clientID)
+	if !ok {
+		writeError(w, http.StatusNotFound,
+			fmt.Sprintf("client %s does not exist", clientID))
+		return
+	}
+
+	tunnels := make([]RestTunnel, 0)
+	for id, tunnel := range endpoint.GetTunnels() {
+		restTunnel := RestTunnel{
+			ID:                id,
+			Direction:         directionToString(tunnel.GetDirection()),
+			ListenIP:          tunnel.GetListenIP().String(),
+			ListenPort:        tunnel.GetListenPort(),
+			DestinationIP:     tunnel.GetDestinationIP().String(),
+			DestinationPort:   tunnel.GetDestinationPort(),
+			Command:           tunnel.GetOptions().Command,
+			DetectDeception:   tunnel.GetOptions().DetectDeception,
+			Hostname:          tunnel.GetOptions().Hostname,
+			Connections:       len(tunnel.GetConnections()),
+			BytesSent:         tunnel.GetBytesSent(),
+			BytesReceived:     tunnel.GetBytesReceived(),
+			Paused:            tunnel.IsPaused(),
+			TTL:               int64(tunnel.GetOptions().TTL / time.Second),
+			ByteLimit:         tunnel.GetOptions().ByteLimit,
+			Schedule:          tunnel.GetOptions().Schedule,
+			Timezone:          tunnel.GetOptions().Timezone,
+			ChunkSize:         tunnel.GetOptions().ChunkSize,
+			Nagle:             tunnel.GetOptions().Nagle,
+			TCPKeepalive:      int64(tunnel.GetOptions().TCPKeepalive / time.Second),
+			ReadBuffer:        uint32(tunnel.GetOptions().ReadBuffer),
+			WriteBuffer:       uint32(tunnel.GetOptions().WriteBuffer),
+			RateLimit:         tunnel.GetOptions().RateLimit,
+			IdleTimeout:       int64(tunnel.GetOptions().IdleTimeout / time.Second),
+			MaxConnections:    tunnel.GetOptions().MaxConnections,
+			Compress:          tunnel.GetOptions().Compress,
+			StreamCompression: tunnel.GetOptions().StreamCompression,
+			Encrypt:           tunnel.GetOptions().Encrypt,
+			RekeyInterval:     int64(tunnel.GetOptions().RekeyInterval / time.Second),
+			RekeyBytes:        tunnel.GetOptions().RekeyBytes,
+			PadSize:           tunnel.GetOptions().PadSize,
+			SendJitter:        int64(tunnel.GetOptions().SendJitter / time.Millisecond),
+			Hops:              tunnel.GetOptions().Hops,
+			Balance:           tunnel.GetOptions().Balance,
+			Failover:          tunnel.GetOptions().Failover,
+			HealthCheck:       int64(tunnel.GetOptions().HealthCheck / time.Second),
+			HealthBanner:      tunnel.GetOptions().HealthBanner,
+		}
+		if failures, lastFailure := tunnel.GetFailedConnections(); failures > 0 {
+			restTunnel.FailedConns = failures
+			restTunnel.LastError = lastFailure.String()
+		}
+		restTunnel.Health = tunnel.GetHealth().String()
+		tunnels = append(tunnels, restTunnel)
+	}
+
+	writeJSON(w, http.StatusOK, tunnels)
+}
+
+// tunnelAdd creates a new tunnel on a client.
+func (s *RestServiceServer) tunnelAdd(w http.ResponseWriter, r *http.Request,
+	clientID string) {
+	common.Log.Debugf("REST TunnelAdd called")
+
+	req := new(RestTunnel)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	direction, ok := directionFromString(req.Direction)
+	if !ok {
+		writeError(w, http.StatusBadRequest,
+			"invalid direction. Should be 'forward' or 'reverse'")
+		return
+	}
+
+	if req.Command != "" && direction != common.TunnelDirectionForward {
+		writeError(w, http.StatusBadRequest,
+			"commands are only supported for forward tunnels")
+		return
+	}
+
+	if req.ListenIP == "" {
+		req.ListenIP = "0.0.0.0"
+	}
+	listenIP := net.ParseIP(req.ListenIP)
+	destinationIP := net.ParseIP(req.DestinationIP)
+	if listenIP == nil || destinationIP == nil {
+		writeError(w, http.StatusBadRequest, "invalid listen_ip or destination_ip")
+		return
+	}
+
+	if req.LoopbackAlias {
+		if direction != common.TunnelDirectionForward {
+			writeError(w, http.StatusBadRequest,
+				"loopback aliases are only supported for forward tunnels")
+			return
+		}
+
+		var err error
+		listenIP, err = s.gServer.AllocateLoopbackAlias(destinationIP)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		req.ListenIP = listenIP.String()
+	}
+
+	if req.ID == "" {
+		req.ID = common.GenerateString(common.TunnelIDSize)
+	}
+
+	err := s.gServer.AddTunnel(
+		clientID,
+		req.ID,
+		direction,
+		listenIP,
+		req.ListenPort,
+		destinationIP,
+		req.DestinationPort,
+		req.options())
+
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, req)
+}
+
+// tunnelDelete deletes a tunnel from a client.
+func (s *RestServiceServer) tunnelDelete(w http.ResponseWriter, clientID string,
+	tunnelID string) {
+	common.Log.Debugf("REST TunnelDelete called")
+
+	if err := s.gServer.DeleteTunnel(clientID, tunnelID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tunnelPause stops a tunnel from accepting new connections.
+func (s *RestServiceServer) tunnelPause(w http.ResponseWriter, r *http.Request,
+	clientID string, tunnelID string) {
+	common.Log.Debugf("REST TunnelPause called")
+
+	freeze := r.URL.Query().Get("freeze") == "true"
+	if err := s.gServer.PauseTunnel(clientID, tunnelID, freeze); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tunnelResume resumes a paused tunnel.
+func (s *RestServiceServer) tunnelResume(w http.ResponseWriter, clientID string,
+	tunnelID string) {
+	common.Log.Debugf("REST TunnelResume called")
+
+	if err := s.gServer.ResumeTunnel(clientID, tunnelID); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// connectionList writes out all of the connections for a tunnel.
+func (s *RestServiceServer) connectionList(w http.ResponseWriter, clientID string,
+	tunnelID string) {
+	common.Log.Debugf("REST ConnectionList called")
+
+	endpoint, ok := s.gServer.GetEndpoint(clientID)
+	if !ok {
+		writeError(w, http.StatusNotFound,
+			fmt.Sprintf("client %s does not exist", clientID))
+		return
+	}
+
+	tunnel, ok := endpoint.GetTunnel(tunnelID)
+	if !ok {
+		writeError(w, http.StatusNotFound,
+			fmt.Sprintf("tunnel %s does not exist", tunnelID))
+		return
+	}
+
+	connections := make([]RestConnection, 0)
+	for _, connection := range tunnel.GetConnections() {
+		sourceIP, sourcePort := common.AddrToIPPort(connection.TCPConn.LocalAddr())
+		destIP, destPort := common.AddrToIPPort(connection.TCPConn.RemoteAddr())
+		restConnection := RestConnection{
+			SourceIP:           sourceIP.String(),
+			SourcePort:         sourcePort,
+			DestinationIP:      destIP.String(),
+			DestinationPort:    destPort,
+			Warnings:           connection.GetWarnings(),
+			ID:                 connection.ID,
+			BytesSent:          connection.GetBytesSent(),
+			BytesReceived:      connection.GetBytesReceived(),
+			StartTime:          connection.GetStartTime().Unix(),
+			OriginAddress:      connection.GetOriginAddress(),
+			DestinationAddress: connection.GetDestinationAddress(),
+		}
+		connections = append(connections, restConnection)
+	}
+
+	writeJSON(w, http.StatusOK, connections)
+}
+
+// socksStart starts a socks proxy on a client.
+func (s *RestServiceServer) socksStart(w http.ResponseWriter, r *http.Request,
+	clientID string) {
+	common.Log.Debugf("REST SocksStart called")
+
+	req := new(RestSocksRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.gServer.StartProxy(clientID, req.Port); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// socksStop stops the socks proxy on a client.
+func (s *RestServiceServer) socksStop(w http.ResponseWriter, clientID string) {
+	common.Log.Debugf("REST SocksStop called")
+
+	if err := s.gServer.StopProxy(clientID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientRename sets the alias of a client.
+func (s *RestServiceServer) clientRename(w http.ResponseWriter, r *http.Request,
+	clientID string) {
+	common.Log.Debugf("REST ClientRename called")
+
+	req := new(RestRenameRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.gServer.RenameEndpoint(clientID, req.Alias); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// clientTag adds tags to and removes tags from a client and writes out
+// its resulting tags.
+func (s *RestServiceServer) clientTag(w http.ResponseWriter, r *http.Request,
+	clientID string) {
+	common.Log.Debugf("REST ClientTag called")
+
+	req := new(RestTagRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tags, err := s.gServer.TagEndpoint(clientID, req.Add, req.Remove)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+}
+
+// directionToString converts a tunnel direction into its string form.
+func directionToString(direction uint32) string {
+	if direction == common.TunnelDirectionReverse {
+		return "reverse"
+	}
+	return "forward"
+}
+
+// directionFromString converts a string into a tunnel direction. An
+// empty string defaults to a forward tunnel.
+func directionFromString(direction string) (uint32, bool) {
+	switch direction {
+	case "", "forward":
+		return common.TunnelDirectionForward, true
+	case "reverse":
+		return common.TunnelDirectionReverse, true
+	}
+	return 0, false
+}
+
+// writeJSON serializes v as the response body with the provided status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		common.Log.Errorf("Failed to write json response: %s", err)
+	}
+}
+
+// writeError writes a JSON error body with the provided status.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, restError{Error: message})
+}
@@ -0,0 +1,52 @@
+package gserverlib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckWrites(t *testing.T) {
+	handler := checkWrites(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		method      string
+		origin      string
+		auth        string
+		contentType string
+		want        int
+	}{
+		{http.MethodGet, "https://elsewhere", "", "", http.StatusNoContent},
+		{http.MethodPost, "", "Bearer token", "application/json", http.StatusNoContent},
+		{http.MethodPost, "http://gserver:8080", "Bearer", "application/json; charset=utf-8", http.StatusNoContent},
+		{http.MethodDelete, "http://gserver:8080", "Bearer token", "", http.StatusNoContent},
+		// A form or fetch of another web page
+		{http.MethodPost, "https://elsewhere", "Bearer token", "application/json", http.StatusForbidden},
+		{http.MethodPost, "", "", "application/json", http.StatusUnauthorized},
+		{http.MethodPost, "", "Bearer token", "text/plain", http.StatusUnsupportedMediaType},
+		{http.MethodDelete, "", "", "", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "http://gserver:8080/api/v1/clients",
+			strings.NewReader("{}"))
+		for header, value := range map[string]string{
+			"Origin":        test.origin,
+			"Authorization": test.auth,
+			"Content-Type":  test.contentType,
+		} {
+			if value != "" {
+				req.Header.Set(header, value)
+			}
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != test.want {
+			t.Errorf("checkWrites(%s %q %q %q): Got: %d Want: %d", test.method,
+				test.origin, test.auth, test.contentType, w.Code, test.want)
+		}
+	}
+}
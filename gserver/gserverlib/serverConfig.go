@@ -0,0 +1,282 @@
+package gserverlib
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"sort"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// ServerConfig is the contents of a gServer configuration file. It is a
+// JSON object whose keys are the names of gServer command line flags,
+// such as "clientPort" or "cert_file", plus two lists:
+//
+//	"clients" are configured clients, each with a "name" and a "token",
+//	that may register in addition to those in the datastore.
+//
+//	"tunnels" are tunnels in the format of the rest api with an extra
+//	"client" key. Each endpoint of the configured client with that name
+//	gets the tunnel when it connects.
+type ServerConfig struct {
+	Settings map[string]string
+	Clients  []*ConfiguredClient
+	Tunnels  []*ConfigTunnel
+}
+
+// ConfigTunnel is a tunnel defined in the configuration file.
+type ConfigTunnel struct {
+	Client string `json:"client"`
+	RestTunnel
+}
+
+// LoadServerConfig is a constructor for ServerConfig that reads and
+// validates a configuration file.
+func LoadServerConfig(path string) (*ServerConfig, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseServerConfig(buf)
+}
+
+// ParseServerConfig is a constructor for ServerConfig that parses and
+// validates the contents of a configuration file.
+func ParseServerConfig(buf []byte) (*ServerConfig, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &values); err != nil {
+		return nil, fmt.Errorf("invalid json: %v", err)
+	}
+
+	c := new(ServerConfig)
+	c.Settings = make(map[string]string)
+
+	for key, value := range values {
+		var err error
+		switch key {
+		case "clients":
+			err = strictUnmarshal(value, &c.Clients)
+		case "tunnels":
+			err = strictUnmarshal(value, &c.Tunnels)
+		default:
+			// Strings are used as they are, anything else as written
+			var setting string
+			if json.Unmarshal(value, &setting) != nil {
+				setting = string(value)
+			}
+			c.Settings[key] = setting
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", key, err)
+		}
+	}
+
+	for i, client := range c.Clients {
+		if client.Name == "" || client.Token == "" {
+			return nil, fmt.Errorf("clients[%d]: name and token are required", i)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for i, tunnel := range c.Tunnels {
+		if err := tunnel.validate(); err != nil {
+			return nil, fmt.Errorf("tunnels[%d]: %v", i, err)
+		}
+		key := tunnel.Client + "/" + tunnel.ID
+		if ids[key] {
+			return nil, fmt.Errorf("tunnels[%d]: duplicate id %q for client %s",
+				i, tunnel.ID, tunnel.Client)
+		}
+		ids[key] = true
+	}
+
+	return c, nil
+}
+
+// strictUnmarshal decodes json and rejects unknown keys, which are most
+// likely misspelled.
+func strictUnmarshal(buf []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// validate checks a configured tunnel and fills in its defaults.
+func (t *ConfigTunnel) validate() error {
+	if t.Client == "" {
+		return fmt.Errorf("client is required")
+	}
+
+	direction, ok := directionFromString(t.Direction)
+	if !ok {
+		return fmt.Errorf("invalid direction %q. Should be 'forward' or 'reverse'",
+			t.Direction)
+	}
+
+	if t.ListenIP == "" {
+		t.ListenIP = "0.0.0.0"
+	}
+	if net.ParseIP(t.ListenIP) == nil {
+		return fmt.Errorf("invalid listen_ip %q", t.ListenIP)
+	}
+	if net.ParseIP(t.DestinationIP) == nil {
+		return fmt.Errorf("invalid destination_ip %q", t.DestinationIP)
+	}
+	if t.ListenPort == 0 || t.ListenPort > 65535 {
+		return fmt.Errorf("invalid listen_port %d", t.ListenPort)
+	}
+	if t.DestinationPort == 0 || t.DestinationPort > 65535 {
+		return fmt.Errorf("invalid destination_port %d", t.DestinationPort)
+	}
+	if t.LoopbackAlias && direction != common.TunnelDirectionForward {
+		return fmt.Errorf("loopback aliases are only supported for forward tunnels")
+	}
+	if t.ChunkSize > common.MaxChunkSize {
+		return fmt.Errorf("chunk_size is larger than %d", common.MaxChunkSize)
+	}
+	if t.Schedule != "" {
+		if _, err := common.ParseTimeWindow(t.Schedule, t.Timezone); err != nil {
+			return fmt.Errorf("invalid schedule: %v", err)
+		}
+	}
+
+	if t.ID == "" {
+		t.ID = t.derivedID(direction)
+	}
+	return nil
+}
+
+// derivedID returns the ID of a tunnel that has none in the
+// configuration file. It is derived from the definition of the tunnel,
+// so that the tunnel keeps its ID when the file is reloaded.
+func (t *ConfigTunnel) derivedID(direction uint32) string {
+	definition := fmt.Sprintf("%s %d %s %d %s %d", t.Client, direction,
+		net.ParseIP(t.ListenIP), t.ListenPort,
+		net.ParseIP(t.DestinationIP), t.DestinationPort)
+	sum := sha256.Sum256([]byte(definition))
+	return hex.EncodeToString(sum[:])[:common.TunnelIDSize]
+}
+
+// SetFlags returns the names of the flags of a flag set that were set,
+// which are the flags given on the command line if it is called right
+// after parsing.
+func SetFlags(flags *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// ApplyFlags sets the flags of a flag set to the settings of the
+// configuration file. The flags in keep, usually those given on the
+// command line, keep their value.
+func (c *ServerConfig) ApplyFlags(flags *flag.FlagSet, keep map[string]bool) error {
+	// Sorted so that the first error is always the same
+	names := make([]string, 0, len(c.Settings))
+	for name := range c.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if flags.Lookup(name) == nil {
+			return fmt.Errorf("unknown setting %q", name)
+		}
+		if keep[name] {
+			continue
+		}
+		if err := flags.Set(name, c.Settings[name]); err != nil {
+			return fmt.Errorf("invalid value %q for %s: %v", c.Settings[name],
+				name, err)
+		}
+	}
+	return nil
+}
+
+// ApplyConfig adds the clients and tunnels of a configuration file to
+// the server.
+func (s *GServer) ApplyConfig(config *ServerConfig) {
+	for _, client := range config.Clients {
+		s.configStore.LoadConfiguredClient(client)
+	}
+
+	s.configMutex.Lock()
+	s.configTunnels = config.Tunnels
+	s.configMutex.Unlock()
+}
+
+// ReloadConfig applies a configuration file that was read again while
+// the server is running. Connected endpoints get the tunnels that were
+// added to it. Tunnels that were removed from it are left alone.
+func (s *GServer) ReloadConfig(config *ServerConfig) {
+	s.ApplyConfig(config)
+
+	for clientID, client := range s.getClients() {
+		go s.addConfigTunnels(clientID, client)
+	}
+}
+
+// isConfigTunnel returns true if a tunnel of an endpoint is defined in
+// the configuration file.
+func (s *GServer) isConfigTunnel(clientID string, tunnelID string) bool {
+	client, ok := s.getClient(clientID)
+	if !ok || client.configuredClient == nil {
+		return false
+	}
+
+	s.configMutex.Lock()
+	defer s.configMutex.Unlock()
+
+	for _, tunnel := range s.configTunnels {
+		if tunnel.Client == client.configuredClient.Name && tunnel.ID == tunnelID {
+			return true
+		}
+	}
+	return false
+}
+
+// addConfigTunnels adds the tunnels of the configuration file to an
+// endpoint that connected, unless it already has them.
+func (s *GServer) addConfigTunnels(clientID string, client *ConnectedClient) {
+	if client.configuredClient == nil {
+		return
+	}
+
+	s.configMutex.Lock()
+	tunnels := s.configTunnels
+	s.configMutex.Unlock()
+
+	for _, tunnel := range tunnels {
+		if tunnel.Client != client.configuredClient.Name {
+			continue
+		}
+		if _, ok := client.endpoint.GetTunnel(tunnel.ID); ok {
+			continue
+		}
+
+		direction, _ := directionFromString(tunnel.Direction)
+		listenIP := net.ParseIP(tunnel.ListenIP)
+		destinationIP := net.ParseIP(tunnel.DestinationIP)
+
+		var err error
+		if tunnel.LoopbackAlias {
+			listenIP, err = s.AllocateLoopbackAlias(destinationIP)
+		}
+		if err == nil {
+			err = s.AddTunnel(clientID, tunnel.ID, direction, listenIP,
+				tunnel.ListenPort, destinationIP, tunnel.DestinationPort,
+				tunnel.options())
+		}
+		if err != nil {
+			common.Log.WithEndpoint(clientID).WithTunnel(tunnel.ID).Warnf(
+				"Failed to add configured tunnel: %v", err)
+		}
+	}
+}
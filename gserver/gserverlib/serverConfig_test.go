@@ -0,0 +1,108 @@
+package gserverlib
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerConfig(t *testing.T) {
+	config, err := ParseServerConfig([]byte(`{
+		"clientPort": 8443,
+		"logLevel": "debug",
+		"tls": false,
+		"clients": [{"name": "office", "token": "secret"}],
+		"tunnels": [{"client": "office", "listen_port": 8080,
+			"destination_ip": "10.0.0.1", "destination_port": 80}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseServerConfig: %v", err)
+	}
+	if len(config.Tunnels) != 1 || config.Tunnels[0].ID == "" ||
+		config.Tunnels[0].ListenIP != "0.0.0.0" {
+		t.Errorf("tunnels: Got: %+v", config.Tunnels)
+	}
+
+	// Tunnels without an ID keep theirs when the file is reloaded
+	reloaded, _ := ParseServerConfig([]byte(`{"tunnels": [
+		{"client": "office", "listen_port": 8080,
+			"destination_ip": "10.0.0.1", "destination_port": 80},
+		{"client": "office", "listen_port": 8081,
+			"destination_ip": "10.0.0.1", "destination_port": 80}]}`))
+	if reloaded.Tunnels[0].ID != config.Tunnels[0].ID {
+		t.Errorf("reload: Got: %s Want: %s", reloaded.Tunnels[0].ID,
+			config.Tunnels[0].ID)
+	}
+	if reloaded.Tunnels[1].ID == config.Tunnels[0].ID {
+		t.Errorf("reload: another tunnel got the same ID %s", config.Tunnels[0].ID)
+	}
+
+	flags := flag.NewFlagSet("gserver", flag.ContinueOnError)
+	clientPort := flags.Int("clientPort", 443, "")
+	logLevel := flags.String("logLevel", "info", "")
+	tls := flags.Bool("tls", true, "")
+	flags.Parse([]string{"-logLevel", "warn"})
+
+	if err := config.ApplyFlags(flags, SetFlags(flags)); err != nil {
+		t.Fatalf("ApplyFlags: %v", err)
+	}
+	if *clientPort != 8443 || *logLevel != "warn" || *tls {
+		t.Errorf("flags: Got: %d %s %t Want: 8443 warn false",
+			*clientPort, *logLevel, *tls)
+	}
+}
+
+func TestServerConfigErrors(t *testing.T) {
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{`{"tunnels": [{"client": "office", "listen_port": 8080,
+			"destination_ip": "nowhere", "destination_port": 80}]}`,
+			`tunnels[0]: invalid destination_ip "nowhere"`},
+		{`{"tunnels": [{"client": "office", "listenport": 8080}]}`,
+			`tunnels: json: unknown field "listenport"`},
+		{`{"clients": [{"name": "office"}]}`,
+			`clients[0]: name and token are required`},
+		{`{"tunnels": [{"client": "office", "id": "web", "listen_port": 8080,
+			"destination_ip": "10.0.0.1", "destination_port": 80},
+			{"client": "office", "id": "web", "listen_port": 8081,
+			"destination_ip": "10.0.0.1", "destination_port": 80}]}`,
+			`tunnels[1]: duplicate id "web" for client office`},
+	}
+
+	for _, test := range tests {
+		_, err := ParseServerConfig([]byte(test.config))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("ParseServerConfig: Got: %v Want: %s", err, test.want)
+		}
+	}
+
+	config, _ := ParseServerConfig([]byte(`{"clientport": 8443}`))
+	flags := flag.NewFlagSet("gserver", flag.ContinueOnError)
+	flags.Int("clientPort", 443, "")
+	if err := config.ApplyFlags(flags, nil); err == nil ||
+		!strings.Contains(err.Error(), `unknown setting "clientport"`) {
+		t.Errorf("ApplyFlags: Got: %v", err)
+	}
+}
+
+func TestConfigTunnelNotStored(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	s.connectedClients["endpoint"].configuredClient = &ConfiguredClient{Name: "office"}
+	config, _ := ParseServerConfig([]byte(`{"tunnels": [{"client": "office",
+		"id": "web", "listen_port": 8080,
+		"destination_ip": "10.0.0.1", "destination_port": 80}]}`))
+	s.configTunnels = config.Tunnels
+
+	if !s.isConfigTunnel("endpoint", "web") {
+		t.Errorf("isConfigTunnel(web): Got: false Want: true")
+	}
+	if s.isConfigTunnel("endpoint", "other") {
+		t.Errorf("isConfigTunnel(other): Got: true Want: false")
+	}
+	if s.isConfigTunnel("missing", "web") {
+		t.Errorf("isConfigTunnel(missing): Got: true Want: false")
+	}
+}
@@ -0,0 +1,13 @@
+package gserverlib
+
+import (
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// SetServerKey sets the static key with which gServer authenticates
+// the key exchange of encrypted tunnels. Only gClients built with its
+// public key can open encrypted tunnels. It has to be called before
+// Start.
+func (s *GServer) SetServerKey(key *common.ServerKey) {
+	s.serverKey = key
+}
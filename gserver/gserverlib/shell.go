@@ -0,0 +1,76 @@
+package gserverlib
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// ShellEndpoint runs an interactive shell on an endpoint until the
+// shell exits or ctx is cancelled. Input is called for what is written
+// to the shell until it returns io.EOF, which closes the input of the
+// shell. Output is called with what the shell writes.
+func (s *GServer) ShellEndpoint(ctx context.Context, clientID string,
+	input func() ([]byte, error), output func(data []byte) error) error {
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return fmt.Errorf("shell failed - client does not exist")
+	}
+	if s.isLost(client) {
+		return fmt.Errorf("shell failed - client lost its control stream")
+	}
+	if !common.HasCapability(client.capabilities, common.CapabilityShell) {
+		return fmt.Errorf("shell failed - client was built without shell support")
+	}
+
+	controlMessage := new(cs.EndpointControlMessage)
+	controlMessage.Operation = common.EndpointCtrlShell
+	stream, p, err := s.openProbeStream(clientID, client, controlMessage, "shell")
+	if err != nil {
+		return fmt.Errorf("shell failed - %v", err)
+	}
+	defer s.forgetProbe(p)
+	p.closeOnCancel(ctx)
+
+	common.Log.WithEndpoint(clientID).Infof("Shell started")
+	defer common.Log.WithEndpoint(clientID).Infof("Shell ended")
+
+	go func() {
+		for {
+			data, err := input()
+			if err == io.EOF {
+				message := new(cs.BytesMessage)
+				message.HalfClose = true
+				stream.Send(message)
+				return
+			} else if err != nil {
+				p.close()
+				return
+			}
+			message := new(cs.BytesMessage)
+			message.Content = data
+			if err := stream.Send(message); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("shell failed - %v", err)
+		}
+		if message.Error != "" {
+			return fmt.Errorf("shell failed - %s", message.Error)
+		}
+		if err := output(message.Content); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package gserverlib
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+const (
+	// DefaultDrainTimeout is how long a shutdown waits for active
+	// connections to finish.
+	DefaultDrainTimeout = 30 * time.Second
+
+	// shutdownNoticeTimeout is how long a shutdown waits for the
+	// control stream of an endpoint to take the shutdown notice.
+	shutdownNoticeTimeout = time.Second
+)
+
+// errShuttingDown is returned for requests refused during a shutdown.
+var errShuttingDown = errors.New("server is shutting down")
+
+// Shutdown stops the server gracefully. New tunnels and connections
+// are refused and endpoints are told that the server is going away.
+// Active connections get up to timeout to finish before the client
+// gRPC server is stopped.
+func (s *GServer) Shutdown(timeout time.Duration) {
+	if !atomic.CompareAndSwapInt32(&s.shuttingDown, 0, 1) {
+		return
+	}
+	common.Log.Infof("Shutting down, draining connections for up to %s", timeout)
+
+	for clientID, client := range s.getClients() {
+		for _, tunnel := range client.endpoint.GetTunnels() {
+			tunnel.CloseListeners()
+		}
+
+		if s.isLost(client) {
+			continue
+		}
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlShutdown
+		select {
+		case client.endpointInput <- controlMessage:
+		case <-time.After(shutdownNoticeTimeout):
+			common.Log.WithEndpoint(clientID).Warnf(
+				"Endpoint did not take the shutdown notice")
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		active := s.activeConnections()
+		if active == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			common.Log.Warnf("Dropping %d connections that did not finish", active)
+			break
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+
+	if s.clientServer != nil && s.clientServer.grpcServer != nil {
+		grpcServer := s.clientServer.grpcServer
+		// Streams of connections that did not finish would keep a
+		// graceful stop waiting forever.
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			grpcServer.Stop()
+		}
+	}
+	common.Log.Infof("Shutdown complete")
+}
+
+// isShuttingDown returns true once Shutdown was called.
+func (s *GServer) isShuttingDown() bool {
+	return atomic.LoadInt32(&s.shuttingDown) == 1
+}
+
+// activeConnections returns the number of connections of all tunnels.
+func (s *GServer) activeConnections() int {
+	active := 0
+	for _, client := range s.getClients() {
+		for _, tunnel := range client.endpoint.GetTunnels() {
+			active += tunnel.ConnectionCount()
+		}
+	}
+	return active
+}
@@ -0,0 +1,34 @@
+package gserverlib
+
+import (
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestShutdown(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 1)
+
+	s.Shutdown(time.Second)
+
+	select {
+	case message := <-client.endpointInput:
+		if message.Operation != common.EndpointCtrlShutdown {
+			t.Errorf("Shutdown: Got: operation %d Want: %d",
+				message.Operation, common.EndpointCtrlShutdown)
+		}
+	default:
+		t.Errorf("Shutdown: endpoint was not notified")
+	}
+
+	err := s.AddTunnel("endpoint", "tunnel", common.TunnelDirectionReverse,
+		nil, 8080, nil, 80, common.TunnelOptions{})
+	if err != errShuttingDown {
+		t.Errorf("AddTunnel: Got: %v Want: %v", err, errShuttingDown)
+	}
+}
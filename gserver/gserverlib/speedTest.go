@@ -0,0 +1,141 @@
+package gserverlib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// defaultSpeedTestBytes is the number of bytes sent in each direction
+// if none is given.
+const defaultSpeedTestBytes = 10 * 1024 * 1024
+
+// maxSpeedTestBytes limits the number of bytes per direction.
+const maxSpeedTestBytes = 1024 * 1024 * 1024
+
+// SpeedTestResult holds the time it took to send a number of bytes to
+// an endpoint and to receive as many from it.
+type SpeedTestResult struct {
+	Bytes    uint64
+	Upload   time.Duration
+	Download time.Duration
+}
+
+// watchedStream releases the test stream of a probe if it makes no
+// progress within a timeout.
+type watchedStream struct {
+	common.ByteStream
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (w *watchedStream) Send(message *cs.BytesMessage) error {
+	err := w.ByteStream.Send(message)
+	w.timer.Reset(w.timeout)
+	return err
+}
+
+func (w *watchedStream) Recv() (*cs.BytesMessage, error) {
+	message, err := w.ByteStream.Recv()
+	w.timer.Reset(w.timeout)
+	return message, err
+}
+
+// SpeedTestEndpoint measures the throughput to and from an endpoint by
+// sending size bytes over a temporary byte stream and receiving as many
+// over another one.
+func (s *GServer) SpeedTestEndpoint(clientID string,
+	size uint64) (*SpeedTestResult, error) {
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return nil, fmt.Errorf("speed test failed - client does not exist")
+	}
+	if s.isLost(client) {
+		return nil, fmt.Errorf("speed test failed - client lost its control stream")
+	}
+	if !common.HasCapability(client.capabilities, common.CapabilitySpeedTest) {
+		return nil, fmt.Errorf("speed test failed - client does not support speed tests")
+	}
+
+	result := new(SpeedTestResult)
+	result.Bytes = size
+
+	var err error
+	result.Upload, err = s.runSpeedTest(clientID, client,
+		common.StreamTestSink, size, measureUpload)
+	if err != nil {
+		return nil, fmt.Errorf("speed test failed - upload: %v", err)
+	}
+	result.Download, err = s.runSpeedTest(clientID, client,
+		common.StreamTestSource, size, measureDownload)
+	if err != nil {
+		return nil, fmt.Errorf("speed test failed - download: %v", err)
+	}
+	return result, nil
+}
+
+// runSpeedTest opens a test stream to an endpoint and measures one
+// direction over it.
+func (s *GServer) runSpeedTest(clientID string, client *ConnectedClient,
+	test string, size uint64,
+	measure func(common.ByteStream, uint64) (time.Duration, error)) (time.Duration, error) {
+
+	stream, p, err := s.openTestStream(clientID, client, test, size)
+	if err != nil {
+		return 0, err
+	}
+	defer s.forgetProbe(p)
+
+	watched := s.watchStream(stream, p, client)
+	defer watched.timer.Stop()
+
+	return measure(watched, size)
+}
+
+// watchStream returns the test stream of a probe that is released if it
+// makes no progress within the probe timeout. The caller must stop the
+// timer of the returned stream.
+func (s *GServer) watchStream(stream common.ByteStream, p *probe,
+	client *ConnectedClient) *watchedStream {
+
+	timeout := s.probeTimeout(client)
+	watched := new(watchedStream)
+	watched.ByteStream = stream
+	watched.timeout = timeout
+	watched.timer = time.AfterFunc(timeout, p.close)
+	return watched
+}
+
+// measureUpload sends size bytes to the sink stream of an endpoint and
+// returns how long it took until the endpoint had them all.
+func measureUpload(stream common.ByteStream, size uint64) (time.Duration, error) {
+	start := time.Now()
+	if err := common.SendTestBytes(stream, size); err != nil {
+		return 0, err
+	}
+	if _, err := stream.Recv(); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// measureDownload receives size bytes from the source stream of an
+// endpoint and returns how long it took.
+func measureDownload(stream common.ByteStream, size uint64) (time.Duration, error) {
+	start := time.Now()
+	if err := stream.Send(new(cs.BytesMessage)); err != nil {
+		return 0, err
+	}
+	var received uint64
+	for received < size {
+		message, err := stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		received += uint64(len(message.Content))
+	}
+	return time.Since(start), nil
+}
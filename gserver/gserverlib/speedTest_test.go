@@ -0,0 +1,69 @@
+package gserverlib
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// chanStream is one end of an in-memory byte stream.
+type chanStream struct {
+	send chan<- *cs.BytesMessage
+	recv <-chan *cs.BytesMessage
+}
+
+func (s *chanStream) Send(message *cs.BytesMessage) error {
+	s.send <- message
+	return nil
+}
+
+func (s *chanStream) Recv() (*cs.BytesMessage, error) {
+	message, ok := <-s.recv
+	if !ok {
+		return nil, io.EOF
+	}
+	return message, nil
+}
+
+// testStreamPair returns the gServer end of a test stream that an
+// endpoint serves as test says, and a function that closes it.
+func testStreamPair(test string, size uint64) (common.ByteStream, func()) {
+	toEndpoint := make(chan *cs.BytesMessage, 4)
+	toServer := make(chan *cs.BytesMessage, 4)
+	endpoint := &chanStream{send: toServer, recv: toEndpoint}
+	go common.ServeTestStream(endpoint, test, size)
+	return &chanStream{send: toEndpoint, recv: toServer}, func() { close(toEndpoint) }
+}
+
+func TestSpeedTest(t *testing.T) {
+	// Sizes that are not a multiple of the chunk size still finish
+	size := uint64(3*common.StreamTestChunkSize + 100)
+
+	stream, done := testStreamPair(common.StreamTestSink, size)
+	if _, err := measureUpload(stream, size); err != nil {
+		t.Errorf("measureUpload: %v", err)
+	}
+	done()
+
+	stream, done = testStreamPair(common.StreamTestSource, size)
+	if _, err := measureDownload(stream, size); err != nil {
+		t.Errorf("measureDownload: %v", err)
+	}
+	done()
+
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	s.SetControlTimeout(50 * time.Millisecond)
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 1)
+	client.capabilities = []string{common.CapabilityPing}
+	s.connectedClients["endpoint"] = client
+	if _, err := s.SpeedTestEndpoint("endpoint", size); err == nil {
+		t.Errorf("SpeedTestEndpoint: tested an endpoint without speed test support")
+	}
+}
@@ -0,0 +1,179 @@
+package gserverlib
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+const (
+	// otlpBatchSize is the number of spans sent in a single request.
+	otlpBatchSize = 128
+
+	// otlpFlushInterval is how often queued spans are sent.
+	otlpFlushInterval = 5 * time.Second
+
+	// otlpQueueSize is the number of spans that may be queued before
+	// new spans are dropped.
+	otlpQueueSize = 4096
+)
+
+// OTLPExporter sends spans to an OpenTelemetry collector using the
+// OTLP/HTTP protocol with JSON encoding.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+	spans    chan *common.Span
+}
+
+// NewOTLPExporter is a constructor for OTLPExporter. The endpoint is
+// the traces url of the collector, such as
+// http://localhost:4318/v1/traces.
+func NewOTLPExporter(endpoint string) *OTLPExporter {
+	e := new(OTLPExporter)
+	e.endpoint = endpoint
+	e.client = &http.Client{Timeout: 10 * time.Second}
+	e.spans = make(chan *common.Span, otlpQueueSize)
+
+	go e.run()
+	return e
+}
+
+// Export queues a span to be sent. It is a common.SpanExporter and
+// never blocks, spans are dropped if the queue is full.
+func (e *OTLPExporter) Export(span *common.Span) {
+	select {
+	case e.spans <- span:
+	default:
+		common.Log.Debugf("Dropped span %s, export queue is full", span.Name)
+	}
+}
+
+// run sends queued spans in batches.
+func (e *OTLPExporter) run() {
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*common.Span, 0, otlpBatchSize)
+	for {
+		select {
+		case span := <-e.spans:
+			batch = append(batch, span)
+			if len(batch) < otlpBatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+
+		if err := e.send(batch); err != nil {
+			common.Log.Warnf("Failed to export %d spans: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+}
+
+// otlpAttribute is a key/value pair in the OTLP JSON encoding.
+type otlpAttribute struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+// otlpSpan is a span in the OTLP JSON encoding.
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+func newOTLPAttribute(key string, value string) otlpAttribute {
+	var a otlpAttribute
+	a.Key = key
+	a.Value.StringValue = value
+	return a
+}
+
+// send posts a batch of spans to the collector.
+func (e *OTLPExporter) send(batch []*common.Span) error {
+	spans := make([]otlpSpan, 0, len(batch))
+	for _, span := range batch {
+		var s otlpSpan
+		s.TraceID = span.TraceID
+		s.SpanID = span.SpanID
+		s.ParentSpanID = span.ParentID
+		s.Name = span.Name
+		// SPAN_KIND_INTERNAL
+		s.Kind = 1
+		s.StartTimeUnixNano = fmt.Sprint(span.Start.UnixNano())
+		s.EndTimeUnixNano = fmt.Sprint(span.End.UnixNano())
+
+		keys := make([]string, 0, len(span.Attributes))
+		for key := range span.Attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			s.Attributes = append(s.Attributes,
+				newOTLPAttribute(key, span.Attributes[key]))
+		}
+
+		if span.Error != "" {
+			// STATUS_CODE_ERROR
+			s.Status.Code = 2
+			s.Status.Message = span.Error
+		}
+		spans = append(spans, s)
+	}
+
+	request := map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []otlpAttribute{
+						newOTLPAttribute("service.name", "gserver"),
+					},
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]string{"name": "gtunnel"},
+						"spans": spans,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package gserverlib
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/kai5263499/gtunnel/common"
+	"google.golang.org/grpc"
+)
+
+// transportListener hands the connections of transports that are not
+// plain TCP, such as WebSockets, to the gRPC server of the client
+// service.
+type transportListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newTransportListener is a constructor for transportListener.
+func newTransportListener(addr net.Addr) *transportListener {
+	l := new(transportListener)
+	l.addr = addr
+	l.conns = make(chan net.Conn)
+	l.done = make(chan struct{})
+	return l
+}
+
+// Accept returns the next connection.
+func (l *transportListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, fmt.Errorf("transport listener closed")
+	}
+}
+
+// put passes conn to the gRPC server. It returns false if the listener
+// is closed.
+func (l *transportListener) put(conn net.Conn) bool {
+	select {
+	case l.conns <- conn:
+		return true
+	case <-l.done:
+		return false
+	}
+}
+
+// Close stops handing out connections.
+func (l *transportListener) Close() error {
+	l.closeOnce.Do(func() { close(l.done) })
+	return nil
+}
+
+// Addr returns the address of the port of the transport.
+func (l *transportListener) Addr() net.Addr {
+	return l.addr
+}
+
+// serveTransport serves grpcServer to an HTTP based transport on port.
+// newHandler returns the handler of path, which passes the connections
+// of the transport to the listener.
+func (s *ClientServiceServer) serveTransport(grpcServer *grpc.Server,
+	name string,
+	port int,
+	tls bool,
+	certFile string,
+	keyFile string,
+	path string,
+	newHandler func(l *transportListener) http.Handler) {
+
+	common.Log.Infof("Starting client %s server on port: %d", name, port)
+	lis, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", port))
+	if err != nil {
+		common.Log.Errorf("Failed to listen for %s: %v", name, err)
+		return
+	}
+	lis = &allowlistListener{Listener: lis, gServer: s.gServer}
+
+	tl := newTransportListener(lis.Addr())
+	go grpcServer.Serve(tl)
+
+	mux := http.NewServeMux()
+	mux.Handle(path, newHandler(tl))
+	server := &http.Server{Handler: mux}
+
+	if tls {
+		err = server.ServeTLS(lis, certFile, keyFile)
+	} else {
+		err = server.Serve(lis)
+	}
+	common.Log.Errorf("The %s server stopped: %v", name, err)
+}
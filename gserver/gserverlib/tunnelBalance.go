@@ -0,0 +1,33 @@
+package gserverlib
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// balancer returns the balancer of the forward tunnels that listen on
+// listenIP:listenPort in mode, which is created if none of them is
+// left. A tunnel cannot join a group that balances in another mode.
+func (s *GServer) balancer(listenIP net.IP, listenPort uint32,
+	mode string) (*common.Balancer, error) {
+
+	s.balanceMutex.Lock()
+	defer s.balanceMutex.Unlock()
+
+	key := net.JoinHostPort(listenIP.String(), fmt.Sprint(listenPort))
+	if b, ok := s.balancers[key]; ok && b.Len() > 0 {
+		if b.GetMode() != mode {
+			return nil, fmt.Errorf("the tunnels on %s balance in %s mode",
+				key, b.GetMode())
+		}
+		return b, nil
+	}
+	if s.balancers == nil {
+		s.balancers = make(map[string]*common.Balancer)
+	}
+	b := common.NewBalancer(mode)
+	s.balancers[key] = b
+	return b, nil
+}
@@ -0,0 +1,96 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestTunnelBalance(t *testing.T) {
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	for _, id := range []string{"a", "b", "c"} {
+		client := new(ConnectedClient)
+		client.endpoint = common.NewEndpoint()
+		client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+		s.connectedClients[id] = client
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := uint32(ln.Addr().(*net.TCPAddr).Port)
+	ln.Close()
+
+	add := func(id string, mode string) error {
+		return s.AddTunnel(id, "web", common.TunnelDirectionForward,
+			net.ParseIP("127.0.0.1"), port, net.ParseIP("10.0.0.1"), 80,
+			common.TunnelOptions{Balance: mode})
+	}
+	for _, id := range []string{"a", "b"} {
+		if err := add(id, common.BalanceLeastConnections); err != nil {
+			t.Fatalf("AddTunnel on %s: %v", id, err)
+		}
+	}
+	b, err := s.balancer(net.ParseIP("127.0.0.1"), port, common.BalanceLeastConnections)
+	if err != nil || b.Len() != 2 {
+		t.Errorf("balancer: Got: %v Want: both tunnels", err)
+	}
+	if err := add("c", common.BalanceRoundRobin); err == nil {
+		t.Errorf("AddTunnel: joined a group in another mode")
+	}
+
+	err = s.AddTunnel("c", "reverse", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), port, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{Balance: common.BalanceRoundRobin})
+	if err == nil {
+		t.Errorf("AddTunnel: balanced a reverse tunnel")
+	}
+
+	// A group that is gone may be started again in another mode
+	for _, id := range []string{"a", "b"} {
+		if err := s.DeleteTunnel(id, "web"); err != nil {
+			t.Fatalf("DeleteTunnel on %s: %v", id, err)
+		}
+	}
+	if err := add("c", common.BalanceRoundRobin); err != nil {
+		t.Errorf("AddTunnel after the group was deleted: %v", err)
+	}
+	s.DeleteTunnel("c", "web")
+}
+
+func TestTunnelBalanceListenFailure(t *testing.T) {
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	client := new(ConnectedClient)
+	client.endpoint = common.NewEndpoint()
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+	s.connectedClients["a"] = client
+
+	// The port is taken by a listener that does not share it
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	port := uint32(ln.Addr().(*net.TCPAddr).Port)
+
+	add := func(mode string) error {
+		return s.AddTunnel("a", "web", common.TunnelDirectionForward,
+			net.ParseIP("127.0.0.1"), port, net.ParseIP("10.0.0.1"), 80,
+			common.TunnelOptions{Balance: mode})
+	}
+	if err := add(common.BalanceLeastConnections); err == nil {
+		t.Fatalf("AddTunnel: succeeded on a port that is taken")
+	}
+	b, _ := s.balancer(net.ParseIP("127.0.0.1"), port, common.BalanceLeastConnections)
+	if b.Len() != 0 {
+		t.Errorf("balancer: Got: %d tunnels Want: 0 after the listener failed", b.Len())
+	}
+	if _, ok := client.endpoint.GetTunnel("web"); ok {
+		t.Errorf("the tunnel was kept after the listener failed")
+	}
+}
@@ -0,0 +1,51 @@
+package gserverlib
+
+import (
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// checkTunnelHealth checks the destination of a forward tunnel every
+// HealthCheck and emits an event whenever it goes down or comes back
+// up. It returns once the tunnel is deleted.
+func (s *GServer) checkTunnelHealth(clientID string, tunnelID string,
+	tunnel *common.Tunnel) {
+
+	options := tunnel.GetOptions()
+	ticker := time.NewTicker(options.HealthCheck)
+	defer ticker.Stop()
+
+	up := true
+	for {
+		select {
+		case <-ticker.C:
+		case <-tunnel.Context().Done():
+			return
+		}
+		// A paused tunnel is closed on purpose
+		if tunnel.IsPaused() {
+			continue
+		}
+
+		health := tunnel.CheckHealth(options.HealthBanner)
+		if tunnel.Context().Err() != nil {
+			return
+		}
+		if health.Up == up {
+			continue
+		}
+		up = health.Up
+		if up {
+			common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Infof(
+				"Destination is up again")
+			s.emitEvent(EventDestinationUp, clientID, tunnelID,
+				"destination is up again")
+		} else {
+			common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf(
+				"Destination is down: %s", health.Message)
+			s.emitEvent(EventDestinationDown, clientID, tunnelID,
+				health.String())
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package gserverlib
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/kai5263499/gtunnel/common"
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+)
+
+// relayID identifies the relays of a multi-hop tunnel on its hops,
+// since tunnel IDs are only unique per endpoint.
+func relayID(clientID string, tunnelID string) string {
+	return clientID + "/" + tunnelID
+}
+
+// parseHops parses the hops of a tunnel and resolves the aliases of
+// their endpoints.
+func (s *GServer) parseHops(hops string) ([]common.TunnelHop, error) {
+	parsed, err := common.ParseTunnelHops(hops)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parsed {
+		parsed[i].EndpointID = s.ResolveEndpointID(parsed[i].EndpointID)
+	}
+	return parsed, nil
+}
+
+// startHops starts the relays of a multi-hop tunnel from its last hop
+// back to its first, each relaying to the next hop or the destination.
+// The endpoint of the tunnel dials the first hop instead of the
+// destination. Each relay only accepts the addresses that the previous
+// hop reported when it registered. If a relay cannot be started, the
+// ones after it are stopped again.
+func (s *GServer) startHops(clientID string, tunnelID string,
+	hops []common.TunnelHop, destinationIP net.IP, destinationPort uint32) error {
+
+	for _, hop := range hops {
+		if hop.EndpointID == clientID {
+			return fmt.Errorf("endpoint %s is a hop of its own tunnel", hop.EndpointID)
+		}
+		client, ok := s.getClient(hop.EndpointID)
+		if !ok {
+			return fmt.Errorf("hop %s does not exist", hop.EndpointID)
+		}
+		if s.isLost(client) {
+			return fmt.Errorf("hop %s lost its control stream", hop.EndpointID)
+		}
+		if !common.HasCapability(client.capabilities, common.CapabilityRelay) {
+			return fmt.Errorf("hop %s does not support relaying", hop.EndpointID)
+		}
+	}
+
+	id := relayID(clientID, tunnelID)
+	for i := len(hops) - 1; i >= 0; i-- {
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlAddRelay
+		controlMessage.TunnelId = id
+		controlMessage.ListenIp = common.IpToInt32(hops[i].IP)
+		controlMessage.ListenPort = hops[i].Port
+		previous := clientID
+		if i > 0 {
+			previous = hops[i-1].EndpointID
+		}
+		controlMessage.RelaySources = s.endpointIPs(previous)
+		if len(controlMessage.RelaySources) == 0 && !hops[i].IP.IsLoopback() {
+			s.stopHops(clientID, tunnelID, hops[i+1:])
+			return fmt.Errorf("hop %s: the addresses of %s are unknown",
+				hops[i].EndpointID, previous)
+		}
+		if i == len(hops)-1 {
+			controlMessage.DestinationIp = common.IpToInt32(destinationIP)
+			controlMessage.DestinationPort = destinationPort
+		} else {
+			controlMessage.DestinationIp = common.IpToInt32(hops[i+1].IP)
+			controlMessage.DestinationPort = hops[i+1].Port
+		}
+
+		client, _ := s.getClient(hops[i].EndpointID)
+		if err := s.sendControlMessage(hops[i].EndpointID, client, controlMessage); err != nil {
+			s.stopHops(clientID, tunnelID, hops[i+1:])
+			return fmt.Errorf("hop %s: %v", hops[i].EndpointID, err)
+		}
+		s.setRelay(hops[i].EndpointID, id, controlMessage)
+	}
+	return nil
+}
+
+// endpointIPs returns the IP addresses that an endpoint reported when
+// it registered, such as 10.0.0.5 for "eth0 10.0.0.5/24".
+func (s *GServer) endpointIPs(clientID string) []string {
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return nil
+	}
+
+	ips := make([]string, 0)
+	for _, address := range client.addresses {
+		fields := strings.Fields(address)
+		if len(fields) == 0 {
+			continue
+		}
+		if ip, _, err := net.ParseCIDR(fields[len(fields)-1]); err == nil {
+			ips = append(ips, ip.String())
+		}
+	}
+	return ips
+}
+
+// stopHops stops the relays of a multi-hop tunnel. Hops that are gone
+// have nothing to stop.
+func (s *GServer) stopHops(clientID string, tunnelID string,
+	hops []common.TunnelHop) {
+
+	id := relayID(clientID, tunnelID)
+	for _, hop := range hops {
+		s.setRelay(hop.EndpointID, id, nil)
+
+		client, ok := s.getClient(hop.EndpointID)
+		if !ok || s.isLost(client) {
+			continue
+		}
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlDeleteRelay
+		controlMessage.TunnelId = id
+		if err := s.sendControlMessage(hop.EndpointID, client, controlMessage); err != nil {
+			common.Log.WithEndpoint(hop.EndpointID).WithTunnel(id).Warnf(
+				"Failed to stop relay: %v", err)
+		}
+	}
+}
+
+// setRelay records the message that started a relay on an endpoint, so
+// that it can be sent again when the endpoint returns. A nil message
+// forgets the relay.
+func (s *GServer) setRelay(clientID string, id string,
+	controlMessage *cs.EndpointControlMessage) {
+
+	s.relayMutex.Lock()
+	defer s.relayMutex.Unlock()
+
+	if controlMessage == nil {
+		delete(s.relays[clientID], id)
+		if len(s.relays[clientID]) == 0 {
+			delete(s.relays, clientID)
+		}
+		return
+	}
+	if s.relays == nil {
+		s.relays = make(map[string]map[string]*cs.EndpointControlMessage)
+	}
+	if s.relays[clientID] == nil {
+		s.relays[clientID] = make(map[string]*cs.EndpointControlMessage)
+	}
+	s.relays[clientID][id] = controlMessage
+}
+
+// forgetRelays forgets the relays on a removed endpoint.
+func (s *GServer) forgetRelays(clientID string) {
+	s.relayMutex.Lock()
+	defer s.relayMutex.Unlock()
+
+	delete(s.relays, clientID)
+}
+
+// getRelays returns the messages that started the relays on an
+// endpoint.
+func (s *GServer) getRelays(clientID string) []*cs.EndpointControlMessage {
+	s.relayMutex.Lock()
+	defer s.relayMutex.Unlock()
+
+	var messages []*cs.EndpointControlMessage
+	for _, controlMessage := range s.relays[clientID] {
+		messages = append(messages, controlMessage)
+	}
+	return messages
+}
@@ -0,0 +1,90 @@
+package gserverlib
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestTunnelHops(t *testing.T) {
+	s := new(GServer)
+	s.connectedClients = make(map[string]*ConnectedClient)
+	addresses := map[string]string{"a": "10.0.9.1/24", "b": "10.0.0.2/24"}
+	for _, id := range []string{"a", "b", "c", "old"} {
+		client := new(ConnectedClient)
+		if address, ok := addresses[id]; ok {
+			client.addresses = []string{"eth0 " + address}
+		}
+		client.endpoint = common.NewEndpoint()
+		client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+		if id != "old" {
+			client.capabilities = []string{common.CapabilityRelay}
+		}
+		s.connectedClients[id] = client
+	}
+	options := common.TunnelOptions{Hops: "b@10.0.0.2:4000,c@10.0.1.3:5000"}
+
+	err := s.AddTunnel("a", "chain", common.TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("10.0.2.4"), 22, options)
+	if err != nil {
+		t.Fatalf("AddTunnel: %v", err)
+	}
+	// The last hop relays to the destination, the others to the next
+	// hop, and each accepts the previous one
+	want := map[string]string{
+		"c": "[10.0.0.2] 10.0.1.3:5000 -> 10.0.2.4:22",
+		"b": "[10.0.9.1] 10.0.0.2:4000 -> 10.0.1.3:5000",
+	}
+	for id, route := range want {
+		message := <-s.connectedClients[id].endpointInput
+		got := fmt.Sprintf("%v %s:%d -> %s:%d", message.RelaySources,
+			common.Int32ToIP(message.ListenIp), message.ListenPort,
+			common.Int32ToIP(message.DestinationIp), message.DestinationPort)
+		if message.Operation != common.EndpointCtrlAddRelay || got != route {
+			t.Errorf("relay on %s: Got: %d %s Want: %s", id, message.Operation, got, route)
+		}
+	}
+	message := <-s.connectedClients["a"].endpointInput
+	if common.Int32ToIP(message.DestinationIp).String() != "10.0.0.2" || message.DestinationPort != 4000 {
+		t.Errorf("tunnel: Got destination %s:%d Want: the first hop",
+			common.Int32ToIP(message.DestinationIp), message.DestinationPort)
+	}
+	if len(s.getRelays("b")) != 1 {
+		t.Errorf("getRelays: the relay on b was not recorded")
+	}
+
+	if err := s.DeleteTunnel("a", "chain"); err != nil {
+		t.Fatalf("DeleteTunnel: %v", err)
+	}
+	for _, id := range []string{"b", "c"} {
+		message := <-s.connectedClients[id].endpointInput
+		if message.Operation != common.EndpointCtrlDeleteRelay || message.TunnelId != "a/chain" {
+			t.Errorf("delete on %s: Got: %d %s", id, message.Operation, message.TunnelId)
+		}
+	}
+	if len(s.getRelays("b")) != 0 {
+		t.Errorf("getRelays: the relay on b was kept")
+	}
+
+	// c reported no addresses that the relay on b could accept
+	options.Hops = "c@10.0.1.3:5000,b@10.0.0.2:4000"
+	err = s.AddTunnel("a", "unknown", common.TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("10.0.2.4"), 22, options)
+	if err == nil {
+		t.Errorf("AddTunnel: Got no error for a hop with unknown addresses")
+	}
+
+	options.Hops = "b@10.0.0.2:4000,old@10.0.1.3:5000"
+	err = s.AddTunnel("a", "unsupported", common.TunnelDirectionForward,
+		net.ParseIP("127.0.0.1"), 0, net.ParseIP("10.0.2.4"), 22, options)
+	if err == nil {
+		t.Errorf("AddTunnel: Got no error for a hop without relays")
+	}
+	if _, ok := s.connectedClients["a"].endpoint.GetTunnel("unsupported"); ok {
+		t.Errorf("AddTunnel: the failed tunnel was kept")
+	}
+}
@@ -0,0 +1,59 @@
+package gserverlib
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// tunnelLimitInterval is how often the byte budget of a tunnel is
+// checked.
+const tunnelLimitInterval = time.Second
+
+// enforceTunnelLimits deletes a tunnel once it outlives its TTL or
+// exceeds its byte limit. It returns early if the tunnel is deleted
+// by other means.
+func (s *GServer) enforceTunnelLimits(clientID string, tunnelID string,
+	tunnel *common.Tunnel) {
+
+	options := tunnel.GetOptions()
+
+	var expired <-chan time.Time
+	if options.TTL > 0 {
+		timer := time.NewTimer(options.TTL)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	var check <-chan time.Time
+	if options.ByteLimit > 0 {
+		ticker := time.NewTicker(tunnelLimitInterval)
+		defer ticker.Stop()
+		check = ticker.C
+	}
+
+	var reason string
+	for reason == "" {
+		select {
+		case <-expired:
+			reason = fmt.Sprintf("lifetime of %s reached", options.TTL)
+		case <-check:
+			used := tunnel.GetBytesSent() + tunnel.GetBytesReceived()
+			if used >= options.ByteLimit {
+				reason = fmt.Sprintf("byte limit of %d reached", options.ByteLimit)
+			}
+		case <-tunnel.Context().Done():
+			return
+		}
+	}
+
+	common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Infof(
+		"Tunnel expired: %s", reason)
+	s.emitEvent(EventTunnelExpired, clientID, tunnelID, reason)
+
+	if err := s.DeleteTunnel(clientID, tunnelID); err != nil {
+		common.Log.WithEndpoint(clientID).WithTunnel(tunnelID).Warnf(
+			"Failed to delete expired tunnel: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestTunnelTTL(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+
+	// The expiry event is emitted once the tunnel is deleted
+	expired := make(chan bool, 1)
+	s.AddEventHandler(func(event Event) {
+		if event.Type == EventTunnelExpired {
+			expired <- true
+		}
+	})
+
+	err := s.AddTunnel("endpoint", "tunnel", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{TTL: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("AddTunnel: %v", err)
+	}
+
+	select {
+	case <-expired:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("events: no %s event", EventTunnelExpired)
+	}
+	if _, ok := client.endpoint.GetTunnel("tunnel"); ok {
+		t.Errorf("tunnel did not expire")
+	}
+}
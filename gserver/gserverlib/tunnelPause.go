@@ -0,0 +1,92 @@
+package gserverlib
+
+import (
+	"fmt"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// PauseTunnel stops a tunnel from accepting new connections without
+// deleting it. If freeze is true its existing connections stop relaying
+// data as well until the tunnel is resumed.
+func (s *GServer) PauseTunnel(clientID string, tunnelID string,
+	freeze bool) error {
+
+	client, tunnel, err := s.pausableTunnel(clientID, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	// Data of all connections passes through the server, so freezing
+	// them here stops both directions.
+	tunnel.Pause(freeze)
+
+	if tunnel.GetDirection() == common.TunnelDirectionReverse {
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlPauseTunnel
+		controlMessage.TunnelId = tunnelID
+		if err := s.sendControlMessage(clientID, client, controlMessage); err != nil {
+			return err
+		}
+	}
+
+	s.emitEvent(EventTunnelPaused, clientID, tunnelID, "tunnel paused")
+	return nil
+}
+
+// ResumeTunnel lets a paused tunnel accept new connections again and
+// thaws its frozen connections.
+func (s *GServer) ResumeTunnel(clientID string, tunnelID string) error {
+	client, tunnel, err := s.pausableTunnel(clientID, tunnelID)
+	if err != nil {
+		return err
+	}
+
+	if !tunnel.IsPaused() {
+		return fmt.Errorf("tunnel %s is not paused", tunnelID)
+	}
+
+	if err := tunnel.Unpause(); err != nil {
+		return err
+	}
+
+	if tunnel.GetDirection() == common.TunnelDirectionReverse {
+		controlMessage := new(cs.EndpointControlMessage)
+		controlMessage.Operation = common.EndpointCtrlResumeTunnel
+		controlMessage.TunnelId = tunnelID
+		if err := s.sendControlMessage(clientID, client, controlMessage); err != nil {
+			return err
+		}
+	}
+
+	s.emitEvent(EventTunnelResumed, clientID, tunnelID, "tunnel resumed")
+	return nil
+}
+
+// pausableTunnel looks up a tunnel that can be paused and resumed.
+func (s *GServer) pausableTunnel(clientID string,
+	tunnelID string) (*ConnectedClient, *common.Tunnel, error) {
+
+	client, ok := s.getClient(clientID)
+	if !ok {
+		return nil, nil, fmt.Errorf("client %s does not exist", clientID)
+	}
+
+	tunnel, ok := client.endpoint.GetTunnel(tunnelID)
+	if !ok {
+		return nil, nil, fmt.Errorf("tunnel %s does not exist", tunnelID)
+	}
+
+	// The listeners of reverse tunnels are on the client
+	if tunnel.GetDirection() == common.TunnelDirectionReverse {
+		if s.isLost(client) {
+			return nil, nil, fmt.Errorf("client lost its control stream")
+		}
+		if !common.HasCapability(client.capabilities, common.CapabilityTunnelPause) {
+			return nil, nil, fmt.Errorf("client does not support pausing reverse tunnels")
+		}
+	}
+	return client, tunnel, nil
+}
@@ -0,0 +1,55 @@
+package gserverlib
+
+import (
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+// scheduleTunnel opens a tunnel while its schedule is open and closes
+// it, dropping its connections, while the schedule is closed. It returns
+// once the tunnel is deleted.
+func (s *GServer) scheduleTunnel(clientID string, tunnelID string,
+	tunnel *common.Tunnel, schedule *common.TimeWindow) {
+
+	logger := common.Log.WithEndpoint(clientID).WithTunnel(tunnelID)
+
+	for {
+		now := time.Now()
+		var next time.Time
+
+		if schedule.Contains(now) {
+			if tunnel.IsPaused() {
+				if err := s.ResumeTunnel(clientID, tunnelID); err != nil {
+					logger.Warnf("Failed to open scheduled tunnel: %v", err)
+				} else {
+					logger.Infof("Opened tunnel on schedule")
+				}
+			}
+			next = schedule.End(now)
+		} else {
+			if !tunnel.IsPaused() {
+				if err := s.PauseTunnel(clientID, tunnelID, false); err != nil {
+					logger.Warnf("Failed to close scheduled tunnel: %v", err)
+				} else {
+					for _, conn := range tunnel.GetConnections() {
+						conn.Close()
+					}
+					logger.Infof("Closed tunnel on schedule")
+				}
+			}
+			next = schedule.Next(now)
+		}
+
+		// The schedule never changes state again
+		if next.IsZero() {
+			return
+		}
+
+		select {
+		case <-time.After(time.Until(next)):
+		case <-tunnel.Context().Done():
+			return
+		}
+	}
+}
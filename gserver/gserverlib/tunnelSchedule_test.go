@@ -0,0 +1,45 @@
+package gserverlib
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	cs "github.com/kai5263499/gtunnel/grpc/client"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestTunnelSchedule(t *testing.T) {
+	s, _ := newTestServer(time.Hour)
+	client := s.connectedClients["endpoint"]
+	client.endpointInput = make(chan *cs.EndpointControlMessage, 4)
+	client.capabilities = []string{common.CapabilityTunnelPause}
+
+	// A window that opens two hours from now
+	start := time.Now().UTC().Add(2 * time.Hour)
+	schedule := start.Format("15:04") + "-" + start.Add(time.Hour).Format("15:04")
+
+	err := s.AddTunnel("endpoint", "tunnel", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8080, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{Schedule: schedule})
+	if err != nil {
+		t.Fatalf("AddTunnel: %v", err)
+	}
+	tunnel, _ := client.endpoint.GetTunnel("tunnel")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && !tunnel.IsPaused() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !tunnel.IsPaused() {
+		t.Fatalf("tunnel was not closed outside its schedule")
+	}
+
+	err = s.AddTunnel("endpoint", "invalid", common.TunnelDirectionReverse,
+		net.ParseIP("127.0.0.1"), 8081, net.ParseIP("10.0.0.1"), 80,
+		common.TunnelOptions{Schedule: "Someday 09:00-17:00"})
+	if err == nil {
+		t.Errorf("AddTunnel accepted an invalid schedule")
+	}
+}
@@ -0,0 +1,72 @@
+package gserverlib
+
+import (
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/kai5263499/gtunnel/common"
+	"golang.org/x/net/websocket"
+	"google.golang.org/grpc"
+)
+
+// SetWebSocketPort makes the client service accept gClients over the
+// WebSocket transport on port as well. Disabled if 0. It has to be
+// called before Start.
+func (s *GServer) SetWebSocketPort(port int) {
+	s.webSocketPort = port
+}
+
+// webSocketConn is the connection of a WebSocket transport. It reports
+// the address of its peer instead of the origin of the WebSocket.
+type webSocketConn struct {
+	*websocket.Conn
+	remoteAddr net.Addr
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// RemoteAddr returns the address of the gClient.
+func (c *webSocketConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// Close closes the WebSocket.
+func (c *webSocketConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return c.Conn.Close()
+}
+
+// newWebSocketHandler returns the handler that passes new WebSockets
+// to l. Without a handshake function the origin is not checked, which
+// only matters to browsers.
+func newWebSocketHandler(l *transportListener) http.Handler {
+	return websocket.Server{Handler: func(ws *websocket.Conn) {
+		ws.PayloadType = websocket.BinaryFrame
+
+		conn := new(webSocketConn)
+		conn.Conn = ws
+		conn.closed = make(chan struct{})
+		conn.remoteAddr, _ = net.ResolveTCPAddr("tcp", ws.Request().RemoteAddr)
+
+		if !l.put(conn) {
+			return
+		}
+		// Returning closes the WebSocket
+		select {
+		case <-conn.closed:
+		case <-ws.Request().Context().Done():
+		}
+	}}
+}
+
+// startWebSocket serves grpcServer to WebSocket transports on port.
+func (s *ClientServiceServer) startWebSocket(grpcServer *grpc.Server,
+	port int,
+	tls bool,
+	certFile string,
+	keyFile string) {
+
+	s.serveTransport(grpcServer, "websocket", port, tls, certFile, keyFile,
+		common.WebSocketPath, newWebSocketHandler)
+}
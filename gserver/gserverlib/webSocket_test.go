@@ -0,0 +1,64 @@
+package gserverlib
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+func TestWebSocketTransport(t *testing.T) {
+	ln := newTransportListener(nil)
+	defer ln.Close()
+
+	server := httptest.NewTLSServer(newWebSocketHandler(ln))
+	defer server.Close()
+
+	// Echo what the client sends
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		if ip, _ := common.AddrToIPPort(conn.RemoteAddr()); !ip.IsLoopback() {
+			t.Errorf("RemoteAddr: Got: %s Want: the address of the client",
+				conn.RemoteAddr())
+		}
+		io.Copy(conn, conn)
+		conn.Close()
+	}()
+
+	transports, err := common.ParseTransports("grpc, websocket", common.TransportConfig{
+		Fingerprint: common.TLSFingerprintChrome,
+	})
+	if err != nil || len(transports) != 2 {
+		t.Fatalf("ParseTransports: Got: %d transports, %v", len(transports), err)
+	}
+	if transports[0].Dialer() != nil {
+		t.Errorf("Dialer: the grpc transport has a dialer")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	address := strings.TrimPrefix(server.URL, "https://")
+	conn, err := transports[1].Dialer()(ctx, address)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte("ping"))
+	reply := make([]byte, 4)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.ReadFull(conn, reply); err != nil || string(reply) != "ping" {
+		t.Errorf("Read: Got: %q, %v Want: ping", reply, err)
+	}
+
+	if _, err := common.ParseTransports("carrier-pigeon", common.TransportConfig{}); err == nil {
+		t.Errorf("ParseTransports: Got no error for an unknown transport")
+	}
+}
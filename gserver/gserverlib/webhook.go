@@ -0,0 +1,135 @@
+package gserverlib
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kai5263499/gtunnel/common"
+)
+
+const (
+	// webhookQueueSize is the number of events that may be queued
+	// before new events are dropped.
+	webhookQueueSize = 256
+
+	// webhookAttempts is how often delivery of an event is attempted.
+	webhookAttempts = 3
+
+	// webhookRetryDelay is the delay before the first retry, it is
+	// doubled for every following retry.
+	webhookRetryDelay = time.Second
+
+	// WebhookSignatureHeader carries the HMAC-SHA256 of the request
+	// body if a webhook secret is configured.
+	WebhookSignatureHeader = "X-Gtunnel-Signature"
+)
+
+// WebhookNotifier posts events as JSON to a webhook url.
+type WebhookNotifier struct {
+	url     string
+	secret  string
+	events  map[string]bool
+	payload func(event Event) interface{}
+	client  *http.Client
+	queue   chan Event
+}
+
+// NewWebhookNotifier is a constructor for WebhookNotifier. If secret is
+// not empty every request is signed with it. If events is not empty
+// only events of those types are posted.
+func NewWebhookNotifier(url string, secret string,
+	events []string) *WebhookNotifier {
+
+	w := new(WebhookNotifier)
+	w.url = url
+	w.secret = secret
+	w.events = make(map[string]bool)
+	w.payload = func(event Event) interface{} { return event }
+	w.client = &http.Client{Timeout: 10 * time.Second}
+	w.queue = make(chan Event, webhookQueueSize)
+
+	for _, event := range events {
+		w.events[event] = true
+	}
+
+	go w.run()
+	return w
+}
+
+// Handle queues an event to be posted. It is an EventHandler and never
+// blocks, events are dropped if the queue is full.
+func (w *WebhookNotifier) Handle(event Event) {
+	if len(w.events) > 0 && !w.events[event.Type] {
+		return
+	}
+
+	select {
+	case w.queue <- event:
+	default:
+		common.Log.Warnf("Dropped %s event, webhook queue is full", event.Type)
+	}
+}
+
+// run posts queued events, retrying failed deliveries.
+func (w *WebhookNotifier) run() {
+	for event := range w.queue {
+		delay := webhookRetryDelay
+		for attempt := 1; ; attempt++ {
+			err := w.send(event)
+			if err == nil {
+				break
+			}
+			if attempt == webhookAttempts {
+				common.Log.Warnf("Failed to post %s event to webhook: %v",
+					event.Type, err)
+				break
+			}
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+}
+
+// send posts a single event.
+func (w *WebhookNotifier) send(event Event) error {
+	body, err := json.Marshal(w.payload(event))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if w.secret != "" {
+		req.Header.Set(WebhookSignatureHeader,
+			"sha256="+SignWebhookBody(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SignWebhookBody returns the hex encoded HMAC-SHA256 of a request
+// body, as sent in the signature header.
+func SignWebhookBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
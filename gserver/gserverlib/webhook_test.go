@@ -0,0 +1,76 @@
+package gserverlib
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	received := make(chan Event, 2)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			signature := r.Header.Get(WebhookSignatureHeader)
+			if signature != "sha256="+SignWebhookBody("secret", body) {
+				t.Errorf("unexpected signature %q", signature)
+			}
+
+			var event Event
+			if err := json.Unmarshal(body, &event); err != nil {
+				t.Errorf("failed to decode event: %v", err)
+			}
+			received <- event
+		}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, "secret",
+		[]string{EventEndpointConnected})
+
+	notifier.Handle(Event{Type: EventTunnelAdded, TunnelID: "ignored"})
+	notifier.Handle(Event{Type: EventEndpointConnected, EndpointID: "abc"})
+
+	select {
+	case event := <-received:
+		if event.Type != EventEndpointConnected || event.EndpointID != "abc" {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not posted")
+	}
+
+	select {
+	case event := <-received:
+		t.Fatalf("filtered event was posted: %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSlackNotifier(t *testing.T) {
+	received := make(chan map[string]string, 1)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			var payload map[string]string
+			json.NewDecoder(r.Body).Decode(&payload)
+			received <- payload
+		}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(server.URL)
+	notifier.Handle(Event{Type: EventTunnelAdded, TunnelID: "ignored"})
+	notifier.Handle(Event{Type: EventEndpointLost, EndpointID: "abc",
+		Time: time.Now()})
+
+	select {
+	case payload := <-received:
+		if !strings.Contains(payload["text"], "Endpoint went silent: abc") {
+			t.Fatalf("unexpected payload %+v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not posted")
+	}
+}
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -10,12 +15,21 @@ import (
 	"log"
 	"net"
 	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kai5263499/gtunnel/common"
 	as "github.com/kai5263499/gtunnel/grpc/admin"
 	"github.com/olekukonko/tablewriter"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // ServerHost constant is the env variable
@@ -26,6 +40,18 @@ const ServerHost = "GTUNNEL_HOST"
 // used to configure the port for the gtunnel server
 const ServerPort = "GTUNNEL_PORT"
 
+// ServerToken constant is the env variable
+// used to configure the admin token for the gtunnel server
+const ServerToken = "GTUNNEL_TOKEN"
+
+// ServerTLS constant is the env variable
+// used to connect to the gtunnel server over TLS
+const ServerTLS = "GTUNNEL_TLS"
+
+// ServerCAFile constant is the env variable
+// used to configure the CA that signed the gtunnel server certificate
+const ServerCAFile = "GTUNNEL_CA_FILE"
+
 // ConfigFileName is the filename in which
 // configuration parameters will be read
 const ConfigFileName = ".gtunnel.conf"
@@ -39,7 +65,61 @@ var commands = []string{
 	"tunnellist",
 	"connectionlist",
 	"socksstart",
-	"socksstop"}
+	"socksstop",
+	"clientconfigure",
+	"aliaslist",
+	"loglevel",
+	"tunnelstats",
+	"connectionwatch",
+	"endpointinfo",
+	"rename",
+	"tag",
+	"unban",
+	"banlist",
+	"tunnelpause",
+	"tunnelresume",
+	"tunnelexport",
+	"tunnelimport",
+	"profilelist",
+	"exec",
+	"console",
+	"ping",
+	"speedtest",
+	"scan",
+	"upload",
+	"download",
+	"shell"}
+
+// profileTunnel is one tunnel of a tunnel profile. The listen port
+// defaults to the destination port and the direction to the direction
+// given to tunnelcreate.
+type profileTunnel struct {
+	Direction       string `json:"direction,omitempty"`
+	ListenPort      uint32 `json:"listen_port,omitempty"`
+	DestinationPort uint32 `json:"destination_port"`
+}
+
+// profiles are named sets of tunnels that tunnelcreate adds at once.
+// More can be defined, and these replaced, under "profiles" in the
+// configuration file.
+var profiles = map[string][]*profileTunnel{
+	"ssh":   {{DestinationPort: 22}},
+	"rdp":   {{DestinationPort: 3389}},
+	"smb":   {{DestinationPort: 445}},
+	"winrm": {{DestinationPort: 5985}, {DestinationPort: 5986}},
+	"mssql": {{DestinationPort: 1433}},
+	"ad-suite": {
+		{DestinationPort: 53},
+		{DestinationPort: 88},
+		{DestinationPort: 135},
+		{DestinationPort: 389},
+		{DestinationPort: 445},
+		{DestinationPort: 636},
+		{DestinationPort: 3268},
+		{DestinationPort: 3269},
+		{DestinationPort: 5985},
+	},
+}
 
 func printCommands(progName string) {
 	fmt.Printf("[*] Usage: %s <gTunServerIP> <gTunSergerPort> command\n", progName)
@@ -50,11 +130,57 @@ func printCommands(progName string) {
 	}
 }
 
-func connect(ip string, port uint32) (as.AdminServiceClient, error) {
+// adminSecurity is how gtuncli connects to a gServer that is not on
+// the same host.
+type adminSecurity struct {
+	tls      bool
+	caFile   string
+	insecure bool
+	token    string
+}
+
+// adminToken sends the token of an operator with every call.
+type adminToken string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (t adminToken) GetRequestMetadata(ctx context.Context,
+	uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": common.BearerString + string(t)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+// Tokens may be sent in the clear to a gServer on the same host.
+func (t adminToken) RequireTransportSecurity() bool {
+	return false
+}
+
+func connect(ip string, port uint32,
+	security adminSecurity) (as.AdminServiceClient, error) {
 	addr := fmt.Sprintf("%s:%d", ip, port)
 
 	var opts []grpc.DialOption
-	opts = append(opts, grpc.WithInsecure())
+	if security.tls {
+		config := new(tls.Config)
+		// gServer uses a self signed certificate by default
+		config.InsecureSkipVerify = security.insecure
+		if security.caFile != "" {
+			pem, err := ioutil.ReadFile(security.caFile)
+			if err != nil {
+				return nil, err
+			}
+			config.RootCAs = x509.NewCertPool()
+			if !config.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates in %s", security.caFile)
+			}
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	if security.token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(adminToken(security.token)))
+	}
 
 	conn, err := grpc.Dial(addr, opts...)
 	if err != nil {
@@ -65,35 +191,574 @@ func connect(ip string, port uint32) (as.AdminServiceClient, error) {
 	return adminClient, nil
 }
 
-func clientList(ctx context.Context, adminClient as.AdminServiceClient) {
+func clientList(ctx context.Context, adminClient as.AdminServiceClient,
+	args []string) {
+	clientListCmd := flag.NewFlagSet(commands[0], flag.ExitOnError)
+	tags := clientListCmd.String("tag", "",
+		"Only list clients with all of these comma separated tags")
+	jsonOutput := clientListCmd.Bool("json", false, "Print the clients as JSON")
+	clientListCmd.Parse(args)
+
 	req := new(as.ClientListRequest)
+	if *tags != "" {
+		req.Tags = strings.Split(*tags, ",")
+	}
 	stream, err := adminClient.ClientList(ctx, req)
 	if err != nil {
 		log.Fatalf("[!] ClientList failed: %s", err)
 	}
+	clients := make([]*as.Client, 0)
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Unique ID", "Status", "Remote Address", "Hostname", "Date Connected"})
+	table.SetHeader([]string{"Name", "Alias", "Tags", "Unique ID", "Status", "Remote Address", "Hostname", "Date Connected", "Version", "Country", "ASN", "State", "Last Seen"})
 	for {
 		message, err := stream.Recv()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			log.Fatalf("[!] Error receiving: %s", err)
+		} else if *jsonOutput {
+			clients = append(clients, message)
 		} else {
 			name := message.Name
 			status := fmt.Sprintf("%d", message.Status)
 			row := []string{name,
+				message.Alias,
+				strings.Join(message.Tags, ","),
 				message.ClientId,
 				status,
 				message.RemoteAddress,
 				message.Hostname,
-				message.ConnectDate}
+				message.ConnectDate,
+				fmt.Sprintf("%d", message.ProtocolVersion),
+				message.Country,
+				formatASN(message.Asn, message.AsOrganization),
+				message.State,
+				formatLastSeen(message.LastSeen)}
 			table.Append(row)
 		}
 	}
+
+	if *jsonOutput {
+		printJSON(clients)
+		return
+	}
 	table.Render()
 }
 
+// endpointInfo prints the metadata that an endpoint reported when it
+// registered.
+func endpointInfo(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	endpointInfoCmd := flag.NewFlagSet(commands[14], flag.ExitOnError)
+	clientID := endpointInfoCmd.String("clientid", "",
+		"The ID of the endpoint.")
+	jsonOutput := endpointInfoCmd.Bool("json", false, "Print the endpoint as JSON")
+	endpointInfoCmd.Parse(args)
+
+	if *clientID == "" {
+		log.Fatalf("[!] clientid is required")
+	}
+
+	req := new(as.ClientListRequest)
+	stream, err := adminClient.ClientList(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] ClientList failed: %s", err)
+	}
+
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("[!] Error receiving: %s", err)
+		}
+		if message.ClientId != *clientID && message.Alias != *clientID {
+			continue
+		}
+
+		if *jsonOutput {
+			printJSON(message)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetAutoWrapText(false)
+		table.AppendBulk([][]string{
+			{"Name", message.Name},
+			{"Unique ID", message.ClientId},
+			{"Alias", message.Alias},
+			{"Tags", strings.Join(message.Tags, ", ")},
+			{"Hostname", message.Hostname},
+			{"Username", message.Username},
+			{"OS", message.Os},
+			{"Architecture", message.Arch},
+			{"Remote Address", message.RemoteAddress},
+			{"Country", message.Country},
+			{"ASN", formatASN(message.Asn, message.AsOrganization)},
+			{"Date Connected", message.ConnectDate},
+			{"State", message.State},
+			{"Last Seen", formatLastSeen(message.LastSeen)},
+			{"Version", fmt.Sprintf("%d", message.ProtocolVersion)},
+			{"Capabilities", strings.Join(message.Capabilities, ", ")},
+			{"Addresses", strings.Join(message.Addresses, "\n")},
+		})
+		table.Render()
+		return
+	}
+
+	log.Fatalf("[!] Endpoint %s does not exist", *clientID)
+}
+
+// clientRename assigns an alias to a client that can be used in place
+// of its ID in all other commands.
+func clientRename(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	renameCmd := flag.NewFlagSet(commands[15], flag.ExitOnError)
+	renameCmd.Usage = func() {
+		fmt.Printf("Usage: %s <clientid> <alias>\n", commands[15])
+		fmt.Printf("An empty alias removes the alias of the client.\n")
+	}
+	renameCmd.Parse(args)
+
+	if renameCmd.NArg() != 2 {
+		renameCmd.Usage()
+		os.Exit(1)
+	}
+
+	req := new(as.ClientRenameRequest)
+	req.ClientId = renameCmd.Arg(0)
+	req.Alias = renameCmd.Arg(1)
+
+	_, err := adminClient.ClientRename(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to rename client: %s", err)
+	}
+}
+
+// clientPing measures the round-trip time to a client over its control
+// stream and, if asked to, over a byte stream that the client echoes.
+func clientPing(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	pingCmd := flag.NewFlagSet(commands[26], flag.ExitOnError)
+	count := pingCmd.Uint("count", 4, "The number of pings")
+	echo := pingCmd.Bool("echo", false,
+		"Also measure the round-trip time over a byte stream, as seen by tunneled connections")
+	jsonOutput := pingCmd.Bool("json", false, "Print the round-trip times as JSON")
+	pingCmd.Usage = func() {
+		fmt.Printf("Usage: %s [options] <clientid>\n", commands[26])
+		pingCmd.PrintDefaults()
+	}
+	pingCmd.Parse(args)
+
+	if pingCmd.NArg() != 1 {
+		pingCmd.Usage()
+		os.Exit(1)
+	}
+
+	req := new(as.ClientPingRequest)
+	req.ClientId = pingCmd.Arg(0)
+	req.Count = uint32(*count)
+	req.Echo = *echo
+
+	resp, err := adminClient.ClientPing(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to ping client: %s", err)
+	}
+
+	if *jsonOutput {
+		printJSON(resp)
+		return
+	}
+
+	printRTTs("control", resp.ControlRtts)
+	if *echo {
+		printRTTs("echo", resp.StreamRtts)
+	}
+}
+
+// clientSpeedTest measures the throughput to and from a client.
+func clientSpeedTest(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	speedTestCmd := flag.NewFlagSet(commands[27], flag.ExitOnError)
+	size := speedTestCmd.Uint64("mb", 10,
+		"The number of MiB sent in each direction")
+	jsonOutput := speedTestCmd.Bool("json", false, "Print the result as JSON")
+	speedTestCmd.Usage = func() {
+		fmt.Printf("Usage: %s [options] <clientid>\n", commands[27])
+		speedTestCmd.PrintDefaults()
+	}
+	speedTestCmd.Parse(args)
+
+	if speedTestCmd.NArg() != 1 || *size == 0 {
+		speedTestCmd.Usage()
+		os.Exit(1)
+	}
+
+	req := new(as.ClientSpeedTestRequest)
+	req.ClientId = speedTestCmd.Arg(0)
+	req.Bytes = *size * 1024 * 1024
+
+	resp, err := adminClient.ClientSpeedTest(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Speed test failed: %s", err)
+	}
+
+	if *jsonOutput {
+		printJSON(resp)
+		return
+	}
+
+	// A zero duration would make the rate infinite
+	upload := time.Duration(resp.UploadMicros+1) * time.Microsecond
+	download := time.Duration(resp.DownloadMicros+1) * time.Microsecond
+	fmt.Printf("upload   %s in %s, %s\n", formatBytes(resp.Bytes), upload,
+		formatRate(0, resp.Bytes, upload))
+	fmt.Printf("download %s in %s, %s\n", formatBytes(resp.Bytes), download,
+		formatRate(0, resp.Bytes, download))
+}
+
+// clientScan has a client scan its network for open TCP ports and
+// prints each one as it is found.
+func clientScan(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	scanCmd := flag.NewFlagSet(commands[28], flag.ExitOnError)
+	hosts := scanCmd.String("hosts", "",
+		"Comma separated addresses and networks to scan, such as 10.0.0.0/24")
+	ports := scanCmd.String("ports", "21,22,23,25,80,135,139,443,445,1433,3306,3389,5432,5900,8080",
+		"Comma separated ports and ranges to scan, such as 22,80,8000-8100")
+	timeout := scanCmd.Duration("timeout", time.Second,
+		"How long to wait for each port")
+	scanCmd.Usage = func() {
+		fmt.Printf("Usage: %s [options] <clientid>\n", commands[28])
+		scanCmd.PrintDefaults()
+	}
+	scanCmd.Parse(args)
+
+	if scanCmd.NArg() != 1 || *hosts == "" {
+		scanCmd.Usage()
+		os.Exit(1)
+	}
+
+	req := new(as.ClientScanRequest)
+	req.ClientId = scanCmd.Arg(0)
+	req.Hosts = *hosts
+	req.Ports = *ports
+	req.Timeout = uint32(*timeout / time.Millisecond)
+
+	stream, err := adminClient.ClientScan(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Scan failed: %s", err)
+	}
+
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("[!] Scan failed: %s", err)
+		}
+		fmt.Printf("%s open\n", result.Address)
+	}
+}
+
+// fileUpload writes a local file to a client.
+func fileUpload(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	uploadCmd := flag.NewFlagSet(commands[29], flag.ExitOnError)
+	quiet := uploadCmd.Bool("quiet", false, "Do not report progress")
+	uploadCmd.Usage = func() {
+		fmt.Printf("Usage: %s [options] <clientid> <local file> <remote file>\n",
+			commands[29])
+		uploadCmd.PrintDefaults()
+	}
+	uploadCmd.Parse(args)
+
+	if uploadCmd.NArg() != 3 {
+		uploadCmd.Usage()
+		os.Exit(1)
+	}
+
+	f, err := os.Open(uploadCmd.Arg(1))
+	if err != nil {
+		log.Fatalf("[!] Failed to open file: %s", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		log.Fatalf("[!] Failed to open file: %s", err)
+	}
+	size := uint64(info.Size())
+
+	stream, err := adminClient.FileUpload(ctx)
+	if err != nil {
+		log.Fatalf("[!] Upload failed: %s", err)
+	}
+
+	chunk := new(as.FileChunk)
+	chunk.ClientId = uploadCmd.Arg(0)
+	chunk.Path = uploadCmd.Arg(2)
+	chunk.Size = size
+	chunk.Mode = uint32(info.Mode().Perm())
+
+	hash := sha256.New()
+	r := io.TeeReader(io.LimitReader(f, info.Size()), hash)
+	buf := make([]byte, common.FileChunkSize)
+	var sent uint64
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := stream.Send(chunk); err != nil {
+				break
+			}
+			chunk = new(as.FileChunk)
+			sent += uint64(n)
+			if !*quiet {
+				printProgress(sent, size)
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			log.Fatalf("[!] Failed to read file: %s", err)
+		}
+	}
+	if sent == 0 {
+		// An empty file still has to name the client and path
+		stream.Send(chunk)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if !*quiet {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		log.Fatalf("[!] Upload failed: %s", err)
+	}
+	if !bytes.Equal(resp.Sha256, hash.Sum(nil)) {
+		log.Fatalf("[!] Upload failed: the hash of the remote file differs")
+	}
+
+	fmt.Printf("Uploaded %s, sha256 %x\n", formatBytes(resp.Size), resp.Sha256)
+}
+
+// fileDownload reads a file from a client into a local file.
+func fileDownload(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	downloadCmd := flag.NewFlagSet(commands[30], flag.ExitOnError)
+	quiet := downloadCmd.Bool("quiet", false, "Do not report progress")
+	downloadCmd.Usage = func() {
+		fmt.Printf("Usage: %s [options] <clientid> <remote file> <local file>\n",
+			commands[30])
+		downloadCmd.PrintDefaults()
+	}
+	downloadCmd.Parse(args)
+
+	if downloadCmd.NArg() != 3 {
+		downloadCmd.Usage()
+		os.Exit(1)
+	}
+
+	req := new(as.FileDownloadRequest)
+	req.ClientId = downloadCmd.Arg(0)
+	req.Path = downloadCmd.Arg(1)
+	stream, err := adminClient.FileDownload(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Download failed: %s", err)
+	}
+
+	path := downloadCmd.Arg(2)
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("[!] Failed to create file: %s", err)
+	}
+	// The file is not kept unless it is complete
+	fail := func(format string, v ...interface{}) {
+		f.Close()
+		os.Remove(path)
+		if !*quiet {
+			fmt.Fprintln(os.Stderr)
+		}
+		log.Fatalf(format, v...)
+	}
+
+	hash := sha256.New()
+	w := io.MultiWriter(f, hash)
+	var size, received uint64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			fail("[!] Download failed: the transfer ended early")
+		} else if err != nil {
+			fail("[!] Download failed: %s", err)
+		}
+		if chunk.Sha256 != nil {
+			if received != size || !bytes.Equal(chunk.Sha256, hash.Sum(nil)) {
+				fail("[!] Download failed: the hash of the local file differs")
+			}
+			break
+		}
+		if chunk.Size > 0 {
+			size = chunk.Size
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			fail("[!] Failed to write file: %s", err)
+		}
+		received += uint64(len(chunk.Data))
+		if !*quiet && len(chunk.Data) > 0 {
+			printProgress(received, size)
+		}
+	}
+	if err := f.Close(); err != nil {
+		fail("[!] Failed to write file: %s", err)
+	}
+	if !*quiet {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	fmt.Printf("Downloaded %s, sha256 %x\n", formatBytes(size), hash.Sum(nil))
+}
+
+// clientShell runs an interactive shell on a client that was built with
+// shell support, wired to stdin and stdout, until the shell exits.
+func clientShell(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	shellCmd := flag.NewFlagSet(commands[31], flag.ExitOnError)
+	shellCmd.Usage = func() {
+		fmt.Printf("Usage: %s <clientid>\n", commands[31])
+		fmt.Printf("The client has to be built with -shell.\n")
+	}
+	shellCmd.Parse(args)
+
+	if shellCmd.NArg() != 1 {
+		shellCmd.Usage()
+		os.Exit(1)
+	}
+
+	stream, err := adminClient.ClientShell(ctx)
+	if err != nil {
+		log.Fatalf("[!] Shell failed: %s", err)
+	}
+
+	in := new(as.ShellInput)
+	in.ClientId = shellCmd.Arg(0)
+	if err := stream.Send(in); err != nil {
+		log.Fatalf("[!] Shell failed: %s", err)
+	}
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				in := new(as.ShellInput)
+				in.Data = buf[:n]
+				if stream.Send(in) != nil {
+					return
+				}
+			}
+			if err != nil {
+				stream.CloseSend()
+				return
+			}
+		}
+	}()
+
+	for {
+		out, err := stream.Recv()
+		if err == io.EOF {
+			return
+		} else if err != nil {
+			log.Fatalf("[!] Shell failed: %s", err)
+		}
+		os.Stdout.Write(out.Data)
+	}
+}
+
+// printProgress shows on stderr how much of a transfer is done.
+func printProgress(done uint64, total uint64) {
+	percent := uint64(100)
+	if total > 0 {
+		percent = done * 100 / total
+	}
+	fmt.Fprintf(os.Stderr, "\r%s of %s (%d%%)", formatBytes(done),
+		formatBytes(total), percent)
+}
+
+// printRTTs prints round-trip times in microseconds followed by their
+// minimum, average and maximum.
+func printRTTs(name string, rtts []int64) {
+	if len(rtts) == 0 {
+		return
+	}
+
+	var min, max, sum time.Duration
+	for i, rtt := range rtts {
+		d := time.Duration(rtt) * time.Microsecond
+		fmt.Printf("%s seq=%d time=%s\n", name, i+1, d)
+		if i == 0 || d < min {
+			min = d
+		}
+		if d > max {
+			max = d
+		}
+		sum += d
+	}
+	fmt.Printf("%s min/avg/max = %s/%s/%s\n", name, min,
+		sum/time.Duration(len(rtts)), max)
+}
+
+// clientTag adds tags to and removes tags from a client.
+func clientTag(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tagCmd := flag.NewFlagSet(commands[16], flag.ExitOnError)
+	clientID := tagCmd.String("clientid", "",
+		"The ID or alias of the client.")
+	add := tagCmd.String("add", "",
+		"Comma separated tags to add, such as dmz,win")
+	remove := tagCmd.String("remove", "",
+		"Comma separated tags to remove")
+	tagCmd.Parse(args)
+
+	if *clientID == "" {
+		log.Fatalf("[!] clientid is required")
+	}
+
+	req := new(as.ClientTagRequest)
+	req.ClientId = *clientID
+	if *add != "" {
+		req.Add = strings.Split(*add, ",")
+	}
+	if *remove != "" {
+		req.Remove = strings.Split(*remove, ",")
+	}
+
+	resp, err := adminClient.ClientTag(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to tag client: %s", err)
+	}
+
+	fmt.Printf("Tags: %s\n", strings.Join(resp.Tags, ", "))
+}
+
 func clientRegister(ctx context.Context,
 	adminClient as.AdminServiceClient,
 	args []string) {
@@ -168,10 +833,16 @@ func clientDisconnect(ctx context.Context,
 	disconnectCmd := flag.NewFlagSet(commands[2], flag.ExitOnError)
 	clientID := disconnectCmd.String("clientid", "",
 		"The client to disconnect")
+	ban := disconnectCmd.Bool("ban", false,
+		"Ban the client ID from registering again")
+	banToken := disconnectCmd.Bool("bantoken", false,
+		"Also ban the token of the client, rejecting every gClient built with it")
 	disconnectCmd.Parse(args)
 
 	disconnectReq := new(as.ClientDisconnectRequest)
 	disconnectReq.ClientId = *clientID
+	disconnectReq.Ban = *ban
+	disconnectReq.BanToken = *banToken
 
 	_, err := adminClient.ClientDisconnect(ctx, disconnectReq)
 	if err != nil {
@@ -179,6 +850,119 @@ func clientDisconnect(ctx context.Context,
 	}
 }
 
+func clientUnban(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	unbanCmd := flag.NewFlagSet(commands[17], flag.ExitOnError)
+	clientID := unbanCmd.String("clientid", "",
+		"The client to unban")
+	unbanCmd.Parse(args)
+
+	req := new(as.ClientUnbanRequest)
+	req.ClientId = *clientID
+
+	_, err := adminClient.ClientUnban(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to unban: %s", err)
+	}
+}
+
+func banList(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	banListCmd := flag.NewFlagSet(commands[18], flag.ExitOnError)
+	jsonOutput := banListCmd.Bool("json", false, "Print the bans as JSON")
+	banListCmd.Parse(args)
+
+	req := new(as.BanListRequest)
+
+	stream, err := adminClient.BanList(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] BanList failed: %s", err)
+	}
+
+	bans := make([]*as.Ban, 0)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Unique ID", "Token Banned", "Date Banned"})
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("[!] Error receiving: %s", err)
+		}
+		bans = append(bans, message)
+		table.Append([]string{message.ClientId,
+			fmt.Sprintf("%t", message.TokenBanned),
+			time.Unix(message.Date, 0).String()})
+	}
+
+	if *jsonOutput {
+		printJSON(bans)
+		return
+	}
+	table.Render()
+}
+
+func clientConfigure(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	configureCmd := flag.NewFlagSet(commands[9], flag.ExitOnError)
+	clientID := configureCmd.String("clientid", "",
+		"The client to configure")
+	mtu := configureCmd.Int("mtu", 0,
+		"The maximum number of bytes in a single data message. 0 uses the default")
+	keepalive := configureCmd.Int("keepalive", 0,
+		"The interval in seconds at which keepalives are sent on control streams. 0 disables keepalives")
+	dialAllow := configureCmd.String("dialallow", "",
+		"Comma separated destinations the client may dial, such as 10.0.0.0/8,*:443. Use * to lift a previous policy")
+	dialDeny := configureCmd.String("dialdeny", "",
+		"Comma separated destinations the client may not dial, such as 169.254.169.254")
+	configureCmd.Parse(args)
+
+	req := new(as.ClientConfigureRequest)
+	req.ClientId = *clientID
+	req.Mtu = uint32(*mtu)
+	req.KeepaliveInterval = uint32(*keepalive)
+	req.DialAllow = *dialAllow
+	req.DialDeny = *dialDeny
+
+	_, err := adminClient.ClientConfigure(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to configure client: %s", err)
+	}
+}
+
+func logLevelSet(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	logLevelCmd := flag.NewFlagSet(commands[11], flag.ExitOnError)
+	level := logLevelCmd.String("level", "info",
+		"The log level: debug, info, warn, error or none")
+	clientID := logLevelCmd.String("clientid", "",
+		"The ID of the client whose log level is changed. gServer if empty")
+	logLevelCmd.Parse(args)
+
+	req := new(as.LogLevelSetRequest)
+	req.Level = *level
+	req.ClientId = *clientID
+
+	resp, err := adminClient.LogLevelSet(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] Failed to set log level: %s", err)
+	}
+
+	if *clientID != "" {
+		fmt.Printf("Log level of %s changed to %s\n", *clientID, *level)
+		return
+	}
+	fmt.Printf("Log level changed from %s to %s\n", resp.PreviousLevel, *level)
+}
+
 func tunnelAdd(ctx context.Context,
 	adminClient as.AdminServiceClient,
 	args []string) {
@@ -198,6 +982,67 @@ func tunnelAdd(ctx context.Context,
 		"The port to which the connection will be forwarded")
 	tunnelID := tunnelAddCmd.String("tunnelid", "",
 		"A friendly name for the tunnel. A random string will be generated if none is provided")
+	command := tunnelAddCmd.String("command", "",
+		"A command to spawn for each connection instead of dialing the destination")
+	detectDeception := tunnelAddCmd.Bool("detectdeception", false,
+		"Flag destinations that look like tarpits or honeypots")
+	loopbackAlias := tunnelAddCmd.Bool("loopbackalias", false,
+		"Listen on a loopback alias unique to the destination instead of listenip")
+	hostname := tunnelAddCmd.String("hostname", "",
+		"A hostname to publish for the listen address of a forward tunnel")
+	ttl := tunnelAddCmd.Duration("ttl", 0,
+		"Delete the tunnel after this long, such as 2h. Unlimited if 0")
+	byteLimit := tunnelAddCmd.Uint64("bytelimit", 0,
+		"Delete the tunnel after it carried this many bytes. Unlimited if 0")
+	schedule := tunnelAddCmd.String("schedule", "",
+		"Only open the tunnel during this window, such as 'Mon-Fri 09:00-17:00'")
+	timezone := tunnelAddCmd.String("timezone", "",
+		"The timezone of the schedule, such as America/New_York or -05:00. UTC if empty")
+	chunkSize := tunnelAddCmd.Uint("chunksize", 0,
+		"Bytes relayed per message, such as 4096 for interactive or 65536 for bulk transfers. The client MTU if 0")
+	nagle := tunnelAddCmd.Bool("nagle", false,
+		"Enable Nagle's algorithm instead of setting TCP_NODELAY on the connections")
+	tcpKeepalive := tunnelAddCmd.Duration("tcpkeepalive", 0,
+		"The TCP keepalive period of the connections. The system default if 0, disabled if negative")
+	readBuffer := tunnelAddCmd.Uint("readbuffer", 0,
+		"The socket receive buffer size of the connections. The system default if 0")
+	writeBuffer := tunnelAddCmd.Uint("writebuffer", 0,
+		"The socket send buffer size of the connections. The system default if 0")
+	rateLimit := tunnelAddCmd.Uint64("ratelimit", 0,
+		"The bandwidth limit of the tunnel in bytes per second. Unlimited if 0")
+	idleTimeout := tunnelAddCmd.Duration("idletimeout", 0,
+		"Close connections that relay no data for this long, such as 10m. Never if 0")
+	maxConnections := tunnelAddCmd.Uint("maxconns", 0,
+		"The number of connections the tunnel may carry at the same time. Unlimited if 0")
+	compress := tunnelAddCmd.Bool("compress", false,
+		"Compress the data of the tunnel with gzip. Helps over slow links unless the data is already compressed")
+	streamCompression := tunnelAddCmd.String("streamcompression", "",
+		"The algorithm with which gtunnel compresses the data of each connection: snappy, which is faster than -compress. None if empty")
+	encrypt := tunnelAddCmd.Bool("encrypt", false,
+		"Encrypt the data of the tunnel with a key of its own, which protects it where TLS is terminated before gServer")
+	rekeyInterval := tunnelAddCmd.Duration("rekeyinterval", 0,
+		"How often the key of an encrypted tunnel is replaced. Never if 0")
+	rekeyBytes := tunnelAddCmd.Uint64("rekeybytes", 0,
+		"Number of bytes after which the key of an encrypted tunnel is replaced. Never if 0")
+	padSize := tunnelAddCmd.Uint("padsize", 0,
+		"Pad the data messages of the tunnel with random bytes to a multiple of this size, which hides the sizes of writes. Not padded if 0")
+	sendJitter := tunnelAddCmd.Duration("sendjitter", 0,
+		"The random delay of up to which each data message of the tunnel is held back, such as 50ms. No delay if 0")
+	hops := tunnelAddCmd.String("hops", "",
+		"Comma separated endpoints that a forward tunnel passes after its own, such as clientB@10.0.0.5:4444. Each hop listens on its address for the previous one and relays to the next hop or the destination")
+	balance := tunnelAddCmd.String("balance", "",
+		"Spread the new connections of forward tunnels with the same listen address on several endpoints across them, roundrobin or leastconn. Add the tunnel to each endpoint with the same mode")
+	failover := tunnelAddCmd.String("failover", "",
+		"Comma separated destinations, such as 10.0.0.6:80,10.0.0.7:80, that are dialed in order when the destination cannot be")
+	healthCheck := tunnelAddCmd.Duration("healthcheck", 0,
+		"How often a connection is opened through a forward tunnel to check that its destination is up, such as 30s. Not checked if 0")
+	healthBanner := tunnelAddCmd.String("healthbanner", "",
+		"Text, such as SSH-, that the destination has to send first for a health check to pass")
+
+	profile := tunnelAddCmd.String("profile", "",
+		"Add every tunnel of a profile instead of a single tunnel. See profilelist")
+	portOffset := tunnelAddCmd.Int("portoffset", 0,
+		"A number added to the listen ports of a profile, such as 10000 to avoid privileged ports")
 
 	tunnelAddCmd.Parse(args)
 
@@ -216,6 +1061,40 @@ func tunnelAdd(ctx context.Context,
 	tunnel.DestinationPort = uint32(*destinationPort)
 	tunnel.ListenIp = common.IpToInt32(lIP)
 	tunnel.ListenPort = uint32(*listenPort)
+	tunnel.Command = *command
+	tunnel.DetectDeception = *detectDeception
+	tunnel.LoopbackAlias = *loopbackAlias
+	tunnel.Hostname = *hostname
+	tunnel.Ttl = int64(*ttl / time.Second)
+	tunnel.ByteLimit = *byteLimit
+	tunnel.Schedule = *schedule
+	tunnel.Timezone = *timezone
+	tunnel.ChunkSize = uint32(*chunkSize)
+	tunnel.Nagle = *nagle
+	tunnel.TcpKeepalive = int64(*tcpKeepalive / time.Second)
+	tunnel.ReadBuffer = uint32(*readBuffer)
+	tunnel.WriteBuffer = uint32(*writeBuffer)
+	tunnel.RateLimit = *rateLimit
+	tunnel.IdleTimeout = int64(*idleTimeout / time.Second)
+	tunnel.MaxConnections = uint32(*maxConnections)
+	tunnel.Compress = *compress
+	tunnel.StreamCompression = *streamCompression
+	tunnel.Encrypt = *encrypt
+	tunnel.RekeyInterval = int64(*rekeyInterval / time.Second)
+	tunnel.RekeyBytes = *rekeyBytes
+	tunnel.PadSize = uint32(*padSize)
+	tunnel.SendJitter = int64(*sendJitter / time.Millisecond)
+	tunnel.Hops = *hops
+	tunnel.Balance = *balance
+	tunnel.Failover = *failover
+	tunnel.HealthCheck = int64(*healthCheck / time.Second)
+	tunnel.HealthBanner = *healthBanner
+
+	if *profile != "" {
+		profileAdd(ctx, adminClient, *clientID, *profile, *tunnelID,
+			uint32(*portOffset), tunnel)
+		return
+	}
 
 	if len(*tunnelID) == 0 {
 		tunnel.Id = common.GenerateString(common.TunnelIDSize)
@@ -234,6 +1113,188 @@ func tunnelAdd(ctx context.Context,
 
 }
 
+// profileAdd adds the tunnels of a profile to a client, all at once.
+// Every tunnel is a copy of base with the ports of the profile. The
+// tunnel IDs are the prefix, or else the profile name, followed by the
+// destination port.
+func profileAdd(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	clientID string,
+	name string,
+	prefix string,
+	portOffset uint32,
+	base *as.Tunnel) {
+
+	tunnels, ok := profiles[name]
+	if !ok {
+		log.Fatalf("[!] Unknown profile %s", name)
+	}
+	if prefix == "" {
+		prefix = name
+	}
+
+	req := new(as.TunnelImportRequest)
+	for _, profileTunnel := range tunnels {
+		tunnel := proto.Clone(base).(*as.Tunnel)
+		tunnel.Id = fmt.Sprintf("%s-%d", prefix, profileTunnel.DestinationPort)
+		tunnel.DestinationPort = profileTunnel.DestinationPort
+		tunnel.ListenPort = profileTunnel.ListenPort
+		if tunnel.ListenPort == 0 {
+			tunnel.ListenPort = profileTunnel.DestinationPort
+		}
+		tunnel.ListenPort += portOffset
+
+		switch profileTunnel.Direction {
+		case "":
+		case "forward":
+			tunnel.Direction = common.TunnelDirectionForward
+		case "reverse":
+			tunnel.Direction = common.TunnelDirectionReverse
+		default:
+			log.Fatalf("[!] Invalid direction %q in profile %s",
+				profileTunnel.Direction, name)
+		}
+
+		addReq := new(as.TunnelAddRequest)
+		addReq.ClientId = clientID
+		addReq.Tunnel = tunnel
+		req.Tunnels = append(req.Tunnels, addReq)
+	}
+
+	resp, err := adminClient.TunnelImport(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] TunnelAdd failed: %s", err)
+	}
+
+	fmt.Printf("[*] Added tunnels %s\n", strings.Join(resp.TunnelIds, ", "))
+}
+
+// execScript runs the commands of a script file, or of standard input
+// if no file or - is given, one per line. Blank lines and lines that
+// start with # are skipped. Arguments may be quoted with single or
+// double quotes. The script stops at the first command that fails.
+func execScript(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	input := os.Stdin
+	if len(args) > 0 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			log.Fatalf("[!] Failed to open script: %s", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	scanner := bufio.NewScanner(input)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		words, err := splitCommandLine(line)
+		if err != nil {
+			log.Fatalf("[!] Line %d: %s", lineNumber, err)
+		}
+
+		fmt.Printf("[*] > %s\n", line)
+		if !runCommand(ctx, adminClient, words[0], words[1:]) {
+			log.Fatalf("[!] Line %d: command %s not recognized", lineNumber,
+				words[0])
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("[!] Failed to read script: %s", err)
+	}
+}
+
+// splitCommandLine splits a line of a script into words the way a
+// shell would, honoring quotes and backslash escapes.
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// profileList prints the available tunnel profiles.
+func profileList(args []string) {
+	profileListCmd := flag.NewFlagSet(commands[23], flag.ExitOnError)
+	jsonOutput := profileListCmd.Bool("json", false, "Print the profiles as JSON")
+	profileListCmd.Parse(args)
+
+	if *jsonOutput {
+		printJSON(profiles)
+		return
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Profile", "Tunnels"})
+	for _, name := range names {
+		var tunnels []string
+		for _, tunnel := range profiles[name] {
+			description := fmt.Sprint(tunnel.DestinationPort)
+			if tunnel.ListenPort != 0 && tunnel.ListenPort != tunnel.DestinationPort {
+				description = fmt.Sprintf("%d->%d", tunnel.ListenPort,
+					tunnel.DestinationPort)
+			}
+			if tunnel.Direction != "" {
+				description += " " + tunnel.Direction
+			}
+			tunnels = append(tunnels, description)
+		}
+		table.Append([]string{name, strings.Join(tunnels, ", ")})
+	}
+	table.Render()
+}
+
 func tunnelDelete(ctx context.Context,
 	adminClient as.AdminServiceClient,
 	args []string) {
@@ -246,16 +1307,382 @@ func tunnelDelete(ctx context.Context,
 
 	tunnelDeleteCmd.Parse(args)
 
-	req := new(as.TunnelDeleteRequest)
+	req := new(as.TunnelDeleteRequest)
+
+	req.ClientId = *clientID
+	req.TunnelId = *tunnelID
+
+	_, err := adminClient.TunnelDelete(ctx, req)
+
+	if err != nil {
+		log.Fatalf("[!] Failed to delete tunnel: %s", err)
+	}
+}
+
+func tunnelPause(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tunnelPauseCmd := flag.NewFlagSet(commands[19], flag.ExitOnError)
+	clientID := tunnelPauseCmd.String("clientid", "",
+		"The ID of the client that has the tunnel to be paused")
+	tunnelID := tunnelPauseCmd.String("tunnelid", "",
+		"The ID of the tunnel to pause")
+	freeze := tunnelPauseCmd.Bool("freeze", false,
+		"Stop relaying data over the existing connections as well")
+
+	tunnelPauseCmd.Parse(args)
+
+	req := new(as.TunnelPauseRequest)
+	req.ClientId = *clientID
+	req.TunnelId = *tunnelID
+	req.Freeze = *freeze
+
+	_, err := adminClient.TunnelPause(ctx, req)
+
+	if err != nil {
+		log.Fatalf("[!] Failed to pause tunnel: %s", err)
+	}
+}
+
+func tunnelResume(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tunnelResumeCmd := flag.NewFlagSet(commands[20], flag.ExitOnError)
+	clientID := tunnelResumeCmd.String("clientid", "",
+		"The ID of the client that has the tunnel to be resumed")
+	tunnelID := tunnelResumeCmd.String("tunnelid", "",
+		"The ID of the tunnel to resume")
+
+	tunnelResumeCmd.Parse(args)
+
+	req := new(as.TunnelResumeRequest)
+	req.ClientId = *clientID
+	req.TunnelId = *tunnelID
+
+	_, err := adminClient.TunnelResume(ctx, req)
+
+	if err != nil {
+		log.Fatalf("[!] Failed to resume tunnel: %s", err)
+	}
+}
+
+// tunnelDefinition is the JSON format in which tunnels are exported
+// and imported. It uses the same keys as the rest api.
+type tunnelDefinition struct {
+	ClientID          string `json:"client_id"`
+	ID                string `json:"id"`
+	Direction         string `json:"direction"`
+	ListenIP          string `json:"listen_ip"`
+	ListenPort        uint32 `json:"listen_port"`
+	DestinationIP     string `json:"destination_ip"`
+	DestinationPort   uint32 `json:"destination_port"`
+	Command           string `json:"command,omitempty"`
+	DetectDeception   bool   `json:"detect_deception,omitempty"`
+	LoopbackAlias     bool   `json:"loopback_alias,omitempty"`
+	Hostname          string `json:"hostname,omitempty"`
+	TTL               int64  `json:"ttl,omitempty"`
+	ByteLimit         uint64 `json:"byte_limit,omitempty"`
+	Schedule          string `json:"schedule,omitempty"`
+	Timezone          string `json:"timezone,omitempty"`
+	ChunkSize         uint32 `json:"chunk_size,omitempty"`
+	Nagle             bool   `json:"nagle,omitempty"`
+	TCPKeepalive      int64  `json:"tcp_keepalive,omitempty"`
+	ReadBuffer        uint32 `json:"read_buffer,omitempty"`
+	WriteBuffer       uint32 `json:"write_buffer,omitempty"`
+	RateLimit         uint64 `json:"rate_limit,omitempty"`
+	IdleTimeout       int64  `json:"idle_timeout,omitempty"`
+	MaxConnections    uint32 `json:"max_connections,omitempty"`
+	Compress          bool   `json:"compress,omitempty"`
+	StreamCompression string `json:"stream_compression,omitempty"`
+	Encrypt           bool   `json:"encrypt,omitempty"`
+	RekeyInterval     int64  `json:"rekey_interval,omitempty"`
+	RekeyBytes        uint64 `json:"rekey_bytes,omitempty"`
+	PadSize           uint32 `json:"pad_size,omitempty"`
+	SendJitter        int64  `json:"send_jitter,omitempty"`
+	Hops              string `json:"hops,omitempty"`
+	Balance           string `json:"balance,omitempty"`
+	Failover          string `json:"failover,omitempty"`
+	HealthCheck       int64  `json:"health_check,omitempty"`
+	HealthBanner      string `json:"health_banner,omitempty"`
+}
+
+// newTunnelDefinition returns the definition of a listed tunnel.
+func newTunnelDefinition(clientID string, tunnel *as.Tunnel) *tunnelDefinition {
+	direction := "forward"
+	if tunnel.Direction == common.TunnelDirectionReverse {
+		direction = "reverse"
+	}
+
+	return &tunnelDefinition{
+		ClientID:          clientID,
+		ID:                tunnel.Id,
+		Direction:         direction,
+		ListenIP:          common.Int32ToIP(tunnel.ListenIp).String(),
+		ListenPort:        tunnel.ListenPort,
+		DestinationIP:     common.Int32ToIP(tunnel.DestinationIp).String(),
+		DestinationPort:   tunnel.DestinationPort,
+		Command:           tunnel.Command,
+		DetectDeception:   tunnel.DetectDeception,
+		LoopbackAlias:     tunnel.LoopbackAlias,
+		Hostname:          tunnel.Hostname,
+		TTL:               tunnel.Ttl,
+		ByteLimit:         tunnel.ByteLimit,
+		Schedule:          tunnel.Schedule,
+		Timezone:          tunnel.Timezone,
+		ChunkSize:         tunnel.ChunkSize,
+		Nagle:             tunnel.Nagle,
+		TCPKeepalive:      tunnel.TcpKeepalive,
+		ReadBuffer:        tunnel.ReadBuffer,
+		WriteBuffer:       tunnel.WriteBuffer,
+		RateLimit:         tunnel.RateLimit,
+		IdleTimeout:       tunnel.IdleTimeout,
+		MaxConnections:    tunnel.MaxConnections,
+		Compress:          tunnel.Compress,
+		StreamCompression: tunnel.StreamCompression,
+		Encrypt:           tunnel.Encrypt,
+		RekeyInterval:     tunnel.RekeyInterval,
+		RekeyBytes:        tunnel.RekeyBytes,
+		PadSize:           tunnel.PadSize,
+		SendJitter:        tunnel.SendJitter,
+		Hops:              tunnel.Hops,
+		Balance:           tunnel.Balance,
+		Failover:          tunnel.Failover,
+		HealthCheck:       tunnel.HealthCheck,
+		HealthBanner:      tunnel.HealthBanner,
+	}
+}
+
+// tunnelStatus is the JSON format in which tunnellist prints tunnels.
+type tunnelStatus struct {
+	tunnelDefinition
+	Connections   uint32 `json:"connections"`
+	BytesSent     uint64 `json:"bytes_sent"`
+	BytesReceived uint64 `json:"bytes_received"`
+	StartTime     int64  `json:"start_time"`
+	Paused        bool   `json:"paused"`
+	FailedConns   uint64 `json:"failed_connections,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastErrorTime int64  `json:"last_error_time,omitempty"`
+	Health        string `json:"health,omitempty"`
+}
+
+// newTunnelStatus returns the status of a listed tunnel.
+func newTunnelStatus(clientID string, tunnel *as.Tunnel) *tunnelStatus {
+	t := new(tunnelStatus)
+	t.tunnelDefinition = *newTunnelDefinition(clientID, tunnel)
+	t.Connections = tunnel.Connections
+	t.BytesSent = tunnel.BytesSent
+	t.BytesReceived = tunnel.BytesReceived
+	t.StartTime = tunnel.StartTime
+	t.Paused = tunnel.Paused
+	t.FailedConns = tunnel.FailedConnections
+	t.LastError = tunnel.LastError
+	t.LastErrorTime = tunnel.LastErrorTime
+	t.Health = tunnel.Health
+	return t
+}
+
+// connectionStatus is the JSON format in which connectionlist prints
+// connections.
+type connectionStatus struct {
+	ID                 string   `json:"id"`
+	SourceIP           string   `json:"source_ip"`
+	SourcePort         uint32   `json:"source_port"`
+	DestinationIP      string   `json:"destination_ip"`
+	DestinationPort    uint32   `json:"destination_port"`
+	OriginAddress      string   `json:"origin_address,omitempty"`
+	DestinationAddress string   `json:"destination_address,omitempty"`
+	Warnings           []string `json:"warnings,omitempty"`
+	BytesSent          uint64   `json:"bytes_sent"`
+	BytesReceived      uint64   `json:"bytes_received"`
+	StartTime          int64    `json:"start_time"`
+}
+
+// newConnectionStatus returns the status of a listed connection.
+func newConnectionStatus(connection *as.Connection) *connectionStatus {
+	return &connectionStatus{
+		ID:                 connection.Id,
+		SourceIP:           common.Int32ToIP(connection.SourceIp).String(),
+		SourcePort:         connection.SourcePort,
+		DestinationIP:      common.Int32ToIP(connection.DestinationIp).String(),
+		DestinationPort:    connection.DestinationPort,
+		OriginAddress:      connection.OriginAddress,
+		DestinationAddress: connection.DestinationAddress,
+		Warnings:           connection.Warnings,
+		BytesSent:          connection.BytesSent,
+		BytesReceived:      connection.BytesReceived,
+		StartTime:          connection.StartTime,
+	}
+}
+
+// printJSON writes v to standard output as indented JSON. It is used
+// by the commands that take -json.
+func printJSON(v interface{}) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		log.Fatalf("[!] Failed to encode output: %s", err)
+	}
+	fmt.Println(string(out))
+}
+
+// tunnelExport writes the tunnels of one or all clients as JSON.
+func tunnelExport(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tunnelExportCmd := flag.NewFlagSet(commands[21], flag.ExitOnError)
+	clientID := tunnelExportCmd.String("clientid", "",
+		"The ID of the client whose tunnels are exported. All clients if empty")
+	file := tunnelExportCmd.String("file", "",
+		"The file to write the tunnels to. Standard output if empty")
+
+	tunnelExportCmd.Parse(args)
+
+	clientIDs := []string{*clientID}
+	if *clientID == "" {
+		clientIDs = nil
+		stream, err := adminClient.ClientList(ctx, new(as.ClientListRequest))
+		if err != nil {
+			log.Fatalf("[!] ClientList failed: %s", err)
+		}
+		for {
+			message, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				log.Fatalf("[!] Error receiving: %s", err)
+			}
+			clientIDs = append(clientIDs, message.ClientId)
+		}
+	}
+
+	definitions := make([]*tunnelDefinition, 0)
+	for _, id := range clientIDs {
+		tunnels := fetchTunnels(ctx, adminClient, id)
+
+		tunnelIDs := make([]string, 0, len(tunnels))
+		for tunnelID := range tunnels {
+			tunnelIDs = append(tunnelIDs, tunnelID)
+		}
+		sort.Strings(tunnelIDs)
+
+		for _, tunnelID := range tunnelIDs {
+			definitions = append(definitions,
+				newTunnelDefinition(id, tunnels[tunnelID]))
+		}
+	}
+
+	out, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		log.Fatalf("[!] Failed to encode tunnels: %s", err)
+	}
+	out = append(out, '\n')
+
+	if *file == "" {
+		os.Stdout.Write(out)
+	} else if err := ioutil.WriteFile(*file, out, 0600); err != nil {
+		log.Fatalf("[!] Failed to write %s: %s", *file, err)
+	}
+}
+
+// tunnelImport adds the tunnels of a file written by tunnelexport. The
+// tunnels are added all at once, so either all of them are created or
+// none are.
+func tunnelImport(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tunnelImportCmd := flag.NewFlagSet(commands[22], flag.ExitOnError)
+	file := tunnelImportCmd.String("file", "",
+		"The file to read the tunnels from")
+	clientID := tunnelImportCmd.String("clientid", "",
+		"Add all tunnels to this client instead of the clients in the file")
+
+	tunnelImportCmd.Parse(args)
+
+	if *file == "" {
+		log.Fatalf("[!] No file provided")
+	}
+
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("[!] Failed to read %s: %s", *file, err)
+	}
+
+	var definitions []*tunnelDefinition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		log.Fatalf("[!] Failed to parse %s: %s", *file, err)
+	}
+
+	req := new(as.TunnelImportRequest)
+	for i, definition := range definitions {
+		tunnel := new(as.Tunnel)
+		switch definition.Direction {
+		case "", "forward":
+			tunnel.Direction = common.TunnelDirectionForward
+		case "reverse":
+			tunnel.Direction = common.TunnelDirectionReverse
+		default:
+			log.Fatalf("[!] Tunnel %d: invalid direction %q", i, definition.Direction)
+		}
 
-	req.ClientId = *clientID
-	req.TunnelId = *tunnelID
+		listenIP := net.ParseIP(definition.ListenIP)
+		destinationIP := net.ParseIP(definition.DestinationIP)
+		if listenIP == nil || destinationIP == nil {
+			log.Fatalf("[!] Tunnel %d: invalid listen_ip or destination_ip", i)
+		}
 
-	_, err := adminClient.TunnelDelete(ctx, req)
+		tunnel.Id = definition.ID
+		tunnel.ListenIp = common.IpToInt32(listenIP)
+		tunnel.ListenPort = definition.ListenPort
+		tunnel.DestinationIp = common.IpToInt32(destinationIP)
+		tunnel.DestinationPort = definition.DestinationPort
+		tunnel.Command = definition.Command
+		tunnel.DetectDeception = definition.DetectDeception
+		tunnel.LoopbackAlias = definition.LoopbackAlias
+		tunnel.Hostname = definition.Hostname
+		tunnel.Ttl = definition.TTL
+		tunnel.ByteLimit = definition.ByteLimit
+		tunnel.Schedule = definition.Schedule
+		tunnel.Timezone = definition.Timezone
+		tunnel.ChunkSize = definition.ChunkSize
+		tunnel.Nagle = definition.Nagle
+		tunnel.TcpKeepalive = definition.TCPKeepalive
+		tunnel.ReadBuffer = definition.ReadBuffer
+		tunnel.WriteBuffer = definition.WriteBuffer
+		tunnel.RateLimit = definition.RateLimit
+		tunnel.IdleTimeout = definition.IdleTimeout
+		tunnel.MaxConnections = definition.MaxConnections
+		tunnel.Compress = definition.Compress
+		tunnel.StreamCompression = definition.StreamCompression
+		tunnel.Encrypt = definition.Encrypt
+		tunnel.RekeyInterval = definition.RekeyInterval
+		tunnel.RekeyBytes = definition.RekeyBytes
+		tunnel.PadSize = definition.PadSize
+		tunnel.SendJitter = definition.SendJitter
+		tunnel.Hops = definition.Hops
+		tunnel.Balance = definition.Balance
+		tunnel.Failover = definition.Failover
+		tunnel.HealthCheck = definition.HealthCheck
+		tunnel.HealthBanner = definition.HealthBanner
+
+		addReq := new(as.TunnelAddRequest)
+		addReq.ClientId = definition.ClientID
+		if *clientID != "" {
+			addReq.ClientId = *clientID
+		}
+		addReq.Tunnel = tunnel
+		req.Tunnels = append(req.Tunnels, addReq)
+	}
 
+	resp, err := adminClient.TunnelImport(ctx, req)
 	if err != nil {
-		log.Fatalf("[!] Failed to delete tunnel: %s", err)
+		log.Fatalf("[!] Failed to import tunnels: %s", err)
 	}
+
+	fmt.Printf("[*] Imported %d tunnels\n", len(resp.TunnelIds))
 }
 
 func tunnelList(ctx context.Context,
@@ -265,6 +1692,7 @@ func tunnelList(ctx context.Context,
 	tunnelListCmd := flag.NewFlagSet(commands[5], flag.ExitOnError)
 	clientID := tunnelListCmd.String("clientid", "",
 		"Tunnels will be listed for this client ID")
+	jsonOutput := tunnelListCmd.Bool("json", false, "Print the tunnels as JSON")
 
 	tunnelListCmd.Parse(args)
 	req := new(as.TunnelListRequest)
@@ -281,14 +1709,22 @@ func tunnelList(ctx context.Context,
 		"Listen IP",
 		"Listen Port",
 		"Destination IP",
-		"Destination Port"})
-
+		"Destination Port",
+		"Command",
+		"Hostname",
+		"Paused",
+		"Health",
+		"Last Error"})
+
+	tunnels := make([]*tunnelStatus, 0)
 	for {
 		message, err := stream.Recv()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			log.Fatalf("[!] Error receiving: %s", err)
+		} else if *jsonOutput {
+			tunnels = append(tunnels, newTunnelStatus(*clientID, message))
 		} else {
 			var direction = ""
 			if message.Direction == common.TunnelDirectionForward {
@@ -308,15 +1744,36 @@ func tunnelList(ctx context.Context,
 				listenIP.String(),
 				listenPort,
 				destIP.String(),
-				destPort}
+				destPort,
+				message.Command,
+				message.Hostname,
+				fmt.Sprintf("%t", message.Paused),
+				message.Health,
+				lastError(message)}
 			table.Append(row)
 
 		}
 	}
 
+	if *jsonOutput {
+		printJSON(tunnels)
+		return
+	}
 	table.Render()
 }
 
+// lastError describes why the last connection of a listed tunnel
+// failed, such as "DIAL_REFUSED: ... (3 failed, 2m0s ago)". It is empty
+// if no connection failed.
+func lastError(tunnel *as.Tunnel) string {
+	if tunnel.FailedConnections == 0 {
+		return ""
+	}
+	ago := time.Since(time.Unix(tunnel.LastErrorTime, 0)).Round(time.Second)
+	return fmt.Sprintf("%s (%d failed, %s ago)", tunnel.LastError,
+		tunnel.FailedConnections, ago)
+}
+
 func connectionList(ctx context.Context,
 	adminClient as.AdminServiceClient,
 	args []string) {
@@ -326,6 +1783,8 @@ func connectionList(ctx context.Context,
 		"The client for which connections will be listed")
 	tunnelID := connectionListCmd.String("tunnelid", "",
 		"The tunnel for which connections will be listed")
+	jsonOutput := connectionListCmd.Bool("json", false,
+		"Print the connections as JSON")
 
 	connectionListCmd.Parse(args)
 	req := new(as.ConnectionListRequest)
@@ -336,24 +1795,670 @@ func connectionList(ctx context.Context,
 	if err != nil {
 		log.Fatalf("[!] ConnectionList failed: %s", err)
 	}
+
+	connections := make([]*connectionStatus, 0)
 	for {
 		message, err := stream.Recv()
 		if err == io.EOF {
 			break
 		} else if err != nil {
 			log.Fatalf("[!] Error receiving: %s", err)
+		} else if *jsonOutput {
+			connections = append(connections, newConnectionStatus(message))
 		} else {
 
 			sourceIP := common.Int32ToIP(message.SourceIp)
 			destIP := common.Int32ToIP(message.DestinationIp)
 
-			log.Printf("%s\t%d\t%s\t%d\n",
+			log.Printf("%s\t%d\t%s\t%d\t%s\t%s\t%s\n",
 				sourceIP,
 				message.SourcePort,
 				destIP,
-				message.DestinationPort)
+				message.DestinationPort,
+				message.OriginAddress,
+				message.DestinationAddress,
+				strings.Join(message.Warnings, "; "))
+		}
+	}
+
+	if *jsonOutput {
+		printJSON(connections)
+	}
+}
+
+// formatASN returns an autonomous system number and organization such
+// as "AS15169 Google LLC", or an empty string if it is not known.
+func formatASN(asn uint32, organization string) string {
+	if asn == 0 {
+		return organization
+	}
+	return strings.TrimSpace(fmt.Sprintf("AS%d %s", asn, organization))
+}
+
+// formatLastSeen returns how long ago a unix timestamp was.
+func formatLastSeen(lastSeen int64) string {
+	if lastSeen <= 0 {
+		return ""
+	}
+	ago := time.Since(time.Unix(lastSeen, 0)).Truncate(time.Second)
+	return fmt.Sprintf("%s ago", ago)
+}
+
+// formatBytes returns a human readable byte count.
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// formatRate returns the human readable rate between two byte counts.
+func formatRate(previous uint64, current uint64, interval time.Duration) string {
+	if current < previous {
+		return "-"
+	}
+	perSecond := float64(current-previous) / interval.Seconds()
+	return formatBytes(uint64(perSecond)) + "/s"
+}
+
+// fetchTunnels returns the tunnels of a client keyed by tunnel ID.
+func fetchTunnels(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	clientID string) map[string]*as.Tunnel {
+
+	tunnels, err := listTunnels(ctx, adminClient, clientID)
+	if err != nil {
+		log.Fatalf("[!] TunnelList failed: %s", err)
+	}
+	return tunnels
+}
+
+// listTunnels returns the tunnels of a client keyed by tunnel ID.
+func listTunnels(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	clientID string) (map[string]*as.Tunnel, error) {
+
+	req := new(as.TunnelListRequest)
+	req.ClientId = clientID
+
+	stream, err := adminClient.TunnelList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	tunnels := make(map[string]*as.Tunnel)
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF || status.Code(err) == codes.OutOfRange {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		tunnels[message.Id] = message
+	}
+	return tunnels, nil
+}
+
+// fetchConnections returns the connections of a tunnel keyed by
+// connection ID.
+func fetchConnections(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	clientID string,
+	tunnelID string) map[string]*as.Connection {
+
+	connections, err := listConnections(ctx, adminClient, clientID, tunnelID)
+	if err != nil {
+		log.Fatalf("[!] ConnectionList failed: %s", err)
+	}
+	return connections
+}
+
+// listConnections returns the connections of a tunnel keyed by
+// connection ID.
+func listConnections(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	clientID string,
+	tunnelID string) (map[string]*as.Connection, error) {
+
+	req := new(as.ConnectionListRequest)
+	req.ClientId = clientID
+	req.TunnelId = tunnelID
+
+	stream, err := adminClient.ConnectionList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	connections := make(map[string]*as.Connection)
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF || status.Code(err) == codes.OutOfRange {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		connections[message.Id] = message
+	}
+	return connections, nil
+}
+
+// tunnelStats prints the bytes transferred by the tunnels of a client,
+// or by the connections of a single tunnel, with their current rates.
+func tunnelStats(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	tunnelStatsCmd := flag.NewFlagSet(commands[12], flag.ExitOnError)
+	clientID := tunnelStatsCmd.String("clientid", "",
+		"The client for which tunnel stats will be shown")
+	tunnelID := tunnelStatsCmd.String("tunnelid", "",
+		"Show the stats of the connections of this tunnel instead")
+	interval := tunnelStatsCmd.Duration("interval", time.Second,
+		"The interval over which rates are measured")
+
+	tunnelStatsCmd.Parse(args)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	var totalSent, totalReceived uint64
+
+	if *tunnelID == "" {
+		previous := fetchTunnels(ctx, adminClient, *clientID)
+		time.Sleep(*interval)
+		current := fetchTunnels(ctx, adminClient, *clientID)
+
+		table.SetHeader([]string{"Tunnel ID",
+			"Listen",
+			"Destination",
+			"Connections",
+			"Sent",
+			"Received",
+			"Sent Rate",
+			"Received Rate"})
+
+		for id, tunnel := range current {
+			last, ok := previous[id]
+			if !ok {
+				last = new(as.Tunnel)
+			}
+			totalSent += tunnel.BytesSent
+			totalReceived += tunnel.BytesReceived
+
+			table.Append([]string{id,
+				fmt.Sprintf("%s:%d", common.Int32ToIP(tunnel.ListenIp), tunnel.ListenPort),
+				fmt.Sprintf("%s:%d", common.Int32ToIP(tunnel.DestinationIp), tunnel.DestinationPort),
+				fmt.Sprintf("%d", tunnel.Connections),
+				formatBytes(tunnel.BytesSent),
+				formatBytes(tunnel.BytesReceived),
+				formatRate(last.BytesSent, tunnel.BytesSent, *interval),
+				formatRate(last.BytesReceived, tunnel.BytesReceived, *interval)})
+		}
+	} else {
+		previous := fetchConnections(ctx, adminClient, *clientID, *tunnelID)
+		time.Sleep(*interval)
+		current := fetchConnections(ctx, adminClient, *clientID, *tunnelID)
+
+		table.SetHeader([]string{"Connection ID",
+			"Source",
+			"Destination",
+			"Age",
+			"Sent",
+			"Received",
+			"Sent Rate",
+			"Received Rate"})
+
+		for id, connection := range current {
+			last, ok := previous[id]
+			if !ok {
+				last = new(as.Connection)
+			}
+			totalSent += connection.BytesSent
+			totalReceived += connection.BytesReceived
+
+			age := time.Since(time.Unix(connection.StartTime, 0)).Truncate(time.Second)
+
+			table.Append([]string{id,
+				fmt.Sprintf("%s:%d", common.Int32ToIP(connection.SourceIp), connection.SourcePort),
+				fmt.Sprintf("%s:%d", common.Int32ToIP(connection.DestinationIp), connection.DestinationPort),
+				age.String(),
+				formatBytes(connection.BytesSent),
+				formatBytes(connection.BytesReceived),
+				formatRate(last.BytesSent, connection.BytesSent, *interval),
+				formatRate(last.BytesReceived, connection.BytesReceived, *interval)})
+		}
+	}
+
+	table.SetFooter([]string{"", "", "", "Total",
+		formatBytes(totalSent), formatBytes(totalReceived), "", ""})
+	table.Render()
+}
+
+// connectionWatch refreshes a table of the active connections of a
+// client every interval until interrupted.
+func connectionWatch(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	connectionWatchCmd := flag.NewFlagSet(commands[13], flag.ExitOnError)
+	clientID := connectionWatchCmd.String("clientid", "",
+		"The client for which connections will be watched")
+	tunnelID := connectionWatchCmd.String("tunnelid", "",
+		"Only watch the connections of this tunnel")
+	interval := connectionWatchCmd.Duration("interval", time.Second,
+		"The refresh interval")
+
+	connectionWatchCmd.Parse(args)
+
+	previous := make(map[string]*as.Connection)
+
+	for {
+		tunnelIDs := []string{*tunnelID}
+		if *tunnelID == "" {
+			tunnelIDs = tunnelIDs[:0]
+			for id := range fetchTunnels(ctx, adminClient, *clientID) {
+				tunnelIDs = append(tunnelIDs, id)
+			}
+		}
+		sort.Strings(tunnelIDs)
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Tunnel ID",
+			"Source",
+			"Destination",
+			"Age",
+			"Sent",
+			"Received",
+			"Sent Rate",
+			"Received Rate"})
+
+		current := make(map[string]*as.Connection)
+		for _, id := range tunnelIDs {
+			connections := fetchConnections(ctx, adminClient, *clientID, id)
+
+			connectionIDs := make([]string, 0, len(connections))
+			for connectionID := range connections {
+				connectionIDs = append(connectionIDs, connectionID)
+			}
+			sort.Strings(connectionIDs)
+
+			for _, connectionID := range connectionIDs {
+				connection := connections[connectionID]
+				current[connectionID] = connection
+
+				last, ok := previous[connectionID]
+				if !ok {
+					last = new(as.Connection)
+				}
+
+				age := time.Since(time.Unix(connection.StartTime, 0)).Truncate(time.Second)
+
+				table.Append([]string{id,
+					fmt.Sprintf("%s:%d", common.Int32ToIP(connection.SourceIp), connection.SourcePort),
+					fmt.Sprintf("%s:%d", common.Int32ToIP(connection.DestinationIp), connection.DestinationPort),
+					age.String(),
+					formatBytes(connection.BytesSent),
+					formatBytes(connection.BytesReceived),
+					formatRate(last.BytesSent, connection.BytesSent, *interval),
+					formatRate(last.BytesReceived, connection.BytesReceived, *interval)})
+			}
+		}
+		previous = current
+
+		// Clear the terminal before drawing the new table
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("Connections of %s at %s (%d active)\n",
+			*clientID, time.Now().Format("15:04:05"), len(current))
+		table.Render()
+
+		time.Sleep(*interval)
+	}
+}
+
+// consoleConnectionRows is the most connections the console shows.
+const consoleConnectionRows = 20
+
+// consoleView is the state of the full screen console.
+type consoleView struct {
+	clients     []*as.Client
+	tunnels     []*as.Tunnel
+	connections []*as.Connection
+	previous    map[string]*as.Connection
+	client      int
+	tunnel      int
+	focus       int
+	escape      []byte
+	err         error
+	drawn       time.Time
+}
+
+// console shows a full screen view of the endpoints, the tunnels of the
+// selected endpoint and the live connections of the selected tunnel.
+// It needs a terminal that supports ANSI escape codes and stty.
+func console(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	consoleCmd := flag.NewFlagSet(commands[25], flag.ExitOnError)
+	interval := consoleCmd.Duration("interval", 2*time.Second,
+		"The refresh interval")
+
+	consoleCmd.Parse(args)
+
+	restore, err := rawTerminal()
+	if err != nil {
+		log.Fatalf("[!] Failed to set up the terminal: %s", err)
+	}
+	defer restore()
+
+	keys := make(chan byte)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			if _, err := os.Stdin.Read(buf); err != nil {
+				close(keys)
+				return
+			}
+			keys <- buf[0]
+		}
+	}()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	view := new(consoleView)
+	for {
+		view.refresh(ctx, adminClient)
+		view.draw()
+
+		select {
+		case <-ticker.C:
+		case <-interrupts:
+			return
+		case key, ok := <-keys:
+			if !ok || key == 'q' {
+				return
+			}
+			view.handleKey(key)
+		}
+	}
+}
+
+// rawTerminal switches the terminal to unbuffered input without echo
+// and returns a function that restores it.
+func rawTerminal() (func(), error) {
+	stty := func(args ...string) (string, error) {
+		cmd := exec.Command("stty", args...)
+		cmd.Stdin = os.Stdin
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, err
+	}
+
+	// Hide the cursor while drawing
+	fmt.Print("\033[?25l")
+	return func() {
+		fmt.Print("\033[H\033[2J\033[?25h")
+		stty(saved)
+	}, nil
+}
+
+// handleKey moves the selection. Tab switches between the endpoint and
+// tunnel panes, j and k or the arrow keys move within a pane.
+func (v *consoleView) handleKey(key byte) {
+	// Arrow keys arrive as ESC [ A and ESC [ B
+	if key == 0x1b || len(v.escape) > 0 {
+		v.escape = append(v.escape, key)
+		if len(v.escape) < 3 {
+			return
+		}
+		switch string(v.escape) {
+		case "\x1b[A":
+			key = 'k'
+		case "\x1b[B":
+			key = 'j'
+		}
+		v.escape = nil
+	}
+
+	move := 0
+	switch key {
+	case '\t':
+		v.focus = 1 - v.focus
+	case 'j':
+		move = 1
+	case 'k':
+		move = -1
+	}
+
+	if v.focus == 0 {
+		v.client += move
+		v.tunnel = 0
+	} else {
+		v.tunnel += move
+	}
+}
+
+// refresh fetches the endpoints, tunnels and connections to show.
+func (v *consoleView) refresh(ctx context.Context,
+	adminClient as.AdminServiceClient) {
+
+	v.err = nil
+	v.clients = v.clients[:0]
+	v.tunnels = v.tunnels[:0]
+	v.connections = v.connections[:0]
+
+	stream, err := adminClient.ClientList(ctx, new(as.ClientListRequest))
+	for err == nil {
+		var message *as.Client
+		message, err = stream.Recv()
+		if err == nil {
+			v.clients = append(v.clients, message)
+		}
+	}
+	if err != io.EOF {
+		v.err = err
+		return
+	}
+	sort.Slice(v.clients, func(i, j int) bool {
+		return v.clients[i].ClientId < v.clients[j].ClientId
+	})
+
+	v.client = clampIndex(v.client, len(v.clients))
+	if len(v.clients) == 0 {
+		return
+	}
+
+	clientID := v.clients[v.client].ClientId
+	tunnels, err := listTunnels(ctx, adminClient, clientID)
+	if err != nil {
+		v.err = err
+		return
+	}
+	for _, tunnel := range tunnels {
+		v.tunnels = append(v.tunnels, tunnel)
+	}
+	sort.Slice(v.tunnels, func(i, j int) bool {
+		return v.tunnels[i].Id < v.tunnels[j].Id
+	})
+
+	v.tunnel = clampIndex(v.tunnel, len(v.tunnels))
+	if len(v.tunnels) == 0 {
+		return
+	}
+
+	connections, err := listConnections(ctx, adminClient, clientID,
+		v.tunnels[v.tunnel].Id)
+	if err != nil {
+		v.err = err
+		return
+	}
+	for _, connection := range connections {
+		v.connections = append(v.connections, connection)
+	}
+	sort.Slice(v.connections, func(i, j int) bool {
+		return v.connections[i].StartTime < v.connections[j].StartTime
+	})
+}
+
+// clampIndex keeps a selected index within a list of length n.
+func clampIndex(index int, n int) int {
+	if index >= n {
+		index = n - 1
+	}
+	if index < 0 {
+		index = 0
+	}
+	return index
+}
+
+// draw renders the three panes of the console.
+func (v *consoleView) draw() {
+	var out bytes.Buffer
+
+	fmt.Fprintf(&out, "gTunnel console  %s  [tab] switch pane  [j/k] move  [q] quit\n",
+		time.Now().Format("15:04:05"))
+	if v.err != nil {
+		fmt.Fprintf(&out, "[!] %s\n", v.err)
+	}
+
+	marker := func(selected bool) string {
+		if selected {
+			return ">"
+		}
+		return ""
+	}
+	title := func(name string, focused bool) string {
+		if focused {
+			return "\n[" + name + "]\n"
+		}
+		return "\n " + name + "\n"
+	}
+
+	out.WriteString(title("Endpoints", v.focus == 0))
+	table := tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"", "Unique ID", "Alias", "Hostname", "Remote Address", "State", "Last Seen"})
+	for i, client := range v.clients {
+		table.Append([]string{marker(i == v.client),
+			client.ClientId,
+			client.Alias,
+			client.Hostname,
+			client.RemoteAddress,
+			client.State,
+			formatLastSeen(client.LastSeen)})
+	}
+	table.Render()
+
+	out.WriteString(title("Tunnels", v.focus == 1))
+	table = tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"", "Tunnel ID", "Listen", "Destination", "Connections", "Sent", "Received", "Paused", "Health"})
+	for i, tunnel := range v.tunnels {
+		table.Append([]string{marker(i == v.tunnel),
+			tunnel.Id,
+			fmt.Sprintf("%s:%d", common.Int32ToIP(tunnel.ListenIp), tunnel.ListenPort),
+			fmt.Sprintf("%s:%d", common.Int32ToIP(tunnel.DestinationIp), tunnel.DestinationPort),
+			fmt.Sprintf("%d", tunnel.Connections),
+			formatBytes(tunnel.BytesSent),
+			formatBytes(tunnel.BytesReceived),
+			fmt.Sprintf("%t", tunnel.Paused),
+			tunnel.Health})
+	}
+	table.Render()
+
+	out.WriteString(title("Connections", false))
+	table = tablewriter.NewWriter(&out)
+	table.SetHeader([]string{"Source", "Destination", "Age", "Sent", "Received", "Sent Rate", "Received Rate"})
+	elapsed := time.Since(v.drawn)
+	current := make(map[string]*as.Connection)
+	for i, connection := range v.connections {
+		current[connection.Id] = connection
+		if i >= consoleConnectionRows {
+			continue
+		}
+
+		// Connections that were not shown before have no rate yet
+		last, ok := v.previous[connection.Id]
+		if !ok {
+			last = connection
+		}
+		age := time.Since(time.Unix(connection.StartTime, 0)).Truncate(time.Second)
+
+		table.Append([]string{
+			fmt.Sprintf("%s:%d", common.Int32ToIP(connection.SourceIp), connection.SourcePort),
+			fmt.Sprintf("%s:%d", common.Int32ToIP(connection.DestinationIp), connection.DestinationPort),
+			age.String(),
+			formatBytes(connection.BytesSent),
+			formatBytes(connection.BytesReceived),
+			formatRate(last.BytesSent, connection.BytesSent, elapsed),
+			formatRate(last.BytesReceived, connection.BytesReceived, elapsed)})
+	}
+	table.Render()
+	if len(v.connections) > consoleConnectionRows {
+		fmt.Fprintf(&out, " ... and %d more\n", len(v.connections)-consoleConnectionRows)
+	}
+	v.previous = current
+	v.drawn = time.Now()
+
+	// Clear the terminal before drawing the new view
+	fmt.Print("\033[H\033[2J")
+	os.Stdout.Write(out.Bytes())
+}
+
+func aliasList(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	args []string) {
+
+	aliasListCmd := flag.NewFlagSet(commands[10], flag.ExitOnError)
+	jsonOutput := aliasListCmd.Bool("json", false, "Print the aliases as JSON")
+	aliasListCmd.Parse(args)
+
+	req := new(as.AliasListRequest)
+
+	stream, err := adminClient.AliasList(ctx, req)
+	if err != nil {
+		log.Fatalf("[!] AliasList failed: %s", err)
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Destination IP", "Alias IP"})
+
+	aliases := make([]map[string]string, 0)
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatalf("[!] Error receiving: %s", err)
+		} else {
+			row := []string{common.Int32ToIP(message.DestinationIp).String(),
+				common.Int32ToIP(message.AliasIp).String()}
+			table.Append(row)
+			aliases = append(aliases, map[string]string{
+				"destination_ip": row[0],
+				"alias_ip":       row[1],
+			})
 		}
 	}
+
+	if *jsonOutput {
+		printJSON(aliases)
+		return
+	}
+	table.Render()
 }
 
 func socksStart(ctx context.Context,
@@ -399,7 +2504,7 @@ func socksStop(ctx context.Context,
 	}
 }
 
-func loadConfiguration(hostname *string, port *int) {
+func loadConfiguration(hostname *string, port *int, security *adminSecurity) {
 	var configData map[string]interface{}
 
 	data, err := ioutil.ReadFile(ConfigFileName)
@@ -422,6 +2527,33 @@ func loadConfiguration(hostname *string, port *int) {
 	if val, ok := configData["port"]; ok {
 		*port = int(val.(float64))
 	}
+
+	if val, ok := configData["tls"]; ok {
+		security.tls = val.(bool)
+	}
+
+	if val, ok := configData["ca_file"]; ok {
+		security.caFile = val.(string)
+	}
+
+	if val, ok := configData["insecure_skip_verify"]; ok {
+		security.insecure = val.(bool)
+	}
+
+	if val, ok := configData["token"]; ok {
+		security.token = val.(string)
+	}
+
+	var profileData struct {
+		Profiles map[string][]*profileTunnel `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &profileData); err != nil {
+		log.Printf("[!] Failed to load profiles: %s", err)
+		os.Exit(1)
+	}
+	for name, tunnels := range profileData.Profiles {
+		profiles[name] = tunnels
+	}
 }
 
 func main() {
@@ -434,8 +2566,9 @@ func main() {
 
 	host := ""
 	port := 0
+	var security adminSecurity
 
-	loadConfiguration(&host, &port)
+	loadConfiguration(&host, &port, &security)
 
 	// Environment variables override configuration file
 	if os.Getenv(ServerHost) != "" {
@@ -449,6 +2582,20 @@ func main() {
 			os.Exit(1)
 		}
 	}
+	if os.Getenv(ServerToken) != "" {
+		security.token = os.Getenv(ServerToken)
+	}
+	if os.Getenv(ServerTLS) != "" {
+		var err error
+		security.tls, err = strconv.ParseBool(os.Getenv(ServerTLS))
+		if err != nil {
+			fmt.Println("[!] Invalid TLS setting specified.")
+			os.Exit(1)
+		}
+	}
+	if os.Getenv(ServerCAFile) != "" {
+		security.caFile = os.Getenv(ServerCAFile)
+	}
 
 	if host == "" {
 		fmt.Println("[!] No server host specified.")
@@ -460,41 +2607,99 @@ func main() {
 		port = 1337
 	}
 
-	adminClient, err := connect(host, uint32(port))
+	adminClient, err := connect(host, uint32(port), security)
 
 	if err != nil {
 		log.Fatalf("[!] Failed to connect to server: %s", err)
 	}
 
-	ctx, _ := context.WithCancel(context.Background())
+	ctx := context.Background()
 
 	if len(os.Args) == 1 {
 		printCommands(os.Args[0])
 		os.Exit(1)
 	}
 
-	switch os.Args[1] {
+	if !runCommand(ctx, adminClient, os.Args[1], os.Args[2:]) {
+		log.Printf("[*] Command: %s not recognized\n", os.Args[1])
+	}
+}
+
+// runCommand runs a single command. It returns false if the command is
+// not recognized.
+func runCommand(ctx context.Context,
+	adminClient as.AdminServiceClient,
+	command string,
+	args []string) bool {
+
+	switch command {
 	case commands[0]:
-		clientList(ctx, adminClient)
+		clientList(ctx, adminClient, args)
 	// List out all the configured clients and their connection status
 	case commands[1]:
-		clientRegister(ctx, adminClient, os.Args[2:])
+		clientRegister(ctx, adminClient, args)
 	case commands[2]:
-		clientDisconnect(ctx, adminClient, os.Args[2:])
+		clientDisconnect(ctx, adminClient, args)
 	case commands[3]:
-		tunnelAdd(ctx, adminClient, os.Args[2:])
+		tunnelAdd(ctx, adminClient, args)
 	case commands[4]:
-		tunnelDelete(ctx, adminClient, os.Args[2:])
+		tunnelDelete(ctx, adminClient, args)
 	case commands[5]:
-		tunnelList(ctx, adminClient, os.Args[2:])
+		tunnelList(ctx, adminClient, args)
 	case commands[6]:
-		connectionList(ctx, adminClient, os.Args[2:])
+		connectionList(ctx, adminClient, args)
 	case commands[7]:
-		socksStart(ctx, adminClient, os.Args[2:])
+		socksStart(ctx, adminClient, args)
 	case commands[8]:
-		socksStop(ctx, adminClient, os.Args[2:])
+		socksStop(ctx, adminClient, args)
+	case commands[9]:
+		clientConfigure(ctx, adminClient, args)
+	case commands[10]:
+		aliasList(ctx, adminClient, args)
+	case commands[11]:
+		logLevelSet(ctx, adminClient, args)
+	case commands[12]:
+		tunnelStats(ctx, adminClient, args)
+	case commands[13]:
+		connectionWatch(ctx, adminClient, args)
+	case commands[14]:
+		endpointInfo(ctx, adminClient, args)
+	case commands[15]:
+		clientRename(ctx, adminClient, args)
+	case commands[16]:
+		clientTag(ctx, adminClient, args)
+	case commands[17]:
+		clientUnban(ctx, adminClient, args)
+	case commands[18]:
+		banList(ctx, adminClient, args)
+	case commands[19]:
+		tunnelPause(ctx, adminClient, args)
+	case commands[20]:
+		tunnelResume(ctx, adminClient, args)
+	case commands[21]:
+		tunnelExport(ctx, adminClient, args)
+	case commands[22]:
+		tunnelImport(ctx, adminClient, args)
+	case commands[23]:
+		profileList(args)
+	case commands[24]:
+		execScript(ctx, adminClient, args)
+	case commands[25]:
+		console(ctx, adminClient, args)
+	case commands[26]:
+		clientPing(ctx, adminClient, args)
+	case commands[27]:
+		clientSpeedTest(ctx, adminClient, args)
+	case commands[28]:
+		clientScan(ctx, adminClient, args)
+	case commands[29]:
+		fileUpload(ctx, adminClient, args)
+	case commands[30]:
+		fileDownload(ctx, adminClient, args)
+	case commands[31]:
+		clientShell(ctx, adminClient, args)
 	default:
-		log.Printf("[*] Command: %s not recognized\n", os.Args[1])
+		return false
 	}
-
+	return true
 }